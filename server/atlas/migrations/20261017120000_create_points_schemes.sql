@@ -0,0 +1,40 @@
+-- Create "points_schemes" table
+CREATE TABLE "points_schemes" (
+  "id" serial NOT NULL,
+  "semester_id" uuid NOT NULL,
+  "size_factor" numeric NOT NULL DEFAULT 50,
+  "rounding_mode" text NOT NULL DEFAULT 'ceil',
+  "minimum_points" integer NOT NULL DEFAULT 0,
+  "default_payout" integer NOT NULL DEFAULT 1,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_semesters_points_scheme" FOREIGN KEY ("semester_id") REFERENCES "semesters" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_points_schemes_semester_id" to table: "points_schemes"
+CREATE UNIQUE INDEX "idx_points_schemes_semester_id" ON "points_schemes" ("semester_id");
+-- Create "points_payouts" table
+CREATE TABLE "points_payouts" (
+  "id" serial NOT NULL,
+  "points_scheme_id" integer NOT NULL,
+  "placement" integer NOT NULL,
+  "points" integer NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_points_schemes_payouts" FOREIGN KEY ("points_scheme_id") REFERENCES "points_schemes" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_points_scheme_placement" to table: "points_payouts"
+CREATE UNIQUE INDEX "idx_points_scheme_placement" ON "points_payouts" ("points_scheme_id", "placement");
+
+-- Backfill existing semesters with the scheme that was previously hard-coded in
+-- points_service.go: a 40 place payout table, 1 point beyond 40th, a size
+-- factor of 50 and rounding up.
+INSERT INTO "points_schemes" ("semester_id", "size_factor", "rounding_mode", "minimum_points", "default_payout")
+SELECT "id", 50, 'ceil', 0, 1 FROM "semesters";
+
+INSERT INTO "points_payouts" ("points_scheme_id", "placement", "points")
+SELECT ps."id", p.placement, p.points
+FROM "points_schemes" ps
+CROSS JOIN unnest(ARRAY[
+  32, 28, 24, 21, 18, 16, 14, 12, 11, 10,
+  9, 9, 8, 8, 7, 7, 6, 6, 5, 5,
+  4, 4, 4, 4, 4, 3, 3, 3, 3, 3,
+  2, 2, 2, 2, 2, 2, 2, 2, 2, 2
+]) WITH ORDINALITY AS p(points, placement);
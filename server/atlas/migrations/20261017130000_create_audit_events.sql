@@ -0,0 +1,20 @@
+-- Create "audit_events" table
+CREATE TABLE "audit_events" (
+  "id" bigserial NOT NULL,
+  "actor" text NOT NULL,
+  "role" character varying(20) NOT NULL,
+  "action" text NOT NULL,
+  "method" text NOT NULL,
+  "path" text NOT NULL,
+  "targets" jsonb NULL,
+  "changes" jsonb NULL,
+  "status_code" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_audit_events_action" to table: "audit_events"
+CREATE INDEX "idx_audit_events_action" ON "audit_events" ("action");
+-- Create index "idx_audit_events_actor" to table: "audit_events"
+CREATE INDEX "idx_audit_events_actor" ON "audit_events" ("actor");
+-- Create index "idx_audit_events_created_at" to table: "audit_events"
+CREATE INDEX "idx_audit_events_created_at" ON "audit_events" ("created_at");
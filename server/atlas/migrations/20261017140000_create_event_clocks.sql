@@ -0,0 +1,10 @@
+-- Create "event_clocks" table
+CREATE TABLE "event_clocks" (
+  "event_id" integer NOT NULL,
+  "state" smallint NOT NULL DEFAULT 0,
+  "level_index" integer NOT NULL DEFAULT 0,
+  "remaining_ms" bigint NOT NULL,
+  "snapshot_at" timestamptz NOT NULL,
+  PRIMARY KEY ("event_id"),
+  CONSTRAINT "fk_event_clocks_event" FOREIGN KEY ("event_id") REFERENCES "events" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
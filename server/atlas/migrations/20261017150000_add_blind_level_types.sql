@@ -0,0 +1,2 @@
+-- Modify "blinds" table
+ALTER TABLE "blinds" ALTER COLUMN "time" TYPE integer, ADD COLUMN "type" text NOT NULL DEFAULT 'blind', ADD COLUMN "label" text NOT NULL DEFAULT '';
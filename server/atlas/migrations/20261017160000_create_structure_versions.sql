@@ -0,0 +1,36 @@
+-- Modify "structures" table
+ALTER TABLE "structures" ADD COLUMN "version" integer NOT NULL DEFAULT 1;
+-- Create "structure_versions" table
+CREATE TABLE "structure_versions" (
+  "id" serial NOT NULL,
+  "structure_id" integer NOT NULL,
+  "version" integer NOT NULL,
+  "name" text NOT NULL,
+  "blinds" jsonb NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_structure_versions_structure" FOREIGN KEY ("structure_id") REFERENCES "structures" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_structure_versions_structure_version" to table: "structure_versions"
+CREATE UNIQUE INDEX "idx_structure_versions_structure_version" ON "structure_versions" ("structure_id", "version");
+-- Modify "events" table
+ALTER TABLE "events" ADD COLUMN "structure_version_id" integer NULL, ADD CONSTRAINT "fk_events_structure_version" FOREIGN KEY ("structure_version_id") REFERENCES "structure_versions" ("id") ON UPDATE CASCADE ON DELETE SET NULL;
+-- Record the current levels of every structure as its first version
+INSERT INTO "structure_versions" ("structure_id", "version", "name", "blinds")
+SELECT "structures"."id", 1, "structures"."name", COALESCE((
+  SELECT jsonb_agg(jsonb_build_object(
+    'type', "blinds"."type",
+    'label', "blinds"."label",
+    'small', "blinds"."small",
+    'big', "blinds"."big",
+    'ante', "blinds"."ante",
+    'time', "blinds"."time"
+  ) ORDER BY "blinds"."index")
+  FROM "blinds"
+  WHERE "blinds"."structure_id" = "structures"."id"
+), '[]'::jsonb)
+FROM "structures";
+-- Pin existing events to the first version of their structure
+UPDATE "events" SET "structure_version_id" = "structure_versions"."id"
+FROM "structure_versions"
+WHERE "structure_versions"."structure_id" = "events"."structure_id";
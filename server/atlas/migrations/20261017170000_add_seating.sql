@@ -0,0 +1,6 @@
+-- Modify "events" table
+ALTER TABLE "events" ADD COLUMN "table_size" smallint NOT NULL DEFAULT 9;
+-- Modify "participants" table
+ALTER TABLE "participants" ADD COLUMN "table_number" integer NULL, ADD COLUMN "seat_number" integer NULL;
+-- Create index "idx_participant_seat" to table: "participants"
+CREATE UNIQUE INDEX "idx_participant_seat" ON "participants" ("event_id", "table_number", "seat_number");
@@ -0,0 +1,18 @@
+-- Modify "events" table
+ALTER TABLE "events" ADD COLUMN "max_rebuys" smallint NULL;
+-- Modify "participants" table
+ALTER TABLE "participants" ADD COLUMN "rebuys" smallint NOT NULL DEFAULT 0, ADD COLUMN "add_ons" smallint NOT NULL DEFAULT 0;
+-- Modify "semesters" table
+ALTER TABLE "semesters" ADD COLUMN "add_on_fee" smallint NOT NULL DEFAULT 0;
+-- Create "rebuys" table
+CREATE TABLE "rebuys" (
+  "id" serial NOT NULL,
+  "participant_id" integer NOT NULL,
+  "type" text NOT NULL DEFAULT 'rebuy',
+  "amount" numeric NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_rebuys_participant" FOREIGN KEY ("participant_id") REFERENCES "participants" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_rebuys_participant_id" to table: "rebuys"
+CREATE INDEX "idx_rebuys_participant_id" ON "rebuys" ("participant_id");
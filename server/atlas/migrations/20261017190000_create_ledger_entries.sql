@@ -0,0 +1,55 @@
+-- Create "ledger_entries" table
+CREATE TABLE "ledger_entries" (
+  "id" bigserial NOT NULL,
+  "semester_id" uuid NOT NULL,
+  "type" text NOT NULL,
+  "amount_cents" bigint NOT NULL,
+  "description" text NOT NULL DEFAULT '',
+  "membership_id" uuid NULL,
+  "event_id" integer NULL,
+  "transaction_id" integer NULL,
+  "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_ledger_entries_semester" FOREIGN KEY ("semester_id") REFERENCES "semesters" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_ledger_entries_membership_id" to table: "ledger_entries"
+CREATE INDEX "idx_ledger_entries_membership_id" ON "ledger_entries" ("membership_id");
+-- Create index "idx_ledger_entries_semester_id" to table: "ledger_entries"
+CREATE INDEX "idx_ledger_entries_semester_id" ON "ledger_entries" ("semester_id");
+-- Create index "idx_ledger_entries_type" to table: "ledger_entries"
+CREATE INDEX "idx_ledger_entries_type" ON "ledger_entries" ("type");
+-- Backfill the fees of paid memberships at the current fees of their semester
+INSERT INTO "ledger_entries" ("semester_id", "type", "amount_cents", "description", "membership_id")
+SELECT m."semester_id", 'membership_fee',
+       (CASE WHEN m."discounted" THEN s."membership_discount_fee" ELSE s."membership_fee" END)::bigint * 100,
+       CASE WHEN m."discounted" THEN 'Discounted membership fee' ELSE 'Membership fee' END,
+       m."id"
+FROM "memberships" m
+JOIN "semesters" s ON s."id" = m."semester_id"
+WHERE m."paid";
+-- Backfill transactions
+INSERT INTO "ledger_entries" ("semester_id", "type", "amount_cents", "description", "transaction_id")
+SELECT t."semester_id", 'transaction', ROUND(t."amount" * 100)::bigint, COALESCE(t."description", ''), t."id"
+FROM "transactions" t
+WHERE t."semester_id" IS NOT NULL;
+-- Backfill rebuys and add-ons recorded against a participant
+INSERT INTO "ledger_entries" ("semester_id", "type", "amount_cents", "description", "event_id", "created_at")
+SELECT e."semester_id", r."type", ROUND(r."amount" * 100)::bigint,
+       CASE WHEN r."type" = 'add_on' THEN 'Add-on' ELSE 'Rebuy' END,
+       e."id", r."created_at"
+FROM "rebuys" r
+JOIN "participants" p ON p."id" = r."participant_id"
+JOIN "events" e ON e."id" = p."event_id";
+-- Backfill rebuys only counted on the event, at the current rebuy fee
+INSERT INTO "ledger_entries" ("semester_id", "type", "amount_cents", "description", "event_id")
+SELECT e."semester_id", 'rebuy', (e."rebuys" - COALESCE(c."count", 0))::bigint * s."rebuy_fee" * 100, 'Rebuys', e."id"
+FROM "events" e
+JOIN "semesters" s ON s."id" = e."semester_id"
+LEFT JOIN (
+  SELECT p."event_id", COUNT(*) AS "count"
+  FROM "rebuys" r
+  JOIN "participants" p ON p."id" = r."participant_id"
+  WHERE r."type" = 'rebuy'
+  GROUP BY p."event_id"
+) c ON c."event_id" = e."id"
+WHERE e."rebuys" > COALESCE(c."count", 0) AND s."rebuy_fee" > 0;
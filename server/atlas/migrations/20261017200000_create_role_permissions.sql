@@ -0,0 +1,7 @@
+-- Create "role_permissions" table
+CREATE TABLE "role_permissions" (
+  "action" text NOT NULL,
+  "roles" jsonb NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("action")
+);
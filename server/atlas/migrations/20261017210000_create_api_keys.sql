@@ -0,0 +1,19 @@
+-- Create "api_keys" table
+CREATE TABLE "api_keys" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "username" text NOT NULL,
+  "name" text NOT NULL,
+  "prefix" text NOT NULL,
+  "key_hash" text NOT NULL,
+  "scopes" jsonb NOT NULL,
+  "expires_at" timestamptz NULL,
+  "last_used_at" timestamptz NULL,
+  "revoked_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_api_keys_login" FOREIGN KEY ("username") REFERENCES "logins" ("username") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_api_keys_key_hash" to table: "api_keys"
+CREATE UNIQUE INDEX "idx_api_keys_key_hash" ON "api_keys" ("key_hash");
+-- Create index "idx_api_keys_username" to table: "api_keys"
+CREATE INDEX "idx_api_keys_username" ON "api_keys" ("username");
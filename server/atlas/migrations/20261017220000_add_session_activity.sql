@@ -0,0 +1,4 @@
+-- Modify "sessions" table
+ALTER TABLE "sessions" ADD COLUMN "last_seen_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP, ADD COLUMN "user_agent" text NOT NULL DEFAULT '', ADD COLUMN "ip_address" text NOT NULL DEFAULT '';
+-- Create index "idx_sessions_username" to table: "sessions"
+CREATE INDEX "idx_sessions_username" ON "sessions" ("username");
@@ -0,0 +1,16 @@
+-- Create "password_reset_tokens" table
+CREATE TABLE "password_reset_tokens" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "username" text NOT NULL,
+  "token_hash" text NOT NULL,
+  "created_by" text NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_password_reset_tokens_login" FOREIGN KEY ("username") REFERENCES "logins" ("username") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_password_reset_tokens_token_hash" to table: "password_reset_tokens"
+CREATE UNIQUE INDEX "idx_password_reset_tokens_token_hash" ON "password_reset_tokens" ("token_hash");
+-- Create index "idx_password_reset_tokens_username" to table: "password_reset_tokens"
+CREATE INDEX "idx_password_reset_tokens_username" ON "password_reset_tokens" ("username");
@@ -0,0 +1,19 @@
+-- Modify "logins" table
+ALTER TABLE "logins" ADD COLUMN "totp_secret" text NOT NULL DEFAULT '', ADD COLUMN "totp_last_step" bigint NOT NULL DEFAULT 0, ADD COLUMN "two_factor_enabled_at" timestamptz NULL, ADD COLUMN "recovery_codes" jsonb NOT NULL DEFAULT '[]';
+-- Modify "sessions" table
+ALTER TABLE "sessions" ADD COLUMN "two_factor_verified" boolean NOT NULL DEFAULT false;
+-- Create "two_factor_challenges" table
+CREATE TABLE "two_factor_challenges" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "username" text NOT NULL,
+  "token_hash" text NOT NULL,
+  "attempts" bigint NOT NULL DEFAULT 0,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_two_factor_challenges_login" FOREIGN KEY ("username") REFERENCES "logins" ("username") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_two_factor_challenges_token_hash" to table: "two_factor_challenges"
+CREATE UNIQUE INDEX "idx_two_factor_challenges_token_hash" ON "two_factor_challenges" ("token_hash");
+-- Create index "idx_two_factor_challenges_username" to table: "two_factor_challenges"
+CREATE INDEX "idx_two_factor_challenges_username" ON "two_factor_challenges" ("username");
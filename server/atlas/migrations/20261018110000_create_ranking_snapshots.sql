@@ -0,0 +1,24 @@
+-- Create "ranking_snapshots" table
+CREATE TABLE "ranking_snapshots" (
+  "id" bigserial NOT NULL,
+  "semester_id" uuid NOT NULL,
+  "event_id" integer NULL,
+  "reason" text NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_ranking_snapshots_semester" FOREIGN KEY ("semester_id") REFERENCES "semesters" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_ranking_snapshots_semester_id" to table: "ranking_snapshots"
+CREATE INDEX "idx_ranking_snapshots_semester_id" ON "ranking_snapshots" ("semester_id");
+-- Create "ranking_snapshot_entries" table
+CREATE TABLE "ranking_snapshot_entries" (
+  "snapshot_id" bigint NOT NULL,
+  "membership_id" uuid NOT NULL,
+  "points" integer NOT NULL,
+  "position" integer NOT NULL,
+  PRIMARY KEY ("snapshot_id", "membership_id"),
+  CONSTRAINT "fk_ranking_snapshot_entries_membership" FOREIGN KEY ("membership_id") REFERENCES "memberships" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fk_ranking_snapshots_entries" FOREIGN KEY ("snapshot_id") REFERENCES "ranking_snapshots" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_ranking_snapshot_entries_membership_id" to table: "ranking_snapshot_entries"
+CREATE INDEX "idx_ranking_snapshot_entries_membership_id" ON "ranking_snapshot_entries" ("membership_id");
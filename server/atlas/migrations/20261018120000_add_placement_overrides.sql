@@ -0,0 +1,2 @@
+-- Modify "participants" table
+ALTER TABLE "participants" ADD COLUMN "placement_override" integer NULL;
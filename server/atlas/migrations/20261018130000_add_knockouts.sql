@@ -0,0 +1,6 @@
+-- Modify "events" table
+ALTER TABLE "events" ADD COLUMN "knockout_points" integer NOT NULL DEFAULT 0;
+-- Modify "participants" table
+ALTER TABLE "participants" ADD COLUMN "knocked_out_by_id" integer NULL, ADD CONSTRAINT "fk_participants_knocked_out_by" FOREIGN KEY ("knocked_out_by_id") REFERENCES "participants" ("id") ON UPDATE CASCADE ON DELETE SET NULL;
+-- Create index "idx_participants_knocked_out_by_id" to table: "participants"
+CREATE INDEX "idx_participants_knocked_out_by_id" ON "participants" ("knocked_out_by_id");
@@ -0,0 +1,19 @@
+-- Create "kiosk_tokens" table
+CREATE TABLE "kiosk_tokens" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "event_id" integer NOT NULL,
+  "name" text NOT NULL,
+  "prefix" text NOT NULL,
+  "token_hash" text NOT NULL,
+  "created_by" text NOT NULL,
+  "expires_at" timestamptz NULL,
+  "last_used_at" timestamptz NULL,
+  "revoked_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_kiosk_tokens_event" FOREIGN KEY ("event_id") REFERENCES "events" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_kiosk_tokens_event_id" to table: "kiosk_tokens"
+CREATE INDEX "idx_kiosk_tokens_event_id" ON "kiosk_tokens" ("event_id");
+-- Create index "idx_kiosk_tokens_token_hash" to table: "kiosk_tokens"
+CREATE UNIQUE INDEX "idx_kiosk_tokens_token_hash" ON "kiosk_tokens" ("token_hash");
@@ -0,0 +1,15 @@
+-- Modify "events" table
+ALTER TABLE "events" ADD COLUMN "max_entries" integer NULL;
+-- Create "event_registrations" table
+CREATE TABLE "event_registrations" (
+  "id" serial NOT NULL,
+  "event_id" integer NOT NULL,
+  "membership_id" uuid NOT NULL,
+  "status" text NOT NULL DEFAULT 'registered',
+  "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_event_registrations_event" FOREIGN KEY ("event_id") REFERENCES "events" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fk_event_registrations_membership" FOREIGN KEY ("membership_id") REFERENCES "memberships" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_registration_event_membership" to table: "event_registrations"
+CREATE UNIQUE INDEX "idx_registration_event_membership" ON "event_registrations" ("event_id", "membership_id");
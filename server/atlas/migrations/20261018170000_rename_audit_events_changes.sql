@@ -0,0 +1,2 @@
+-- Modify "audit_events" table
+ALTER TABLE "audit_events" RENAME COLUMN "changes" TO "request";
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"api/internal/database"
+	"api/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var REBUILD_SEMESTER string
+var REBUILD_DRY_RUN bool
+
+var rankingsCmd = &cobra.Command{
+	Use:   "rankings",
+	Short: "Manage semester rankings",
+}
+
+var rankingsRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Recalculates a semester's rankings from the placements of its ended events",
+	Run: func(cmd *cobra.Command, args []string) {
+		semesterID, err := uuid.Parse(REBUILD_SEMESTER)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Semester ID '%s' is not a valid UUID\n", REBUILD_SEMESTER)
+			os.Exit(1)
+		}
+
+		db, err := database.OpenConnection(false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to open connection to the database: %s\n", err.Error())
+			os.Exit(1)
+		}
+
+		result, err := services.NewRankingService(db).RebuildRankings(semesterID, REBUILD_DRY_RUN)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to rebuild rankings: %s\n", err.Error())
+			os.Exit(1)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "MEMBERSHIP\tNAME\tPOINTS\tATTENDANCE")
+		for _, diff := range result.Diffs {
+			fmt.Fprintf(
+				w,
+				"%s\t%s %s\t%d -> %d\t%d -> %d\n",
+				diff.MembershipID,
+				diff.FirstName,
+				diff.LastName,
+				diff.PreviousPoints,
+				diff.Points,
+				diff.PreviousAttendance,
+				diff.Attendance,
+			)
+		}
+		w.Flush()
+
+		if result.DryRun {
+			fmt.Printf("Dry run: %d ranking(s) would change across %d ended event(s)\n", len(result.Diffs), result.EventsProcessed)
+		} else {
+			fmt.Printf("Updated %d ranking(s) across %d ended event(s)\n", len(result.Diffs), result.EventsProcessed)
+		}
+	},
+}
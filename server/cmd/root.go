@@ -24,4 +24,11 @@ func init() {
 
 	startCmd.Flags().StringVarP(&PORT, "port", "p", "5000", "The port number for the server to run on.")
 	startCmd.Flags().BoolVar(&RUN_MIGRATIONS, "run-migrations", false, "Run the SQL migrations on startup.")
+
+	rootCmd.AddCommand(rankingsCmd)
+	rankingsCmd.AddCommand(rankingsRebuildCmd)
+
+	rankingsRebuildCmd.Flags().StringVarP(&REBUILD_SEMESTER, "semester", "s", "", "The ID of the semester to rebuild rankings for.")
+	rankingsRebuildCmd.Flags().BoolVar(&REBUILD_DRY_RUN, "dry-run", false, "Report the ranking changes without saving them.")
+	rankingsRebuildCmd.MarkFlagRequired("semester")
 }
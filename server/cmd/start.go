@@ -14,6 +14,7 @@ import (
 	cr "api/cron"
 	"api/internal/database"
 	"api/internal/server"
+	"api/internal/services"
 
 	"github.com/robfig/cron/v3"
 	"github.com/spf13/cobra"
@@ -33,9 +34,17 @@ var startCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		// Load the permission policy, seeding it with the default roles of any
+		// new actions. Requests are authorized against the default policy if it
+		// cannot be loaded
+		if err := services.NewPermissionService(db).LoadPolicy(); err != nil {
+			log.Printf("Failed to load the permission policy: %s", err.Error())
+		}
+
 		// Initialize cron tasks
 		c := cron.New()
 		c.AddFunc("@daily", cr.SessionCleanup(db))
+		c.AddFunc(fmt.Sprintf("@every %s", policyRefreshInterval()), cr.PolicyRefresh(db))
 		c.Start()
 
 		// Initialize the server
@@ -111,3 +120,22 @@ var startCmd = &cobra.Command{
 		log.Println("Server exited")
 	},
 }
+
+// policyRefreshInterval returns how often the permission policy is reloaded
+// from the database, from POLICY_REFRESH_INTERVAL. Defaults to 30 seconds.
+func policyRefreshInterval() time.Duration {
+	fallback := time.Second * 30
+
+	value := os.Getenv("POLICY_REFRESH_INTERVAL")
+	if value == "" {
+		return fallback
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < time.Second {
+		log.Printf("Invalid POLICY_REFRESH_INTERVAL '%s', using %s", value, fallback)
+		return fallback
+	}
+
+	return interval
+}
@@ -0,0 +1,18 @@
+package cron
+
+import (
+	"api/internal/services"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// PolicyRefresh is a cron task that reloads the stored permission policy, so that roles changed through another
+// instance of the API are applied here too.
+func PolicyRefresh(db *gorm.DB) func() {
+	return func() {
+		if err := services.NewPermissionService(db).LoadPolicy(); err != nil {
+			log.Printf("Failed to reload the permission policy: %v", err)
+		}
+	}
+}
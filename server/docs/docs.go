@@ -22,6 +22,94 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/audit": {
+            "get": {
+                "description": "Retrieve the audit log of mutating API actions, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "List audit events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of results to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by the username that performed the action",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by action (e.g. event.end). A value ending in '.' matches every action with that prefix",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by the ID of a targeted resource",
+                        "name": "targetId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include events at or after this time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include events at or before this time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ListResponse-AuditEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/health": {
             "get": {
                 "description": "Check the health of the API service",
@@ -45,6 +133,114 @@ const docTemplate = `{
                 }
             }
         },
+        "/kiosk/check-in": {
+            "post": {
+                "description": "Enter a member into the event of the kiosk by their student ID or QuestID, and tell them where to sit. The member must hold a membership for the semester of the event, and is added to the waitlist when the event is full. Check-ins which do not match a membership are throttled for each kiosk token. Requires a kiosk token as a bearer token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosk"
+                ],
+                "summary": "Check in at a kiosk",
+                "parameters": [
+                    {
+                        "description": "Student ID or QuestID of the member",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/KioskCheckInRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The member was already entered",
+                        "schema": {
+                            "$ref": "#/definitions/KioskCheckInResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "The member was entered",
+                        "schema": {
+                            "$ref": "#/definitions/KioskCheckInResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/kiosk/event": {
+            "get": {
+                "description": "Get the event that the kiosk checks members in to. Requires a kiosk token as a bearer token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosk"
+                ],
+                "summary": "Get kiosk event",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/KioskEventResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/logins": {
             "get": {
                 "description": "Retrieve a list of all logins with their linked member information",
@@ -332,43 +528,70 @@ const docTemplate = `{
                 }
             }
         },
-        "/members": {
-            "get": {
-                "description": "Retrieve a list of Members with optional filters",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+        "/logins/{username}/2fa": {
+            "delete": {
+                "description": "Turn off two-factor authentication for a login, e.g. when its authenticator app and recovery codes are lost. If its role requires two-factor authentication, it must set it up again after logging in.",
                 "tags": [
-                    "Members"
+                    "Logins"
                 ],
-                "summary": "List Members",
+                "summary": "Reset two-factor authentication",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Filter by Member ID",
-                        "name": "id",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Filter by Member Email",
-                        "name": "email",
-                        "in": "query"
+                        "description": "Login username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
-                    {
-                        "type": "string",
-                        "description": "Filter by Member Name",
-                        "name": "name",
-                        "in": "query"
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/logins/{username}/api-keys": {
+            "get": {
+                "description": "List the API keys of a login, newest first, including revoked keys. The keys themselves are never returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logins"
+                ],
+                "summary": "List API keys",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by Member Faculty",
-                        "name": "faculty",
-                        "in": "query"
+                        "description": "Login username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -377,16 +600,10 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/Member"
+                                "$ref": "#/definitions/APIKey"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -399,6 +616,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -408,7 +631,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new Member with the provided details",
+                "description": "Create a long-lived API key for a bot login, scoped to a list of actions such as event.participant.signin. Send it in an \"Authorization: Bearer\" header. The key is only returned in this response.",
                 "consumes": [
                     "application/json"
                 ],
@@ -416,17 +639,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
+                    "Logins"
                 ],
-                "summary": "Create a new Member",
+                "summary": "Create an API key",
                 "parameters": [
                     {
-                        "description": "Member details",
-                        "name": "member",
+                        "type": "string",
+                        "description": "Login username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "API key details",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateMemberRequest"
+                            "$ref": "#/definitions/CreateAPIKeyRequest"
                         }
                     }
                 ],
@@ -434,7 +664,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/Member"
+                            "$ref": "#/definitions/CreateAPIKeyResponse"
                         }
                     },
                     "400": {
@@ -455,6 +685,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -464,34 +700,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/members/{id}": {
-            "get": {
-                "description": "Retrieve a Member by their ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+        "/logins/{username}/api-keys/{keyId}": {
+            "delete": {
+                "description": "Revoke an API key so that it can no longer be used. The key is kept in the list of keys of the login.",
                 "tags": [
-                    "Members"
+                    "Logins"
                 ],
-                "summary": "Get Member by ID",
+                "summary": "Revoke an API key",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Member ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Login username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "keyId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/Member"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -524,24 +758,20 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/logins/{username}/lockout": {
             "delete": {
-                "description": "Delete a Member by their ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Forget the failed logins of a username, lifting any delay or lockout on logging in as it. Lockouts of IP addresses are not lifted.",
                 "tags": [
-                    "Members"
+                    "Logins"
                 ],
-                "summary": "Delete Member by ID",
+                "summary": "Unlock a login",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Member ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Login username",
+                        "name": "username",
                         "in": "path",
                         "required": true
                     }
@@ -550,12 +780,53 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/logins/{username}/password-reset": {
+            "post": {
+                "description": "Issue a single-use token the user redeems with POST /session/password-reset to set a new password. The token is only returned once, and any unused token previously issued for the login stops working.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Logins"
+                ],
+                "summary": "Create a password reset token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Login username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/CreatePasswordResetTokenResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -581,49 +852,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update a Member's details by their ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            }
+        },
+        "/logins/{username}/sessions": {
+            "delete": {
+                "description": "End every session of a login, e.g. when it should no longer have access. Sessions are also ended when the password or role of a login is updated.",
                 "tags": [
-                    "Members"
+                    "Logins"
                 ],
-                "summary": "Update Member by ID",
+                "summary": "Force logout a login",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Member ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Login username",
+                        "name": "username",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated Member details",
-                        "name": "member",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/UpdateMemberRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/Member"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -652,26 +901,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/semesters": {
+        "/members": {
             "get": {
-                "description": "List all semesters",
+                "description": "Retrieve a list of Members with optional filters",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Semesters"
+                    "Members"
+                ],
+                "summary": "List Members",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter by Member ID",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Member Email",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Member Name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Member Faculty",
+                        "name": "faculty",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List Semesters",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/Semester"
+                                "$ref": "#/definitions/Member"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -693,7 +977,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new semester",
+                "description": "Create a new Member with the provided details",
                 "consumes": [
                     "application/json"
                 ],
@@ -701,17 +985,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Semesters"
+                    "Members"
                 ],
-                "summary": "Create Semester",
+                "summary": "Create a new Member",
                 "parameters": [
                     {
-                        "description": "Semester data",
-                        "name": "semester",
+                        "description": "Member details",
+                        "name": "member",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateSemesterRequest"
+                            "$ref": "#/definitions/CreateMemberRequest"
                         }
                     }
                 ],
@@ -719,7 +1003,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/Semester"
+                            "$ref": "#/definitions/Member"
                         }
                     },
                     "400": {
@@ -749,30 +1033,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/semesters/{id}": {
+        "/members/export": {
             "get": {
-                "description": "Get a specific semester by ID",
+                "description": "Download every Member matching the filters as a CSV file, newest first. The file is streamed as it is read, so it is not limited in size.",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "Semesters"
+                    "Members"
                 ],
-                "summary": "Get Semester",
+                "summary": "Export Members",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Semester ID",
+                        "type": "integer",
+                        "description": "Filter by Member ID",
                         "name": "id",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Member Email",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Member Name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Member Faculty",
+                        "name": "faculty",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "CSV file with the columns id, first_name, last_name, email, faculty, quest_id and created_at",
                         "schema": {
-                            "$ref": "#/definitions/Semester"
+                            "type": "file"
                         }
                     },
                     "401": {
@@ -787,12 +1088,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -802,48 +1097,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/semesters/{semesterId}/events": {
-            "get": {
-                "description": "Retrieve a list of events for a specific semester",
+        "/members/import": {
+            "post": {
+                "description": "Create or update Members from CSV, matching existing Members by student ID. The CSV file needs a header row naming the columns: id (or student id), first name, last name, email and faculty are required, quest id, paid and discounted are optional and unknown columns are ignored. Every row is validated with the same rules as Create Member, and all invalid rows are reported together without importing anything. With a semesterId, Members without a membership in the semester are given one, paid and discounted as set by the query or the paid and discounted columns of their row. Existing memberships are left unchanged. Every row is imported in a single transaction, and dry runs report what would be done without saving anything.",
                 "consumes": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Members"
                 ],
-                "summary": "List Events",
+                "summary": "Import Members",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Semester ID",
+                        "description": "Semester to create memberships in",
                         "name": "semesterId",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Search events by name (case-insensitive)",
-                        "name": "search",
+                        "type": "boolean",
+                        "description": "Whether created memberships are paid (default false)",
+                        "name": "paid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether created memberships are discounted (default false)",
+                        "name": "discounted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the import and report what it would do without saving anything",
+                        "name": "dryRun",
                         "in": "query"
+                    },
+                    {
+                        "description": "CSV file of Members",
+                        "name": "members",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Event"
-                            }
+                            "$ref": "#/definitions/MemberImportResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/MemberImportErrorResponse"
                         }
                     },
                     "401": {
@@ -858,6 +1170,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -865,9 +1189,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new event",
+            }
+        },
+        "/members/{id}": {
+            "get": {
+                "description": "Retrieve a Member by their ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -875,32 +1201,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Members"
                 ],
-                "summary": "Create Event",
+                "summary": "Get Member by ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Semester ID",
-                        "name": "semesterId",
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Event data",
-                        "name": "event",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/CreateEventRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Event"
+                            "$ref": "#/definitions/Member"
                         }
                     },
                     "400": {
@@ -921,6 +1238,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -928,11 +1251,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/semesters/{semesterId}/events/{eventId}": {
-            "get": {
-                "description": "Retrieve a specific event by its ID",
+            },
+            "delete": {
+                "description": "Delete a Member by their ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -940,31 +1261,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Members"
                 ],
-                "summary": "Get Event",
+                "summary": "Delete Member by ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Semester ID",
-                        "name": "semesterId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Event ID",
-                        "name": "eventId",
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/Event"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -999,7 +1310,7 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "Update an existing event",
+                "description": "Update a Member's details by their ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1007,31 +1318,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Members"
                 ],
-                "summary": "Update Event",
+                "summary": "Update Member by ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Semester ID",
-                        "name": "semesterId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Event ID",
-                        "name": "eventId",
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Event data",
-                        "name": "event",
+                        "description": "Updated Member details",
+                        "name": "member",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UpdateEventRequestV2"
+                            "$ref": "#/definitions/UpdateMemberRequest"
                         }
                     }
                 ],
@@ -1039,7 +1343,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Event"
+                            "$ref": "#/definitions/Member"
                         }
                     },
                     "400": {
@@ -1075,43 +1379,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/semesters/{semesterId}/events/{eventId}/end": {
-            "post": {
-                "description": "End an existing event",
-                "consumes": [
-                    "application/json"
-                ],
+        "/permissions": {
+            "get": {
+                "description": "List the roles allowed to perform every action, such as event.end or semester.rankings.export, along with the roles allowed by default",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
-                ],
-                "summary": "End Event",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Semester ID",
-                        "name": "semesterId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Event ID",
-                        "name": "eventId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Permissions"
                 ],
+                "summary": "List permissions",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/RolePermission"
+                            }
                         }
                     },
                     "401": {
@@ -1126,12 +1411,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1141,9 +1420,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/semesters/{semesterId}/events/{eventId}/entries": {
-            "get": {
-                "description": "Retrieve a list of participant entries for a specific event",
+        "/permissions/{action}": {
+            "put": {
+                "description": "Change the roles allowed to perform an action. The change applies to the next request. The permission actions themselves cannot be changed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1151,39 +1430,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Entries"
+                    "Permissions"
                 ],
-                "summary": "List Entries",
+                "summary": "Update permission",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Semester ID",
-                        "name": "semesterId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Event ID",
-                        "name": "eventId",
+                        "description": "Action, e.g. event.end",
+                        "name": "action",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Search term to filter entries by participant name or student number (case-insensitive)",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Roles allowed to perform the action",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateRolePermissionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Participant"
-                            }
+                            "$ref": "#/definitions/RolePermission"
                         }
                     },
                     "400": {
@@ -1204,6 +1476,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1212,61 +1490,71 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Create new participant entries for an event",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            "delete": {
+                "description": "Restore the roles allowed to perform an action to the default policy",
                 "tags": [
-                    "Entries"
+                    "Permissions"
                 ],
-                "summary": "Create Entries",
+                "summary": "Reset permission",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Semester ID",
-                        "name": "semesterId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Event ID",
-                        "name": "eventId",
+                        "description": "Action, e.g. event.end",
+                        "name": "action",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Array of membership UUIDs",
-                        "name": "membershipIds",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
                     }
                 ],
                 "responses": {
-                    "207": {
-                        "description": "Multi-Status",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/CreateEntryResult"
-                            }
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/semesters": {
+            "get": {
+                "description": "List all semesters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Semesters"
+                ],
+                "summary": "List Semesters",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Semester"
+                            }
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -1287,11 +1575,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/semesters/{semesterId}/events/{eventId}/entries/{entryId}": {
-            "delete": {
-                "description": "Delete a participant entry from an event",
+            },
+            "post": {
+                "description": "Create a new semester",
                 "consumes": [
                     "application/json"
                 ],
@@ -1299,40 +1585,78 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Entries"
+                    "Semesters"
                 ],
-                "summary": "Delete Entry",
+                "summary": "Create Semester",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Semester ID",
-                        "name": "semesterId",
-                        "in": "path",
-                        "required": true
+                        "description": "Semester data",
+                        "name": "semester",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateSemesterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Semester"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Event ID",
-                        "name": "eventId",
-                        "in": "path",
-                        "required": true
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{id}": {
+            "get": {
+                "description": "Get a specific semester by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Semesters"
+                ],
+                "summary": "Get Semester",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Membership ID (UUID format)",
-                        "name": "entryId",
+                        "description": "Semester ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
+                            "$ref": "#/definitions/Semester"
                         }
                     },
                     "401": {
@@ -1362,9 +1686,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/semesters/{semesterId}/events/{eventId}/entries/{entryId}/sign-in": {
-            "post": {
-                "description": "Sign in a participant to an event",
+        "/semesters/{semesterId}/events": {
+            "get": {
+                "description": "Retrieve a list of events for a specific semester",
                 "consumes": [
                     "application/json"
                 ],
@@ -1372,9 +1696,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Entries"
+                    "Events"
                 ],
-                "summary": "Sign In Entry",
+                "summary": "List Events",
                 "parameters": [
                     {
                         "type": "string",
@@ -1385,24 +1709,19 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Event ID",
-                        "name": "eventId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Membership ID (UUID format)",
-                        "name": "entryId",
-                        "in": "path",
-                        "required": true
+                        "description": "Search events by name (case-insensitive)",
+                        "name": "search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Participant"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Event"
+                            }
                         }
                     },
                     "400": {
@@ -1423,12 +1742,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1436,11 +1749,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/semesters/{semesterId}/events/{eventId}/entries/{entryId}/sign-out": {
+            },
             "post": {
-                "description": "Sign out a participant from an event",
+                "description": "Create a new event",
                 "consumes": [
                     "application/json"
                 ],
@@ -1448,9 +1759,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Entries"
+                    "Events"
                 ],
-                "summary": "Sign Out Entry",
+                "summary": "Create Event",
                 "parameters": [
                     {
                         "type": "string",
@@ -1460,25 +1771,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Event ID",
-                        "name": "eventId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Membership ID (UUID format)",
-                        "name": "entryId",
-                        "in": "path",
-                        "required": true
+                        "description": "Event data",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateEventRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/Participant"
+                            "$ref": "#/definitions/Event"
                         }
                     },
                     "400": {
@@ -1499,12 +1805,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1514,9 +1814,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/semesters/{semesterId}/events/{eventId}/rebuy": {
-            "post": {
-                "description": "Add a new rebuy entry to an event",
+        "/semesters/{semesterId}/events/{eventId}": {
+            "get": {
+                "description": "Retrieve a specific event by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1526,7 +1826,7 @@ const docTemplate = `{
                 "tags": [
                     "Events"
                 ],
-                "summary": "Rebuy Event",
+                "summary": "Get Event",
                 "parameters": [
                     {
                         "type": "string",
@@ -1544,8 +1844,11 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Event"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1578,11 +1881,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/semesters/{semesterId}/events/{eventId}/restart": {
-            "post": {
-                "description": "Restart an existing Event",
+            },
+            "patch": {
+                "description": "Update an existing event",
                 "consumes": [
                     "application/json"
                 ],
@@ -1592,7 +1893,7 @@ const docTemplate = `{
                 "tags": [
                     "Events"
                 ],
-                "summary": "Restart Event",
+                "summary": "Update Event",
                 "parameters": [
                     {
                         "type": "string",
@@ -1607,11 +1908,23 @@ const docTemplate = `{
                         "name": "eventId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Event data",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateEventRequestV2"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Event"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1646,19 +1959,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/semesters/{semesterId}/memberships": {
+        "/semesters/{semesterId}/events/{eventId}/clock": {
             "get": {
-                "description": "Retrieve a list of all Memberships with extended information including email",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the current level, time remaining and upcoming blinds of an event's tournament clock",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Memberships"
+                    "Clocks"
                 ],
-                "summary": "List all Memberships",
+                "summary": "Get event clock",
                 "parameters": [
                     {
                         "type": "string",
@@ -1667,77 +1977,19 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "integer",
-                        "description": "Maximum number of results to return",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of results to skip",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by first name, last name, email, or full name",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by first name, last name, or full name (case-insensitive partial match)",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by email (case-insensitive partial match)",
-                        "name": "email",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "AHS",
-                            "Arts",
-                            "Engineering",
-                            "Environment",
-                            "Math",
-                            "Science"
-                        ],
-                        "type": "string",
-                        "description": "Filter by faculty (exact match)",
-                        "name": "faculty",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Filter by student ID (exact match)",
-                        "name": "studentId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by paid status",
-                        "name": "paid",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by discounted status",
-                        "name": "discounted",
-                        "in": "query"
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/MembershipWithAttendance"
-                            }
+                            "$ref": "#/definitions/ClockResponse"
                         }
                     },
                     "400": {
@@ -1758,6 +2010,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1765,9 +2023,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/clock/adjust-time": {
             "post": {
-                "description": "Create a new Membership with the provided details",
+                "description": "Add or remove time from the current level of an event's tournament clock",
                 "consumes": [
                     "application/json"
                 ],
@@ -1775,9 +2035,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Memberships"
+                    "Clocks"
                 ],
-                "summary": "Create a new Membership",
+                "summary": "Adjust clock time",
                 "parameters": [
                     {
                         "type": "string",
@@ -1787,20 +2047,27 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Membership details",
-                        "name": "membership",
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Time adjustment",
+                        "name": "adjustment",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateMembershipRequest"
+                            "$ref": "#/definitions/AdjustClockTimeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Membership"
+                            "$ref": "#/definitions/ClockResponse"
                         }
                     },
                     "400": {
@@ -1821,6 +2088,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1830,19 +2103,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/semesters/{semesterId}/memberships/{id}": {
-            "get": {
-                "description": "Retrieve details of a specific Membership by ID",
-                "consumes": [
-                    "application/json"
-                ],
+        "/semesters/{semesterId}/events/{eventId}/clock/pause": {
+            "post": {
+                "description": "Pause an event's running tournament clock",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Memberships"
+                    "Clocks"
                 ],
-                "summary": "Get a Membership",
+                "summary": "Pause event clock",
                 "parameters": [
                     {
                         "type": "string",
@@ -1853,8 +2123,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Membership ID",
-                        "name": "id",
+                        "description": "Event ID",
+                        "name": "eventId",
                         "in": "path",
                         "required": true
                     }
@@ -1863,7 +2133,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Membership"
+                            "$ref": "#/definitions/ClockResponse"
                         }
                     },
                     "400": {
@@ -1897,19 +2167,18 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a specific Membership by ID. Rankings are cascade deleted and participant entries are unlinked.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/clock/resume": {
+            "post": {
+                "description": "Resume an event's paused tournament clock",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Memberships"
+                    "Clocks"
                 ],
-                "summary": "Delete a Membership",
+                "summary": "Resume event clock",
                 "parameters": [
                     {
                         "type": "string",
@@ -1920,15 +2189,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Membership ID",
-                        "name": "id",
+                        "description": "Event ID",
+                        "name": "eventId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ClockResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1961,9 +2233,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update details of a specific Membership by ID",
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/clock/skip-level": {
+            "post": {
+                "description": "Move an event's tournament clock forwards or backwards by a number of levels. The new level starts with its full duration.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1971,9 +2245,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Memberships"
+                    "Clocks"
                 ],
-                "summary": "Update a Membership",
+                "summary": "Skip clock level",
                 "parameters": [
                     {
                         "type": "string",
@@ -1984,18 +2258,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Membership ID",
-                        "name": "id",
+                        "description": "Event ID",
+                        "name": "eventId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated Membership details",
-                        "name": "membership",
+                        "description": "Levels to skip",
+                        "name": "skip",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UpdateMembershipRequest"
+                            "$ref": "#/definitions/SkipClockLevelRequest"
                         }
                     }
                 ],
@@ -2003,7 +2276,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Membership"
+                            "$ref": "#/definitions/ClockResponse"
                         }
                     },
                     "400": {
@@ -2039,16 +2312,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/semesters/{semesterId}/rankings": {
-            "get": {
-                "description": "List the current rankings for a semester",
+        "/semesters/{semesterId}/events/{eventId}/clock/start": {
+            "post": {
+                "description": "Start an event's tournament clock at the first level of its structure",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Rankings"
+                    "Clocks"
                 ],
-                "summary": "List rankings",
+                "summary": "Start event clock",
                 "parameters": [
                     {
                         "type": "string",
@@ -2059,19 +2332,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Search term to filter rankings by name (case-insensitive)",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/RankingResponse"
-                            }
+                            "$ref": "#/definitions/ClockResponse"
                         }
                     },
                     "400": {
@@ -2092,6 +2363,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2101,16 +2378,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/semesters/{semesterId}/rankings/export": {
+        "/semesters/{semesterId}/events/{eventId}/clock/stream": {
             "get": {
-                "description": "Export the rankings for a semester",
+                "description": "Stream the state of an event's tournament clock as server-sent events. A \"clock\" event containing the clock's state is sent every second. If the clock cannot be loaded an \"error\" event is sent and the stream is closed.",
                 "produces": [
-                    "application/octet-stream"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "Rankings"
+                    "Clocks"
                 ],
-                "summary": "Export rankings",
+                "summary": "Stream event clock",
                 "parameters": [
                     {
                         "type": "string",
@@ -2118,13 +2395,20 @@ const docTemplate = `{
                         "name": "semesterId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Rankings exported successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/ClockResponse"
                         }
                     },
                     "400": {
@@ -2145,6 +2429,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2154,16 +2444,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/semesters/{semesterId}/rankings/{membershipId}": {
-            "get": {
-                "description": "Get the ranking for a specific membership in a semester",
+        "/semesters/{semesterId}/events/{eventId}/end": {
+            "post": {
+                "description": "End an existing event. Entries with a placement override take it, and the others are placed by the order they signed out in, with entries that signed out at the same time tied. Overrides which do not fit in the event are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Rankings"
+                    "Events"
                 ],
-                "summary": "Get ranking",
+                "summary": "End Event",
                 "parameters": [
                     {
                         "type": "string",
@@ -2174,18 +2467,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Membership ID",
-                        "name": "membershipId",
+                        "description": "Event ID",
+                        "name": "eventId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/GetRankingResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2205,34 +2495,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/session": {
-            "get": {
-                "description": "Retrieve current user's session information",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "Get current session",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/GetSessionResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -2244,9 +2508,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Authenticate user and create a session",
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/entries": {
+            "get": {
+                "description": "Retrieve a list of participant entries for a specific event",
                 "consumes": [
                     "application/json"
                 ],
@@ -2254,103 +2520,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Entries"
                 ],
-                "summary": "User login",
+                "summary": "List Entries",
                 "parameters": [
                     {
-                        "description": "User credentials",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/NewSessionRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created"
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/session/logout": {
-            "post": {
-                "description": "Invalidate current user session",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "User logout",
-                "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
+                    {
+                        "type": "string",
+                        "description": "Search term to filter entries by participant name or student number (case-insensitive)",
+                        "name": "search",
+                        "in": "query"
                     }
-                }
-            }
-        },
-        "/structures": {
-            "get": {
-                "description": "Retrieve a list of all structures",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
                 ],
-                "tags": [
-                    "Structures"
-                ],
-                "summary": "List Structures",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/Structure"
+                                "$ref": "#/definitions/Participant"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -2372,7 +2582,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new structure with blind levels",
+                "description": "Create new participant entries for an event. Once the event is full, members are added to its waitlist instead",
                 "consumes": [
                     "application/json"
                 ],
@@ -2380,25 +2590,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Structures"
+                    "Entries"
                 ],
-                "summary": "Create Structure",
+                "summary": "Create Entries",
                 "parameters": [
                     {
-                        "description": "Structure data",
-                        "name": "structure",
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Array of membership UUIDs",
+                        "name": "membershipIds",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateStructureRequest"
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "207": {
+                        "description": "Multi-Status",
                         "schema": {
-                            "$ref": "#/definitions/Structure"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/CreateEntryResult"
+                            }
                         }
                     },
                     "400": {
@@ -2428,9 +2658,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/structures/{id}": {
-            "get": {
-                "description": "Retrieve a specific structure by its ID with blinds",
+        "/semesters/{semesterId}/events/{eventId}/entries/{entryId}": {
+            "delete": {
+                "description": "Delete a participant entry from an event",
                 "consumes": [
                     "application/json"
                 ],
@@ -2438,24 +2668,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Structures"
+                    "Entries"
                 ],
-                "summary": "Get Structure",
+                "summary": "Delete Entry",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Structure ID",
-                        "name": "id",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID (UUID format)",
+                        "name": "entryId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/Structure"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2488,31 +2729,50 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete an existing structure",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/entries/{entryId}/rebuys": {
+            "get": {
+                "description": "List the rebuys and add-ons of a participant, oldest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Structures"
+                    "Entries"
                 ],
-                "summary": "Delete Structure",
+                "summary": "List Entry Rebuys",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Structure ID",
-                        "name": "id",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID (UUID format)",
+                        "name": "entryId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Rebuy"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2532,6 +2792,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2540,8 +2806,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
-                "description": "Partially update an existing structure",
+            "post": {
+                "description": "Record a rebuy or add-on for a participant and add its fee to the semester budget. Rebuys are limited by the event's max rebuys, add-ons are not.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2549,32 +2815,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Structures"
+                    "Entries"
                 ],
-                "summary": "Update Structure",
+                "summary": "Create Entry Rebuy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Structure ID",
-                        "name": "id",
+                        "description": "Semester ID",
+                        "name": "semesterId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Partial structure data",
-                        "name": "structure",
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID (UUID format)",
+                        "name": "entryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Type of the purchase",
+                        "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/CreateRebuyRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/Structure"
+                            "$ref": "#/definitions/Rebuy"
                         }
                     },
                     "400": {
@@ -2609,277 +2888,5653 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "Blind": {
-            "type": "object",
-            "properties": {
+        },
+        "/semesters/{semesterId}/events/{eventId}/entries/{entryId}/rebuys/{rebuyId}": {
+            "delete": {
+                "description": "Remove a rebuy or add-on of a participant and take its fee back out of the semester budget",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Entries"
+                ],
+                "summary": "Undo Entry Rebuy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID (UUID format)",
+                        "name": "entryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rebuy ID",
+                        "name": "rebuyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/entries/{entryId}/sign-in": {
+            "post": {
+                "description": "Sign in a participant to an event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Entries"
+                ],
+                "summary": "Sign In Entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID (UUID format)",
+                        "name": "entryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Participant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/entries/{entryId}/sign-out": {
+            "post": {
+                "description": "Sign out a participant from an event, optionally recording the entry that knocked them out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Entries"
+                ],
+                "summary": "Sign Out Entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID (UUID format)",
+                        "name": "entryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Entry that knocked the player out",
+                        "name": "knockout",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/SignOutEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Participant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/guests": {
+            "post": {
+                "description": "Enter a walk-in player without a membership. Guests count towards the size of the event but do not earn ranking points",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Entries"
+                ],
+                "summary": "Create Guest Entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Name of the guest",
+                        "name": "guest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateGuestEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Participant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/guests/{guestId}": {
+            "delete": {
+                "description": "Remove a guest from an event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Entries"
+                ],
+                "summary": "Delete Guest Entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Guest entry ID",
+                        "name": "guestId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/guests/{guestId}/convert": {
+            "post": {
+                "description": "Give a guest entry to a membership of the semester. If the event has ended, the membership earns the points of the entry",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Entries"
+                ],
+                "summary": "Convert Guest Entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Guest entry ID",
+                        "name": "guestId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Membership of the player",
+                        "name": "membership",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ConvertGuestEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Participant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/guests/{guestId}/sign-in": {
+            "post": {
+                "description": "Sign a guest back in to an event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Entries"
+                ],
+                "summary": "Sign In Guest Entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Guest entry ID",
+                        "name": "guestId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Participant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/guests/{guestId}/sign-out": {
+            "post": {
+                "description": "Sign out a guest from an event, optionally recording the entry that knocked them out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Entries"
+                ],
+                "summary": "Sign Out Guest Entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Guest entry ID",
+                        "name": "guestId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Entry that knocked the player out",
+                        "name": "knockout",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/SignOutEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Participant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/kiosk-tokens": {
+            "get": {
+                "description": "List the self check-in kiosk tokens of an event, newest first. Revoked tokens are included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosk"
+                ],
+                "summary": "List kiosk tokens",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/KioskToken"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a token for a self check-in kiosk of an event. The token is only returned once, and stops working when the event ends.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosk"
+                ],
+                "summary": "Create a kiosk token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Kiosk token details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateKioskTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/CreateKioskTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/kiosk-tokens/{tokenId}": {
+            "delete": {
+                "description": "Revoke a kiosk token so that it can no longer be used. The token is kept in the list of tokens of the event.",
+                "tags": [
+                    "Kiosk"
+                ],
+                "summary": "Revoke a kiosk token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kiosk token ID",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/placements": {
+            "put": {
+                "description": "Replace the placement overrides of an event. Entries overridden to the same placement are tied and split the points of the placements they share, and entries without an override are placed by the order they signed out in. Placements of an ended event are corrected, and the rankings are updated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Set Event Placements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Placement overrides",
+                        "name": "placements",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetPlacementsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/EventPlacement"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/rebuy": {
+            "post": {
+                "description": "Add a new rebuy entry to an event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Rebuy Event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/registrations": {
+            "get": {
+                "description": "List the members registered for an event, followed by its waitlist in order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Entries"
+                ],
+                "summary": "List Registrations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Registration"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register a member for an event before it starts. Once the event is full, the member is added to the end of its waitlist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Entries"
+                ],
+                "summary": "Create Registration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Membership to register",
+                        "name": "registration",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateRegistrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Registration"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/registrations/convert": {
+            "post": {
+                "description": "Enter every member holding a spot in the event and draw their seats. Waitlisted members stay on the waitlist",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Entries"
+                ],
+                "summary": "Convert Registrations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Participant"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/registrations/{registrationId}": {
+            "delete": {
+                "description": "Withdraw a registration from an event. The spot it held goes to the first member on the waitlist",
+                "tags": [
+                    "Entries"
+                ],
+                "summary": "Delete Registration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "registrationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/restart": {
+            "post": {
+                "description": "Restart an existing Event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Restart Event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/seating": {
+            "get": {
+                "description": "Get the tables of an event with the seat of every player still in. Seats are drawn when players are entered or sign back in, and tables are rebalanced when players sign out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seating"
+                ],
+                "summary": "Get event seating",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SeatingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/seating/break-plan": {
+            "get": {
+                "description": "Get the seat each player of a table would move to if the table was broken. The plan is the same one Break Table applies, as long as no players enter or sign out in between.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seating"
+                ],
+                "summary": "Get table break plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Table to break. Defaults to the table with the fewest players",
+                        "name": "table",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/TableBreakPlan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/events/{eventId}/seating/break-table": {
+            "post": {
+                "description": "Break a table, moving each of its players to an open seat at the other tables",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seating"
+                ],
+                "summary": "Break table",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Table to break",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/BreakTableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/TableBreakPlan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/ledger": {
+            "get": {
+                "description": "List the entries of the budget ledger of a semester, newest first. Every membership fee, rebuy, add-on and transaction is an entry in cents, and the balance is the starting budget plus every entry.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ledger"
+                ],
+                "summary": "List ledger entries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/LedgerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/ledger/reconciliation": {
+            "get": {
+                "description": "Compare the balance computed from the ledger with the legacy current budget of a semester. Drift is the legacy balance minus the ledger balance, and is usually left over from adjustments made before the ledger existed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ledger"
+                ],
+                "summary": "Reconcile ledger",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/LedgerReconciliation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/memberships": {
+            "get": {
+                "description": "Retrieve a list of all Memberships with extended information including email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Memberships"
+                ],
+                "summary": "List all Memberships",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of results to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by first name, last name, email, or full name",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by first name, last name, or full name (case-insensitive partial match)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by email (case-insensitive partial match)",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "AHS",
+                            "Arts",
+                            "Engineering",
+                            "Environment",
+                            "Math",
+                            "Science"
+                        ],
+                        "type": "string",
+                        "description": "Filter by faculty (exact match)",
+                        "name": "faculty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by student ID (exact match)",
+                        "name": "studentId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by paid status",
+                        "name": "paid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by discounted status",
+                        "name": "discounted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/MembershipWithAttendance"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new Membership with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Memberships"
+                ],
+                "summary": "Create a new Membership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Membership details",
+                        "name": "membership",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateMembershipRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Membership"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/memberships/export": {
+            "get": {
+                "description": "Download every Membership of a semester matching the filters as a CSV file, with each member's paid and discounted status, the fee they paid and their attendance, so payments can be reconciled. The file is streamed as it is read, so it is not limited in size.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Memberships"
+                ],
+                "summary": "Export Memberships",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by first name, last name, email, or full name",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by first name, last name, or full name (case-insensitive partial match)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by email (case-insensitive partial match)",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "AHS",
+                            "Arts",
+                            "Engineering",
+                            "Environment",
+                            "Math",
+                            "Science"
+                        ],
+                        "type": "string",
+                        "description": "Filter by faculty (exact match)",
+                        "name": "faculty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by student ID (exact match)",
+                        "name": "studentId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by paid status",
+                        "name": "paid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by discounted status",
+                        "name": "discounted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV file with the columns membership_id, user_id, first_name, last_name, email, faculty, quest_id, paid, discounted, fee and attendance",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/memberships/{id}": {
+            "get": {
+                "description": "Retrieve details of a specific Membership by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Memberships"
+                ],
+                "summary": "Get a Membership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Membership"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a specific Membership by ID. Rankings are cascade deleted and participant entries are unlinked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Memberships"
+                ],
+                "summary": "Delete a Membership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update details of a specific Membership by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Memberships"
+                ],
+                "summary": "Update a Membership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated Membership details",
+                        "name": "membership",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateMembershipRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Membership"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/points-scheme": {
+            "get": {
+                "description": "Get the points scheme used to award points for events in a semester. Semesters without a configured scheme return the default scheme.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Points Schemes"
+                ],
+                "summary": "Get points scheme",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PointsScheme"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Create or replace the points scheme for a semester. Payouts are listed in placement order, starting with 1st place. The scheme cannot be changed once an event of the semester has ended.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Points Schemes"
+                ],
+                "summary": "Create or replace points scheme",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Points scheme",
+                        "name": "pointsScheme",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpsertPointsSchemeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PointsScheme"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete the configured points scheme for a semester, reverting it to the default scheme. The scheme cannot be changed once an event of the semester has ended.",
+                "tags": [
+                    "Points Schemes"
+                ],
+                "summary": "Delete points scheme",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/rankings": {
+            "get": {
+                "description": "List the current rankings for a semester",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rankings"
+                ],
+                "summary": "List rankings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term to filter rankings by name (case-insensitive)",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/RankingResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/rankings/export": {
+            "get": {
+                "description": "Download every ranking of a semester with its position, points, attendance and the number of ended events played. The file is streamed as it is read, so it is not limited in size. The format is csv by default, json for an array of rankings, or tsv for tab separated values that spreadsheet programs such as Excel open directly. With eventId or asOf, the rankings are computed as they stood once that event ended, or from the ended events that started by that time. A date for asOf includes every event that started on that day.",
+                "produces": [
+                    "text/csv",
+                    "application/json",
+                    "text/tab-separated-values"
+                ],
+                "tags": [
+                    "Rankings"
+                ],
+                "summary": "Export rankings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json",
+                            "tsv"
+                        ],
+                        "type": "string",
+                        "description": "Format of the export (default csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Export the rankings as they stood once this ended event ended",
+                        "name": "eventId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export the rankings as of this date (YYYY-MM-DD) or RFC 3339 time",
+                        "name": "asOf",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rankings in the requested format",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/RankingExportRow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/rankings/history": {
+            "get": {
+                "description": "List the snapshots of a semester's rankings, oldest first, to show how the leaderboard moved over the semester. A snapshot is recorded whenever an event is ended or its end is undone. Use top to only include the rankings at or above a position in each snapshot.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rankings"
+                ],
+                "summary": "List rankings history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only include rankings at or above this position",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of snapshots to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of snapshots to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ListResponse-RankingHistorySnapshot"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/rankings/rebuild": {
+            "post": {
+                "description": "Recalculate every ranking's points and attendance in a semester from the placements of its ended events. With dryRun the changes are reported without being saved.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rankings"
+                ],
+                "summary": "Rebuild rankings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Report the changes without saving them",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/RebuildRankingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/rankings/{membershipId}": {
+            "get": {
+                "description": "Get the ranking for a specific membership in a semester",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rankings"
+                ],
+                "summary": "Get ranking",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID",
+                        "name": "membershipId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/GetRankingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/semesters/{semesterId}/rankings/{membershipId}/history": {
+            "get": {
+                "description": "Get the points and position of a membership in every ranking snapshot of its semester, oldest first, as a time series for charts. Snapshots from before the membership was first ranked are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rankings"
+                ],
+                "summary": "Get ranking history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Membership ID",
+                        "name": "membershipId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/RankingHistoryPoint"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/session": {
+            "get": {
+                "description": "Retrieve current user's session information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Get current session",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/GetSessionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Authenticate user and create a session. Logins with two-factor authentication get a challenge instead, which is completed with POST /session/2fa/verify.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "User login",
+                "parameters": [
+                    {
+                        "description": "User credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/NewSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "202": {
+                        "description": "A two-factor code is required, submit it to /session/2fa/verify",
+                        "schema": {
+                            "$ref": "#/definitions/TwoFactorChallengeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/session/2fa": {
+            "get": {
+                "description": "Get whether the current user has enabled two-factor authentication, whether their role requires it, and how many recovery codes are left",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Get two-factor status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/TwoFactorStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Turn off two-factor authentication for the current user after checking their password. Roles which require two-factor authentication cannot turn it off.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Disable two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "Current password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/DisableTwoFactorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/session/2fa/enable": {
+            "post": {
+                "description": "Enable two-factor authentication with a code from the authenticator app it was set up with. Returns recovery codes, which are only shown once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Enable two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "Code from the authenticator app",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/TwoFactorRecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/session/2fa/recovery-codes": {
+            "post": {
+                "description": "Replace the recovery codes of the current user after checking a code from their authenticator app. The previous recovery codes stop working.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Regenerate recovery codes",
+                "parameters": [
+                    {
+                        "description": "Code from the authenticator app",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/TwoFactorRecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/session/2fa/setup": {
+            "post": {
+                "description": "Generate a secret to add to an authenticator app, e.g. by showing the otpauth URL as a QR code. Two-factor authentication is enabled once a code from the app is submitted to /session/2fa/enable.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Set up two-factor authentication",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/TwoFactorSetupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/session/2fa/verify": {
+            "post": {
+                "description": "Complete a login that returned a two-factor challenge with a code from an authenticator app or a recovery code, and create a session. Recovery codes can only be used once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Verify two-factor code",
+                "parameters": [
+                    {
+                        "description": "Challenge token and code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/VerifyTwoFactorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/session/logout": {
+            "post": {
+                "description": "Invalidate current user session",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "User logout",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/session/password": {
+            "put": {
+                "description": "Set a new password for the current user after checking the current password. Every other session of the user is ended. Incorrect current passwords count as failed logins for login throttling.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Change password",
+                "parameters": [
+                    {
+                        "description": "Current and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/session/password-reset": {
+            "post": {
+                "description": "Set a new password with a single-use reset token issued by an administrator. Every session of the login is ended, and any login lockout of the username is lifted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/session/sessions": {
+            "get": {
+                "description": "List the active sessions of the current user, most recently used first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "List sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SessionInfo"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "End every session of the current user except the one making the request",
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Revoke other sessions",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/session/sessions/{sessionId}": {
+            "delete": {
+                "description": "End another session of the current user. The current session is ended by logging out instead.",
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Revoke a session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID from the list of sessions",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/structures": {
+            "get": {
+                "description": "Retrieve a list of all structures",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Structures"
+                ],
+                "summary": "List Structures",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Structure"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new structure with blind levels",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Structures"
+                ],
+                "summary": "Create Structure",
+                "parameters": [
+                    {
+                        "description": "Structure data",
+                        "name": "structure",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateStructureRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Structure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/structures/import": {
+            "post": {
+                "description": "Create a new structure from CSV or the JSON interchange format. CSV files need a header row naming the columns: small, big and time (in minutes) are required, type, label and ante are optional and unknown columns are ignored. Every level is validated with the same rules as Create Structure, and all invalid levels are reported together.",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Structures"
+                ],
+                "summary": "Import Structure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Format of the request body, csv or json. Defaults to csv when the Content-Type is text/csv, otherwise json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the structure. Required for CSV, overrides the name in JSON",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Structure to import",
+                        "name": "structure",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/StructureInterchange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Structure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/StructureImportErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/structures/{id}": {
+            "get": {
+                "description": "Retrieve a specific structure by its ID with blinds",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Structures"
+                ],
+                "summary": "Get Structure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Structure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Structure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an existing structure. Structures used by an event cannot be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Structures"
+                ],
+                "summary": "Delete Structure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Structure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update an existing structure",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Structures"
+                ],
+                "summary": "Update Structure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Structure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Partial structure data",
+                        "name": "structure",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Structure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/structures/{id}/clone": {
+            "post": {
+                "description": "Create a new structure with the levels of an existing structure. The current version is copied unless a version is given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Structures"
+                ],
+                "summary": "Clone Structure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Structure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Clone options",
+                        "name": "clone",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/CloneStructureRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Structure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/structures/{id}/export": {
+            "get": {
+                "description": "Download a structure as CSV or in the JSON interchange format accepted by Import Structure",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Structures"
+                ],
+                "summary": "Export Structure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Structure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export format, csv or json (default json)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/StructureInterchange"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/structures/{id}/versions": {
+            "get": {
+                "description": "Retrieve every version of a structure with its levels, newest first. A version is recorded each time the structure is created or edited.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Structures"
+                ],
+                "summary": "List Structure Versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Structure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of versions to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of versions to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ListResponse-StructureVersion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/structures/{id}/versions/{version}": {
+            "get": {
+                "description": "Retrieve a version of a structure with the levels it had at that version",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Structures"
+                ],
+                "summary": "Get Structure Version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Structure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version number",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/StructureVersion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "APIKey": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "description": "ExpiresAt is when the key stops working. Null keys do not expire.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Discord bot"
+                },
+                "prefix": {
+                    "description": "Prefix is the start of the key, to tell keys apart without storing them.",
+                    "type": "string",
+                    "example": "uwpsc_3f9a1c"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "event.list",
+                        "event.participant.signin"
+                    ]
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "AdjustClockTimeRequest": {
+            "type": "object",
+            "required": [
+                "seconds"
+            ],
+            "properties": {
+                "seconds": {
+                    "description": "Seconds is added to the time remaining in the current level; negative values remove time.",
+                    "type": "integer",
+                    "example": 60
+                }
+            }
+        },
+        "AuditChange": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "object"
+                },
+                "to": {
+                    "type": "object"
+                }
+            }
+        },
+        "AuditEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action is the authorization action string, e.g. \"event.end\".",
+                    "type": "string",
+                    "example": "event.end"
+                },
+                "actor": {
+                    "description": "Actor is the username of the login that performed the action.",
+                    "type": "string",
+                    "example": "jdoe"
+                },
+                "changes": {
+                    "description": "Changes contains the fields of the targeted resource which the action\nchanged, keyed by field. It is only recorded for actions whose target\ncan be loaded from the path, such as \"login.edit\".",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/AuditChange"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "POST"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/api/v2/semesters/550e8400-e29b-41d4-a716-446655440000/events/1/end"
+                },
+                "request": {
+                    "description": "Request contains the JSON request body with any sensitive fields redacted.\nIt is what was asked for, while Changes is what changed.",
+                    "type": "object"
+                },
+                "role": {
+                    "description": "Role is the role the actor held when performing the action.",
+                    "type": "string",
+                    "example": "president"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "targets": {
+                    "description": "Targets contains the resource IDs taken from the request's path parameters.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "Blind": {
+            "type": "object",
+            "properties": {
+                "ante": {
+                    "type": "integer"
+                },
+                "big": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Dinner break"
+                },
+                "small": {
+                    "type": "integer"
+                },
+                "time": {
+                    "description": "Time is the length of the level in minutes",
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "blind",
+                        "break",
+                        "chip_race"
+                    ],
+                    "example": "blind"
+                }
+            }
+        },
+        "BreakTableRequest": {
+            "type": "object",
+            "properties": {
+                "table": {
+                    "description": "Table to break. Defaults to the table with the fewest players",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "currentPassword",
+                "newPassword"
+            ],
+            "properties": {
+                "currentPassword": {
+                    "type": "string"
+                },
+                "newPassword": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "ClockLevel": {
+            "type": "object",
+            "properties": {
                 "ante": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "big": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "duration": {
+                    "description": "Duration is the length of the level in seconds",
+                    "type": "integer",
+                    "example": 1200
+                },
+                "label": {
+                    "type": "string",
+                    "example": ""
+                },
+                "small": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "blind",
+                        "break",
+                        "chip_race"
+                    ],
+                    "example": "blind"
+                }
+            }
+        },
+        "ClockResponse": {
+            "type": "object",
+            "properties": {
+                "currentLevel": {
+                    "$ref": "#/definitions/ClockLevel"
+                },
+                "eventId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "finished": {
+                    "description": "Finished is true once the final level has elapsed",
+                    "type": "boolean"
+                },
+                "level": {
+                    "description": "Level is the 1-based number of the current level",
+                    "type": "integer",
+                    "example": 1
+                },
+                "nextLevel": {
+                    "$ref": "#/definitions/ClockLevel"
+                },
+                "running": {
+                    "description": "Running is false while the clock is paused or after the final level has elapsed",
+                    "type": "boolean"
+                },
+                "serverTime": {
+                    "type": "string"
+                },
+                "timeRemaining": {
+                    "description": "TimeRemaining is the time left in the current level in milliseconds",
+                    "type": "integer",
+                    "example": 754000
+                },
+                "totalLevels": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "CloneStructureRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Name of the new structure. Defaults to the name of the original followed by \"(copy)\"",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Monday Turbo (deep)"
+                },
+                "version": {
+                    "description": "Version of the original to copy. Defaults to the current version",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "ConvertGuestEntryRequest": {
+            "type": "object",
+            "required": [
+                "membershipId"
+            ],
+            "properties": {
+                "membershipId": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expiresAt": {
+                    "description": "ExpiresAt is optional. Keys without an expiry last until revoked",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Discord bot"
+                },
+                "scopes": {
+                    "description": "Scopes are the actions the key may perform, e.g. event.participant.signin",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "event.list",
+                        "event.participant.signin"
+                    ]
+                }
+            }
+        },
+        "CreateAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "description": "ExpiresAt is when the key stops working. Null keys do not expire.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "uwpsc_3f9a1c0d2b4e6f8a0c1e3b5d7f9a1c3e5b7d9f1a3c5e7b9d1f3a5c7e9b1d3f5a"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Discord bot"
+                },
+                "prefix": {
+                    "description": "Prefix is the start of the key, to tell keys apart without storing them.",
+                    "type": "string",
+                    "example": "uwpsc_3f9a1c"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "event.list",
+                        "event.participant.signin"
+                    ]
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateEntryResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "membershipId": {
+                    "type": "string"
+                },
+                "participant": {
+                    "$ref": "#/definitions/Participant"
+                },
+                "registration": {
+                    "description": "Registration is the place of the member on the waitlist of a full event",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Registration"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "\"created\", \"waitlisted\" or \"error\"",
+                    "type": "string"
+                }
+            }
+        },
+        "CreateEventRequest": {
+            "type": "object",
+            "required": [
+                "format",
+                "name",
+                "pointsMultiplier",
+                "semesterId",
+                "startDate",
+                "structureId"
+            ],
+            "properties": {
+                "format": {
+                    "type": "string"
+                },
+                "knockoutPoints": {
+                    "description": "KnockoutPoints are the bonus points awarded for each knockout once the\nevent ends. They are not scaled by the points multiplier",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "maxEntries": {
+                    "description": "MaxEntries is the number of players the event has room for. Members\nentered or registered once it is full are waitlisted. Unlimited when null",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 45
+                },
+                "maxRebuys": {
+                    "description": "MaxRebuys is the number of times each player may rebuy. Unlimited when null",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "pointsMultiplier": {
+                    "type": "number"
+                },
+                "semesterId": {
+                    "type": "string"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "structureId": {
+                    "type": "integer"
+                },
+                "tableSize": {
+                    "description": "TableSize is the number of seats at each table. Defaults to 9",
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 2
+                }
+            }
+        },
+        "CreateGuestEntryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Sam Walker"
+                }
+            }
+        },
+        "CreateKioskTokenRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "expiresAt": {
+                    "description": "ExpiresAt is optional. Tokens without an expiry last until the event ends",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Front desk"
+                }
+            }
+        },
+        "CreateKioskTokenResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "CreatedBy is the username of the login that created the token",
+                    "type": "string",
+                    "example": "director"
+                },
+                "eventId": {
+                    "type": "integer"
+                },
+                "expiresAt": {
+                    "description": "ExpiresAt is when the token stops working, if the event has not ended by then.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Front desk"
+                },
+                "prefix": {
+                    "description": "Prefix is the start of the token, to tell tokens apart without storing them.",
+                    "type": "string",
+                    "example": "uwpsck_8b2e41"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "uwpsck_8b2e41c0d2b4e6f8a0c1e3b5d7f9a1c3e5b7d9f1a3c5e7b9d1f3a5c7e9b1d3f5"
+                }
+            }
+        },
+        "CreateLoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "role",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "bot",
+                        "executive",
+                        "tournament_director",
+                        "secretary",
+                        "treasurer",
+                        "vice_president",
+                        "president",
+                        "webmaster"
+                    ]
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateMemberRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "firstName",
+                "id",
+                "lastName"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "faculty": {
+                    "type": "string",
+                    "enum": [
+                        "AHS",
+                        "Arts",
+                        "Engineering",
+                        "Environment",
+                        "Math",
+                        "Science"
+                    ]
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "questId": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "CreateMembershipRequest": {
+            "type": "object",
+            "required": [
+                "userId"
+            ],
+            "properties": {
+                "discounted": {
+                    "type": "boolean"
+                },
+                "paid": {
+                    "type": "boolean"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CreatePasswordResetTokenResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "3f9a1c0d2b4e6f8a0c1e3b5d7f9a1c3e5b7d9f1a3c5e7b9d1f3a5c7e9b1d3f5a"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "jdoe"
+                }
+            }
+        },
+        "CreateRebuyRequest": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "description": "Type of the purchase. Defaults to rebuy",
+                    "type": "string",
+                    "enum": [
+                        "rebuy",
+                        "add_on"
+                    ],
+                    "example": "add_on"
+                }
+            }
+        },
+        "CreateRegistrationRequest": {
+            "type": "object",
+            "required": [
+                "membershipId"
+            ],
+            "properties": {
+                "membershipId": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateSemesterRequest": {
+            "type": "object",
+            "required": [
+                "endDate",
+                "membershipDiscountFee",
+                "membershipFee",
+                "name",
+                "rebuyFee",
+                "startDate"
+            ],
+            "properties": {
+                "addOnFee": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "endDate": {
+                    "type": "string",
+                    "example": "2023-12-31T23:59:59Z"
+                },
+                "membershipDiscountFee": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "membershipFee": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                },
+                "meta": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Fall 2023"
+                },
+                "rebuyFee": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2023-09-01T00:00:00Z"
+                },
+                "startingBudget": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 100
+                }
+            }
+        },
+        "DisableTwoFactorRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the HTTP status code for the error.",
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "Message is a more descriptive error message.",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type is the name of the HTTP status code.",
+                    "type": "string"
+                }
+            }
+        },
+        "Event": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Participant"
+                    }
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "knockoutPoints": {
+                    "type": "integer"
+                },
+                "maxEntries": {
+                    "type": "integer"
+                },
+                "maxRebuys": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "pointsMultiplier": {
+                    "type": "number"
+                },
+                "rebuys": {
+                    "type": "integer"
+                },
+                "semester": {
+                    "$ref": "#/definitions/Semester"
+                },
+                "semesterId": {
+                    "type": "string"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "integer"
+                },
+                "structure": {
+                    "$ref": "#/definitions/Structure"
+                },
+                "structureId": {
+                    "type": "integer"
+                },
+                "structureVersionId": {
+                    "type": "integer"
+                },
+                "tableSize": {
+                    "type": "integer"
+                }
+            }
+        },
+        "EventPlacement": {
+            "type": "object",
+            "properties": {
+                "entryId": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "firstName": {
+                    "type": "string",
+                    "example": "Jane"
+                },
+                "lastName": {
+                    "type": "string",
+                    "example": "Doe"
+                },
+                "membershipId": {
+                    "type": "string"
+                },
+                "overridden": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "placement": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "points": {
+                    "type": "integer",
+                    "example": 18
+                },
+                "tied": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "GetRankingResponse": {
+            "type": "object",
+            "properties": {
+                "points": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "GetSessionResponse": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {}
+                    }
+                },
+                "role": {
+                    "type": "string"
+                },
+                "twoFactorSetupRequired": {
+                    "description": "TwoFactorSetupRequired is true when the role must use two-factor\nauthentication and the session has not. Such sessions may only set up\ntwo-factor authentication.",
+                    "type": "boolean"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "KioskCheckInRequest": {
+            "type": "object",
+            "properties": {
+                "questId": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "jdoe"
+                },
+                "studentId": {
+                    "type": "integer",
+                    "example": 20780648
+                }
+            }
+        },
+        "KioskCheckInResponse": {
+            "type": "object",
+            "properties": {
+                "alreadyCheckedIn": {
+                    "description": "AlreadyCheckedIn is true when the member had already been entered",
+                    "type": "boolean",
+                    "example": false
+                },
+                "firstName": {
+                    "description": "FirstName greets the member. Their last name is not shown on the kiosk",
+                    "type": "string",
+                    "example": "Jane"
+                },
+                "seatNumber": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "tableNumber": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "waitlistPosition": {
+                    "description": "WaitlistPosition is the place of the member on the waitlist when the\nevent is full. They have no seat until a spot frees up",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "KioskEventResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Fall 2025 Event #3"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "KioskToken": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "CreatedBy is the username of the login that created the token",
+                    "type": "string",
+                    "example": "director"
+                },
+                "eventId": {
+                    "type": "integer"
+                },
+                "expiresAt": {
+                    "description": "ExpiresAt is when the token stops working, if the event has not ended by then.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Front desk"
+                },
+                "prefix": {
+                    "description": "Prefix is the start of the token, to tell tokens apart without storing them.",
+                    "type": "string",
+                    "example": "uwpsck_8b2e41"
+                },
+                "revokedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "LedgerEntry": {
+            "type": "object",
+            "properties": {
+                "amountCents": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Membership fee"
+                },
+                "eventId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "membershipId": {
+                    "type": "string"
+                },
+                "semesterId": {
+                    "type": "string"
+                },
+                "transactionId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "membership_fee",
+                        "rebuy",
+                        "add_on",
+                        "transaction"
+                    ],
+                    "example": "membership_fee"
+                }
+            }
+        },
+        "LedgerReconciliation": {
+            "type": "object",
+            "properties": {
+                "balanceCents": {
+                    "type": "integer",
+                    "example": 12500
+                },
+                "driftCents": {
+                    "description": "DriftCents is the legacy balance minus the ledger balance",
+                    "type": "integer",
+                    "example": -2
+                },
+                "drifted": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "legacyBalanceCents": {
+                    "type": "integer",
+                    "example": 12498
+                },
+                "semesterId": {
+                    "type": "string"
+                },
+                "startingBudgetCents": {
+                    "type": "integer",
+                    "example": 10000
+                },
+                "totals": {
+                    "description": "Totals is the sum of the entries of each type",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                }
+            }
+        },
+        "LedgerResponse": {
+            "type": "object",
+            "properties": {
+                "balanceCents": {
+                    "type": "integer",
+                    "example": 12500
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LedgerEntry"
+                    }
+                },
+                "startingBudgetCents": {
+                    "type": "integer",
+                    "example": 10000
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "LinkedMemberInfo": {
+            "type": "object",
+            "properties": {
+                "firstName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastName": {
+                    "type": "string"
+                }
+            }
+        },
+        "LoginWithMember": {
+            "type": "object",
+            "properties": {
+                "linkedMember": {
+                    "$ref": "#/definitions/LinkedMemberInfo"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "Member": {
+            "type": "object",
+            "required": [
+                "email",
+                "firstName",
+                "id",
+                "lastName"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "faculty": {
+                    "type": "string",
+                    "enum": [
+                        "AHS",
+                        "Arts",
+                        "Engineering",
+                        "Environment",
+                        "Math",
+                        "Science"
+                    ]
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "questId": {
+                    "type": "string"
+                }
+            }
+        },
+        "MemberImportErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/MemberImportRowError"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "The import has 1 invalid row"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "INVALID_REQUEST"
+                }
+            }
+        },
+        "MemberImportResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "description": "DryRun is true when nothing was saved",
+                    "type": "boolean"
+                },
+                "membershipsCreated": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/MemberImportRowResult"
+                    }
+                },
+                "semesterId": {
+                    "type": "string"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "MemberImportRowError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "email"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "email must be a valid email address"
+                },
+                "row": {
+                    "description": "Row is the 1-based number of the row in the file, not counting the header",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "MemberImportRowResult": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 20801234
+                },
+                "member": {
+                    "description": "Member is created, updated or unchanged",
+                    "type": "string",
+                    "example": "created"
+                },
+                "membership": {
+                    "description": "Membership is created or exists, and empty when the import has no semester",
+                    "type": "string",
+                    "example": "created"
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "Membership": {
+            "type": "object",
+            "properties": {
+                "discounted": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "paid": {
+                    "type": "boolean"
+                },
+                "ranking": {
+                    "$ref": "#/definitions/Ranking"
+                },
+                "semester": {
+                    "$ref": "#/definitions/Semester"
+                },
+                "semesterId": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/Member"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "MembershipWithAttendance": {
+            "type": "object",
+            "properties": {
+                "attendance": {
+                    "type": "integer"
+                },
+                "discounted": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "paid": {
+                    "type": "boolean"
+                },
+                "ranking": {
+                    "$ref": "#/definitions/Ranking"
+                },
+                "semester": {
+                    "$ref": "#/definitions/Semester"
+                },
+                "semesterId": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/Member"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "NewSessionRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "Participant": {
+            "type": "object",
+            "properties": {
+                "addOns": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "eventId": {
+                    "type": "integer"
+                },
+                "guestName": {
+                    "description": "GuestName is the name of a walk-in player without a membership. Guests\ncount towards the size of the event but do not earn ranking points\nuntil they are converted to a membership.",
+                    "type": "string",
+                    "example": "Sam Walker"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "knockedOutById": {
+                    "description": "KnockedOutByID is the entry that eliminated the player, recorded when\nthey sign out. It is cleared when they sign back in.",
+                    "type": "integer",
+                    "example": 14
+                },
+                "membership": {
+                    "$ref": "#/definitions/Membership"
+                },
+                "membershipId": {
+                    "type": "string"
+                },
+                "placement": {
                     "type": "integer"
                 },
-                "big": {
-                    "type": "integer"
+                "placementOverride": {
+                    "description": "PlacementOverride is the placement set by the director, which takes\nprecedence over the order players signed out in when the event ends.\nEntries overridden to the same placement are tied.",
+                    "type": "integer",
+                    "example": 2
+                },
+                "rebuys": {
+                    "description": "Rebuys and AddOns count the rebuys and add-ons the player has bought",
+                    "type": "integer",
+                    "example": 1
+                },
+                "seatNumber": {
+                    "type": "integer",
+                    "example": 7
                 },
-                "small": {
-                    "type": "integer"
+                "signedOutAt": {
+                    "type": "string"
                 },
-                "time": {
-                    "type": "integer"
+                "tableNumber": {
+                    "description": "TableNumber and SeatNumber are the seat of a player still in the event.\nBoth are null once the player signs out.",
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "CreateEntryResult": {
+        "PlacementOverride": {
             "type": "object",
+            "required": [
+                "membershipId",
+                "placement"
+            ],
             "properties": {
-                "error": {
-                    "type": "string"
-                },
                 "membershipId": {
                     "type": "string"
                 },
-                "participant": {
-                    "$ref": "#/definitions/Participant"
+                "placement": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                }
+            }
+        },
+        "PointsPayout": {
+            "type": "object",
+            "properties": {
+                "placement": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "status": {
-                    "description": "\"created\" or \"error\"",
-                    "type": "string"
+                "points": {
+                    "type": "integer",
+                    "example": 32
                 }
             }
         },
-        "CreateEventRequest": {
+        "PointsScheme": {
             "type": "object",
-            "required": [
-                "format",
-                "name",
-                "pointsMultiplier",
-                "semesterId",
-                "startDate",
-                "structureId"
-            ],
             "properties": {
-                "format": {
-                    "type": "string"
+                "defaultPayout": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "name": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "notes": {
-                    "type": "string"
+                "minimumPoints": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "pointsMultiplier": {
-                    "type": "number"
+                "payouts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PointsPayout"
+                    }
                 },
-                "semesterId": {
-                    "type": "string"
+                "roundingMode": {
+                    "type": "string",
+                    "example": "ceil"
                 },
-                "startDate": {
-                    "type": "string"
+                "semesterId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "structureId": {
-                    "type": "integer"
+                "sizeFactor": {
+                    "type": "number",
+                    "example": 50
                 }
             }
         },
-        "CreateLoginRequest": {
+        "Ranking": {
             "type": "object",
-            "required": [
-                "password",
-                "role",
-                "username"
-            ],
             "properties": {
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "attendance": {
+                    "type": "integer"
                 },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "bot",
-                        "executive",
-                        "tournament_director",
-                        "secretary",
-                        "treasurer",
-                        "vice_president",
-                        "president",
-                        "webmaster"
-                    ]
+                "id": {
+                    "type": "integer"
                 },
-                "username": {
+                "membershipId": {
                     "type": "string"
+                },
+                "points": {
+                    "type": "integer"
                 }
             }
         },
-        "CreateMemberRequest": {
+        "RankingDiff": {
             "type": "object",
-            "required": [
-                "email",
-                "firstName",
-                "id",
-                "lastName"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "faculty": {
-                    "type": "string",
-                    "enum": [
-                        "AHS",
-                        "Arts",
-                        "Engineering",
-                        "Environment",
-                        "Math",
-                        "Science"
-                    ]
+                "attendance": {
+                    "type": "integer"
                 },
                 "firstName": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
                 "lastName": {
                     "type": "string"
                 },
-                "questId": {
+                "membershipId": {
                     "type": "string"
-                }
-            }
-        },
-        "CreateMembershipRequest": {
-            "type": "object",
-            "required": [
-                "userId"
-            ],
-            "properties": {
-                "discounted": {
-                    "type": "boolean"
                 },
-                "paid": {
-                    "type": "boolean"
+                "points": {
+                    "type": "integer"
                 },
-                "userId": {
+                "previousAttendance": {
+                    "type": "integer"
+                },
+                "previousPoints": {
                     "type": "integer"
                 }
             }
         },
-        "CreateSemesterRequest": {
+        "RankingExportRow": {
             "type": "object",
-            "required": [
-                "endDate",
-                "membershipDiscountFee",
-                "membershipFee",
-                "name",
-                "rebuyFee",
-                "startDate"
-            ],
             "properties": {
-                "endDate": {
-                    "type": "string",
-                    "example": "2023-12-31T23:59:59Z"
-                },
-                "membershipDiscountFee": {
+                "attendance": {
+                    "description": "Attendance is the number of events the member was entered in, counting\nevents that have not ended",
                     "type": "integer",
-                    "minimum": 0,
-                    "example": 5
+                    "example": 6
                 },
-                "membershipFee": {
+                "eventsPlayed": {
+                    "description": "EventsPlayed is the number of ended events the member was entered in,\nwhich are the events their points come from",
                     "type": "integer",
-                    "minimum": 0,
-                    "example": 10
-                },
-                "meta": {
-                    "type": "string"
+                    "example": 5
                 },
-                "name": {
+                "firstName": {
                     "type": "string",
-                    "example": "Fall 2023"
+                    "example": "Jane"
                 },
-                "rebuyFee": {
+                "id": {
                     "type": "integer",
-                    "minimum": 0,
-                    "example": 2
+                    "example": 20780648
                 },
-                "startDate": {
+                "lastName": {
                     "type": "string",
-                    "example": "2023-09-01T00:00:00Z"
+                    "example": "Doe"
                 },
-                "startingBudget": {
-                    "type": "number",
-                    "minimum": 0,
-                    "example": 100
+                "points": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "ErrorResponse": {
+        "RankingHistoryEntry": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "Code is the HTTP status code for the error.",
-                    "type": "integer"
+                "firstName": {
+                    "type": "string",
+                    "example": "Jane"
                 },
-                "message": {
-                    "description": "Message is a more descriptive error message.",
-                    "type": "string"
+                "lastName": {
+                    "type": "string",
+                    "example": "Doe"
                 },
-                "type": {
-                    "description": "Type is the name of the HTTP status code.",
+                "membershipId": {
                     "type": "string"
+                },
+                "points": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "userId": {
+                    "type": "integer",
+                    "example": 20780648
                 }
             }
         },
-        "Event": {
+        "RankingHistoryPoint": {
             "type": "object",
             "properties": {
-                "entries": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/Participant"
-                    }
-                },
-                "format": {
+                "createdAt": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
+                "eventId": {
+                    "type": "integer",
+                    "example": 4
                 },
-                "notes": {
-                    "type": "string"
+                "eventName": {
+                    "type": "string",
+                    "example": "Week 4 Tournament"
                 },
-                "pointsMultiplier": {
-                    "type": "number"
+                "points": {
+                    "type": "integer",
+                    "example": 120
                 },
-                "rebuys": {
-                    "type": "integer"
+                "position": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "semester": {
-                    "$ref": "#/definitions/Semester"
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "event_ended",
+                        "event_end_undone",
+                        "placements_corrected"
+                    ],
+                    "example": "event_ended"
                 },
-                "semesterId": {
+                "snapshotId": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "RankingHistorySnapshot": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
                     "type": "string"
                 },
-                "startDate": {
-                    "type": "string"
+                "eventId": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "eventName": {
+                    "type": "string",
+                    "example": "Week 4 Tournament"
                 },
-                "state": {
-                    "type": "integer"
+                "id": {
+                    "type": "integer",
+                    "example": 12
                 },
-                "structure": {
-                    "$ref": "#/definitions/Structure"
+                "rankings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RankingHistoryEntry"
+                    }
                 },
-                "structureId": {
-                    "type": "integer"
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "event_ended",
+                        "event_end_undone",
+                        "placements_corrected"
+                    ],
+                    "example": "event_ended"
                 }
             }
         },
-        "GetRankingResponse": {
+        "RankingResponse": {
             "type": "object",
             "properties": {
+                "firstName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "knockouts": {
+                    "description": "Knockouts is the number of players the member eliminated in ended events",
+                    "type": "integer"
+                },
+                "lastName": {
+                    "type": "string"
+                },
                 "points": {
                     "type": "integer"
                 },
@@ -2888,286 +8543,531 @@ const docTemplate = `{
                 }
             }
         },
-        "GetSessionResponse": {
+        "RebuildRankingsResponse": {
             "type": "object",
             "properties": {
-                "permissions": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "object",
-                        "additionalProperties": {}
+                "diffs": {
+                    "description": "Diffs contains only the memberships whose ranking changed",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RankingDiff"
                     }
                 },
-                "role": {
-                    "type": "string"
+                "dryRun": {
+                    "type": "boolean"
                 },
-                "username": {
+                "eventsProcessed": {
+                    "description": "EventsProcessed is the number of ended events the rankings were rebuilt from",
+                    "type": "integer"
+                },
+                "semesterId": {
                     "type": "string"
                 }
             }
         },
-        "LinkedMemberInfo": {
+        "Rebuy": {
             "type": "object",
             "properties": {
-                "firstName": {
+                "amount": {
+                    "type": "number",
+                    "example": 2
+                },
+                "createdAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "lastName": {
-                    "type": "string"
+                "participantId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "rebuy",
+                        "add_on"
+                    ],
+                    "example": "rebuy"
                 }
             }
         },
-        "LoginWithMember": {
+        "Registration": {
             "type": "object",
             "properties": {
-                "linkedMember": {
-                    "$ref": "#/definitions/LinkedMemberInfo"
-                },
-                "role": {
+                "createdAt": {
                     "type": "string"
                 },
-                "username": {
+                "eventId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "membership": {
+                    "$ref": "#/definitions/Membership"
+                },
+                "membershipId": {
                     "type": "string"
+                },
+                "position": {
+                    "description": "Position is the place of a waitlisted registration in the waitlist,\nstarting at 1. It is omitted for registrations holding a spot.",
+                    "type": "integer",
+                    "example": 2
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "registered",
+                        "waitlisted"
+                    ],
+                    "example": "waitlisted"
                 }
             }
         },
-        "Member": {
+        "ResetPasswordRequest": {
             "type": "object",
             "required": [
-                "email",
-                "firstName",
-                "id",
-                "lastName"
+                "newPassword",
+                "token"
             ],
             "properties": {
-                "createdAt": {
-                    "type": "string"
+                "newPassword": {
+                    "type": "string",
+                    "minLength": 8
                 },
-                "email": {
+                "token": {
                     "type": "string"
-                },
-                "faculty": {
+                }
+            }
+        },
+        "RolePermission": {
+            "type": "object",
+            "properties": {
+                "action": {
                     "type": "string",
-                    "enum": [
-                        "AHS",
-                        "Arts",
-                        "Engineering",
-                        "Environment",
-                        "Math",
-                        "Science"
-                    ]
+                    "example": "event.end"
                 },
-                "firstName": {
-                    "type": "string"
+                "defaultRoles": {
+                    "description": "DefaultRoles are the roles allowed by the default policy.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "secretary",
+                        "treasurer",
+                        "vice_president",
+                        "president",
+                        "webmaster"
+                    ]
                 },
-                "id": {
-                    "type": "integer"
+                "protected": {
+                    "description": "Protected actions manage the policy itself and cannot be changed.",
+                    "type": "boolean",
+                    "example": false
                 },
-                "lastName": {
-                    "type": "string"
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "secretary",
+                        "treasurer",
+                        "vice_president",
+                        "president",
+                        "webmaster"
+                    ]
                 },
-                "questId": {
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "Membership": {
+        "SeatMove": {
             "type": "object",
             "properties": {
-                "discounted": {
-                    "type": "boolean"
+                "fromSeat": {
+                    "type": "integer",
+                    "example": 5
                 },
-                "id": {
+                "fromTable": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "membershipId": {
                     "type": "string"
                 },
-                "paid": {
-                    "type": "boolean"
+                "participantId": {
+                    "type": "integer",
+                    "example": 12
                 },
-                "ranking": {
-                    "$ref": "#/definitions/Ranking"
+                "toSeat": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "semester": {
-                    "$ref": "#/definitions/Semester"
+                "toTable": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "SeatingResponse": {
+            "type": "object",
+            "properties": {
+                "tableSize": {
+                    "type": "integer",
+                    "example": 9
                 },
-                "semesterId": {
-                    "type": "string"
+                "tables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SeatingTable"
+                    }
+                }
+            }
+        },
+        "SeatingSeat": {
+            "type": "object",
+            "properties": {
+                "participant": {
+                    "$ref": "#/definitions/Participant"
                 },
-                "user": {
-                    "$ref": "#/definitions/Member"
+                "seat": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "SeatingTable": {
+            "type": "object",
+            "properties": {
+                "number": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "userId": {
-                    "type": "integer"
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SeatingSeat"
+                    }
                 }
             }
         },
-        "MembershipWithAttendance": {
+        "Semester": {
             "type": "object",
             "properties": {
-                "attendance": {
-                    "type": "integer"
+                "addOnFee": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "discounted": {
-                    "type": "boolean"
+                "currentBudget": {
+                    "type": "number",
+                    "example": 100
+                },
+                "endDate": {
+                    "type": "string",
+                    "example": "2023-12-31T23:59:59Z"
                 },
                 "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "membershipDiscountFee": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "membershipFee": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "meta": {
                     "type": "string"
                 },
-                "paid": {
+                "name": {
+                    "type": "string",
+                    "example": "Fall 2023"
+                },
+                "pointsScheme": {
+                    "$ref": "#/definitions/PointsScheme"
+                },
+                "rebuyFee": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2023-09-01T00:00:00Z"
+                },
+                "startingBudget": {
+                    "type": "number",
+                    "example": 100
+                }
+            }
+        },
+        "SessionInfo": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "Current is true for the session making the request.",
                     "type": "boolean"
                 },
-                "ranking": {
-                    "$ref": "#/definitions/Ranking"
+                "expiresAt": {
+                    "type": "string"
                 },
-                "semester": {
-                    "$ref": "#/definitions/Semester"
+                "id": {
+                    "type": "string",
+                    "example": "9f86d081884c7d65"
                 },
-                "semesterId": {
+                "ipAddress": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "lastSeenAt": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/Member"
+                "startedAt": {
+                    "type": "string"
                 },
-                "userId": {
-                    "type": "integer"
+                "userAgent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0"
+                }
+            }
+        },
+        "SetPlacementsRequest": {
+            "type": "object",
+            "required": [
+                "placements"
+            ],
+            "properties": {
+                "placements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PlacementOverride"
+                    }
                 }
             }
         },
-        "NewSessionRequest": {
+        "SignOutEntryRequest": {
             "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
             "properties": {
-                "password": {
+                "knockedOutBy": {
+                    "description": "KnockedOutBy is the membership of the entry that eliminated the player",
                     "type": "string"
                 },
-                "username": {
-                    "type": "string"
+                "knockedOutByEntry": {
+                    "description": "KnockedOutByEntry is the entry that eliminated the player, used instead\nof KnockedOutBy when the eliminator is a guest",
+                    "type": "integer",
+                    "example": 14
                 }
             }
         },
-        "Participant": {
+        "SkipClockLevelRequest": {
             "type": "object",
             "properties": {
-                "eventId": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "membership": {
-                    "$ref": "#/definitions/Membership"
-                },
-                "membershipId": {
-                    "type": "string"
-                },
-                "placement": {
-                    "type": "integer"
-                },
-                "signedOutAt": {
-                    "type": "string"
+                "levels": {
+                    "description": "Levels is the number of levels to move; negative values move backwards. Defaults to 1.",
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "Ranking": {
+        "Structure": {
             "type": "object",
             "properties": {
-                "attendance": {
-                    "type": "integer"
+                "blinds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Blind"
+                    }
                 },
                 "id": {
                     "type": "integer"
                 },
-                "membershipId": {
+                "name": {
                     "type": "string"
                 },
-                "points": {
-                    "type": "integer"
+                "version": {
+                    "description": "Version is the number of the current version of the structure. It\nincreases every time the structure is edited.",
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "RankingResponse": {
+        "StructureImportErrorResponse": {
             "type": "object",
             "properties": {
-                "firstName": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
+                "code": {
+                    "type": "integer",
+                    "example": 400
                 },
-                "lastName": {
-                    "type": "string"
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/StructureImportRowError"
+                    }
                 },
-                "points": {
-                    "type": "integer"
+                "message": {
+                    "type": "string",
+                    "example": "The structure has 1 invalid level"
                 },
-                "position": {
-                    "type": "integer"
+                "type": {
+                    "type": "string",
+                    "example": "INVALID_REQUEST"
                 }
             }
         },
-        "Semester": {
+        "StructureImportRowError": {
             "type": "object",
             "properties": {
-                "currentBudget": {
-                    "type": "number",
-                    "example": 100
-                },
-                "endDate": {
+                "field": {
                     "type": "string",
-                    "example": "2023-12-31T23:59:59Z"
+                    "example": "big"
                 },
-                "id": {
+                "message": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "big is required"
                 },
-                "membershipDiscountFee": {
+                "row": {
+                    "description": "Row is the 1-based number of the level in the import, not counting the CSV header",
                     "type": "integer",
-                    "example": 5
+                    "example": 3
+                }
+            }
+        },
+        "StructureInterchange": {
+            "type": "object",
+            "properties": {
+                "levels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BlindJSON"
+                    }
                 },
-                "membershipFee": {
+                "name": {
+                    "type": "string",
+                    "example": "Monday Turbo"
+                },
+                "version": {
+                    "description": "Version of the interchange format. Defaults to 1 on import.",
                     "type": "integer",
-                    "example": 10
+                    "example": 1
+                }
+            }
+        },
+        "StructureVersion": {
+            "type": "object",
+            "properties": {
+                "blinds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Blind"
+                    }
                 },
-                "meta": {
+                "createdAt": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string",
-                    "example": "Fall 2023"
+                    "example": "Monday Turbo"
                 },
-                "rebuyFee": {
+                "structureId": {
+                    "type": "integer"
+                },
+                "version": {
                     "type": "integer",
                     "example": 2
+                }
+            }
+        },
+        "TableBreakPlan": {
+            "type": "object",
+            "properties": {
+                "moves": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SeatMove"
+                    }
                 },
-                "startDate": {
+                "table": {
+                    "description": "Table is the table that is broken",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "TwoFactorChallengeResponse": {
+            "type": "object",
+            "properties": {
+                "challengeToken": {
                     "type": "string",
-                    "example": "2023-09-01T00:00:00Z"
+                    "example": "3f9a1c0d2b4e6f8a0c1e3b5d7f9a1c3e5b7d9f1a3c5e7b9d1f3a5c7e9b1d3f5a"
                 },
-                "startingBudget": {
-                    "type": "number",
-                    "example": 100
+                "expiresAt": {
+                    "type": "string"
+                },
+                "twoFactorRequired": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "Structure": {
+        "TwoFactorCodeRequest": {
             "type": "object",
+            "required": [
+                "code"
+            ],
             "properties": {
-                "blinds": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "TwoFactorRecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recoveryCodes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/Blind"
-                    }
+                        "type": "string"
+                    },
+                    "example": [
+                        "k3f9a-2bq7x"
+                    ]
+                }
+            }
+        },
+        "TwoFactorSetupResponse": {
+            "type": "object",
+            "properties": {
+                "otpauthUrl": {
+                    "type": "string",
+                    "example": "otpauth://totp/UW%20Poker%20Studies%20Club:jdoe?secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
                 },
-                "id": {
-                    "type": "integer"
+                "secret": {
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "TwoFactorStatus": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
                 },
-                "name": {
-                    "type": "string"
+                "recoveryCodesRemaining": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "required": {
+                    "description": "Required is true when the role of the user must use two-factor\nauthentication.",
+                    "type": "boolean"
                 }
             }
         },
@@ -3179,6 +9079,11 @@ const docTemplate = `{
                     "minLength": 1,
                     "example": "No Limit Hold'em"
                 },
+                "knockoutPoints": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2
+                },
                 "name": {
                     "type": "string",
                     "minLength": 1,
@@ -3245,7 +9150,8 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "questId": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 20
                 }
             }
         },
@@ -3260,11 +9166,89 @@ const docTemplate = `{
                 }
             }
         },
+        "UpdateRolePermissionRequest": {
+            "type": "object",
+            "required": [
+                "roles"
+            ],
+            "properties": {
+                "roles": {
+                    "description": "Roles allowed to perform the action. An empty list allows no one",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "tournament_director",
+                        "webmaster"
+                    ]
+                }
+            }
+        },
+        "UpsertPointsSchemeRequest": {
+            "type": "object",
+            "required": [
+                "payouts",
+                "roundingMode",
+                "sizeFactor"
+            ],
+            "properties": {
+                "defaultPayout": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "minimumPoints": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "payouts": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        32,
+                        28,
+                        24
+                    ]
+                },
+                "roundingMode": {
+                    "type": "string",
+                    "enum": [
+                        "ceil",
+                        "floor",
+                        "round"
+                    ],
+                    "example": "ceil"
+                },
+                "sizeFactor": {
+                    "type": "number",
+                    "example": 50
+                }
+            }
+        },
+        "VerifyTwoFactorRequest": {
+            "type": "object",
+            "required": [
+                "challengeToken",
+                "code"
+            ],
+            "properties": {
+                "challengeToken": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
         "models.BlindJSON": {
             "type": "object",
             "required": [
-                "big",
-                "small",
                 "time"
             ],
             "properties": {
@@ -3276,13 +9260,26 @@ const docTemplate = `{
                     "type": "integer",
                     "minimum": 0
                 },
+                "label": {
+                    "type": "string",
+                    "maxLength": 100
+                },
                 "small": {
                     "type": "integer",
                     "minimum": 0
                 },
                 "time": {
+                    "description": "Time is the length of the level in minutes",
                     "type": "integer",
-                    "maximum": 60
+                    "maximum": 1440
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "blind",
+                        "break",
+                        "chip_race"
+                    ]
                 }
             }
         },
@@ -3304,6 +9301,48 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "models.ListResponse-AuditEvent": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AuditEvent"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ListResponse-RankingHistorySnapshot": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RankingHistorySnapshot"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ListResponse-StructureVersion": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/StructureVersion"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,171 @@
+package authentication
+
+import (
+	"api/internal/authorization"
+	e "api/internal/errors"
+	"api/internal/models"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// apiKeyPrefix starts every API key, so that leaked keys are easy to spot.
+const apiKeyPrefix = "uwpsc_"
+
+// apiKeyDisplayLength is the number of characters of a key kept as its prefix.
+const apiKeyDisplayLength = 12
+
+type apiKeyManager struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyManager(db *gorm.DB) *apiKeyManager {
+	return &apiKeyManager{
+		db: db,
+	}
+}
+
+// HashAPIKey hashes an API key for storage. Keys are random, so a fast hash is
+// enough and lets keys be looked up by their hash.
+func HashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func generateAPIKey() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return apiKeyPrefix + hex.EncodeToString(secret), nil
+}
+
+// Create creates an API key for a bot login. The key is only returned here.
+func (svc *apiKeyManager) Create(username string, req *models.CreateAPIKeyRequest) (*models.CreateAPIKeyResponse, error) {
+	login := models.Login{}
+	res := svc.db.Where("username = ?", username).First(&login)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, e.NotFound("Login not found")
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	if !authorization.HasRole(authorization.ROLE_BOT, login.Role) {
+		return nil, e.InvalidRequest("API keys can only be created for bot logins")
+	}
+
+	scopes := []string{}
+	for _, scope := range req.Scopes {
+		if _, exists := authorization.ActivePolicy.DefaultRoles(scope); !exists {
+			return nil, e.InvalidRequest(fmt.Sprintf("Unknown scope '%s'", scope))
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	slices.Sort(scopes)
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, e.InvalidRequest("Expiry must be in the future")
+	}
+
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	apiKey := models.APIKey{
+		Username:  username,
+		Name:      req.Name,
+		Prefix:    key[:apiKeyDisplayLength],
+		KeyHash:   HashAPIKey(key),
+		Scopes:    scopes,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := svc.db.Create(&apiKey).Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &models.CreateAPIKeyResponse{APIKey: apiKey, Key: key}, nil
+}
+
+// List returns the API keys of a login, newest first, including revoked keys.
+func (svc *apiKeyManager) List(username string) ([]models.APIKey, error) {
+	if err := svc.db.Where("username = ?", username).First(&models.Login{}).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, e.NotFound("Login not found")
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	apiKeys := []models.APIKey{}
+	res := svc.db.Where("username = ?", username).Order("created_at DESC").Find(&apiKeys)
+	if err := res.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return apiKeys, nil
+}
+
+// Revoke stops an API key from being used. Revoking a key twice is a no-op.
+func (svc *apiKeyManager) Revoke(username string, keyID uuid.UUID) error {
+	apiKey := models.APIKey{}
+	res := svc.db.Where("id = ? AND username = ?", keyID, username).First(&apiKey)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return e.NotFound("API key not found")
+	} else if err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	if apiKey.RevokedAt != nil {
+		return nil
+	}
+
+	res = svc.db.Model(&apiKey).Update("revoked_at", time.Now().UTC())
+	if err := res.Error; err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+// Authenticate finds the API key matching a bearer token and returns it with
+// the role of its login. Successful authentications record when the key was
+// last used.
+func (svc *apiKeyManager) Authenticate(key string) (*models.APIKey, string, error) {
+	apiKey := models.APIKey{}
+	res := svc.db.Preload("Login").Where("key_hash = ?", HashAPIKey(key)).First(&apiKey)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, "", e.Unauthorized("Invalid API key")
+	} else if err != nil {
+		return nil, "", e.InternalServerError(err.Error())
+	}
+
+	now := time.Now().UTC()
+	if apiKey.RevokedAt != nil {
+		return nil, "", e.Unauthorized("API key has been revoked")
+	}
+
+	if apiKey.ExpiresAt != nil && now.After(*apiKey.ExpiresAt) {
+		return nil, "", e.Unauthorized("API key has expired")
+	}
+
+	// The login may have been given another role since the key was created
+	if !authorization.HasRole(authorization.ROLE_BOT, apiKey.Login.Role) {
+		return nil, "", e.Unauthorized("API keys can only be used by bot logins")
+	}
+
+	res = svc.db.Model(&apiKey).Update("last_used_at", now)
+	if err := res.Error; err != nil {
+		return nil, "", e.InternalServerError(err.Error())
+	}
+
+	return &apiKey, apiKey.Login.Role, nil
+}
@@ -0,0 +1,164 @@
+package authentication
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// kioskTokenPrefix starts every kiosk token, so that they are not mistaken for
+// API keys.
+const kioskTokenPrefix = "uwpsck_"
+
+// kioskTokenDisplayLength is the number of characters of a token kept as its prefix.
+const kioskTokenDisplayLength = 13
+
+type kioskTokenManager struct {
+	db *gorm.DB
+}
+
+func NewKioskTokenManager(db *gorm.DB) *kioskTokenManager {
+	return &kioskTokenManager{
+		db: db,
+	}
+}
+
+func generateKioskToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return kioskTokenPrefix + hex.EncodeToString(secret), nil
+}
+
+// findEvent returns the event of a semester that kiosk tokens are managed for.
+func (svc *kioskTokenManager) findEvent(semesterID uuid.UUID, eventID int32) (*models.Event, error) {
+	event := models.Event{}
+	res := svc.db.Where("id = ? AND semester_id = ?", eventID, semesterID).First(&event)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, e.NotFound("Event not found")
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &event, nil
+}
+
+// Create creates a kiosk token for an event which has not ended. The token is
+// only returned here.
+func (svc *kioskTokenManager) Create(semesterID uuid.UUID, eventID int32, username string, req *models.CreateKioskTokenRequest) (*models.CreateKioskTokenResponse, error) {
+	event, err := svc.findEvent(semesterID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.State == models.EventStateEnded {
+		return nil, e.Forbidden("Kiosk tokens cannot be created for an event that has ended")
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, e.InvalidRequest("Expiry must be in the future")
+	}
+
+	token, err := generateKioskToken()
+	if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	kioskToken := models.KioskToken{
+		EventID:   eventID,
+		Name:      req.Name,
+		Prefix:    token[:kioskTokenDisplayLength],
+		TokenHash: HashAPIKey(token),
+		CreatedBy: username,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := svc.db.Create(&kioskToken).Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &models.CreateKioskTokenResponse{KioskToken: kioskToken, Token: token}, nil
+}
+
+// List returns the kiosk tokens of an event, newest first, including revoked
+// tokens.
+func (svc *kioskTokenManager) List(semesterID uuid.UUID, eventID int32) ([]models.KioskToken, error) {
+	if _, err := svc.findEvent(semesterID, eventID); err != nil {
+		return nil, err
+	}
+
+	kioskTokens := []models.KioskToken{}
+	res := svc.db.Where("event_id = ?", eventID).Order("created_at DESC").Find(&kioskTokens)
+	if err := res.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return kioskTokens, nil
+}
+
+// Revoke stops a kiosk token from being used. Revoking a token twice is a no-op.
+func (svc *kioskTokenManager) Revoke(semesterID uuid.UUID, eventID int32, tokenID uuid.UUID) error {
+	if _, err := svc.findEvent(semesterID, eventID); err != nil {
+		return err
+	}
+
+	kioskToken := models.KioskToken{}
+	res := svc.db.Where("id = ? AND event_id = ?", tokenID, eventID).First(&kioskToken)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return e.NotFound("Kiosk token not found")
+	} else if err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	if kioskToken.RevokedAt != nil {
+		return nil
+	}
+
+	res = svc.db.Model(&kioskToken).Update("revoked_at", time.Now().UTC())
+	if err := res.Error; err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+// Authenticate finds the kiosk token matching a bearer token and returns it
+// with its event. Tokens of events that have ended are rejected even if they
+// were not revoked. Successful authentications record when the token was
+// last used.
+func (svc *kioskTokenManager) Authenticate(token string) (*models.KioskToken, error) {
+	kioskToken := models.KioskToken{}
+	res := svc.db.Preload("Event").Where("token_hash = ?", HashAPIKey(token)).First(&kioskToken)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, e.Unauthorized("Invalid kiosk token")
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	now := time.Now().UTC()
+	if kioskToken.RevokedAt != nil {
+		return nil, e.Unauthorized("Kiosk token has been revoked")
+	}
+
+	if kioskToken.ExpiresAt != nil && now.After(*kioskToken.ExpiresAt) {
+		return nil, e.Unauthorized("Kiosk token has expired")
+	}
+
+	if kioskToken.Event.State == models.EventStateEnded {
+		return nil, e.Unauthorized("Kiosk token has expired because the event has ended")
+	}
+
+	res = svc.db.Model(&kioskToken).Update("last_used_at", now)
+	if err := res.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &kioskToken, nil
+}
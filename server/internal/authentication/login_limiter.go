@@ -0,0 +1,303 @@
+package authentication
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"api/internal/store"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	usernameKeyPrefix  = "username:"
+	ipAddressKeyPrefix = "ip:"
+	// pruneInterval limits how often attempts which no longer delay logins
+	// are forgotten.
+	pruneInterval = time.Minute * 10
+	// LoginLockoutAction is the audit action recorded when a username or IP
+	// address is locked out.
+	LoginLockoutAction = "login.lockout"
+)
+
+// LoginLimit controls how failed logins for a single username or IP address
+// are throttled.
+type LoginLimit struct {
+	// FreeAttempts is the number of failures allowed before logins are
+	// delayed.
+	FreeAttempts int
+	// BaseDelay is the delay after the first failure past FreeAttempts. It
+	// doubles with every further failure, up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// LockoutThreshold is the number of failures after which logins are
+	// locked out for LockoutDuration.
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+	// ResetAfter is how long after the last failure the failures are
+	// forgotten.
+	ResetAfter time.Duration
+}
+
+// delay returns how long logins wait after the given number of failures, and
+// whether they are locked out.
+func (limit LoginLimit) delay(failures int) (time.Duration, bool) {
+	if failures >= limit.LockoutThreshold {
+		return limit.LockoutDuration, true
+	}
+
+	if failures <= limit.FreeAttempts {
+		return 0, false
+	}
+
+	delay := limit.BaseDelay
+	for i := limit.FreeAttempts + 1; i < failures && delay < limit.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, limit.MaxDelay), false
+}
+
+// LoginLimiterConfig controls how failed logins are throttled. Failures are
+// counted separately for the username and the IP address of every login.
+type LoginLimiterConfig struct {
+	Username  LoginLimit
+	IPAddress LoginLimit
+}
+
+// LoadLoginLimiterConfig reads the lockout threshold and duration of
+// usernames from the LOGIN_LOCKOUT_THRESHOLD and LOGIN_LOCKOUT_DURATION
+// environment variables. Missing or invalid values use the defaults of 10
+// failures and 15 minutes. IP addresses, which may be shared, are locked out
+// after five times as many failures.
+func LoadLoginLimiterConfig() LoginLimiterConfig {
+	threshold := intFromEnv("LOGIN_LOCKOUT_THRESHOLD", 10)
+	duration := durationFromEnv("LOGIN_LOCKOUT_DURATION", time.Minute*15)
+
+	return LoginLimiterConfig{
+		Username: LoginLimit{
+			FreeAttempts:     3,
+			BaseDelay:        time.Second,
+			MaxDelay:         time.Minute,
+			LockoutThreshold: threshold,
+			LockoutDuration:  duration,
+			ResetAfter:       time.Hour,
+		},
+		IPAddress: LoginLimit{
+			FreeAttempts:     threshold,
+			BaseDelay:        time.Second,
+			MaxDelay:         time.Minute,
+			LockoutThreshold: threshold * 5,
+			LockoutDuration:  duration,
+			ResetAfter:       time.Hour,
+		},
+	}
+}
+
+func intFromEnv(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("Invalid %s '%s', using %d", key, value, fallback)
+		return fallback
+	}
+
+	return n
+}
+
+// KioskCheckInLimiterConfig throttles failed kiosk check-ins, so that a kiosk
+// cannot be used to find out which student IDs and QuestIDs belong to members.
+// Failures are counted for the kiosk token in place of a username. Kiosks see
+// typos from many members, so more failures are allowed than for logins and
+// they are forgotten sooner.
+func KioskCheckInLimiterConfig() LoginLimiterConfig {
+	limit := LoginLimit{
+		FreeAttempts:     5,
+		BaseDelay:        time.Second,
+		MaxDelay:         time.Second * 30,
+		LockoutThreshold: 30,
+		LockoutDuration:  time.Minute * 5,
+		ResetAfter:       time.Minute * 10,
+	}
+
+	return LoginLimiterConfig{
+		Username:  limit,
+		IPAddress: limit,
+	}
+}
+
+// LoginLockout describes a username or IP address which was locked out by a
+// failed login.
+type LoginLockout struct {
+	// Kind is either "username" or "ipAddress".
+	Kind  string
+	Value string
+	Until time.Time
+}
+
+// AuditEvent returns the audit event recording the lockout, attributed to the
+// username the failed login was for.
+func (lockout LoginLockout) AuditEvent(username string, method string, path string) models.AuditEvent {
+	return models.AuditEvent{
+		Actor:  username,
+		Action: LoginLockoutAction,
+		Method: method,
+		Path:   path,
+		Targets: map[string]string{
+			lockout.Kind: lockout.Value,
+		},
+		Request: map[string]any{
+			"username": username,
+		},
+		StatusCode: http.StatusUnauthorized,
+	}
+}
+
+// LoginLimiter throttles failed logins with an exponential backoff, and locks
+// out usernames and IP addresses with too many failures. It is safe for
+// concurrent use.
+type LoginLimiter struct {
+	mu           sync.Mutex
+	attempts     store.LoginAttemptRepository
+	config       LoginLimiterConfig
+	now          func() time.Time
+	lastPrunedAt time.Time
+}
+
+func NewLoginLimiter(attempts store.LoginAttemptRepository, config LoginLimiterConfig) *LoginLimiter {
+	return &LoginLimiter{
+		attempts: attempts,
+		config:   config,
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+type loginLimitTarget struct {
+	kind  string
+	value string
+	key   string
+	limit LoginLimit
+}
+
+func (l *LoginLimiter) targets(username string, ipAddress string) []loginLimitTarget {
+	username = normalizeUsername(username)
+
+	return []loginLimitTarget{
+		{kind: "username", value: username, key: usernameKeyPrefix + username, limit: l.config.Username},
+		{kind: "ipAddress", value: ipAddress, key: ipAddressKeyPrefix + ipAddress, limit: l.config.IPAddress},
+	}
+}
+
+// normalizeUsername folds usernames so that changing their case does not
+// avoid the limits.
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// Check returns how long a login for the username from the IP address must
+// wait. The login is allowed when it returns zero.
+func (l *LoginLimiter) Check(username string, ipAddress string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	wait := time.Duration(0)
+	for _, target := range l.targets(username, ipAddress) {
+		attempt, err := l.attempts.Find(target.key)
+		if errors.Is(err, store.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return 0, e.InternalServerError(err.Error())
+		}
+
+		wait = max(wait, attempt.LockedUntil.Sub(now))
+	}
+
+	return wait, nil
+}
+
+// RecordFailure counts a failed login for the username and the IP address,
+// and returns the lockouts it caused.
+func (l *LoginLimiter) RecordFailure(username string, ipAddress string) ([]LoginLockout, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if err := l.prune(now); err != nil {
+		return nil, err
+	}
+
+	lockouts := []LoginLockout{}
+	for _, target := range l.targets(username, ipAddress) {
+		attempt, err := l.attempts.Find(target.key)
+		if errors.Is(err, store.ErrNotFound) || (err == nil && now.Sub(attempt.LastFailureAt) >= target.limit.ResetAfter) {
+			attempt = models.LoginAttempt{Key: target.key}
+		} else if err != nil {
+			return nil, e.InternalServerError(err.Error())
+		}
+
+		attempt.Failures++
+		attempt.LastFailureAt = now
+
+		delay, locked := target.limit.delay(attempt.Failures)
+		if lockedUntil := now.Add(delay); lockedUntil.After(attempt.LockedUntil) {
+			attempt.LockedUntil = lockedUntil
+		}
+
+		if err := l.attempts.Save(&attempt); err != nil {
+			return nil, e.InternalServerError(err.Error())
+		}
+
+		if locked {
+			lockouts = append(lockouts, LoginLockout{Kind: target.kind, Value: target.value, Until: attempt.LockedUntil})
+		}
+	}
+
+	return lockouts, nil
+}
+
+// RecordSuccess forgets the failed logins of a username. The failures of the
+// IP address are kept, so that logging in to one login does not lift the
+// limits on guessing the passwords of others.
+func (l *LoginLimiter) RecordSuccess(username string) error {
+	return l.Unlock(username)
+}
+
+// Unlock forgets the failed logins of a username, lifting any delay or
+// lockout.
+func (l *LoginLimiter) Unlock(username string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	err := l.attempts.Delete(usernameKeyPrefix + normalizeUsername(username))
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return e.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+// prune forgets attempts which can no longer delay logins, at most once per
+// prune interval.
+func (l *LoginLimiter) prune(now time.Time) error {
+	if now.Sub(l.lastPrunedAt) < pruneInterval {
+		return nil
+	}
+
+	resetAfter := max(l.config.Username.ResetAfter, l.config.IPAddress.ResetAfter)
+	if err := l.attempts.DeleteStale(now.Add(-resetAfter)); err != nil {
+		return e.InternalServerError(err.Error())
+	}
+	l.lastPrunedAt = now
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package authentication
+
+import (
+	"api/internal/store/inmemory"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLoginLimiter(now *time.Time) *LoginLimiter {
+	limiter := NewLoginLimiter(inmemory.NewLoginAttemptRepository(), LoginLimiterConfig{
+		Username: LoginLimit{
+			FreeAttempts:     2,
+			BaseDelay:        time.Second,
+			MaxDelay:         time.Second * 4,
+			LockoutThreshold: 6,
+			LockoutDuration:  time.Minute * 15,
+			ResetAfter:       time.Hour,
+		},
+		IPAddress: LoginLimit{
+			FreeAttempts:     4,
+			BaseDelay:        time.Second,
+			MaxDelay:         time.Second * 4,
+			LockoutThreshold: 10,
+			LockoutDuration:  time.Minute * 15,
+			ResetAfter:       time.Hour,
+		},
+	})
+	limiter.now = func() time.Time { return *now }
+
+	return limiter
+}
+
+func TestLoginLimiter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Backoff", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+		limiter := newTestLoginLimiter(&now)
+
+		expected := []time.Duration{0, 0, time.Second, time.Second * 2, time.Second * 4}
+		for i, delay := range expected {
+			lockouts, err := limiter.RecordFailure("alice", "192.0.2.1")
+			require.NoError(t, err)
+			assert.Empty(t, lockouts)
+
+			wait, err := limiter.Check("alice", "192.0.2.1")
+			require.NoError(t, err)
+			assert.Equal(t, delay, wait, "failure %d", i+1)
+
+			now = now.Add(wait)
+		}
+
+		// The delay is capped
+		_, err := limiter.RecordFailure("alice", "192.0.2.2")
+		require.NoError(t, err)
+
+		wait, err := limiter.Check("alice", "192.0.2.2")
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute*15, wait, "sixth failure locks the username out")
+	})
+
+	t.Run("UsernameLockout", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+		limiter := newTestLoginLimiter(&now)
+
+		for range 5 {
+			lockouts, err := limiter.RecordFailure("alice", "192.0.2.1")
+			require.NoError(t, err)
+			assert.Empty(t, lockouts)
+			now = now.Add(time.Second * 5)
+		}
+
+		lockouts, err := limiter.RecordFailure("Alice", "192.0.2.1")
+		require.NoError(t, err)
+		require.Len(t, lockouts, 1)
+		assert.Equal(t, "username", lockouts[0].Kind)
+		assert.Equal(t, "alice", lockouts[0].Value)
+		assert.Equal(t, now.Add(time.Minute*15), lockouts[0].Until)
+
+		// The lockout applies from any IP address
+		wait, err := limiter.Check("alice", "198.51.100.7")
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute*15, wait)
+
+		// Other usernames are not locked out
+		wait, err = limiter.Check("bob", "198.51.100.7")
+		require.NoError(t, err)
+		assert.Zero(t, wait)
+
+		// The lockout ends on its own
+		now = now.Add(time.Minute * 15)
+		wait, err = limiter.Check("alice", "198.51.100.7")
+		require.NoError(t, err)
+		assert.Zero(t, wait)
+
+		event := lockouts[0].AuditEvent("Alice", "POST", "/api/v2/session")
+		assert.Equal(t, LoginLockoutAction, event.Action)
+		assert.Equal(t, "Alice", event.Actor)
+		assert.Equal(t, map[string]string{"username": "alice"}, event.Targets)
+	})
+
+	t.Run("IPAddressLockout", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+		limiter := newTestLoginLimiter(&now)
+
+		// Guessing a different username every time still locks out the IP address
+		var lockouts []LoginLockout
+		for i := range 10 {
+			var err error
+			lockouts, err = limiter.RecordFailure("user"+string(rune('a'+i)), "192.0.2.1")
+			require.NoError(t, err)
+			now = now.Add(time.Second * 5)
+		}
+
+		require.Len(t, lockouts, 1)
+		assert.Equal(t, "ipAddress", lockouts[0].Kind)
+		assert.Equal(t, "192.0.2.1", lockouts[0].Value)
+
+		wait, err := limiter.Check("someone", "192.0.2.1")
+		require.NoError(t, err)
+		assert.Positive(t, wait)
+
+		wait, err = limiter.Check("someone", "192.0.2.2")
+		require.NoError(t, err)
+		assert.Zero(t, wait)
+	})
+
+	t.Run("RecordSuccess", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+		limiter := newTestLoginLimiter(&now)
+
+		for range 4 {
+			_, err := limiter.RecordFailure("alice", "192.0.2.1")
+			require.NoError(t, err)
+		}
+		require.NoError(t, limiter.RecordSuccess("alice"))
+
+		// The username starts over, the IP address keeps its failures
+		_, err := limiter.RecordFailure("alice", "192.0.2.1")
+		require.NoError(t, err)
+
+		attempt, err := limiter.attempts.Find(usernameKeyPrefix + "alice")
+		require.NoError(t, err)
+		assert.Equal(t, 1, attempt.Failures)
+
+		attempt, err = limiter.attempts.Find(ipAddressKeyPrefix + "192.0.2.1")
+		require.NoError(t, err)
+		assert.Equal(t, 5, attempt.Failures)
+	})
+
+	t.Run("Unlock", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+		limiter := newTestLoginLimiter(&now)
+
+		for range 6 {
+			_, err := limiter.RecordFailure("alice", "192.0.2.1")
+			require.NoError(t, err)
+		}
+
+		wait, err := limiter.Check("alice", "198.51.100.7")
+		require.NoError(t, err)
+		assert.Positive(t, wait)
+
+		require.NoError(t, limiter.Unlock("ALICE"))
+
+		wait, err = limiter.Check("alice", "198.51.100.7")
+		require.NoError(t, err)
+		assert.Zero(t, wait)
+
+		// Unlocking a username without failures is a no-op
+		require.NoError(t, limiter.Unlock("bob"))
+	})
+
+	t.Run("ResetAfter", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+		limiter := newTestLoginLimiter(&now)
+
+		for range 3 {
+			_, err := limiter.RecordFailure("alice", "192.0.2.1")
+			require.NoError(t, err)
+		}
+
+		// Failures are forgotten an hour after the last one
+		now = now.Add(time.Hour)
+		_, err := limiter.RecordFailure("alice", "192.0.2.1")
+		require.NoError(t, err)
+
+		wait, err := limiter.Check("alice", "192.0.2.1")
+		require.NoError(t, err)
+		assert.Zero(t, wait)
+
+		// Stale attempts are pruned
+		now = now.Add(time.Hour * 2)
+		_, err = limiter.RecordFailure("bob", "198.51.100.7")
+		require.NoError(t, err)
+
+		_, err = limiter.attempts.Find(usernameKeyPrefix + "alice")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,187 @@
+package authentication
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// defaultResetTokenLifetime is how long a password reset token can be
+// redeemed for.
+const defaultResetTokenLifetime = time.Hour * 24
+
+// errInvalidResetToken is returned for every token that cannot be redeemed,
+// so that callers cannot tell unknown, used and expired tokens apart.
+var errInvalidResetToken = e.InvalidRequest("Invalid or expired password reset token")
+
+// ErrIncorrectPassword is returned when the current password given to change
+// a password is incorrect.
+var ErrIncorrectPassword = e.InvalidRequest("Current password is incorrect")
+
+type passwordManager struct {
+	db                 *gorm.DB
+	resetTokenLifetime time.Duration
+}
+
+// NewPasswordManager creates a password manager. Reset tokens last for
+// PASSWORD_RESET_TOKEN_LIFETIME, a Go duration defaulting to 24 hours.
+func NewPasswordManager(db *gorm.DB) *passwordManager {
+	return &passwordManager{
+		db:                 db,
+		resetTokenLifetime: durationFromEnv("PASSWORD_RESET_TOKEN_LIFETIME", defaultResetTokenLifetime),
+	}
+}
+
+// HashResetToken hashes a password reset token for storage. Tokens are random,
+// so a fast hash is enough and lets tokens be looked up by their hash.
+func HashResetToken(token string) string {
+	return hashToken(token)
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// generateToken returns a random single-use token.
+func generateToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+// ChangePassword sets a new password for a login after checking its current
+// password. Every other session of the login is ended.
+func (svc *passwordManager) ChangePassword(username string, currentPassword string, newPassword string, currentSessionID uuid.UUID) error {
+	login := models.Login{}
+	res := svc.db.Where("username = ?", username).First(&login)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return e.NotFound("Login not found")
+	} else if err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(login.Password), []byte(currentPassword)); err != nil {
+		return ErrIncorrectPassword
+	}
+
+	if currentPassword == newPassword {
+		return e.InvalidRequest("New password must be different from the current password")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	err = svc.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&login).Update("password", string(hash)).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("username = ? AND id <> ?", username, currentSessionID).Delete(&models.Session{}).Error
+	})
+	if err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+// CreateResetToken issues a password reset token for a login. Any unused
+// tokens previously issued for the login stop working. The token is only
+// returned here.
+func (svc *passwordManager) CreateResetToken(username string, createdBy string) (*models.CreatePasswordResetTokenResponse, error) {
+	if err := svc.db.Where("username = ?", username).First(&models.Login{}).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, e.NotFound("Login not found")
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	resetToken := models.PasswordResetToken{
+		Username:  username,
+		TokenHash: HashResetToken(token),
+		CreatedBy: createdBy,
+		ExpiresAt: time.Now().UTC().Add(svc.resetTokenLifetime),
+	}
+
+	err = svc.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("username = ? AND used_at IS NULL", username).Delete(&models.PasswordResetToken{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&resetToken).Error
+	})
+	if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &models.CreatePasswordResetTokenResponse{
+		Username:  username,
+		Token:     token,
+		ExpiresAt: resetToken.ExpiresAt,
+	}, nil
+}
+
+// ResetPassword redeems a password reset token, setting a new password for its
+// login and ending every session of the login. Returns the username of the
+// login.
+func (svc *passwordManager) ResetPassword(token string, newPassword string) (string, error) {
+	resetToken := models.PasswordResetToken{}
+	res := svc.db.Where("token_hash = ?", HashResetToken(token)).First(&resetToken)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", errInvalidResetToken
+	} else if err != nil {
+		return "", e.InternalServerError(err.Error())
+	}
+
+	now := time.Now().UTC()
+	if resetToken.UsedAt != nil || now.After(resetToken.ExpiresAt) {
+		return "", errInvalidResetToken
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return "", e.InternalServerError(err.Error())
+	}
+
+	err = svc.db.Transaction(func(tx *gorm.DB) error {
+		// Only one redemption of a token can mark it as used
+		res := tx.Model(&resetToken).Where("used_at IS NULL").Update("used_at", now)
+		if err := res.Error; err != nil {
+			return err
+		}
+		if res.RowsAffected == 0 {
+			return errInvalidResetToken
+		}
+
+		if err := tx.Model(&models.Login{}).Where("username = ?", resetToken.Username).Update("password", string(hash)).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("username = ?", resetToken.Username).Delete(&models.Session{}).Error
+	})
+	if errors.Is(err, errInvalidResetToken) {
+		return "", errInvalidResetToken
+	} else if err != nil {
+		return "", e.InternalServerError(err.Error())
+	}
+
+	return resetToken.Username, nil
+}
@@ -0,0 +1,148 @@
+package authentication
+
+import (
+	"api/internal/database"
+	e "api/internal/errors"
+	"api/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordManager(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "TEST")
+
+	db, err := database.OpenTestConnection()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer sqlDB.Close()
+
+	wipeDB := func() {
+		err := database.WipeDB(db)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	passwordManager := NewPasswordManager(db)
+	credSvc := NewCredentialService(db)
+	sessManager := NewSessionManager(db)
+
+	t.Run("ChangePassword", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		require.NoError(t, CreateTestLogin(db, "testuser", "password"))
+		current, err := sessManager.Create("testuser", "executive", "Firefox", "203.0.113.7")
+		require.NoError(t, err)
+		other, err := sessManager.Create("testuser", "executive", "Safari", "203.0.113.8")
+		require.NoError(t, err)
+
+		err = passwordManager.ChangePassword("testuser", "password", "new-password", current)
+		require.NoError(t, err)
+
+		valid, _, err := credSvc.Validate("testuser", "new-password")
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		// The current session is kept and the other session is ended
+		_, err = sessManager.Authenticate(current)
+		assert.NoError(t, err)
+		_, err = sessManager.Authenticate(other)
+		assert.Error(t, err)
+	})
+
+	t.Run("ChangePassword_IncorrectCurrentPassword", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		require.NoError(t, CreateTestLogin(db, "testuser", "password"))
+
+		err := passwordManager.ChangePassword("testuser", "wrong-password", "new-password", uuid.New())
+		assert.Equal(t, e.InvalidRequest("Current password is incorrect"), err)
+
+		err = passwordManager.ChangePassword("testuser", "password", "password", uuid.New())
+		assert.Equal(t, e.InvalidRequest("New password must be different from the current password"), err)
+
+		valid, _, err := credSvc.Validate("testuser", "password")
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("ResetPassword", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		require.NoError(t, CreateTestLogin(db, "testuser", "password"))
+		sessionID, err := sessManager.Create("testuser", "executive", "Firefox", "203.0.113.7")
+		require.NoError(t, err)
+
+		res, err := passwordManager.CreateResetToken("testuser", "admin")
+		require.NoError(t, err)
+		assert.Equal(t, "testuser", res.Username)
+		assert.WithinDuration(t, time.Now().Add(time.Hour*24), res.ExpiresAt, time.Minute)
+
+		// Only the hash of the token is stored
+		token := models.PasswordResetToken{}
+		require.NoError(t, db.Where("username = ?", "testuser").First(&token).Error)
+		assert.Equal(t, HashResetToken(res.Token), token.TokenHash)
+		assert.Equal(t, "admin", token.CreatedBy)
+
+		username, err := passwordManager.ResetPassword(res.Token, "new-password")
+		require.NoError(t, err)
+		assert.Equal(t, "testuser", username)
+
+		valid, _, err := credSvc.Validate("testuser", "new-password")
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		_, err = sessManager.Authenticate(sessionID)
+		assert.Error(t, err)
+
+		// Tokens can only be used once
+		_, err = passwordManager.ResetPassword(res.Token, "another-password")
+		assert.Equal(t, errInvalidResetToken, err)
+	})
+
+	t.Run("ResetPassword_InvalidToken", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		require.NoError(t, CreateTestLogin(db, "testuser", "password"))
+
+		_, err := passwordManager.ResetPassword("not-a-token", "new-password")
+		assert.Equal(t, errInvalidResetToken, err)
+
+		// Issuing a new token replaces unused tokens
+		first, err := passwordManager.CreateResetToken("testuser", "admin")
+		require.NoError(t, err)
+		second, err := passwordManager.CreateResetToken("testuser", "admin")
+		require.NoError(t, err)
+
+		_, err = passwordManager.ResetPassword(first.Token, "new-password")
+		assert.Equal(t, errInvalidResetToken, err)
+
+		// Expired tokens cannot be used
+		require.NoError(t, db.Model(&models.PasswordResetToken{}).
+			Where("token_hash = ?", HashResetToken(second.Token)).
+			Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+		_, err = passwordManager.ResetPassword(second.Token, "new-password")
+		assert.Equal(t, errInvalidResetToken, err)
+
+		valid, _, err := credSvc.Validate("testuser", "password")
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("CreateResetToken_NoLogin", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		_, err := passwordManager.CreateResetToken("nobody", "admin")
+		assert.Equal(t, e.NotFound("Login not found"), err)
+	})
+}
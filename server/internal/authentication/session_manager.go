@@ -3,31 +3,112 @@ package authentication
 import (
 	e "api/internal/errors"
 	"api/internal/models"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"log"
+	"os"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+const (
+	// defaultIdleTimeout is how long a session lasts without being used.
+	defaultIdleTimeout = time.Hour * 8
+	// defaultMaxLifetime is how long a session lasts at most, however often
+	// it is used.
+	defaultMaxLifetime = time.Hour * 24 * 7
+	// renewalInterval limits how often a session's expiry is written while it
+	// is in use.
+	renewalInterval = time.Minute
+	// maxUserAgentLength bounds the user agent recorded on a session.
+	maxUserAgentLength = 512
+)
+
+// SessionConfig controls how long sessions last.
+type SessionConfig struct {
+	// IdleTimeout is how long a session lasts without being used. Every
+	// authenticated request slides the expiry forward by this much.
+	IdleTimeout time.Duration
+	// MaxLifetime is how long a session lasts at most after it is created.
+	MaxLifetime time.Duration
+}
+
+// LoadSessionConfig reads the session configuration from the
+// SESSION_IDLE_TIMEOUT and SESSION_MAX_LIFETIME environment variables, as Go
+// durations such as "30m" or "12h". Missing or invalid values use the
+// defaults of 8 hours and 7 days.
+func LoadSessionConfig() SessionConfig {
+	return SessionConfig{
+		IdleTimeout: durationFromEnv("SESSION_IDLE_TIMEOUT", defaultIdleTimeout),
+		MaxLifetime: durationFromEnv("SESSION_MAX_LIFETIME", defaultMaxLifetime),
+	}
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s '%s', using %s", key, value, fallback)
+		return fallback
+	}
+
+	return duration
+}
+
+// SessionHandle returns the identifier a session is listed and revoked by.
+// It is derived from the session ID so that listing sessions does not reveal
+// the credentials of other sessions.
+func SessionHandle(sessionID uuid.UUID) string {
+	hash := sha256.Sum256([]byte(sessionID.String()))
+	return hex.EncodeToString(hash[:8])
+}
+
 type sessionManager struct {
-	db *gorm.DB
+	db     *gorm.DB
+	config SessionConfig
 }
 
 func NewSessionManager(db *gorm.DB) *sessionManager {
+	return NewSessionManagerWithConfig(db, LoadSessionConfig())
+}
+
+func NewSessionManagerWithConfig(db *gorm.DB, config SessionConfig) *sessionManager {
 	return &sessionManager{
-		db: db,
+		db:     db,
+		config: config,
 	}
 }
 
-func (svc *sessionManager) Create(username string, role string) (uuid.UUID, error) {
+// Config returns the configuration the session manager was created with.
+func (svc *sessionManager) Config() SessionConfig {
+	return svc.config
+}
+
+func (svc *sessionManager) Create(username string, role string, userAgent string, ipAddress string) (uuid.UUID, error) {
 	// Get the current time
 	now := time.Now().UTC()
-	// Set the expiry time to 8 hours in the future
-	expiry := now.Add(time.Hour * 8).UTC()
+
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
 
 	// Create the session in the database
-	session := models.Session{StartedAt: now, ExpiresAt: expiry, Username: username, Role: role}
+	session := models.Session{
+		StartedAt:  now,
+		ExpiresAt:  svc.expiry(now, now),
+		LastSeenAt: now,
+		Username:   username,
+		Role:       role,
+		UserAgent:  userAgent,
+		IPAddress:  ipAddress,
+	}
 	res := svc.db.Create(&session)
 
 	if err := res.Error; err != nil {
@@ -63,7 +144,8 @@ func (svc *sessionManager) Authenticate(sessionID uuid.UUID) (*models.Session, e
 	}
 
 	// Check if session has expired, if it is delete it from the table and return 401
-	if time.Now().UTC().After(session.ExpiresAt) {
+	now := time.Now().UTC()
+	if now.After(session.ExpiresAt) || now.After(session.StartedAt.Add(svc.config.MaxLifetime)) {
 		res = svc.db.Delete(&session)
 		if err := res.Error; err != nil {
 			return nil, e.InternalServerError(err.Error())
@@ -72,5 +154,100 @@ func (svc *sessionManager) Authenticate(sessionID uuid.UUID) (*models.Session, e
 		return nil, e.Unauthorized("Session has expired. Please reauthenticate")
 	}
 
+	// Slide the expiry forward, at most once per renewal interval
+	if now.Sub(session.LastSeenAt) >= renewalInterval {
+		session.LastSeenAt = now
+		session.ExpiresAt = svc.expiry(session.StartedAt, now)
+
+		res = svc.db.Model(&session).Updates(map[string]any{
+			"last_seen_at": session.LastSeenAt,
+			"expires_at":   session.ExpiresAt,
+		})
+		if err := res.Error; err != nil {
+			return nil, e.InternalServerError(err.Error())
+		}
+	}
+
 	return &session, nil
 }
+
+// MarkTwoFactorVerified records that a session was verified with a two-factor
+// code, or that two-factor authentication was enabled in it.
+func (svc *sessionManager) MarkTwoFactorVerified(sessionID uuid.UUID) error {
+	res := svc.db.Model(&models.Session{ID: sessionID}).Update("two_factor_verified", true)
+	if err := res.Error; err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+// List returns the active sessions of a login, most recently used first.
+func (svc *sessionManager) List(username string, currentID uuid.UUID) ([]models.SessionInfo, error) {
+	sessions := []models.Session{}
+	res := svc.db.
+		Where("username = ? AND expires_at > ?", username, time.Now().UTC()).
+		Order("last_seen_at DESC").
+		Find(&sessions)
+	if err := res.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	infos := make([]models.SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		infos = append(infos, models.SessionInfo{
+			ID:         SessionHandle(session.ID),
+			StartedAt:  session.StartedAt,
+			ExpiresAt:  session.ExpiresAt,
+			LastSeenAt: session.LastSeenAt,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			Current:    session.ID == currentID,
+		})
+	}
+
+	return infos, nil
+}
+
+// Revoke ends another session of a login, identified by its handle. The
+// current session is ended by logging out instead.
+func (svc *sessionManager) Revoke(username string, handle string, currentID uuid.UUID) error {
+	if handle == SessionHandle(currentID) {
+		return e.InvalidRequest("Use logout to end the current session")
+	}
+
+	sessions := []models.Session{}
+	if err := svc.db.Where("username = ?", username).Find(&sessions).Error; err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	for _, session := range sessions {
+		if SessionHandle(session.ID) == handle {
+			return svc.Invalidate(session.ID)
+		}
+	}
+
+	return e.NotFound("Session not found")
+}
+
+// RevokeAll ends every session of a login except the given one, and returns
+// the number of sessions ended. Pass uuid.Nil to end every session.
+func (svc *sessionManager) RevokeAll(username string, exceptID uuid.UUID) (int64, error) {
+	res := svc.db.Where("username = ? AND id <> ?", username, exceptID).Delete(&models.Session{})
+	if err := res.Error; err != nil {
+		return 0, e.InternalServerError(err.Error())
+	}
+
+	return res.RowsAffected, nil
+}
+
+// expiry returns when a session started at startedAt and last used at
+// lastSeenAt expires.
+func (svc *sessionManager) expiry(startedAt time.Time, lastSeenAt time.Time) time.Time {
+	expiresAt := lastSeenAt.Add(svc.config.IdleTimeout)
+	if maxExpiresAt := startedAt.Add(svc.config.MaxLifetime); expiresAt.After(maxExpiresAt) {
+		return maxExpiresAt
+	}
+
+	return expiresAt
+}
@@ -55,7 +55,7 @@ func TestSessionManager(t *testing.T) {
 	t.Run("Create_NoAssociatedLogin", func(t *testing.T) {
 		t.Cleanup(wipeDB)
 
-		_, err := sessManager.Create("testuser", "executive")
+		_, err := sessManager.Create("testuser", "executive", "Mozilla/5.0", "203.0.113.7")
 		assert.Error(t, err)
 	})
 	t.Run("Create", func(t *testing.T) {
@@ -64,7 +64,7 @@ func TestSessionManager(t *testing.T) {
 		err := CreateTestLogin(db, "testuser", "password")
 		assert.NoError(t, err)
 
-		id, err := sessManager.Create("testuser", "executive")
+		id, err := sessManager.Create("testuser", "executive", "Mozilla/5.0", "203.0.113.7")
 		assert.NoError(t, err)
 		assert.NoError(t, uuid.Validate(id.String()))
 
@@ -137,4 +137,97 @@ func TestSessionManager(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, session.Username, foundSession.Username)
 	})
+
+	t.Run("Create_RecordsClient", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		err := CreateTestLogin(db, "testuser", "password")
+		assert.NoError(t, err)
+
+		id, err := sessManager.Create("testuser", "executive", "Mozilla/5.0", "203.0.113.7")
+		assert.NoError(t, err)
+
+		session := models.Session{ID: id}
+		assert.NoError(t, db.First(&session).Error)
+		assert.Equal(t, "Mozilla/5.0", session.UserAgent)
+		assert.Equal(t, "203.0.113.7", session.IPAddress)
+		assert.WithinDuration(t, session.StartedAt, session.LastSeenAt, time.Second)
+	})
+
+	t.Run("Authenticate__SlidesExpiry", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		sessManager := NewSessionManagerWithConfig(db, SessionConfig{IdleTimeout: time.Hour, MaxLifetime: time.Hour * 3})
+
+		session, err := CreateTestSession(db, "testuser", "password", time.Now().Add(-time.Hour*2))
+		assert.NoError(t, err)
+		assert.NoError(t, db.Model(session).Update("last_seen_at", time.Now().Add(-time.Minute*30)).Error)
+
+		foundSession, err := sessManager.Authenticate(session.ID)
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), foundSession.ExpiresAt, time.Second*5)
+		assert.WithinDuration(t, time.Now(), foundSession.LastSeenAt, time.Second*5)
+
+		// The expiry never slides past the maximum lifetime
+		assert.NoError(t, db.Model(session).Updates(map[string]any{
+			"started_at":   time.Now().Add(-(time.Hour*2 + time.Minute*30)),
+			"last_seen_at": time.Now().Add(-time.Minute * 5),
+		}).Error)
+
+		foundSession, err = sessManager.Authenticate(session.ID)
+		assert.NoError(t, err)
+		assert.WithinDuration(t, foundSession.StartedAt.Add(time.Hour*3), foundSession.ExpiresAt, time.Second)
+	})
+
+	t.Run("Authenticate__MaxLifetimeExceeded", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		sessManager := NewSessionManagerWithConfig(db, SessionConfig{IdleTimeout: time.Hour * 8, MaxLifetime: time.Hour})
+
+		session, err := CreateTestSession(db, "testuser", "password", time.Now().Add(-time.Hour*2))
+		assert.NoError(t, err)
+
+		_, err = sessManager.Authenticate(session.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("ListAndRevoke", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		err := CreateTestLogin(db, "testuser", "password")
+		assert.NoError(t, err)
+
+		current, err := sessManager.Create("testuser", "executive", "Firefox", "203.0.113.7")
+		assert.NoError(t, err)
+		other, err := sessManager.Create("testuser", "executive", "Chrome", "203.0.113.8")
+		assert.NoError(t, err)
+		_, err = sessManager.Create("testuser", "executive", "Safari", "203.0.113.9")
+		assert.NoError(t, err)
+
+		sessions, err := sessManager.List("testuser", current)
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 3)
+		for _, session := range sessions {
+			assert.Equal(t, session.ID == SessionHandle(current), session.Current)
+			assert.NotEqual(t, current.String(), session.ID)
+		}
+
+		err = sessManager.Revoke("testuser", SessionHandle(current), current)
+		assert.Error(t, err)
+
+		err = sessManager.Revoke("otheruser", SessionHandle(other), current)
+		assert.Error(t, err)
+
+		err = sessManager.Revoke("testuser", SessionHandle(other), current)
+		assert.NoError(t, err)
+
+		revoked, err := sessManager.RevokeAll("testuser", current)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), revoked)
+
+		sessions, err = sessManager.List("testuser", current)
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 1)
+		assert.True(t, sessions[0].Current)
+	})
 }
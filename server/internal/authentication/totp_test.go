@@ -0,0 +1,96 @@
+package authentication
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTP(t *testing.T) {
+	t.Parallel()
+
+	// The SHA-1 secret of the RFC 6238 test vectors
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	t.Run("TOTPCode", func(t *testing.T) {
+		t.Parallel()
+
+		// The last six digits of the RFC 6238 test vectors
+		testCases := []struct {
+			unix     int64
+			expected string
+		}{
+			{unix: 59, expected: "287082"},
+			{unix: 1111111109, expected: "081804"},
+			{unix: 1111111111, expected: "050471"},
+			{unix: 1234567890, expected: "005924"},
+			{unix: 2000000000, expected: "279037"},
+		}
+		for _, tC := range testCases {
+			code, err := TOTPCode(secret, totpStep(time.Unix(tC.unix, 0)))
+			require.NoError(t, err)
+			assert.Equal(t, tC.expected, code, "time %d", tC.unix)
+		}
+	})
+
+	t.Run("matchTOTP", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Unix(1111111111, 0)
+		current := totpStep(now)
+
+		step, ok := matchTOTP(secret, "050471", now, 0)
+		assert.True(t, ok)
+		assert.Equal(t, current, step)
+
+		// Codes of the previous and next periods are accepted
+		previous, err := TOTPCode(secret, current-1)
+		require.NoError(t, err)
+		_, ok = matchTOTP(secret, previous, now, 0)
+		assert.True(t, ok)
+
+		next, err := TOTPCode(secret, current+1)
+		require.NoError(t, err)
+		_, ok = matchTOTP(secret, next, now, 0)
+		assert.True(t, ok)
+
+		old, err := TOTPCode(secret, current-2)
+		require.NoError(t, err)
+		_, ok = matchTOTP(secret, old, now, 0)
+		assert.False(t, ok)
+
+		// A code cannot be used twice
+		_, ok = matchTOTP(secret, "050471", now, current)
+		assert.False(t, ok)
+
+		_, ok = matchTOTP(secret, "12345", now, 0)
+		assert.False(t, ok)
+	})
+
+	t.Run("GenerateTOTPSecret", func(t *testing.T) {
+		t.Parallel()
+
+		secret, err := GenerateTOTPSecret()
+		require.NoError(t, err)
+		assert.Len(t, secret, 32)
+
+		other, err := GenerateTOTPSecret()
+		require.NoError(t, err)
+		assert.NotEqual(t, secret, other)
+
+		_, err = TOTPCode(secret, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("TOTPURL", func(t *testing.T) {
+		t.Parallel()
+
+		u := TOTPURL("jdoe", "JBSWY3DPEHPK3PXP")
+		assert.True(t, strings.HasPrefix(u, "otpauth://totp/UW%20Poker%20Studies%20Club:jdoe?"), u)
+		assert.Contains(t, u, "secret=JBSWY3DPEHPK3PXP")
+	})
+}
@@ -0,0 +1,398 @@
+package authentication
+
+import (
+	"api/internal/authorization"
+	e "api/internal/errors"
+	"api/internal/models"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// challengeLifetime is how long the second step of a login can be
+	// completed for.
+	challengeLifetime = time.Minute * 5
+	// maxChallengeAttempts is the number of incorrect codes after which a
+	// challenge is discarded and the login must start over.
+	maxChallengeAttempts = 5
+	// recoveryCodeCount is the number of recovery codes generated at a time.
+	recoveryCodeCount = 10
+)
+
+// ErrInvalidTwoFactorCode is returned when a code from an authenticator app
+// or a recovery code is incorrect.
+var ErrInvalidTwoFactorCode = e.Unauthorized("Invalid two-factor code")
+
+// errInvalidChallenge is returned for every challenge that cannot be
+// completed, so that callers cannot tell unknown and expired challenges apart.
+var errInvalidChallenge = e.Unauthorized("Invalid or expired login challenge. Please log in again")
+
+// recoveryCodeAlphabet is the lowercase base32 alphabet, which avoids
+// characters that are easily confused.
+const recoveryCodeAlphabet = "abcdefghijklmnopqrstuvwxyz234567"
+
+type twoFactorManager struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorManager(db *gorm.DB) *twoFactorManager {
+	return &twoFactorManager{
+		db: db,
+	}
+}
+
+// hashRecoveryCode hashes a recovery code for storage, ignoring case and the
+// separator.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	hash := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(hash[:])
+}
+
+// generateRecoveryCodes returns new recovery codes and their hashes.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		random := make([]byte, 10)
+		if _, err := rand.Read(random); err != nil {
+			return nil, nil, err
+		}
+
+		code := make([]byte, 0, 11)
+		for i, b := range random {
+			if i == 5 {
+				code = append(code, '-')
+			}
+			code = append(code, recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+		}
+
+		codes = append(codes, string(code))
+		hashes = append(hashes, hashRecoveryCode(string(code)))
+	}
+
+	return codes, hashes, nil
+}
+
+func (svc *twoFactorManager) findLogin(db *gorm.DB, username string) (*models.Login, error) {
+	login := models.Login{}
+	res := db.Where("username = ?", username).First(&login)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, e.NotFound("Login not found")
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &login, nil
+}
+
+// Status returns whether a login has enabled two-factor authentication.
+func (svc *twoFactorManager) Status(username string) (*models.TwoFactorStatus, error) {
+	login, err := svc.findLogin(svc.db, username)
+	if err != nil {
+		return nil, err
+	}
+
+	status := models.TwoFactorStatus{
+		Enabled:  login.TwoFactorEnabledAt != nil,
+		Required: authorization.RequiresTwoFactor(login.Role),
+	}
+	if status.Enabled {
+		status.RecoveryCodesRemaining = len(login.RecoveryCodes)
+	}
+
+	return &status, nil
+}
+
+// Setup starts enrolling a login in two-factor authentication, generating the
+// secret to add to an authenticator app. Enrollment completes with Enable.
+// Setting up again before then replaces the secret.
+func (svc *twoFactorManager) Setup(username string) (*models.TwoFactorSetupResponse, error) {
+	login, err := svc.findLogin(svc.db, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if login.TwoFactorEnabledAt != nil {
+		return nil, e.InvalidRequest("Two-factor authentication is already enabled")
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	if err := svc.db.Model(login).Update("totp_secret", secret).Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &models.TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURL: TOTPURL(username, secret),
+	}, nil
+}
+
+// Enable completes enrolling a login with a code from the authenticator app
+// it was set up with, and returns its recovery codes.
+func (svc *twoFactorManager) Enable(username string, code string) (*models.TwoFactorRecoveryCodesResponse, error) {
+	login, err := svc.findLogin(svc.db, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if login.TwoFactorEnabledAt != nil {
+		return nil, e.InvalidRequest("Two-factor authentication is already enabled")
+	}
+
+	if login.TOTPSecret == "" {
+		return nil, e.InvalidRequest("Set up two-factor authentication before enabling it")
+	}
+
+	step, ok := matchTOTP(login.TOTPSecret, code, time.Now(), login.TOTPLastStep)
+	if !ok {
+		return nil, e.InvalidRequest("Invalid two-factor code")
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	enabledAt := time.Now().UTC()
+	login.TOTPLastStep = step
+	login.TwoFactorEnabledAt = &enabledAt
+	login.RecoveryCodes = hashes
+
+	res := svc.db.Model(login).Select("totp_last_step", "two_factor_enabled_at", "recovery_codes").Updates(login)
+	if err := res.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &models.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable turns off two-factor authentication for a login after checking its
+// password. Roles which require two-factor authentication cannot disable it.
+func (svc *twoFactorManager) Disable(username string, password string) error {
+	login, err := svc.findLogin(svc.db, username)
+	if err != nil {
+		return err
+	}
+
+	if authorization.RequiresTwoFactor(login.Role) {
+		return e.Forbidden("Two-factor authentication is required for your role")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(login.Password), []byte(password)); err != nil {
+		return e.InvalidRequest("Password is incorrect")
+	}
+
+	return svc.Reset(username)
+}
+
+// Reset turns off two-factor authentication for a login, e.g. when its
+// authenticator app and recovery codes are lost. Its challenges are discarded.
+func (svc *twoFactorManager) Reset(username string) error {
+	login, err := svc.findLogin(svc.db, username)
+	if err != nil {
+		return err
+	}
+
+	err = svc.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(login).Updates(map[string]any{
+			"totp_secret":           "",
+			"totp_last_step":        0,
+			"two_factor_enabled_at": nil,
+			"recovery_codes":        gorm.Expr("'[]'::jsonb"),
+		})
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		return tx.Where("username = ?", username).Delete(&models.TwoFactorChallenge{}).Error
+	})
+	if err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of a login after
+// checking a code from its authenticator app.
+func (svc *twoFactorManager) RegenerateRecoveryCodes(username string, code string) (*models.TwoFactorRecoveryCodesResponse, error) {
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	err = svc.db.Transaction(func(tx *gorm.DB) error {
+		login, err := svc.findLogin(tx.Clauses(clause.Locking{Strength: "UPDATE"}), username)
+		if err != nil {
+			return err
+		}
+
+		if login.TwoFactorEnabledAt == nil {
+			return e.InvalidRequest("Two-factor authentication is not enabled")
+		}
+
+		step, ok := matchTOTP(login.TOTPSecret, code, time.Now(), login.TOTPLastStep)
+		if !ok {
+			return e.InvalidRequest("Invalid two-factor code")
+		}
+
+		login.TOTPLastStep = step
+		login.RecoveryCodes = hashes
+
+		return tx.Model(login).Select("totp_last_step", "recovery_codes").Updates(login).Error
+	})
+	if err != nil {
+		if apiErr, ok := err.(e.APIErrorResponse); ok {
+			return nil, apiErr
+		}
+
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &models.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// CreateChallenge starts the second step of logging in, once the password of
+// a login is checked. It returns nil when the login has not enabled
+// two-factor authentication and can be given a session straight away.
+func (svc *twoFactorManager) CreateChallenge(username string) (*models.TwoFactorChallengeResponse, error) {
+	login, err := svc.findLogin(svc.db, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if login.TwoFactorEnabledAt == nil {
+		return nil, nil
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	challenge := models.TwoFactorChallenge{
+		Username:  username,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().UTC().Add(challengeLifetime),
+	}
+
+	err = svc.db.Transaction(func(tx *gorm.DB) error {
+		// Forget expired challenges of the login as new ones are created
+		if err := tx.Where("username = ? AND expires_at < ?", username, time.Now().UTC()).Delete(&models.TwoFactorChallenge{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&challenge).Error
+	})
+	if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &models.TwoFactorChallengeResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+		ExpiresAt:         challenge.ExpiresAt,
+	}, nil
+}
+
+// FindChallenge returns the challenge a token was issued for, as long as it
+// has not expired.
+func (svc *twoFactorManager) FindChallenge(token string) (*models.TwoFactorChallenge, error) {
+	challenge := models.TwoFactorChallenge{}
+	res := svc.db.Where("token_hash = ?", hashToken(token)).First(&challenge)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errInvalidChallenge
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	if time.Now().After(challenge.ExpiresAt) {
+		return nil, errInvalidChallenge
+	}
+
+	return &challenge, nil
+}
+
+// VerifyChallenge completes a challenge with a code from the authenticator app
+// of its login, or one of its recovery codes, which is then used up. Returns
+// the login on success, and ErrInvalidTwoFactorCode for incorrect codes. The
+// challenge is discarded once it succeeds or has too many incorrect codes.
+func (svc *twoFactorManager) VerifyChallenge(challenge *models.TwoFactorChallenge, code string) (*models.Login, error) {
+	var login *models.Login
+	verified := false
+
+	err := svc.db.Transaction(func(tx *gorm.DB) error {
+		// Reload the challenge under a lock so that concurrent codes each
+		// count as an attempt
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", challenge.ID).First(challenge)
+		if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return errInvalidChallenge
+		} else if err != nil {
+			return err
+		}
+
+		var err error
+		login, err = svc.findLogin(tx.Clauses(clause.Locking{Strength: "UPDATE"}), challenge.Username)
+		if err != nil {
+			return err
+		}
+
+		if login.TwoFactorEnabledAt == nil {
+			return errInvalidChallenge
+		}
+
+		if step, ok := matchTOTP(login.TOTPSecret, code, time.Now(), login.TOTPLastStep); ok {
+			verified = true
+			if err := tx.Model(login).Update("totp_last_step", step).Error; err != nil {
+				return err
+			}
+
+			return tx.Delete(challenge).Error
+		}
+
+		hash := hashRecoveryCode(code)
+		if i := slices.Index(login.RecoveryCodes, hash); i >= 0 {
+			verified = true
+			login.RecoveryCodes = slices.Delete(login.RecoveryCodes, i, i+1)
+			if err := tx.Model(login).Select("recovery_codes").Updates(login).Error; err != nil {
+				return err
+			}
+
+			return tx.Delete(challenge).Error
+		}
+
+		challenge.Attempts++
+		if challenge.Attempts >= maxChallengeAttempts {
+			return tx.Delete(challenge).Error
+		}
+
+		return tx.Model(challenge).Update("attempts", challenge.Attempts).Error
+	})
+	if err != nil {
+		if apiErr, ok := err.(e.APIErrorResponse); ok {
+			return nil, apiErr
+		}
+
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	if verified {
+		return login, nil
+	}
+
+	return nil, ErrInvalidTwoFactorCode
+}
@@ -0,0 +1,286 @@
+package authentication
+
+import (
+	"api/internal/authorization"
+	"api/internal/database"
+	e "api/internal/errors"
+	"api/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoFactorManager(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "TEST")
+
+	db, err := database.OpenTestConnection()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer sqlDB.Close()
+
+	wipeDB := func() {
+		err := database.WipeDB(db)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	twoFactorManager := NewTwoFactorManager(db)
+
+	// enable enrolls a login and returns its secret and recovery codes
+	enable := func(t *testing.T, username string) (string, []string) {
+		setup, err := twoFactorManager.Setup(username)
+		require.NoError(t, err)
+
+		code, err := TOTPCode(setup.Secret, totpStep(time.Now()))
+		require.NoError(t, err)
+
+		res, err := twoFactorManager.Enable(username, code)
+		require.NoError(t, err)
+
+		return setup.Secret, res.RecoveryCodes
+	}
+
+	t.Run("Enable", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		require.NoError(t, CreateTestLogin(db, "testuser", "password"))
+
+		_, err := twoFactorManager.Enable("testuser", "123456")
+		assert.Equal(t, e.InvalidRequest("Set up two-factor authentication before enabling it"), err)
+
+		setup, err := twoFactorManager.Setup("testuser")
+		require.NoError(t, err)
+		assert.Contains(t, setup.OTPAuthURL, "secret="+setup.Secret)
+
+		_, err = twoFactorManager.Enable("testuser", "000000x")
+		assert.Equal(t, e.InvalidRequest("Invalid two-factor code"), err)
+
+		code, err := TOTPCode(setup.Secret, totpStep(time.Now()))
+		require.NoError(t, err)
+		res, err := twoFactorManager.Enable("testuser", code)
+		require.NoError(t, err)
+		assert.Len(t, res.RecoveryCodes, recoveryCodeCount)
+
+		// Only the hashes of the recovery codes are stored
+		login := models.Login{}
+		require.NoError(t, db.Where("username = ?", "testuser").First(&login).Error)
+		assert.NotContains(t, login.RecoveryCodes, res.RecoveryCodes[0])
+		assert.Contains(t, login.RecoveryCodes, hashRecoveryCode(res.RecoveryCodes[0]))
+
+		status, err := twoFactorManager.Status("testuser")
+		require.NoError(t, err)
+		assert.Equal(t, models.TwoFactorStatus{Enabled: true, RecoveryCodesRemaining: recoveryCodeCount}, *status)
+
+		_, err = twoFactorManager.Setup("testuser")
+		assert.Equal(t, e.InvalidRequest("Two-factor authentication is already enabled"), err)
+	})
+
+	t.Run("CreateChallenge_NotEnabled", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		require.NoError(t, CreateTestLogin(db, "testuser", "password"))
+
+		challenge, err := twoFactorManager.CreateChallenge("testuser")
+		require.NoError(t, err)
+		assert.Nil(t, challenge)
+	})
+
+	t.Run("VerifyChallenge", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		require.NoError(t, CreateTestLogin(db, "testuser", "password"))
+		secret, _ := enable(t, "testuser")
+
+		res, err := twoFactorManager.CreateChallenge("testuser")
+		require.NoError(t, err)
+		assert.True(t, res.TwoFactorRequired)
+
+		challenge, err := twoFactorManager.FindChallenge(res.ChallengeToken)
+		require.NoError(t, err)
+
+		// The code used to enable two-factor authentication cannot be reused
+		used, err := TOTPCode(secret, totpStep(time.Now()))
+		require.NoError(t, err)
+		_, err = twoFactorManager.VerifyChallenge(challenge, used)
+		assert.Equal(t, ErrInvalidTwoFactorCode, err)
+
+		next, err := TOTPCode(secret, totpStep(time.Now())+1)
+		require.NoError(t, err)
+		login, err := twoFactorManager.VerifyChallenge(challenge, next)
+		require.NoError(t, err)
+		assert.Equal(t, "testuser", login.Username)
+
+		// Challenges can only be completed once
+		_, err = twoFactorManager.FindChallenge(res.ChallengeToken)
+		assert.Equal(t, errInvalidChallenge, err)
+	})
+
+	t.Run("VerifyChallenge_RecoveryCode", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		require.NoError(t, CreateTestLogin(db, "testuser", "password"))
+		_, recoveryCodes := enable(t, "testuser")
+
+		res, err := twoFactorManager.CreateChallenge("testuser")
+		require.NoError(t, err)
+		challenge, err := twoFactorManager.FindChallenge(res.ChallengeToken)
+		require.NoError(t, err)
+
+		_, err = twoFactorManager.VerifyChallenge(challenge, recoveryCodes[0])
+		require.NoError(t, err)
+
+		status, err := twoFactorManager.Status("testuser")
+		require.NoError(t, err)
+		assert.Equal(t, recoveryCodeCount-1, status.RecoveryCodesRemaining)
+
+		// Recovery codes can only be used once
+		res, err = twoFactorManager.CreateChallenge("testuser")
+		require.NoError(t, err)
+		challenge, err = twoFactorManager.FindChallenge(res.ChallengeToken)
+		require.NoError(t, err)
+
+		_, err = twoFactorManager.VerifyChallenge(challenge, recoveryCodes[0])
+		assert.Equal(t, ErrInvalidTwoFactorCode, err)
+	})
+
+	t.Run("VerifyChallenge_TooManyAttempts", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		require.NoError(t, CreateTestLogin(db, "testuser", "password"))
+		enable(t, "testuser")
+
+		res, err := twoFactorManager.CreateChallenge("testuser")
+		require.NoError(t, err)
+
+		for range maxChallengeAttempts {
+			challenge, err := twoFactorManager.FindChallenge(res.ChallengeToken)
+			require.NoError(t, err)
+
+			_, err = twoFactorManager.VerifyChallenge(challenge, "wrong-code")
+			assert.Equal(t, ErrInvalidTwoFactorCode, err)
+		}
+
+		_, err = twoFactorManager.FindChallenge(res.ChallengeToken)
+		assert.Equal(t, errInvalidChallenge, err)
+	})
+
+	t.Run("VerifyChallenge_StaleChallenge", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		require.NoError(t, CreateTestLogin(db, "testuser", "password"))
+		enable(t, "testuser")
+
+		res, err := twoFactorManager.CreateChallenge("testuser")
+		require.NoError(t, err)
+		challenge, err := twoFactorManager.FindChallenge(res.ChallengeToken)
+		require.NoError(t, err)
+
+		// Requests which found the challenge at the same time each count as an
+		// attempt
+		for range maxChallengeAttempts {
+			stale := *challenge
+			_, err = twoFactorManager.VerifyChallenge(&stale, "wrong-code")
+			assert.Equal(t, ErrInvalidTwoFactorCode, err)
+		}
+
+		_, err = twoFactorManager.FindChallenge(res.ChallengeToken)
+		assert.Equal(t, errInvalidChallenge, err)
+
+		stale := *challenge
+		_, err = twoFactorManager.VerifyChallenge(&stale, "wrong-code")
+		assert.Equal(t, errInvalidChallenge, err)
+	})
+
+	t.Run("FindChallenge_Expired", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		require.NoError(t, CreateTestLogin(db, "testuser", "password"))
+		enable(t, "testuser")
+
+		res, err := twoFactorManager.CreateChallenge("testuser")
+		require.NoError(t, err)
+
+		require.NoError(t, db.Model(&models.TwoFactorChallenge{}).
+			Where("token_hash = ?", hashToken(res.ChallengeToken)).
+			Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+		_, err = twoFactorManager.FindChallenge(res.ChallengeToken)
+		assert.Equal(t, errInvalidChallenge, err)
+	})
+
+	t.Run("RegenerateRecoveryCodes", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		require.NoError(t, CreateTestLogin(db, "testuser", "password"))
+		secret, recoveryCodes := enable(t, "testuser")
+
+		_, err := twoFactorManager.RegenerateRecoveryCodes("testuser", "wrong-code")
+		assert.Equal(t, e.InvalidRequest("Invalid two-factor code"), err)
+
+		code, err := TOTPCode(secret, totpStep(time.Now())+1)
+		require.NoError(t, err)
+		res, err := twoFactorManager.RegenerateRecoveryCodes("testuser", code)
+		require.NoError(t, err)
+		assert.Len(t, res.RecoveryCodes, recoveryCodeCount)
+		assert.NotContains(t, res.RecoveryCodes, recoveryCodes[0])
+
+		// The previous recovery codes stop working
+		challengeRes, err := twoFactorManager.CreateChallenge("testuser")
+		require.NoError(t, err)
+		challenge, err := twoFactorManager.FindChallenge(challengeRes.ChallengeToken)
+		require.NoError(t, err)
+
+		_, err = twoFactorManager.VerifyChallenge(challenge, recoveryCodes[0])
+		assert.Equal(t, ErrInvalidTwoFactorCode, err)
+	})
+
+	t.Run("Disable", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		require.NoError(t, CreateTestLogin(db, "testuser", "password"))
+		enable(t, "testuser")
+
+		err := twoFactorManager.Disable("testuser", "wrong-password")
+		assert.Equal(t, e.InvalidRequest("Password is incorrect"), err)
+
+		require.NoError(t, twoFactorManager.Disable("testuser", "password"))
+
+		status, err := twoFactorManager.Status("testuser")
+		require.NoError(t, err)
+		assert.False(t, status.Enabled)
+
+		challenge, err := twoFactorManager.CreateChallenge("testuser")
+		require.NoError(t, err)
+		assert.Nil(t, challenge)
+	})
+
+	t.Run("Disable_Required", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+		t.Cleanup(func() {
+			require.NoError(t, authorization.SetTwoFactorRequiredRole(""))
+		})
+
+		require.NoError(t, CreateTestLogin(db, "testuser", "password"))
+		enable(t, "testuser")
+
+		require.NoError(t, authorization.SetTwoFactorRequiredRole("executive"))
+
+		err := twoFactorManager.Disable("testuser", "password")
+		assert.Equal(t, e.Forbidden("Two-factor authentication is required for your role"), err)
+
+		// Resetting is still possible, e.g. for an admin
+		require.NoError(t, twoFactorManager.Reset("testuser"))
+
+		status, err := twoFactorManager.Status("testuser")
+		require.NoError(t, err)
+		assert.Equal(t, models.TwoFactorStatus{Required: true}, *status)
+	})
+}
@@ -0,0 +1,37 @@
+package authorization
+
+// apiKeyAuthorizer authorizes actions on the API keys of a login.
+type apiKeyAuthorizer struct {
+	actions []string
+}
+
+// NewAPIKeyAuthorizer creates a new API key authorizer.
+func NewAPIKeyAuthorizer() ResourceAuthorizer {
+	return &apiKeyAuthorizer{
+		actions: []string{"create", "list", "delete"},
+	}
+}
+
+// IsAuthorized checks if a user with the given role is authorized to perform the specified action on an API key.
+func (svc *apiKeyAuthorizer) IsAuthorized(role string, action string) bool {
+	switch action {
+	case "create":
+		return HasRole(ROLE_WEBMASTER, role)
+	case "list":
+		return HasRole(ROLE_WEBMASTER, role)
+	case "delete":
+		return HasRole(ROLE_WEBMASTER, role)
+	}
+
+	return false
+}
+
+func (svc *apiKeyAuthorizer) GetPermissions(role string) map[string]any {
+	permissions := make(map[string]any)
+
+	for _, action := range svc.actions {
+		permissions[action] = svc.IsAuthorized(role, action)
+	}
+
+	return permissions
+}
@@ -0,0 +1,33 @@
+package authorization
+
+// AuditAuthorizer is an interface that defines the methods for authorizing audit events.
+type auditAuthorizer struct {
+	actions []string
+}
+
+// NewAuditAuthorizer creates a new audit authorizer.
+func NewAuditAuthorizer() ResourceAuthorizer {
+	return &auditAuthorizer{
+		actions: []string{"list"},
+	}
+}
+
+// IsAuthorized checks if a user with the given role is authorized to perform the specified action on audit events.
+func (svc *auditAuthorizer) IsAuthorized(role string, action string) bool {
+	switch action {
+	case "list":
+		return HasRole(ROLE_PRESIDENT, role) || HasRole(ROLE_WEBMASTER, role)
+	}
+
+	return false
+}
+
+func (svc *auditAuthorizer) GetPermissions(role string) map[string]any {
+	permissions := make(map[string]any)
+
+	for _, action := range svc.actions {
+		permissions[action] = svc.IsAuthorized(role, action)
+	}
+
+	return permissions
+}
@@ -0,0 +1,98 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditAuthorizer(t *testing.T) {
+	testCases := []struct {
+		name  string
+		roles []struct {
+			role     string
+			expected bool
+		}
+		action string
+	}{
+		{
+			name: "No action",
+			roles: []struct {
+				role     string
+				expected bool
+			}{
+				{role: ROLE_BOT.ToString(), expected: false},
+			},
+			action: "",
+		},
+		{
+			name: "No role",
+			roles: []struct {
+				role     string
+				expected bool
+			}{
+				{role: "", expected: false},
+			},
+			action: "get",
+		},
+		{
+			name: "List Authorized",
+			roles: []struct {
+				role     string
+				expected bool
+			}{
+				{role: ROLE_BOT.ToString(), expected: false},
+				{role: ROLE_EXECUTIVE.ToString(), expected: false},
+				{role: ROLE_TOURNAMENT_DIRECTOR.ToString(), expected: false},
+				{role: ROLE_SECRETARY.ToString(), expected: false},
+				{role: ROLE_TREASURER.ToString(), expected: false},
+				{role: ROLE_VICE_PRESIDENT.ToString(), expected: false},
+				{role: ROLE_PRESIDENT.ToString(), expected: true},
+				{role: ROLE_WEBMASTER.ToString(), expected: true},
+			},
+			action: "list",
+		},
+		{
+			name: "Unknown action",
+			roles: []struct {
+				role     string
+				expected bool
+			}{
+				{role: ROLE_WEBMASTER.ToString(), expected: false},
+			},
+			action: "delete",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			svc := NewAuditAuthorizer()
+			for _, role := range tC.roles {
+				result := svc.IsAuthorized(role.role, tC.action)
+				assert.Equal(t, role.expected, result)
+			}
+		})
+	}
+}
+
+func TestAuditAuthorizer_GetPermissions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		role     string
+		expected map[string]any
+	}{
+		{
+			name: "Should return correct permission map",
+			role: "tournament_director",
+			expected: map[string]any{
+				"list": false,
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			svc := NewAuditAuthorizer()
+			permissions := svc.GetPermissions(tC.role)
+			assert.Equal(t, tC.expected, permissions)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package authorization
+
+// clockAuthorizer authorizes actions on an event's tournament clock.
+type clockAuthorizer struct {
+	actions []string
+}
+
+// NewClockAuthorizer creates a new clock authorizer.
+func NewClockAuthorizer() ResourceAuthorizer {
+	return &clockAuthorizer{
+		actions: []string{"get", "edit"},
+	}
+}
+
+// IsAuthorized checks if a user with the given role is authorized to perform the specified action on a clock.
+func (svc *clockAuthorizer) IsAuthorized(role string, action string) bool {
+	switch action {
+	case "get":
+		return HasAtleastRole(ROLE_BOT, role)
+	case "edit":
+		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
+	}
+
+	return false
+}
+
+func (svc *clockAuthorizer) GetPermissions(role string) map[string]any {
+	permissions := make(map[string]any)
+
+	for _, action := range svc.actions {
+		permissions[action] = svc.IsAuthorized(role, action)
+	}
+
+	return permissions
+}
@@ -0,0 +1,106 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClockAuthorizer(t *testing.T) {
+	testCases := []struct {
+		name  string
+		roles []struct {
+			role     string
+			expected bool
+		}
+		action string
+	}{
+		{
+			name: "No action",
+			roles: []struct {
+				role     string
+				expected bool
+			}{
+				{role: ROLE_BOT.ToString(), expected: false},
+			},
+			action: "",
+		},
+		{
+			name: "No role",
+			roles: []struct {
+				role     string
+				expected bool
+			}{
+				{role: "", expected: false},
+			},
+			action: "get",
+		},
+		{
+			name: "Get Authorized",
+			roles: []struct {
+				role     string
+				expected bool
+			}{
+				{role: ROLE_BOT.ToString(), expected: true},
+				{role: ROLE_EXECUTIVE.ToString(), expected: true},
+				{role: ROLE_TOURNAMENT_DIRECTOR.ToString(), expected: true},
+				{role: ROLE_SECRETARY.ToString(), expected: true},
+				{role: ROLE_TREASURER.ToString(), expected: true},
+				{role: ROLE_VICE_PRESIDENT.ToString(), expected: true},
+				{role: ROLE_PRESIDENT.ToString(), expected: true},
+				{role: ROLE_WEBMASTER.ToString(), expected: true},
+			},
+			action: "get",
+		},
+		{
+			name: "Edit Authorized",
+			roles: []struct {
+				role     string
+				expected bool
+			}{
+				{role: ROLE_BOT.ToString(), expected: false},
+				{role: ROLE_EXECUTIVE.ToString(), expected: false},
+				{role: ROLE_TOURNAMENT_DIRECTOR.ToString(), expected: true},
+				{role: ROLE_SECRETARY.ToString(), expected: true},
+				{role: ROLE_TREASURER.ToString(), expected: true},
+				{role: ROLE_VICE_PRESIDENT.ToString(), expected: true},
+				{role: ROLE_PRESIDENT.ToString(), expected: true},
+				{role: ROLE_WEBMASTER.ToString(), expected: true},
+			},
+			action: "edit",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			svc := NewClockAuthorizer()
+			for _, role := range tC.roles {
+				result := svc.IsAuthorized(role.role, tC.action)
+				assert.Equal(t, role.expected, result)
+			}
+		})
+	}
+}
+
+func TestClockAuthorizer_GetPermissions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		role     string
+		expected map[string]any
+	}{
+		{
+			name: "Should return correct permission map",
+			role: "tournament_director",
+			expected: map[string]any{
+				"get":  true,
+				"edit": true,
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			svc := NewClockAuthorizer()
+			permissions := svc.GetPermissions(tC.role)
+			assert.Equal(t, tC.expected, permissions)
+		})
+	}
+}
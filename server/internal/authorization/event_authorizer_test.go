@@ -239,9 +239,37 @@ func TestEventAuthorizer_GetPermissions(t *testing.T) {
 					"list":   true,
 					"delete": false,
 				},
+				"clock": map[string]any{
+					"create": true,
+					"get":    true,
+					"list":   true,
+					"delete": false,
+				},
+				"seating": map[string]any{
+					"create": true,
+					"get":    true,
+					"list":   true,
+					"delete": false,
+				},
+				"kiosk": map[string]any{
+					"create": true,
+					"get":    true,
+					"list":   true,
+					"delete": false,
+				},
+				"registration": map[string]any{
+					"create": true,
+					"get":    true,
+					"list":   true,
+					"delete": false,
+				},
 			},
 			resourceAuthorizers: ResourceAuthorizerMap{
-				"participant": &MockResourceAuthorizer{},
+				"participant":  &MockResourceAuthorizer{},
+				"clock":        &MockResourceAuthorizer{},
+				"seating":      &MockResourceAuthorizer{},
+				"kiosk":        &MockResourceAuthorizer{},
+				"registration": &MockResourceAuthorizer{},
 			},
 			mockResourceAuthorizer: func(m *MockResourceAuthorizer) {
 				m.On("GetPermissions", mock.Anything).Return(map[string]any{
@@ -256,6 +284,10 @@ func TestEventAuthorizer_GetPermissions(t *testing.T) {
 	for _, tC := range testCases {
 		t.Run(tC.name, func(t *testing.T) {
 			tC.mockResourceAuthorizer(tC.resourceAuthorizers["participant"].(*MockResourceAuthorizer))
+			tC.mockResourceAuthorizer(tC.resourceAuthorizers["clock"].(*MockResourceAuthorizer))
+			tC.mockResourceAuthorizer(tC.resourceAuthorizers["seating"].(*MockResourceAuthorizer))
+			tC.mockResourceAuthorizer(tC.resourceAuthorizers["kiosk"].(*MockResourceAuthorizer))
+			tC.mockResourceAuthorizer(tC.resourceAuthorizers["registration"].(*MockResourceAuthorizer))
 			svc := NewEventAuthorizer(tC.resourceAuthorizers)
 			permissions := svc.GetPermissions(tC.role)
 			assert.Equal(t, tC.expected, permissions)
@@ -0,0 +1,37 @@
+package authorization
+
+// kioskAuthorizer authorizes managing the self check-in kiosk tokens of an event.
+type kioskAuthorizer struct {
+	actions []string
+}
+
+// NewKioskAuthorizer creates a new kiosk authorizer.
+func NewKioskAuthorizer() ResourceAuthorizer {
+	return &kioskAuthorizer{
+		actions: []string{"create", "list", "delete"},
+	}
+}
+
+// IsAuthorized checks if a user with the given role is authorized to perform the specified action on an event's kiosk tokens.
+func (svc *kioskAuthorizer) IsAuthorized(role string, action string) bool {
+	switch action {
+	case "create":
+		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
+	case "list":
+		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
+	case "delete":
+		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
+	}
+
+	return false
+}
+
+func (svc *kioskAuthorizer) GetPermissions(role string) map[string]any {
+	permissions := make(map[string]any)
+
+	for _, action := range svc.actions {
+		permissions[action] = svc.IsAuthorized(role, action)
+	}
+
+	return permissions
+}
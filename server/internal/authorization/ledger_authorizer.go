@@ -0,0 +1,35 @@
+package authorization
+
+// ledgerAuthorizer authorizes actions on a semester's budget ledger.
+type ledgerAuthorizer struct {
+	actions []string
+}
+
+// NewLedgerAuthorizer creates a new ledger authorizer.
+func NewLedgerAuthorizer() ResourceAuthorizer {
+	return &ledgerAuthorizer{
+		actions: []string{"get", "list"},
+	}
+}
+
+// IsAuthorized checks if a user with the given role is authorized to perform the specified action on a ledger.
+func (svc *ledgerAuthorizer) IsAuthorized(role string, action string) bool {
+	switch action {
+	case "get":
+		return HasAtleastRole(ROLE_SECRETARY, role)
+	case "list":
+		return HasAtleastRole(ROLE_SECRETARY, role)
+	}
+
+	return false
+}
+
+func (svc *ledgerAuthorizer) GetPermissions(role string) map[string]any {
+	permissions := make(map[string]any)
+
+	for _, action := range svc.actions {
+		permissions[action] = svc.IsAuthorized(role, action)
+	}
+
+	return permissions
+}
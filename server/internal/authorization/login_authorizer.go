@@ -1,19 +1,38 @@
 package authorization
 
+import "strings"
+
 // loginAuthorizer is a struct that implements the ResourceAuthorizer interface.
 type loginAuthorizer struct {
-	actions []string
+	resourceAuthorizers ResourceAuthorizerMap
+	actions             []string
+	subResources        []string
 }
 
 // NewLoginAuthorizer creates a new login authorizer.
-func NewLoginAuthorizer() ResourceAuthorizer {
+// It takes a ResourceAuthorizerMap as an argument, which is a map of resource names to their respective authorizers.
+func NewLoginAuthorizer(resourceAuthorizers ResourceAuthorizerMap) ResourceAuthorizer {
 	return &loginAuthorizer{
-		actions: []string{"create", "list", "get", "delete", "edit"},
+		resourceAuthorizers: resourceAuthorizers,
+		actions:             []string{"create", "list", "get", "delete", "edit"},
+		subResources:        []string{"apiKey"},
 	}
 }
 
 // IsAuthorized checks if the user is authorized to perform the action.
 func (svc *loginAuthorizer) IsAuthorized(role string, action string) bool {
+	// Split out the sub-resource from the action string if possible
+	parts := strings.SplitAfterN(action, ".", 2)
+	if len(parts) == 2 {
+		resource := strings.TrimSuffix(parts[0], ".")
+
+		authorizer, exists := svc.resourceAuthorizers[resource]
+		if !exists {
+			return false
+		}
+		return authorizer.IsAuthorized(role, parts[1])
+	}
+
 	switch action {
 	case "create", "list", "get", "delete", "edit":
 		return HasRole(ROLE_WEBMASTER, role)
@@ -29,5 +48,9 @@ func (svc *loginAuthorizer) GetPermissions(role string) map[string]any {
 		permissions[action] = svc.IsAuthorized(role, action)
 	}
 
+	for _, subResource := range svc.subResources {
+		permissions[subResource] = svc.resourceAuthorizers[subResource].GetPermissions(role)
+	}
+
 	return permissions
 }
@@ -100,10 +100,32 @@ func TestLoginAuthorizer(t *testing.T) {
 			},
 			action: "edit",
 		},
+		{
+			name: "API key sub-resource",
+			roles: []struct {
+				role     string
+				expected bool
+			}{
+				{role: ROLE_BOT.ToString(), expected: false},
+				{role: ROLE_PRESIDENT.ToString(), expected: false},
+				{role: ROLE_WEBMASTER.ToString(), expected: true},
+			},
+			action: "apiKey.create",
+		},
+		{
+			name: "Unknown sub-resource",
+			roles: []struct {
+				role     string
+				expected bool
+			}{
+				{role: ROLE_WEBMASTER.ToString(), expected: false},
+			},
+			action: "token.create",
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.name, func(t *testing.T) {
-			svc := NewLoginAuthorizer()
+			svc := NewLoginAuthorizer(ResourceAuthorizerMap{"apiKey": NewAPIKeyAuthorizer()})
 			for _, r := range tC.roles {
 				result := svc.IsAuthorized(r.role, tC.action)
 				assert.Equal(t, r.expected, result, "Expected %s to be %v for action %s", r.role, r.expected, tC.action)
@@ -127,12 +149,17 @@ func TestLoginAuthorizer_GetPermissions(t *testing.T) {
 				"get":    false,
 				"delete": false,
 				"edit":   false,
+				"apiKey": map[string]any{
+					"create": false,
+					"list":   false,
+					"delete": false,
+				},
 			},
 		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.name, func(t *testing.T) {
-			svc := NewLoginAuthorizer()
+			svc := NewLoginAuthorizer(ResourceAuthorizerMap{"apiKey": NewAPIKeyAuthorizer()})
 			permissions := svc.GetPermissions(tC.role)
 			assert.Equal(t, tC.expected, permissions)
 		})
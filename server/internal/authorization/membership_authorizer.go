@@ -8,7 +8,7 @@ type membershipAuthorizer struct {
 // NewMembershipAuthorizer creates a new membership authorizer.
 func NewMembershipAuthorizer() ResourceAuthorizer {
 	return &membershipAuthorizer{
-		actions: []string{"create", "get", "list", "edit", "delete"},
+		actions: []string{"create", "get", "list", "edit", "delete", "export"},
 	}
 }
 
@@ -25,6 +25,8 @@ func (svc *membershipAuthorizer) IsAuthorized(role string, action string) bool {
 		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
 	case "delete":
 		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
+	case "export":
+		return HasAtleastRole(ROLE_SECRETARY, role)
 	}
 
 	return false
@@ -103,6 +103,23 @@ func TestMembershipAuthorizer(t *testing.T) {
 			},
 			action: "edit",
 		},
+		{
+			name: "Export Authorized",
+			roles: []struct {
+				role     string
+				expected bool
+			}{
+				{role: ROLE_BOT.ToString(), expected: false},
+				{role: ROLE_EXECUTIVE.ToString(), expected: false},
+				{role: ROLE_TOURNAMENT_DIRECTOR.ToString(), expected: false},
+				{role: ROLE_SECRETARY.ToString(), expected: true},
+				{role: ROLE_TREASURER.ToString(), expected: true},
+				{role: ROLE_VICE_PRESIDENT.ToString(), expected: true},
+				{role: ROLE_PRESIDENT.ToString(), expected: true},
+				{role: ROLE_WEBMASTER.ToString(), expected: true},
+			},
+			action: "export",
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.name, func(t *testing.T) {
@@ -130,6 +147,7 @@ func TestMembershipAuthorizer_GetPermissions(t *testing.T) {
 				"list":   true,
 				"edit":   true,
 				"delete": true,
+				"export": false,
 			},
 		},
 	}
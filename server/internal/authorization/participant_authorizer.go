@@ -8,7 +8,7 @@ type participantAuthorizer struct {
 // NewParticipantAuthorizer creates a new participant authorizer.
 func NewParticipantAuthorizer() ResourceAuthorizer {
 	return &participantAuthorizer{
-		actions: []string{"create", "get", "list", "signin", "signout", "delete"},
+		actions: []string{"create", "get", "list", "signin", "signout", "delete", "rebuy"},
 	}
 }
 
@@ -27,6 +27,8 @@ func (svc *participantAuthorizer) IsAuthorized(role string, action string) bool
 		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
 	case "delete":
 		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
+	case "rebuy":
+		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
 	}
 
 	return false
@@ -137,6 +137,23 @@ func TestParticipantAuthorizer(t *testing.T) {
 			},
 			action: "delete",
 		},
+		{
+			name: "Rebuy Authorized",
+			roles: []struct {
+				role     string
+				expected bool
+			}{
+				{role: ROLE_BOT.ToString(), expected: false},
+				{role: ROLE_EXECUTIVE.ToString(), expected: false},
+				{role: ROLE_TOURNAMENT_DIRECTOR.ToString(), expected: true},
+				{role: ROLE_SECRETARY.ToString(), expected: true},
+				{role: ROLE_TREASURER.ToString(), expected: true},
+				{role: ROLE_VICE_PRESIDENT.ToString(), expected: true},
+				{role: ROLE_PRESIDENT.ToString(), expected: true},
+				{role: ROLE_WEBMASTER.ToString(), expected: true},
+			},
+			action: "rebuy",
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.name, func(t *testing.T) {
@@ -165,6 +182,7 @@ func TestParticipantAuthorizer_GetPermissions(t *testing.T) {
 				"signin":  true,
 				"signout": true,
 				"delete":  true,
+				"rebuy":   true,
 			},
 		},
 	}
@@ -0,0 +1,35 @@
+package authorization
+
+// permissionAuthorizer is an interface that defines the methods for authorizing the permission policy.
+type permissionAuthorizer struct {
+	actions []string
+}
+
+// NewPermissionAuthorizer creates a new permission authorizer.
+func NewPermissionAuthorizer() ResourceAuthorizer {
+	return &permissionAuthorizer{
+		actions: []string{"list", "edit"},
+	}
+}
+
+// IsAuthorized checks if a user with the given role is authorized to perform the specified action on the permission policy.
+func (svc *permissionAuthorizer) IsAuthorized(role string, action string) bool {
+	switch action {
+	case "list":
+		return HasRole(ROLE_WEBMASTER, role)
+	case "edit":
+		return HasRole(ROLE_WEBMASTER, role)
+	}
+
+	return false
+}
+
+func (svc *permissionAuthorizer) GetPermissions(role string) map[string]any {
+	permissions := make(map[string]any)
+
+	for _, action := range svc.actions {
+		permissions[action] = svc.IsAuthorized(role, action)
+	}
+
+	return permissions
+}
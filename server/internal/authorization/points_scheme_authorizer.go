@@ -0,0 +1,37 @@
+package authorization
+
+// PointsSchemeAuthorizer is an interface that defines the methods for authorizing points schemes.
+type pointsSchemeAuthorizer struct {
+	actions []string
+}
+
+// NewPointsSchemeAuthorizer creates a new points scheme authorizer.
+func NewPointsSchemeAuthorizer() ResourceAuthorizer {
+	return &pointsSchemeAuthorizer{
+		actions: []string{"get", "edit", "delete"},
+	}
+}
+
+// IsAuthorized checks if a user with the given role is authorized to perform the specified action on a points scheme.
+func (svc *pointsSchemeAuthorizer) IsAuthorized(role string, action string) bool {
+	switch action {
+	case "get":
+		return HasAtleastRole(ROLE_EXECUTIVE, role)
+	case "edit":
+		return HasAtleastRole(ROLE_VICE_PRESIDENT, role)
+	case "delete":
+		return HasAtleastRole(ROLE_VICE_PRESIDENT, role)
+	}
+
+	return false
+}
+
+func (svc *pointsSchemeAuthorizer) GetPermissions(role string) map[string]any {
+	permissions := make(map[string]any)
+
+	for _, action := range svc.actions {
+		permissions[action] = svc.IsAuthorized(role, action)
+	}
+
+	return permissions
+}
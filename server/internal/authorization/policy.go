@@ -0,0 +1,242 @@
+package authorization
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Policy maps fully qualified actions, such as "event.end" or
+// "semester.rankings.export", to the roles allowed to perform them.
+type Policy map[string][]string
+
+// ErrUnknownAction is returned when changing the roles of an action that is
+// not part of the default policy.
+var ErrUnknownAction = errors.New("unknown action")
+
+// builtinAuthorizerMap is the permission matrix the API ships with. It seeds
+// the default policy and is not used to authorize requests directly.
+var builtinAuthorizerMap = ResourceAuthorizerMap{
+	"login": NewLoginAuthorizer(ResourceAuthorizerMap{
+		"apiKey": NewAPIKeyAuthorizer(),
+	}),
+	"user": NewUserAuthorizer(),
+	"semester": NewSemesterAuthorizer(ResourceAuthorizerMap{
+		"rankings":     NewRankingsAuthorizer(),
+		"transaction":  NewTransactionAuthorizer(),
+		"pointsScheme": NewPointsSchemeAuthorizer(),
+		"ledger":       NewLedgerAuthorizer(),
+	}),
+	"membership": NewMembershipAuthorizer(),
+	"structure":  NewStructureAuthorizer(),
+	"event": NewEventAuthorizer(ResourceAuthorizerMap{
+		"participant":  NewParticipantAuthorizer(),
+		"clock":        NewClockAuthorizer(),
+		"seating":      NewSeatingAuthorizer(),
+		"kiosk":        NewKioskAuthorizer(),
+		"registration": NewRegistrationAuthorizer(),
+	}),
+	"audit":      NewAuditAuthorizer(),
+	"permission": NewPermissionAuthorizer(),
+}
+
+// DefaultPolicy returns the policy described by the built-in permission
+// matrix. Every action is present, including actions no role may perform.
+func DefaultPolicy() Policy {
+	return NewPolicy(builtinAuthorizerMap)
+}
+
+// NewPolicy builds a policy from the permissions reported by a set of
+// resource authorizers for every role.
+func NewPolicy(resourceAuthorizers ResourceAuthorizerMap) Policy {
+	policy := make(Policy)
+
+	for _, r := range allRoles {
+		svc := NewAuthorizationService(r.ToString(), resourceAuthorizers)
+		for resource, permissions := range svc.GetPermissions() {
+			flattenPermissions(policy, resource, permissions, r.ToString())
+		}
+	}
+
+	return policy
+}
+
+func flattenPermissions(policy Policy, prefix string, permissions map[string]any, role string) {
+	for key, value := range permissions {
+		action := prefix + "." + key
+		switch v := value.(type) {
+		case bool:
+			if _, exists := policy[action]; !exists {
+				policy[action] = []string{}
+			}
+			if v {
+				policy[action] = append(policy[action], role)
+			}
+		case map[string]any:
+			flattenPermissions(policy, action, v, role)
+		}
+	}
+}
+
+// IsProtected reports whether the roles of an action may not be changed. The
+// actions that manage the policy itself are protected, so that it cannot be
+// edited into a state no one can recover from.
+func IsProtected(action string) bool {
+	return strings.HasPrefix(action, "permission.")
+}
+
+// PolicyStore holds the policy used to authorize requests. It is safe for
+// concurrent use, so that the policy can be edited while requests are served.
+type PolicyStore struct {
+	mu       sync.RWMutex
+	defaults Policy
+	policy   Policy
+}
+
+// NewPolicyStore creates a policy store that starts out with the default
+// policy.
+func NewPolicyStore(defaults Policy) *PolicyStore {
+	return &PolicyStore{
+		defaults: defaults,
+		policy:   copyPolicy(defaults),
+	}
+}
+
+// ActivePolicy is the policy used by DefaultAuthorizerMap. It starts out as
+// the default policy until the stored policy is loaded.
+var ActivePolicy = NewPolicyStore(DefaultPolicy())
+
+// Allows checks if the given role may perform a fully qualified action.
+// Unknown actions are never allowed.
+func (s *PolicyStore) Allows(action string, role string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Contains(s.policy[action], role)
+}
+
+// Actions returns every action in the policy, sorted.
+func (s *PolicyStore) Actions() []string {
+	actions := make([]string, 0, len(s.defaults))
+	for action := range s.defaults {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	return actions
+}
+
+// Roles returns the roles currently allowed to perform an action.
+func (s *PolicyStore) Roles(action string) ([]string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	roles, exists := s.policy[action]
+	return slices.Clone(roles), exists
+}
+
+// DefaultRoles returns the roles allowed to perform an action by default.
+func (s *PolicyStore) DefaultRoles(action string) ([]string, bool) {
+	roles, exists := s.defaults[action]
+	return slices.Clone(roles), exists
+}
+
+// Validate checks that roles may be given to an action, and returns them
+// ordered from lowest to highest privilege without duplicates.
+func (s *PolicyStore) Validate(action string, roles []string) ([]string, error) {
+	if _, exists := s.defaults[action]; !exists {
+		return nil, ErrUnknownAction
+	}
+
+	return normalizeRoles(roles)
+}
+
+// Set changes the roles allowed to perform an action.
+func (s *PolicyStore) Set(action string, roles []string) error {
+	normalized, err := s.Validate(action, roles)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.policy[action] = normalized
+	return nil
+}
+
+// Load replaces the policy. Actions missing from the given policy keep their
+// default roles, and actions that are not part of the default policy are
+// ignored.
+func (s *PolicyStore) Load(policy Policy) error {
+	loaded := copyPolicy(s.defaults)
+	for action, roles := range policy {
+		if _, exists := s.defaults[action]; !exists {
+			continue
+		}
+
+		normalized, err := normalizeRoles(roles)
+		if err != nil {
+			return fmt.Errorf("action %s: %w", action, err)
+		}
+		loaded[action] = normalized
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.policy = loaded
+	return nil
+}
+
+// Reset restores the default policy.
+func (s *PolicyStore) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.policy = copyPolicy(s.defaults)
+}
+
+// resources returns the top-level resources of the policy.
+func (s *PolicyStore) resources() []string {
+	resources := []string{}
+	for action := range s.defaults {
+		resource, _, _ := strings.Cut(action, ".")
+		if !slices.Contains(resources, resource) {
+			resources = append(resources, resource)
+		}
+	}
+
+	return resources
+}
+
+// normalizeRoles validates a list of roles and orders it from lowest to
+// highest privilege without duplicates.
+func normalizeRoles(roles []string) ([]string, error) {
+	for _, r := range roles {
+		if !IsValidRole(r) {
+			return nil, fmt.Errorf("invalid role '%s'", r)
+		}
+	}
+
+	normalized := []string{}
+	for _, r := range allRoles {
+		if slices.Contains(roles, r.ToString()) {
+			normalized = append(normalized, r.ToString())
+		}
+	}
+
+	return normalized, nil
+}
+
+func copyPolicy(policy Policy) Policy {
+	copied := make(Policy, len(policy))
+	for action, roles := range policy {
+		copied[action] = slices.Clone(roles)
+	}
+
+	return copied
+}
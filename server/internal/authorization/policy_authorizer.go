@@ -0,0 +1,68 @@
+package authorization
+
+import "strings"
+
+// policyAuthorizer authorizes the actions on a resource, and its sub-resources,
+// against a policy store.
+type policyAuthorizer struct {
+	resource string
+	store    *PolicyStore
+}
+
+// NewPolicyAuthorizer creates a new authorizer for a top-level resource, such
+// as "event", backed by the given policy store.
+func NewPolicyAuthorizer(resource string, store *PolicyStore) ResourceAuthorizer {
+	return &policyAuthorizer{
+		resource: resource,
+		store:    store,
+	}
+}
+
+// NewPolicyAuthorizerMap creates an authorizer for every resource in the
+// policy store.
+func NewPolicyAuthorizerMap(store *PolicyStore) ResourceAuthorizerMap {
+	resourceAuthorizers := make(ResourceAuthorizerMap)
+	for _, resource := range store.resources() {
+		resourceAuthorizers[resource] = NewPolicyAuthorizer(resource, store)
+	}
+
+	return resourceAuthorizers
+}
+
+// IsAuthorized checks if a user with the given role is authorized to perform
+// the specified action, e.g. "end" or "participant.signin", on the resource.
+func (svc *policyAuthorizer) IsAuthorized(role string, action string) bool {
+	// Validate input
+	if action == "" || role == "" {
+		return false
+	}
+
+	return svc.store.Allows(svc.resource+"."+action, role)
+}
+
+func (svc *policyAuthorizer) GetPermissions(role string) map[string]any {
+	permissions := make(map[string]any)
+
+	prefix := svc.resource + "."
+	for _, action := range svc.store.Actions() {
+		if !strings.HasPrefix(action, prefix) {
+			continue
+		}
+
+		// Nest the actions of sub-resources under the sub-resource name
+		current := permissions
+		parts := strings.Split(strings.TrimPrefix(action, prefix), ".")
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := current[part].(map[string]any)
+			if !ok {
+				next = make(map[string]any)
+				current[part] = next
+			}
+			current = next
+		}
+
+		current[parts[len(parts)-1]] = svc.store.Allows(action, role)
+	}
+
+	return permissions
+}
@@ -0,0 +1,92 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultPolicy(t *testing.T) {
+	policy := DefaultPolicy()
+
+	assert.Equal(t, []string{"secretary", "treasurer", "vice_president", "president", "webmaster"}, policy["event.end"])
+	assert.Equal(t, []string{"treasurer", "webmaster"}, policy["semester.transaction.create"])
+	assert.Equal(t, []string{"bot", "tournament_director", "secretary", "treasurer", "vice_president", "president", "webmaster"}, policy["membership.create"])
+	assert.Equal(t, []string{"webmaster"}, policy["permission.edit"])
+	assert.Contains(t, policy, "semester.rankings.export")
+	assert.NotContains(t, policy, "event.participant")
+}
+
+func TestDefaultAuthorizerMap_MatchesBuiltinMatrix(t *testing.T) {
+	store := NewPolicyStore(DefaultPolicy())
+	authorizers := NewPolicyAuthorizerMap(store)
+
+	for _, r := range allRoles {
+		t.Run(r.ToString(), func(t *testing.T) {
+			builtin := NewAuthorizationService(r.ToString(), builtinAuthorizerMap)
+			svc := NewAuthorizationService(r.ToString(), authorizers)
+
+			assert.Equal(t, builtin.GetPermissions(), svc.GetPermissions())
+			for _, action := range store.Actions() {
+				assert.Equal(t, builtin.IsAuthorized(action), svc.IsAuthorized(action), action)
+			}
+		})
+	}
+}
+
+func TestPolicyStore_Set(t *testing.T) {
+	store := NewPolicyStore(DefaultPolicy())
+	authorizers := NewPolicyAuthorizerMap(store)
+	svc := NewAuthorizationService(ROLE_TOURNAMENT_DIRECTOR.ToString(), authorizers)
+
+	require.False(t, svc.IsAuthorized("event.end"))
+
+	require.NoError(t, store.Set("event.end", []string{"webmaster", "tournament_director", "webmaster"}))
+	assert.True(t, svc.IsAuthorized("event.end"))
+	assert.Equal(t, true, svc.GetPermissions()["event"]["end"])
+
+	roles, exists := store.Roles("event.end")
+	require.True(t, exists)
+	assert.Equal(t, []string{"tournament_director", "webmaster"}, roles)
+
+	defaults, exists := store.DefaultRoles("event.end")
+	require.True(t, exists)
+	assert.Equal(t, []string{"secretary", "treasurer", "vice_president", "president", "webmaster"}, defaults)
+
+	assert.ErrorIs(t, store.Set("event.fly", []string{"webmaster"}), ErrUnknownAction)
+	assert.Error(t, store.Set("event.end", []string{"admin"}))
+
+	store.Reset()
+	assert.False(t, svc.IsAuthorized("event.end"))
+}
+
+func TestPolicyStore_Load(t *testing.T) {
+	store := NewPolicyStore(DefaultPolicy())
+
+	err := store.Load(Policy{
+		"event.end":      {"president"},
+		"event.teleport": {"bot"},
+	})
+	require.NoError(t, err)
+
+	roles, _ := store.Roles("event.end")
+	assert.Equal(t, []string{"president"}, roles)
+
+	// Actions that are not loaded keep their defaults
+	roles, _ = store.Roles("event.create")
+	defaults, _ := store.DefaultRoles("event.create")
+	assert.Equal(t, defaults, roles)
+
+	_, exists := store.Roles("event.teleport")
+	assert.False(t, exists)
+
+	assert.Error(t, store.Load(Policy{"event.end": {"admin"}}))
+	roles, _ = store.Roles("event.end")
+	assert.Equal(t, []string{"president"}, roles)
+}
+
+func TestIsProtected(t *testing.T) {
+	assert.True(t, IsProtected("permission.edit"))
+	assert.False(t, IsProtected("event.end"))
+}
@@ -8,7 +8,7 @@ type rankingsAuthorizer struct {
 // NewRankingsAuthorizer creates a new rankings authorizer.
 func NewRankingsAuthorizer() ResourceAuthorizer {
 	return &rankingsAuthorizer{
-		actions: []string{"get", "list", "export"},
+		actions: []string{"get", "list", "export", "rebuild"},
 	}
 }
 
@@ -21,6 +21,8 @@ func (svc *rankingsAuthorizer) IsAuthorized(role string, action string) bool {
 		return HasAtleastRole(ROLE_BOT, role)
 	case "export":
 		return HasAtleastRole(ROLE_SECRETARY, role)
+	case "rebuild":
+		return HasAtleastRole(ROLE_PRESIDENT, role)
 	}
 
 	return false
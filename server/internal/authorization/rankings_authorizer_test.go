@@ -86,6 +86,23 @@ func TestRankingsAuthorizer(t *testing.T) {
 			},
 			action: "export",
 		},
+		{
+			name: "Rebuild Authorized",
+			roles: []struct {
+				role     string
+				expected bool
+			}{
+				{role: ROLE_BOT.ToString(), expected: false},
+				{role: ROLE_EXECUTIVE.ToString(), expected: false},
+				{role: ROLE_TOURNAMENT_DIRECTOR.ToString(), expected: false},
+				{role: ROLE_SECRETARY.ToString(), expected: false},
+				{role: ROLE_TREASURER.ToString(), expected: false},
+				{role: ROLE_VICE_PRESIDENT.ToString(), expected: false},
+				{role: ROLE_PRESIDENT.ToString(), expected: true},
+				{role: ROLE_WEBMASTER.ToString(), expected: true},
+			},
+			action: "rebuild",
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.name, func(t *testing.T) {
@@ -108,9 +125,10 @@ func TestRankingsAuthorizer_GetPermissions(t *testing.T) {
 			name: "Should return correct permission map",
 			role: "tournament_director",
 			expected: map[string]any{
-				"get":    true,
-				"list":   true,
-				"export": false,
+				"get":     true,
+				"list":    true,
+				"export":  false,
+				"rebuild": false,
 			},
 		},
 	}
@@ -0,0 +1,39 @@
+package authorization
+
+// registrationAuthorizer authorizes actions on the registrations and waitlist of an event.
+type registrationAuthorizer struct {
+	actions []string
+}
+
+// NewRegistrationAuthorizer creates a new registration authorizer.
+func NewRegistrationAuthorizer() ResourceAuthorizer {
+	return &registrationAuthorizer{
+		actions: []string{"create", "list", "delete", "convert"},
+	}
+}
+
+// IsAuthorized checks if a user with the given role is authorized to perform the specified action on an event's registrations.
+func (svc *registrationAuthorizer) IsAuthorized(role string, action string) bool {
+	switch action {
+	case "create":
+		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
+	case "list":
+		return HasAtleastRole(ROLE_EXECUTIVE, role)
+	case "delete":
+		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
+	case "convert":
+		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
+	}
+
+	return false
+}
+
+func (svc *registrationAuthorizer) GetPermissions(role string) map[string]any {
+	permissions := make(map[string]any)
+
+	for _, action := range svc.actions {
+		permissions[action] = svc.IsAuthorized(role, action)
+	}
+
+	return permissions
+}
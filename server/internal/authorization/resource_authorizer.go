@@ -14,16 +14,6 @@ type ResourceAuthorizer interface {
 // ResourceAuthorizerMap is a map of resource names to their respective authorizers.
 type ResourceAuthorizerMap map[string]ResourceAuthorizer
 
-var DefaultAuthorizerMap = ResourceAuthorizerMap{
-	"login": NewLoginAuthorizer(),
-	"user":  NewUserAuthorizer(),
-	"semester": NewSemesterAuthorizer(ResourceAuthorizerMap{
-		"rankings":    NewRankingsAuthorizer(),
-		"transaction": NewTransactionAuthorizer(),
-	}),
-	"membership": NewMembershipAuthorizer(),
-	"structure":  NewStructureAuthorizer(),
-	"event": NewEventAuthorizer(ResourceAuthorizerMap{
-		"participant": NewParticipantAuthorizer(),
-	}),
-}
+// DefaultAuthorizerMap authorizes requests against the active policy, so that
+// changes to the policy apply without restarting the server.
+var DefaultAuthorizerMap = NewPolicyAuthorizerMap(ActivePolicy)
@@ -1,5 +1,10 @@
 package authorization
 
+import (
+	"fmt"
+	"sync/atomic"
+)
+
 type role int
 
 // Role represents the role of a user in the system.
@@ -72,3 +77,60 @@ func HasRole(r role, userRole string) bool {
 func HasAtleastRole(r role, userRole string) bool {
 	return r <= stringToRole(userRole)
 }
+
+// allRoles lists every role, from lowest to highest privilege.
+var allRoles = []role{
+	ROLE_BOT,
+	ROLE_EXECUTIVE,
+	ROLE_TOURNAMENT_DIRECTOR,
+	ROLE_SECRETARY,
+	ROLE_TREASURER,
+	ROLE_VICE_PRESIDENT,
+	ROLE_PRESIDENT,
+	ROLE_WEBMASTER,
+}
+
+// IsValidRole checks if the given string is the name of a role.
+func IsValidRole(userRole string) bool {
+	return stringToRole(userRole) != -1
+}
+
+// twoFactorRequiredRole is the lowest role which must use two-factor
+// authentication, or -1 when no role must.
+var twoFactorRequiredRole atomic.Int64
+
+func init() {
+	twoFactorRequiredRole.Store(-1)
+}
+
+// SetTwoFactorRequiredRole requires two-factor authentication for the given
+// role and every role above it. An empty role requires it for no role. An
+// invalid role requires it for every role, so that a misconfiguration fails
+// closed, and returns an error.
+func SetTwoFactorRequiredRole(userRole string) error {
+	if userRole == "" {
+		twoFactorRequiredRole.Store(-1)
+		return nil
+	}
+
+	if !IsValidRole(userRole) {
+		twoFactorRequiredRole.Store(int64(allRoles[0]))
+		return fmt.Errorf("invalid role '%s'", userRole)
+	}
+
+	twoFactorRequiredRole.Store(int64(stringToRole(userRole)))
+	return nil
+}
+
+// TwoFactorRequiredRole returns the lowest role which must use two-factor
+// authentication, or an empty string when no role must.
+func TwoFactorRequiredRole() string {
+	return role(twoFactorRequiredRole.Load()).ToString()
+}
+
+// RequiresTwoFactor checks if logins with the given role must use two-factor
+// authentication.
+func RequiresTwoFactor(userRole string) bool {
+	required := twoFactorRequiredRole.Load()
+	return required >= 0 && IsValidRole(userRole) && HasAtleastRole(role(required), userRole)
+}
@@ -104,3 +104,39 @@ func TestHasAtLeastRole(t *testing.T) {
 		})
 	}
 }
+
+func TestRequiresTwoFactor(t *testing.T) {
+	t.Cleanup(func() { _ = SetTwoFactorRequiredRole("") })
+
+	assert.Equal(t, "", TwoFactorRequiredRole())
+	assert.False(t, RequiresTwoFactor("webmaster"))
+
+	assert.NoError(t, SetTwoFactorRequiredRole("treasurer"))
+	assert.Equal(t, "treasurer", TwoFactorRequiredRole())
+
+	testCases := []struct {
+		userRole string
+		expected bool
+	}{
+		{userRole: "bot", expected: false},
+		{userRole: "secretary", expected: false},
+		{userRole: "treasurer", expected: true},
+		{userRole: "vice_president", expected: true},
+		{userRole: "president", expected: true},
+		{userRole: "webmaster", expected: true},
+		{userRole: "unknown", expected: false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.userRole, func(t *testing.T) {
+			assert.Equal(t, tC.expected, RequiresTwoFactor(tC.userRole))
+		})
+	}
+
+	// An invalid role fails closed
+	assert.Error(t, SetTwoFactorRequiredRole("unknown"))
+	assert.Equal(t, "bot", TwoFactorRequiredRole())
+	assert.True(t, RequiresTwoFactor("executive"))
+
+	assert.NoError(t, SetTwoFactorRequiredRole(""))
+	assert.False(t, RequiresTwoFactor("webmaster"))
+}
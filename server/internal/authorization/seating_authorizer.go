@@ -0,0 +1,35 @@
+package authorization
+
+// seatingAuthorizer authorizes actions on the table and seat assignments of an event.
+type seatingAuthorizer struct {
+	actions []string
+}
+
+// NewSeatingAuthorizer creates a new seating authorizer.
+func NewSeatingAuthorizer() ResourceAuthorizer {
+	return &seatingAuthorizer{
+		actions: []string{"get", "edit"},
+	}
+}
+
+// IsAuthorized checks if a user with the given role is authorized to perform the specified action on an event's seating.
+func (svc *seatingAuthorizer) IsAuthorized(role string, action string) bool {
+	switch action {
+	case "get":
+		return HasAtleastRole(ROLE_BOT, role)
+	case "edit":
+		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
+	}
+
+	return false
+}
+
+func (svc *seatingAuthorizer) GetPermissions(role string) map[string]any {
+	permissions := make(map[string]any)
+
+	for _, action := range svc.actions {
+		permissions[action] = svc.IsAuthorized(role, action)
+	}
+
+	return permissions
+}
@@ -173,10 +173,22 @@ func TestSemesterAuthorizer_GetPermissions(t *testing.T) {
 					"get":    true,
 					"list":   true,
 				},
+				"pointsScheme": map[string]any{
+					"create": false,
+					"get":    true,
+					"list":   true,
+				},
+				"ledger": map[string]any{
+					"create": false,
+					"get":    true,
+					"list":   true,
+				},
 			},
 			resourceAuthorizers: ResourceAuthorizerMap{
-				"rankings":    &MockResourceAuthorizer{},
-				"transaction": &MockResourceAuthorizer{},
+				"rankings":     &MockResourceAuthorizer{},
+				"transaction":  &MockResourceAuthorizer{},
+				"pointsScheme": &MockResourceAuthorizer{},
+				"ledger":       &MockResourceAuthorizer{},
 			},
 			mockResourceAuthorizer: func(m *MockResourceAuthorizer) {
 				m.On("GetPermissions", mock.Anything).Return(map[string]any{
@@ -191,6 +203,8 @@ func TestSemesterAuthorizer_GetPermissions(t *testing.T) {
 		t.Run(tC.name, func(t *testing.T) {
 			tC.mockResourceAuthorizer(tC.resourceAuthorizers["rankings"].(*MockResourceAuthorizer))
 			tC.mockResourceAuthorizer(tC.resourceAuthorizers["transaction"].(*MockResourceAuthorizer))
+			tC.mockResourceAuthorizer(tC.resourceAuthorizers["pointsScheme"].(*MockResourceAuthorizer))
+			tC.mockResourceAuthorizer(tC.resourceAuthorizers["ledger"].(*MockResourceAuthorizer))
 			svc := NewSemesterAuthorizer(tC.resourceAuthorizers)
 			permissions := svc.GetPermissions(tC.role)
 			assert.Equal(t, tC.expected, permissions)
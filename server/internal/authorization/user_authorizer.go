@@ -8,7 +8,7 @@ type userAuthorizer struct {
 // NewUserAuthorizer creates a new user authorizer.
 func NewUserAuthorizer() ResourceAuthorizer {
 	return &userAuthorizer{
-		actions: []string{"create", "get", "list", "edit", "delete"},
+		actions: []string{"create", "get", "list", "edit", "delete", "import", "export"},
 	}
 }
 
@@ -25,6 +25,10 @@ func (svc *userAuthorizer) IsAuthorized(role string, action string) bool {
 		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
 	case "delete":
 		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
+	case "import":
+		return HasAtleastRole(ROLE_TOURNAMENT_DIRECTOR, role)
+	case "export":
+		return HasAtleastRole(ROLE_SECRETARY, role)
 	}
 
 	return false
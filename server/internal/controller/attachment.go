@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	apierrors "api/internal/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StreamCSV streams the CSV written by write to the response as an attachment
+// named filename.
+func StreamCSV(ctx *gin.Context, filename string, write func(w io.Writer) error) {
+	StreamAttachment(ctx, filename, "text/csv; charset=utf-8", write)
+}
+
+// StreamAttachment streams the file written by write to the response as an
+// attachment named filename. An error returned before anything is written is
+// sent as an error response. Once the response has started the status cannot
+// change, so later errors are logged and the download is cut short.
+func StreamAttachment(ctx *gin.Context, filename string, contentType string, write func(w io.Writer) error) {
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	if err := write(ctx.Writer); err != nil {
+		if ctx.Writer.Written() {
+			log.Printf("Failed to stream %s: %s", filename, err)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Writer.Header().Del("Content-Disposition")
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	apierrors "api/internal/errors"
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type auditController struct {
+	db *gorm.DB
+}
+
+// NewAuditController creates a new instance of auditController
+func NewAuditController(db *gorm.DB) Controller {
+	return &auditController{db: db}
+}
+
+func (c *auditController) LoadRoutes(router *gin.RouterGroup) {
+	audit := router.Group("audit", middleware.UseAuthentication(c.db))
+	audit.GET("", middleware.UseAuthorization("audit.list"), c.listAuditEvents)
+}
+
+// listAuditEvents handles listing the audit log
+//
+// @Summary List audit events
+// @Description Retrieve the audit log of mutating API actions, newest first
+// @Tags Audit
+// @Produce json
+// @Param limit query int false "Maximum number of results to return"
+// @Param offset query int false "Number of results to skip"
+// @Param actor query string false "Filter by the username that performed the action"
+// @Param action query string false "Filter by action (e.g. event.end). A value ending in '.' matches every action with that prefix"
+// @Param targetId query string false "Filter by the ID of a targeted resource"
+// @Param from query string false "Only include events at or after this time (RFC 3339)"
+// @Param to query string false "Only include events at or before this time (RFC 3339)"
+// @Success 200 {object} models.ListResponse[models.AuditEvent]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /audit [get]
+func (c *auditController) listAuditEvents(ctx *gin.Context) {
+	pagination, err := models.ParsePagination(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	filter := &models.ListAuditEventsFilter{
+		Pagination: pagination,
+		Actor:      ctx.Query("actor"),
+		Action:     ctx.Query("action"),
+		TargetID:   ctx.Query("targetId"),
+	}
+
+	for _, param := range []struct {
+		name string
+		dest **time.Time
+	}{
+		{name: "from", dest: &filter.From},
+		{name: "to", dest: &filter.To},
+	} {
+		value := ctx.Query(param.name)
+		if value == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			ctx.AbortWithStatusJSON(
+				http.StatusBadRequest,
+				apierrors.InvalidRequest(fmt.Sprintf("%s must be an RFC 3339 timestamp", param.name)),
+			)
+			return
+		}
+		*param.dest = &t
+	}
+
+	svc := services.NewAuditService(c.db)
+	events, total, err := svc.ListAuditEvents(filter)
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.ListResponse[models.AuditEvent]{
+		Data:  events,
+		Total: total,
+	})
+}
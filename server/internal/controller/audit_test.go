@@ -0,0 +1,169 @@
+package controller_test
+
+import (
+	"api/internal/authorization"
+	"api/internal/models"
+	"api/internal/testutils"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAuditEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	unauthorizedRoles := []string{"bot", "executive", "tournament_director", "secretary", "treasurer", "vice_president"}
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", "/api/v2/audit", unauthorizedRoles)
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedAll(db))
+
+	sessionID, err := testutils.CreateTestSession(db, "auditor", authorization.ROLE_WEBMASTER.ToString())
+	require.NoError(t, err)
+
+	doRequest := func(method, path string, body any) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, body)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	// Perform some audited actions
+	w := doRequest("POST", "/api/v2/logins", map[string]any{
+		"username": "newlogin",
+		"password": "supersecret",
+		"role":     "executive",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+	w = doRequest("POST", fmt.Sprintf("/api/v2/semesters/%s/events/%d/end", testutils.TEST_SEMESTERS[0].ID, 2), nil)
+	require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+	w = doRequest("PATCH", "/api/v2/logins/newlogin", map[string]any{
+		"password": "anothersecret",
+		"role":     "secretary",
+	})
+	require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+	// Failed and read-only requests are not audited
+	w = doRequest("POST", "/api/v2/logins", map[string]any{"username": "bad"})
+	require.Equal(t, http.StatusBadRequest, w.Code, "Response: %s", w.Body.String())
+
+	testCases := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedActions []string
+	}{
+		{
+			name:            "lists all audit events newest first",
+			query:           "",
+			expectedStatus:  http.StatusOK,
+			expectedActions: []string{"login.edit", "event.end", "login.create"},
+		},
+		{
+			name:            "filters by action",
+			query:           "?action=login.create",
+			expectedStatus:  http.StatusOK,
+			expectedActions: []string{"login.create"},
+		},
+		{
+			name:            "filters by action prefix",
+			query:           "?action=event.",
+			expectedStatus:  http.StatusOK,
+			expectedActions: []string{"event.end"},
+		},
+		{
+			name:            "filters by actor",
+			query:           "?actor=someoneelse",
+			expectedStatus:  http.StatusOK,
+			expectedActions: []string{},
+		},
+		{
+			name:            "filters by target",
+			query:           "?targetId=2",
+			expectedStatus:  http.StatusOK,
+			expectedActions: []string{"event.end"},
+		},
+		{
+			name:            "paginates",
+			query:           "?limit=1&offset=1",
+			expectedStatus:  http.StatusOK,
+			expectedActions: []string{"event.end"},
+		},
+		{
+			name:           "invalid from timestamp",
+			query:          "?from=yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := doRequest("GET", "/api/v2/audit"+tc.query, nil)
+			require.Equal(t, tc.expectedStatus, w.Code, "Response: %s", w.Body.String())
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var resp models.ListResponse[models.AuditEvent]
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+			actions := make([]string, len(resp.Data))
+			for i, event := range resp.Data {
+				actions[i] = event.Action
+				require.Equal(t, "auditor", event.Actor)
+			}
+			require.Equal(t, tc.expectedActions, actions)
+		})
+	}
+
+	t.Run("records targets and changes and redacts sensitive fields", func(t *testing.T) {
+		w := doRequest("GET", "/api/v2/audit", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var resp models.ListResponse[models.AuditEvent]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 3)
+
+		editLogin := resp.Data[0]
+		require.Equal(t, "newlogin", editLogin.Targets["username"])
+		require.Equal(t, models.AuditChange{From: "executive", To: "secretary"}, editLogin.Changes["role"])
+		require.Equal(t, models.AuditChange{From: "[REDACTED]", To: "[REDACTED]"}, editLogin.Changes["password"])
+		require.NotContains(t, editLogin.Changes, "username")
+
+		endEvent := resp.Data[1]
+		require.Equal(t, "POST", endEvent.Method)
+		require.Equal(t, http.StatusNoContent, endEvent.StatusCode)
+		require.Equal(t, "2", endEvent.Targets["eventId"])
+		require.Equal(t, testutils.TEST_SEMESTERS[0].ID.String(), endEvent.Targets["semesterId"])
+		require.Equal(t, models.AuditChange{
+			From: float64(models.EventStateStarted),
+			To:   float64(models.EventStateEnded),
+		}, endEvent.Changes["state"])
+
+		createLogin := resp.Data[2]
+		require.Nil(t, createLogin.Changes)
+		request, ok := createLogin.Request.(map[string]any)
+		require.True(t, ok)
+		require.Equal(t, "newlogin", request["username"])
+		require.Equal(t, "[REDACTED]", request["password"])
+	})
+}
@@ -8,7 +8,6 @@ import (
 	"net/http"
 	"os"
 	"strings"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -18,11 +17,12 @@ import (
 )
 
 type authenticationController struct {
-	db *gorm.DB
+	db           *gorm.DB
+	loginLimiter *authentication.LoginLimiter
 }
 
-func NewAuthenticationController(db *gorm.DB) Controller {
-	return &authenticationController{db: db}
+func NewAuthenticationController(db *gorm.DB, loginLimiter *authentication.LoginLimiter) Controller {
+	return &authenticationController{db: db, loginLimiter: loginLimiter}
 }
 
 // getCookieKey returns the key of the session ID cookie for the environment
@@ -39,6 +39,19 @@ func (controller *authenticationController) LoadRoutes(router *gin.RouterGroup)
 	group.GET("", middleware.UseAuthentication(controller.db), controller.getSession)
 	group.POST("", controller.login)
 	group.POST("logout", controller.logout)
+	group.GET("sessions", middleware.UseAuthentication(controller.db), controller.listSessions)
+	group.DELETE("sessions", middleware.UseAuthentication(controller.db), controller.revokeOtherSessions)
+	group.DELETE("sessions/:sessionId", middleware.UseAuthentication(controller.db), controller.revokeSession)
+	group.PUT("password", middleware.UseAuthentication(controller.db), controller.changePassword)
+	group.POST("password-reset", controller.resetPassword)
+
+	twoFactor := group.Group("2fa")
+	twoFactor.POST("verify", controller.verifyTwoFactor)
+	twoFactor.GET("", middleware.UseAuthentication(controller.db), controller.getTwoFactorStatus)
+	twoFactor.POST("setup", middleware.UseAuthentication(controller.db), controller.setupTwoFactor)
+	twoFactor.POST("enable", middleware.UseAuthentication(controller.db), controller.enableTwoFactor)
+	twoFactor.POST("recovery-codes", middleware.UseAuthentication(controller.db), controller.regenerateRecoveryCodes)
+	twoFactor.DELETE("", middleware.UseAuthentication(controller.db), controller.disableTwoFactor)
 }
 
 // getSession retrieves the current user's session information, including username, role, and permissions.
@@ -59,9 +72,10 @@ func (controller *authenticationController) getSession(ctx *gin.Context) {
 	svc := authorization.NewAuthorizationService(role, authorization.DefaultAuthorizerMap)
 
 	ctx.JSON(http.StatusOK, models.GetSessionResponse{
-		Username:    username,
-		Role:        svc.Role(),
-		Permissions: svc.GetPermissions(),
+		Username:               username,
+		Role:                   svc.Role(),
+		Permissions:            svc.GetPermissions(),
+		TwoFactorSetupRequired: middleware.TwoFactorSetupRequired(ctx),
 	})
 }
 
@@ -69,14 +83,16 @@ func (controller *authenticationController) getSession(ctx *gin.Context) {
 // It expects a NewSessionRequest in the request body and sets a session cookie upon successful login.
 //
 // @Summary User login
-// @Description Authenticate user and create a session
+// @Description Authenticate user and create a session. Logins with two-factor authentication get a challenge instead, which is completed with POST /session/2fa/verify.
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Param credentials body NewSessionRequest true "User credentials"
 // @Success 201 "Created"
+// @Success 202 {object} TwoFactorChallengeResponse "A two-factor code is required, submit it to /session/2fa/verify"
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /session [post]
 func (controller *authenticationController) login(ctx *gin.Context) {
@@ -85,6 +101,19 @@ func (controller *authenticationController) login(ctx *gin.Context) {
 		return
 	}
 
+	// Refuse logins for usernames and IP addresses with too many failures
+	// before checking the password
+	wait, err := controller.loginLimiter.Check(req.Username, ctx.ClientIP())
+	if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	if wait > 0 {
+		AbortLoginThrottled(ctx, wait)
+		return
+	}
+
 	credentialSvc := authentication.NewCredentialService(controller.db)
 	valid, role, err := credentialSvc.Validate(req.Username, req.Password)
 	if err != nil {
@@ -93,6 +122,11 @@ func (controller *authenticationController) login(ctx *gin.Context) {
 	}
 
 	if !valid {
+		if err := RecordLoginFailure(ctx, controller.db, controller.loginLimiter, req.Username); err != nil {
+			ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+			return
+		}
+
 		ctx.AbortWithStatusJSON(
 			http.StatusUnauthorized,
 			e.Unauthorized("Invalid username or password"),
@@ -100,32 +134,126 @@ func (controller *authenticationController) login(ctx *gin.Context) {
 		return
 	}
 
+	// Logins with two-factor authentication get a session once they submit a
+	// code. Their failures are only forgotten then, so that codes cannot be
+	// guessed by logging in again
+	twoFactorManager := authentication.NewTwoFactorManager(controller.db)
+	challenge, err := twoFactorManager.CreateChallenge(req.Username)
+	if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	if challenge != nil {
+		ctx.JSON(http.StatusAccepted, challenge)
+		return
+	}
+
+	if err := controller.loginLimiter.RecordSuccess(req.Username); err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	controller.startSession(ctx, req.Username, role, false)
+}
+
+// startSession creates a session for a login and sets the session cookie.
+func (controller *authenticationController) startSession(ctx *gin.Context, username string, role string, twoFactorVerified bool) {
 	sessionManager := authentication.NewSessionManager(controller.db)
-	token, err := sessionManager.Create(req.Username, role)
+	token, err := sessionManager.Create(username, role, ctx.Request.UserAgent(), ctx.ClientIP())
 	if err != nil {
 		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
 		return
 	}
 
-	eightHoursInSeconds := int((time.Hour * 8).Seconds())
+	if twoFactorVerified {
+		if err := sessionManager.MarkTwoFactorVerified(token); err != nil {
+			ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+			return
+		}
+	}
+
+	// The cookie lasts as long as the session can, the session itself expires
+	// sooner if it is not used
+	maxAgeInSeconds := int(sessionManager.Config().MaxLifetime.Seconds())
 	ctx.SetSameSite(http.SameSiteStrictMode)
 	if strings.ToLower(os.Getenv("ENVIRONMENT")) == "production" {
 		ctx.SetCookie(
 			"uwpsc-session-id",
 			token.String(),
-			eightHoursInSeconds,
+			maxAgeInSeconds,
 			"/",
 			"uwpokerclub.com",
 			true,
 			true,
 		)
 	} else {
-		ctx.SetCookie("uwpsc-dev-session-id", token.String(), eightHoursInSeconds, "/", "localhost", false, true)
+		ctx.SetCookie("uwpsc-dev-session-id", token.String(), maxAgeInSeconds, "/", "localhost", false, true)
 	}
 
 	ctx.Status(http.StatusCreated)
 }
 
+// verifyTwoFactor handles the second step of logging in to a login with
+// two-factor authentication.
+//
+// @Summary Verify two-factor code
+// @Description Complete a login that returned a two-factor challenge with a code from an authenticator app or a recovery code, and create a session. Recovery codes can only be used once.
+// @Tags Authentication
+// @Accept json
+// @Param request body VerifyTwoFactorRequest true "Challenge token and code"
+// @Success 201 "Created"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /session/2fa/verify [post]
+func (controller *authenticationController) verifyTwoFactor(ctx *gin.Context) {
+	var req models.VerifyTwoFactorRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	twoFactorManager := authentication.NewTwoFactorManager(controller.db)
+	challenge, err := twoFactorManager.FindChallenge(req.ChallengeToken)
+	if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	wait, err := controller.loginLimiter.Check(challenge.Username, ctx.ClientIP())
+	if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	if wait > 0 {
+		AbortLoginThrottled(ctx, wait)
+		return
+	}
+
+	login, err := twoFactorManager.VerifyChallenge(challenge, req.Code)
+	if err == authentication.ErrInvalidTwoFactorCode {
+		if err := RecordLoginFailure(ctx, controller.db, controller.loginLimiter, challenge.Username); err != nil {
+			ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, err)
+		return
+	} else if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	if err := controller.loginLimiter.RecordSuccess(login.Username); err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	controller.startSession(ctx, login.Username, login.Role, true)
+}
+
 // logout handles user logout by invalidating the current session.
 // It expects a valid session cookie and returns a 204 No Content status upon successful logout.
 //
@@ -166,3 +294,340 @@ func (controller *authenticationController) logout(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+// currentSessionID returns the ID of the session making the request, or
+// uuid.Nil when the request was authenticated with an API key.
+func currentSessionID(ctx *gin.Context) uuid.UUID {
+	sessionID, _ := ctx.Get("sessionId")
+	id, _ := sessionID.(uuid.UUID)
+	return id
+}
+
+// requireSession returns the ID of the session making the request, and
+// aborts requests authenticated with an API key. API keys are scoped to
+// actions, and must not be able to take over the login they belong to.
+func requireSession(ctx *gin.Context) (uuid.UUID, bool) {
+	sessionID := currentSessionID(ctx)
+	if sessionID == uuid.Nil {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, e.Forbidden("This can only be done from a session"))
+		return uuid.Nil, false
+	}
+
+	return sessionID, true
+}
+
+// listSessions lists the active sessions of the current user.
+//
+// @Summary List sessions
+// @Description List the active sessions of the current user, most recently used first
+// @Tags Authentication
+// @Produce json
+// @Success 200 {array} SessionInfo
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /session/sessions [get]
+func (controller *authenticationController) listSessions(ctx *gin.Context) {
+	sessionManager := authentication.NewSessionManager(controller.db)
+	sessions, err := sessionManager.List(ctx.GetString("username"), currentSessionID(ctx))
+	if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sessions)
+}
+
+// revokeSession ends another session of the current user.
+//
+// @Summary Revoke a session
+// @Description End another session of the current user. The current session is ended by logging out instead.
+// @Tags Authentication
+// @Param sessionId path string true "Session ID from the list of sessions"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /session/sessions/{sessionId} [delete]
+func (controller *authenticationController) revokeSession(ctx *gin.Context) {
+	sessionManager := authentication.NewSessionManager(controller.db)
+	err := sessionManager.Revoke(ctx.GetString("username"), ctx.Param("sessionId"), currentSessionID(ctx))
+	if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// revokeOtherSessions ends every session of the current user except the
+// current one.
+//
+// @Summary Revoke other sessions
+// @Description End every session of the current user except the one making the request
+// @Tags Authentication
+// @Success 204 "No Content"
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /session/sessions [delete]
+func (controller *authenticationController) revokeOtherSessions(ctx *gin.Context) {
+	sessionManager := authentication.NewSessionManager(controller.db)
+	if _, err := sessionManager.RevokeAll(ctx.GetString("username"), currentSessionID(ctx)); err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// changePassword sets a new password for the current user.
+//
+// @Summary Change password
+// @Description Set a new password for the current user after checking the current password. Every other session of the user is ended. Incorrect current passwords count as failed logins for login throttling.
+// @Tags Authentication
+// @Accept json
+// @Param request body ChangePasswordRequest true "Current and new password"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /session/password [put]
+func (controller *authenticationController) changePassword(ctx *gin.Context) {
+	var req models.ChangePasswordRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	sessionID, ok := requireSession(ctx)
+	if !ok {
+		return
+	}
+
+	// Throttle guesses of the current password like failed logins
+	username := ctx.GetString("username")
+	wait, err := controller.loginLimiter.Check(username, ctx.ClientIP())
+	if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	if wait > 0 {
+		AbortLoginThrottled(ctx, wait)
+		return
+	}
+
+	passwordManager := authentication.NewPasswordManager(controller.db)
+	err = passwordManager.ChangePassword(username, req.CurrentPassword, req.NewPassword, sessionID)
+	if err == authentication.ErrIncorrectPassword {
+		if err := RecordLoginFailure(ctx, controller.db, controller.loginLimiter, username); err != nil {
+			ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		return
+	} else if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	if err := controller.loginLimiter.RecordSuccess(username); err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// resetPassword redeems a password reset token.
+//
+// @Summary Reset password
+// @Description Set a new password with a single-use reset token issued by an administrator. Every session of the login is ended, and any login lockout of the username is lifted.
+// @Tags Authentication
+// @Accept json
+// @Param request body ResetPasswordRequest true "Reset token and new password"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /session/password-reset [post]
+func (controller *authenticationController) resetPassword(ctx *gin.Context) {
+	var req models.ResetPasswordRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	passwordManager := authentication.NewPasswordManager(controller.db)
+	username, err := passwordManager.ResetPassword(req.Token, req.NewPassword)
+	if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	if err := controller.loginLimiter.Unlock(username); err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// getTwoFactorStatus returns the two-factor authentication status of the
+// current user.
+//
+// @Summary Get two-factor status
+// @Description Get whether the current user has enabled two-factor authentication, whether their role requires it, and how many recovery codes are left
+// @Tags Authentication
+// @Produce json
+// @Success 200 {object} TwoFactorStatus
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /session/2fa [get]
+func (controller *authenticationController) getTwoFactorStatus(ctx *gin.Context) {
+	twoFactorManager := authentication.NewTwoFactorManager(controller.db)
+	status, err := twoFactorManager.Status(ctx.GetString("username"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, status)
+}
+
+// setupTwoFactor starts enrolling the current user in two-factor
+// authentication.
+//
+// @Summary Set up two-factor authentication
+// @Description Generate a secret to add to an authenticator app, e.g. by showing the otpauth URL as a QR code. Two-factor authentication is enabled once a code from the app is submitted to /session/2fa/enable.
+// @Tags Authentication
+// @Produce json
+// @Success 200 {object} TwoFactorSetupResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /session/2fa/setup [post]
+func (controller *authenticationController) setupTwoFactor(ctx *gin.Context) {
+	if _, ok := requireSession(ctx); !ok {
+		return
+	}
+
+	twoFactorManager := authentication.NewTwoFactorManager(controller.db)
+	setup, err := twoFactorManager.Setup(ctx.GetString("username"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setup)
+}
+
+// enableTwoFactor completes enrolling the current user in two-factor
+// authentication.
+//
+// @Summary Enable two-factor authentication
+// @Description Enable two-factor authentication with a code from the authenticator app it was set up with. Returns recovery codes, which are only shown once.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body TwoFactorCodeRequest true "Code from the authenticator app"
+// @Success 200 {object} TwoFactorRecoveryCodesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /session/2fa/enable [post]
+func (controller *authenticationController) enableTwoFactor(ctx *gin.Context) {
+	var req models.TwoFactorCodeRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	sessionID, ok := requireSession(ctx)
+	if !ok {
+		return
+	}
+
+	twoFactorManager := authentication.NewTwoFactorManager(controller.db)
+	recoveryCodes, err := twoFactorManager.Enable(ctx.GetString("username"), req.Code)
+	if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	// The current session has just used a code
+	sessionManager := authentication.NewSessionManager(controller.db)
+	if err := sessionManager.MarkTwoFactorVerified(sessionID); err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recoveryCodes)
+}
+
+// regenerateRecoveryCodes replaces the recovery codes of the current user.
+//
+// @Summary Regenerate recovery codes
+// @Description Replace the recovery codes of the current user after checking a code from their authenticator app. The previous recovery codes stop working.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body TwoFactorCodeRequest true "Code from the authenticator app"
+// @Success 200 {object} TwoFactorRecoveryCodesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /session/2fa/recovery-codes [post]
+func (controller *authenticationController) regenerateRecoveryCodes(ctx *gin.Context) {
+	var req models.TwoFactorCodeRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	if _, ok := requireSession(ctx); !ok {
+		return
+	}
+
+	twoFactorManager := authentication.NewTwoFactorManager(controller.db)
+	recoveryCodes, err := twoFactorManager.RegenerateRecoveryCodes(ctx.GetString("username"), req.Code)
+	if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recoveryCodes)
+}
+
+// disableTwoFactor turns off two-factor authentication for the current user.
+//
+// @Summary Disable two-factor authentication
+// @Description Turn off two-factor authentication for the current user after checking their password. Roles which require two-factor authentication cannot turn it off.
+// @Tags Authentication
+// @Accept json
+// @Param request body DisableTwoFactorRequest true "Current password"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /session/2fa [delete]
+func (controller *authenticationController) disableTwoFactor(ctx *gin.Context) {
+	var req models.DisableTwoFactorRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	if _, ok := requireSession(ctx); !ok {
+		return
+	}
+
+	twoFactorManager := authentication.NewTwoFactorManager(controller.db)
+	if err := twoFactorManager.Disable(ctx.GetString("username"), req.Password); err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,566 @@
+package controller_test
+
+import (
+	"api/internal/authentication"
+	"api/internal/authorization"
+	"api/internal/models"
+	"api/internal/testutils"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestSessions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&models.Login{Username: "alice", Password: string(hash), Role: "executive"}).Error)
+
+	adminSession, err := testutils.CreateTestSession(db, "admin", authorization.ROLE_WEBMASTER.ToString())
+	require.NoError(t, err)
+
+	login := func(userAgent string) uuid.UUID {
+		req, err := testutils.MakeJSONRequest("POST", "/api/v2/session", map[string]any{
+			"username": "alice",
+			"password": "password123",
+		})
+		require.NoError(t, err)
+		req.Header.Set("User-Agent", userAgent)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		require.Greater(t, cookies[0].MaxAge, 8*60*60)
+		return uuid.MustParse(cookies[0].Value)
+	}
+
+	do := func(sessionID uuid.UUID, method string, path string, body any) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, body)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	listSessions := func(sessionID uuid.UUID) []models.SessionInfo {
+		w := do(sessionID, "GET", "/api/v2/session/sessions", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var sessions []models.SessionInfo
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &sessions))
+		return sessions
+	}
+
+	t.Run("requires authentication", func(t *testing.T) {
+		req, err := testutils.MakeJSONRequest("GET", "/api/v2/session/sessions", nil)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		testutils.AssertErrorResponse(t, w, http.StatusUnauthorized, "Authentication required")
+	})
+
+	t.Run("list and revoke own sessions", func(t *testing.T) {
+		laptop := login("Firefox")
+		phone := login("Safari")
+		tablet := login("Chrome")
+
+		sessions := listSessions(laptop)
+		require.Len(t, sessions, 3)
+		for _, session := range sessions {
+			require.NotEqual(t, laptop.String(), session.ID)
+			require.Equal(t, session.ID == authentication.SessionHandle(laptop), session.Current)
+			if session.Current {
+				require.Equal(t, "Firefox", session.UserAgent)
+				require.NotEmpty(t, session.IPAddress)
+			}
+		}
+
+		w := do(laptop, "DELETE", "/api/v2/session/sessions/"+authentication.SessionHandle(laptop), nil)
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "Use logout to end the current session")
+
+		w = do(laptop, "DELETE", "/api/v2/session/sessions/unknown", nil)
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Session not found")
+
+		// Sessions of other logins cannot be revoked
+		w = do(adminSession, "DELETE", "/api/v2/session/sessions/"+authentication.SessionHandle(phone), nil)
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Session not found")
+
+		w = do(laptop, "DELETE", "/api/v2/session/sessions/"+authentication.SessionHandle(phone), nil)
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+		w = do(phone, "GET", "/api/v2/session", nil)
+		require.Equal(t, http.StatusUnauthorized, w.Code, "Response: %s", w.Body.String())
+
+		w = do(laptop, "DELETE", "/api/v2/session/sessions", nil)
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+		w = do(tablet, "GET", "/api/v2/session", nil)
+		require.Equal(t, http.StatusUnauthorized, w.Code, "Response: %s", w.Body.String())
+
+		sessions = listSessions(laptop)
+		require.Len(t, sessions, 1)
+		require.True(t, sessions[0].Current)
+	})
+
+	t.Run("admin force logout", func(t *testing.T) {
+		unauthorizedRoles := []string{"bot", "executive", "tournament_director", "secretary", "treasurer", "vice_president", "president"}
+		for _, role := range unauthorizedRoles {
+			sessionID, err := testutils.CreateTestSession(db, "logout-"+role, role)
+			require.NoError(t, err)
+
+			w := do(sessionID, "DELETE", "/api/v2/logins/alice/sessions", nil)
+			require.Equal(t, http.StatusForbidden, w.Code, "Role %s: %s", role, w.Body.String())
+		}
+
+		sessionID := login("Firefox")
+
+		w := do(adminSession, "DELETE", "/api/v2/logins/alice/sessions", nil)
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+		w = do(sessionID, "GET", "/api/v2/session", nil)
+		require.Equal(t, http.StatusUnauthorized, w.Code, "Response: %s", w.Body.String())
+
+		w = do(adminSession, "DELETE", "/api/v2/logins/nobody/sessions", nil)
+		require.Equal(t, http.StatusNotFound, w.Code, "Response: %s", w.Body.String())
+	})
+
+	t.Run("role change ends sessions", func(t *testing.T) {
+		sessionID := login("Firefox")
+
+		w := do(adminSession, "PATCH", "/api/v2/logins/alice", map[string]any{"role": "president"})
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+		w = do(sessionID, "GET", "/api/v2/session", nil)
+		require.Equal(t, http.StatusUnauthorized, w.Code, "Response: %s", w.Body.String())
+
+		sessionID = login("Firefox")
+		w = do(sessionID, "GET", "/api/v2/session", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+		require.Contains(t, w.Body.String(), fmt.Sprintf(`"role":"%s"`, "president"))
+	})
+}
+
+func TestLoginLockout(t *testing.T) {
+	// The limiter reads its configuration from the environment, so this test
+	// cannot run in parallel
+	t.Setenv("LOGIN_LOCKOUT_THRESHOLD", "3")
+	t.Setenv("LOGIN_LOCKOUT_DURATION", "15m")
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&models.Login{Username: "alice", Password: string(hash), Role: "executive"}).Error)
+
+	adminSession, err := testutils.CreateTestSession(db, "admin", authorization.ROLE_WEBMASTER.ToString())
+	require.NoError(t, err)
+
+	login := func(password string) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest("POST", "/api/v2/session", map[string]any{
+			"username": "alice",
+			"password": password,
+		})
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("unlock requires webmaster", func(t *testing.T) {
+		unauthorizedRoles := []string{"bot", "executive", "tournament_director", "secretary", "treasurer", "vice_president", "president"}
+		testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "DELETE", "/api/v2/logins/alice/lockout", unauthorizedRoles)
+	})
+
+	t.Run("failed logins lock out the username", func(t *testing.T) {
+		for range 3 {
+			w := login("wrong-password")
+			testutils.AssertErrorResponse(t, w, http.StatusUnauthorized, "Invalid username or password")
+		}
+
+		// Even the correct password is refused while locked out
+		w := login("password123")
+		testutils.AssertErrorResponse(t, w, http.StatusTooManyRequests, "Too many failed login attempts. Try again in 900 seconds")
+		require.Equal(t, "900", w.Header().Get("Retry-After"))
+
+		var events []models.AuditEvent
+		require.NoError(t, db.Where("action = ?", authentication.LoginLockoutAction).Find(&events).Error)
+		require.Len(t, events, 1)
+		require.Equal(t, "alice", events[0].Actor)
+		require.Equal(t, map[string]string{"username": "alice"}, events[0].Targets)
+	})
+
+	t.Run("admin unlock", func(t *testing.T) {
+		req, err := testutils.MakeJSONRequest("DELETE", "/api/v2/logins/alice/lockout", nil)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, adminSession)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+		w = login("password123")
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+	})
+
+	t.Run("incorrect current passwords lock out the username", func(t *testing.T) {
+		w := login("password123")
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		sessionID := uuid.MustParse(cookies[0].Value)
+
+		changePassword := func(currentPassword string) *httptest.ResponseRecorder {
+			req, err := testutils.MakeJSONRequest("PUT", "/api/v2/session/password", map[string]any{
+				"currentPassword": currentPassword,
+				"newPassword":     "new-password",
+			})
+			require.NoError(t, err)
+			testutils.SetAuthCookie(req, sessionID)
+
+			w := httptest.NewRecorder()
+			apiServer.ServeHTTP(w, req)
+			return w
+		}
+
+		for range 3 {
+			w := changePassword("wrong-password")
+			testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "Current password is incorrect")
+		}
+
+		w = changePassword("password123")
+		testutils.AssertErrorResponse(t, w, http.StatusTooManyRequests, "Too many failed login attempts. Try again in 900 seconds")
+
+		w = login("password123")
+		require.Equal(t, http.StatusTooManyRequests, w.Code, "Response: %s", w.Body.String())
+	})
+}
+
+func TestPasswords(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&models.Login{Username: "alice", Password: string(hash), Role: "executive"}).Error)
+
+	adminSession, err := testutils.CreateTestSession(db, "admin", authorization.ROLE_WEBMASTER.ToString())
+	require.NoError(t, err)
+
+	login := func(password string) (uuid.UUID, *httptest.ResponseRecorder) {
+		req, err := testutils.MakeJSONRequest("POST", "/api/v2/session", map[string]any{
+			"username": "alice",
+			"password": password,
+		})
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated {
+			return uuid.Nil, w
+		}
+
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		return uuid.MustParse(cookies[0].Value), w
+	}
+
+	do := func(sessionID uuid.UUID, method string, path string, body any) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, body)
+		require.NoError(t, err)
+		if sessionID != uuid.Nil {
+			testutils.SetAuthCookie(req, sessionID)
+		}
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("change password", func(t *testing.T) {
+		current, _ := login("password123")
+		other, _ := login("password123")
+
+		w := do(uuid.Nil, "PUT", "/api/v2/session/password", map[string]any{
+			"currentPassword": "password123",
+			"newPassword":     "new-password",
+		})
+		require.Equal(t, http.StatusUnauthorized, w.Code, "Response: %s", w.Body.String())
+
+		w = do(current, "PUT", "/api/v2/session/password", map[string]any{
+			"currentPassword": "wrong-password",
+			"newPassword":     "new-password",
+		})
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "Current password is incorrect")
+
+		w = do(current, "PUT", "/api/v2/session/password", map[string]any{
+			"currentPassword": "password123",
+			"newPassword":     "new-password",
+		})
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+		w = do(current, "GET", "/api/v2/session", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		w = do(other, "GET", "/api/v2/session", nil)
+		require.Equal(t, http.StatusUnauthorized, w.Code, "Response: %s", w.Body.String())
+
+		_, w = login("password123")
+		require.Equal(t, http.StatusUnauthorized, w.Code, "Response: %s", w.Body.String())
+
+		_, w = login("new-password")
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+	})
+
+	t.Run("reset token requires webmaster", func(t *testing.T) {
+		unauthorizedRoles := []string{"bot", "executive", "tournament_director", "secretary", "treasurer", "vice_president", "president"}
+		testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "POST", "/api/v2/logins/alice/password-reset", unauthorizedRoles)
+	})
+
+	t.Run("reset password", func(t *testing.T) {
+		sessionID, w := login("new-password")
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		w = do(adminSession, "POST", "/api/v2/logins/nobody/password-reset", nil)
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Login not found")
+
+		w = do(adminSession, "POST", "/api/v2/logins/alice/password-reset", nil)
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		var res models.CreatePasswordResetTokenResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		require.NotEmpty(t, res.Token)
+
+		w = do(uuid.Nil, "POST", "/api/v2/session/password-reset", map[string]any{
+			"token":       res.Token,
+			"newPassword": "short",
+		})
+		require.Equal(t, http.StatusBadRequest, w.Code, "Response: %s", w.Body.String())
+
+		w = do(uuid.Nil, "POST", "/api/v2/session/password-reset", map[string]any{
+			"token":       res.Token,
+			"newPassword": "reset-password",
+		})
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+		// Every session of the login is ended
+		w = do(sessionID, "GET", "/api/v2/session", nil)
+		require.Equal(t, http.StatusUnauthorized, w.Code, "Response: %s", w.Body.String())
+
+		_, w = login("reset-password")
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		// The token cannot be used again
+		w = do(uuid.Nil, "POST", "/api/v2/session/password-reset", map[string]any{
+			"token":       res.Token,
+			"newPassword": "another-password",
+		})
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "Invalid or expired password reset token")
+	})
+}
+
+func TestTwoFactor(t *testing.T) {
+	// The roles which must use two-factor authentication are global, so this
+	// test cannot run in parallel
+	require.NoError(t, authorization.SetTwoFactorRequiredRole("executive"))
+	t.Cleanup(func() {
+		require.NoError(t, authorization.SetTwoFactorRequiredRole(""))
+	})
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&models.Login{Username: "alice", Password: string(hash), Role: "executive"}).Error)
+
+	adminSession, err := testutils.CreateTestSession(db, "admin", authorization.ROLE_WEBMASTER.ToString())
+	require.NoError(t, err)
+	require.NoError(t, db.Model(&models.Session{}).Where("id = ?", adminSession).Update("two_factor_verified", true).Error)
+
+	do := func(sessionID uuid.UUID, method string, path string, body any) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, body)
+		require.NoError(t, err)
+		if sessionID != uuid.Nil {
+			testutils.SetAuthCookie(req, sessionID)
+		}
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	login := func() *httptest.ResponseRecorder {
+		return do(uuid.Nil, "POST", "/api/v2/session", map[string]any{
+			"username": "alice",
+			"password": "password123",
+		})
+	}
+
+	sessionCookie := func(w *httptest.ResponseRecorder) uuid.UUID {
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		return uuid.MustParse(cookies[0].Value)
+	}
+
+	// code returns the current code of a secret, offset by a number of periods
+	// since codes cannot be used twice
+	code := func(secret string, offset int64) string {
+		code, err := authentication.TOTPCode(secret, time.Now().Unix()/30+offset)
+		require.NoError(t, err)
+		return code
+	}
+
+	var secret string
+	var recoveryCodes []string
+
+	t.Run("setup is required before other actions", func(t *testing.T) {
+		w := login()
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+		sessionID := sessionCookie(w)
+
+		w = do(sessionID, "GET", "/api/v2/session", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+		var session models.GetSessionResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &session))
+		require.True(t, session.TwoFactorSetupRequired)
+
+		w = do(sessionID, "GET", "/api/v2/memberships", nil)
+		testutils.AssertErrorResponse(t, w, http.StatusForbidden, "Two-factor authentication is required for your role. Set it up to continue.")
+
+		w = do(sessionID, "POST", "/api/v2/session/2fa/setup", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+		var setup models.TwoFactorSetupResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &setup))
+		secret = setup.Secret
+
+		w = do(sessionID, "POST", "/api/v2/session/2fa/enable", map[string]any{"code": "000000x"})
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "Invalid two-factor code")
+
+		w = do(sessionID, "POST", "/api/v2/session/2fa/enable", map[string]any{"code": code(secret, 0)})
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+		var res models.TwoFactorRecoveryCodesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		require.Len(t, res.RecoveryCodes, 10)
+		recoveryCodes = res.RecoveryCodes
+
+		w = do(sessionID, "GET", "/api/v2/memberships", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		// The role requires two-factor authentication, so it cannot be disabled
+		w = do(sessionID, "DELETE", "/api/v2/session/2fa", map[string]any{"password": "password123"})
+		testutils.AssertErrorResponse(t, w, http.StatusForbidden, "Two-factor authentication is required for your role")
+	})
+
+	t.Run("login requires a code", func(t *testing.T) {
+		w := login()
+		require.Equal(t, http.StatusAccepted, w.Code, "Response: %s", w.Body.String())
+		require.Empty(t, w.Result().Cookies())
+
+		var challenge models.TwoFactorChallengeResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &challenge))
+		require.True(t, challenge.TwoFactorRequired)
+
+		w = do(uuid.Nil, "POST", "/api/v2/session/2fa/verify", map[string]any{
+			"challengeToken": challenge.ChallengeToken,
+			"code":           "wrong-code",
+		})
+		testutils.AssertErrorResponse(t, w, http.StatusUnauthorized, "Invalid two-factor code")
+
+		w = do(uuid.Nil, "POST", "/api/v2/session/2fa/verify", map[string]any{
+			"challengeToken": challenge.ChallengeToken,
+			"code":           code(secret, 1),
+		})
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+		sessionID := sessionCookie(w)
+
+		w = do(sessionID, "GET", "/api/v2/memberships", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		// The challenge cannot be used again
+		w = do(uuid.Nil, "POST", "/api/v2/session/2fa/verify", map[string]any{
+			"challengeToken": challenge.ChallengeToken,
+			"code":           recoveryCodes[0],
+		})
+		testutils.AssertErrorResponse(t, w, http.StatusUnauthorized, "Invalid or expired login challenge. Please log in again")
+	})
+
+	t.Run("login with a recovery code", func(t *testing.T) {
+		w := login()
+		require.Equal(t, http.StatusAccepted, w.Code, "Response: %s", w.Body.String())
+		var challenge models.TwoFactorChallengeResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &challenge))
+
+		w = do(uuid.Nil, "POST", "/api/v2/session/2fa/verify", map[string]any{
+			"challengeToken": challenge.ChallengeToken,
+			"code":           recoveryCodes[0],
+		})
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+		sessionID := sessionCookie(w)
+
+		w = do(sessionID, "GET", "/api/v2/session/2fa", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+		var status models.TwoFactorStatus
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+		require.Equal(t, models.TwoFactorStatus{Enabled: true, Required: true, RecoveryCodesRemaining: 9}, status)
+	})
+
+	t.Run("reset requires webmaster", func(t *testing.T) {
+		unauthorizedRoles := []string{"bot", "executive", "tournament_director", "secretary", "treasurer", "vice_president", "president"}
+		testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "DELETE", "/api/v2/logins/alice/2fa", unauthorizedRoles)
+	})
+
+	t.Run("admin reset", func(t *testing.T) {
+		w := do(adminSession, "DELETE", "/api/v2/logins/alice/2fa", nil)
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+		// The login gets a session straight away, but has to set up
+		// two-factor authentication again
+		w = login()
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		w = do(sessionCookie(w), "GET", "/api/v2/memberships", nil)
+		require.Equal(t, http.StatusForbidden, w.Code, "Response: %s", w.Body.String())
+	})
+}
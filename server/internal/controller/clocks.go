@@ -0,0 +1,318 @@
+package controller
+
+import (
+	apierrors "api/internal/errors"
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// clockStreamInterval is how often the clock stream sends the clock's state.
+const clockStreamInterval = time.Second
+
+type clocksController struct {
+	db *gorm.DB
+}
+
+// NewClocksController creates a new instance of clocksController
+func NewClocksController(db *gorm.DB) Controller {
+	return &clocksController{db: db}
+}
+
+func (c *clocksController) LoadRoutes(router *gin.RouterGroup) {
+	clock := router.Group("semesters/:semesterId/events/:eventId/clock", middleware.UseAuthentication(c.db))
+	clock.GET("", middleware.UseAuthorization("event.clock.get"), c.getClock)
+	clock.GET("stream", middleware.UseAuthorization("event.clock.get"), c.streamClock)
+	clock.POST("start", middleware.UseAuthorization("event.clock.edit"), c.startClock)
+	clock.POST("pause", middleware.UseAuthorization("event.clock.edit"), c.pauseClock)
+	clock.POST("resume", middleware.UseAuthorization("event.clock.edit"), c.resumeClock)
+	clock.POST("skip-level", middleware.UseAuthorization("event.clock.edit"), c.skipLevel)
+	clock.POST("adjust-time", middleware.UseAuthorization("event.clock.edit"), c.adjustTime)
+}
+
+// validateEventID validates and returns the event ID as int32 from the path parameter.
+func (c *clocksController) validateEventID(ctx *gin.Context) (int32, error) {
+	eventIDStr := ctx.Param("eventId")
+	eventIDInt, err := strconv.ParseInt(eventIDStr, 10, 32)
+	if err != nil {
+		return 0, apierrors.InvalidRequest(
+			fmt.Sprintf("Event ID '%s' is not a valid integer", eventIDStr),
+		)
+	}
+	return int32(eventIDInt), nil
+}
+
+// validateParams validates the semester and event IDs from the path. It aborts
+// the request and returns false if either is invalid.
+func (c *clocksController) validateParams(ctx *gin.Context) (uuid.UUID, int32, bool) {
+	semesterID, err := validateSemesterID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		return uuid.Nil, 0, false
+	}
+
+	eventID, err := c.validateEventID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		return uuid.Nil, 0, false
+	}
+
+	return semesterID, eventID, true
+}
+
+// respond writes the clock state, or the error returned by the clock service.
+func (c *clocksController) respond(ctx *gin.Context, clock *models.ClockResponse, err error) {
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, clock)
+}
+
+// getClock handles retrieving the current state of an event's clock
+//
+// @Summary Get event clock
+// @Description Get the current level, time remaining and upcoming blinds of an event's tournament clock
+// @Tags Clocks
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Success 200 {object} ClockResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/clock [get]
+func (c *clocksController) getClock(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateParams(ctx)
+	if !ok {
+		return
+	}
+
+	svc := services.NewClockService(c.db)
+	clock, err := svc.GetClock(semesterID, eventID)
+	c.respond(ctx, clock, err)
+}
+
+// streamClock handles streaming the state of an event's clock
+//
+// @Summary Stream event clock
+// @Description Stream the state of an event's tournament clock as server-sent events. A "clock" event containing the clock's state is sent every second. If the clock cannot be loaded an "error" event is sent and the stream is closed.
+// @Tags Clocks
+// @Produce text/event-stream
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Success 200 {object} ClockResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/clock/stream [get]
+func (c *clocksController) streamClock(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateParams(ctx)
+	if !ok {
+		return
+	}
+
+	// Check the clock exists before committing to a stream, so that missing
+	// clocks are reported with a regular status code
+	svc := services.NewClockService(c.db)
+	clock, err := svc.GetClock(semesterID, eventID)
+	if err != nil {
+		c.respond(ctx, nil, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+
+	ticker := time.NewTicker(clockStreamInterval)
+	defer ticker.Stop()
+
+	ctx.Stream(func(w io.Writer) bool {
+		if err != nil {
+			if _, ok := err.(apierrors.APIErrorResponse); !ok {
+				err = apierrors.InternalServerError(err.Error())
+			}
+			ctx.SSEvent("error", err)
+			return false
+		}
+		ctx.SSEvent("clock", clock)
+
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case <-ticker.C:
+		}
+
+		clock, err = svc.GetClock(semesterID, eventID)
+		return true
+	})
+}
+
+// startClock handles starting an event's clock
+//
+// @Summary Start event clock
+// @Description Start an event's tournament clock at the first level of its structure
+// @Tags Clocks
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Success 200 {object} ClockResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/clock/start [post]
+func (c *clocksController) startClock(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateParams(ctx)
+	if !ok {
+		return
+	}
+
+	svc := services.NewClockService(c.db)
+	clock, err := svc.StartClock(semesterID, eventID)
+	c.respond(ctx, clock, err)
+}
+
+// pauseClock handles pausing an event's clock
+//
+// @Summary Pause event clock
+// @Description Pause an event's running tournament clock
+// @Tags Clocks
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Success 200 {object} ClockResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/clock/pause [post]
+func (c *clocksController) pauseClock(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateParams(ctx)
+	if !ok {
+		return
+	}
+
+	svc := services.NewClockService(c.db)
+	clock, err := svc.PauseClock(semesterID, eventID)
+	c.respond(ctx, clock, err)
+}
+
+// resumeClock handles resuming an event's clock
+//
+// @Summary Resume event clock
+// @Description Resume an event's paused tournament clock
+// @Tags Clocks
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Success 200 {object} ClockResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/clock/resume [post]
+func (c *clocksController) resumeClock(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateParams(ctx)
+	if !ok {
+		return
+	}
+
+	svc := services.NewClockService(c.db)
+	clock, err := svc.ResumeClock(semesterID, eventID)
+	c.respond(ctx, clock, err)
+}
+
+// skipLevel handles moving an event's clock to another level
+//
+// @Summary Skip clock level
+// @Description Move an event's tournament clock forwards or backwards by a number of levels. The new level starts with its full duration.
+// @Tags Clocks
+// @Accept json
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Param skip body SkipClockLevelRequest false "Levels to skip"
+// @Success 200 {object} ClockResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/clock/skip-level [post]
+func (c *clocksController) skipLevel(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.SkipClockLevelRequest
+	if ctx.Request.ContentLength != 0 && !BindJSON(ctx, &req) {
+		return
+	}
+
+	levels := int32(1)
+	if req.Levels != nil {
+		levels = *req.Levels
+	}
+
+	svc := services.NewClockService(c.db)
+	clock, err := svc.SkipLevel(semesterID, eventID, levels)
+	c.respond(ctx, clock, err)
+}
+
+// adjustTime handles adding or removing time from the current level of an event's clock
+//
+// @Summary Adjust clock time
+// @Description Add or remove time from the current level of an event's tournament clock
+// @Tags Clocks
+// @Accept json
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Param adjustment body AdjustClockTimeRequest true "Time adjustment"
+// @Success 200 {object} ClockResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/clock/adjust-time [post]
+func (c *clocksController) adjustTime(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.AdjustClockTimeRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	svc := services.NewClockService(c.db)
+	clock, err := svc.AdjustTime(semesterID, eventID, req.Seconds)
+	c.respond(ctx, clock, err)
+}
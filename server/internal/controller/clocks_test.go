@@ -0,0 +1,266 @@
+package controller_test
+
+import (
+	"api/internal/authorization"
+	"api/internal/models"
+	"api/internal/testutils"
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventClock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	clockPath := func(eventID int32) string {
+		return fmt.Sprintf("/api/v2/semesters/%s/events/%d/clock", testutils.TEST_SEMESTERS[0].ID, eventID)
+	}
+
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "POST", clockPath(2)+"/start", []string{"bot", "executive"})
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", clockPath(2), []string{})
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedAll(db))
+
+	sessionID, err := testutils.CreateTestSession(db, "director", authorization.ROLE_TOURNAMENT_DIRECTOR.ToString())
+	require.NoError(t, err)
+
+	levelMs := int64(testutils.TEST_STRUCTURES[0].Blinds[0].Time) * 60 * 1000
+
+	testCases := []struct {
+		name                 string
+		method               string
+		path                 string
+		body                 any
+		expectedStatus       int
+		expectedErrorMessage string
+		expectedLevel        int32
+		expectedRunning      bool
+		// expectedRemaining is checked exactly when the clock is paused
+		expectedRemaining int64
+	}{
+		{
+			name:                 "clock not started",
+			method:               "GET",
+			path:                 clockPath(2),
+			expectedStatus:       http.StatusNotFound,
+			expectedErrorMessage: "The clock for this event has not been started",
+		},
+		{
+			name:                 "stream for clock not started",
+			method:               "GET",
+			path:                 clockPath(2) + "/stream",
+			expectedStatus:       http.StatusNotFound,
+			expectedErrorMessage: "The clock for this event has not been started",
+		},
+		{
+			name:                 "event in another semester",
+			method:               "POST",
+			path:                 clockPath(3) + "/start",
+			expectedStatus:       http.StatusNotFound,
+			expectedErrorMessage: "Event not found",
+		},
+		{
+			name:                 "ended event",
+			method:               "POST",
+			path:                 clockPath(1) + "/start",
+			expectedStatus:       http.StatusForbidden,
+			expectedErrorMessage: "This event has ended",
+		},
+		{
+			name:                 "invalid event ID",
+			method:               "POST",
+			path:                 fmt.Sprintf("/api/v2/semesters/%s/events/abc/clock/start", testutils.TEST_SEMESTERS[0].ID),
+			expectedStatus:       http.StatusBadRequest,
+			expectedErrorMessage: "Event ID 'abc' is not a valid integer",
+		},
+		{
+			name:            "start",
+			method:          "POST",
+			path:            clockPath(2) + "/start",
+			expectedStatus:  http.StatusOK,
+			expectedLevel:   1,
+			expectedRunning: true,
+		},
+		{
+			name:                 "start twice",
+			method:               "POST",
+			path:                 clockPath(2) + "/start",
+			expectedStatus:       http.StatusForbidden,
+			expectedErrorMessage: "The clock for this event has already been started",
+		},
+		{
+			name:           "pause",
+			method:         "POST",
+			path:           clockPath(2) + "/pause",
+			expectedStatus: http.StatusOK,
+			expectedLevel:  1,
+		},
+		{
+			name:                 "pause twice",
+			method:               "POST",
+			path:                 clockPath(2) + "/pause",
+			expectedStatus:       http.StatusForbidden,
+			expectedErrorMessage: "The clock is already paused",
+		},
+		{
+			name:              "skip level",
+			method:            "POST",
+			path:              clockPath(2) + "/skip-level",
+			expectedStatus:    http.StatusOK,
+			expectedLevel:     2,
+			expectedRemaining: levelMs,
+		},
+		{
+			name:              "adjust time",
+			method:            "POST",
+			path:              clockPath(2) + "/adjust-time",
+			body:              map[string]any{"seconds": -120},
+			expectedStatus:    http.StatusOK,
+			expectedLevel:     2,
+			expectedRemaining: levelMs - 120*1000,
+		},
+		{
+			name:                 "adjust time without seconds",
+			method:               "POST",
+			path:                 clockPath(2) + "/adjust-time",
+			body:                 map[string]any{},
+			expectedStatus:       http.StatusBadRequest,
+			expectedErrorMessage: "Seconds",
+		},
+		{
+			name:              "skip back past the first level",
+			method:            "POST",
+			path:              clockPath(2) + "/skip-level",
+			body:              map[string]any{"levels": -5},
+			expectedStatus:    http.StatusOK,
+			expectedLevel:     1,
+			expectedRemaining: levelMs,
+		},
+		{
+			name:              "get paused clock",
+			method:            "GET",
+			path:              clockPath(2),
+			expectedStatus:    http.StatusOK,
+			expectedLevel:     1,
+			expectedRemaining: levelMs,
+		},
+		{
+			name:            "resume",
+			method:          "POST",
+			path:            clockPath(2) + "/resume",
+			expectedStatus:  http.StatusOK,
+			expectedLevel:   1,
+			expectedRunning: true,
+		},
+		{
+			name:                 "resume twice",
+			method:               "POST",
+			path:                 clockPath(2) + "/resume",
+			expectedStatus:       http.StatusForbidden,
+			expectedErrorMessage: "The clock is already running",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := testutils.MakeJSONRequest(tc.method, tc.path, tc.body)
+			require.NoError(t, err)
+			testutils.SetAuthCookie(req, sessionID)
+
+			w := httptest.NewRecorder()
+			apiServer.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedStatus, w.Code, "Response: %s", w.Body.String())
+
+			if tc.expectedErrorMessage != "" {
+				require.Contains(t, w.Body.String(), tc.expectedErrorMessage)
+				return
+			}
+
+			var clock models.ClockResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &clock))
+			require.Equal(t, int32(2), clock.EventID)
+			require.Equal(t, tc.expectedLevel, clock.Level)
+			require.Equal(t, tc.expectedRunning, clock.Running)
+			require.Equal(t, int32(len(testutils.TEST_STRUCTURES[0].Blinds)), clock.TotalLevels)
+			require.Equal(t, testutils.TEST_STRUCTURES[0].Blinds[tc.expectedLevel-1].Big, clock.CurrentLevel.Big)
+			require.NotNil(t, clock.NextLevel)
+			require.Equal(t, testutils.TEST_STRUCTURES[0].Blinds[tc.expectedLevel].Big, clock.NextLevel.Big)
+			if tc.expectedRunning {
+				require.LessOrEqual(t, clock.TimeRemaining, levelMs)
+			} else if tc.expectedRemaining != 0 {
+				require.Equal(t, tc.expectedRemaining, clock.TimeRemaining)
+			}
+		})
+	}
+
+	t.Run("clock persists across server instances", func(t *testing.T) {
+		restarted := testutils.NewTestAPIServer(db)
+
+		req, err := testutils.MakeJSONRequest("GET", clockPath(2), nil)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		restarted.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var clock models.ClockResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &clock))
+		require.True(t, clock.Running)
+		require.Equal(t, int32(1), clock.Level)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		server := httptest.NewServer(apiServer)
+		defer server.Close()
+
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(streamCtx, "GET", server.URL+clockPath(2)+"/stream", nil)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, sessionID)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Contains(t, resp.Header.Get("Content-Type"), "text/event-stream")
+
+		// Read the first two clock events
+		scanner := bufio.NewScanner(resp.Body)
+		events := 0
+		for events < 2 && scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "data:") {
+				continue
+			}
+
+			var clock models.ClockResponse
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data:")), &clock))
+			require.Equal(t, int32(2), clock.EventID)
+			require.True(t, clock.Running)
+			events++
+		}
+		require.NoError(t, scanner.Err())
+		require.Equal(t, 2, events)
+	})
+}
@@ -31,6 +31,24 @@ func (c *entriesController) LoadRoutes(router *gin.RouterGroup) {
 	group.POST(":entryId/sign-out", middleware.UseAuthorization("event.participant.signout"), c.signOutEntry)
 	group.POST(":entryId/sign-in", middleware.UseAuthorization("event.participant.signin"), c.signInEntry)
 	group.DELETE(":entryId", middleware.UseAuthorization("event.participant.delete"), c.deleteEntry)
+	group.GET(":entryId/rebuys", middleware.UseAuthorization("event.participant.list"), c.listRebuys)
+	group.POST(":entryId/rebuys", middleware.UseAuthorization("event.participant.rebuy"), c.createRebuy)
+	group.DELETE(":entryId/rebuys/:rebuyId", middleware.UseAuthorization("event.participant.rebuy"), c.undoRebuy)
+
+	// Walk-in players without a membership are entered by name
+	guests := router.Group("semesters/:semesterId/events/:eventId/guests", middleware.UseAuthentication(c.db))
+	guests.POST("", middleware.UseAuthorization("event.participant.create"), c.createGuestEntry)
+	guests.POST(":guestId/sign-out", middleware.UseAuthorization("event.participant.signout"), c.signOutGuestEntry)
+	guests.POST(":guestId/sign-in", middleware.UseAuthorization("event.participant.signin"), c.signInGuestEntry)
+	guests.DELETE(":guestId", middleware.UseAuthorization("event.participant.delete"), c.deleteGuestEntry)
+	guests.POST(":guestId/convert", middleware.UseAuthorization("event.participant.create"), c.convertGuestEntry)
+
+	// Members registered before the event starts, and the waitlist of a full event
+	registrations := router.Group("semesters/:semesterId/events/:eventId/registrations", middleware.UseAuthentication(c.db))
+	registrations.GET("", middleware.UseAuthorization("event.registration.list"), c.listRegistrations)
+	registrations.POST("", middleware.UseAuthorization("event.registration.create"), c.createRegistration)
+	registrations.POST("convert", middleware.UseAuthorization("event.registration.convert"), c.convertRegistrations)
+	registrations.DELETE(":registrationId", middleware.UseAuthorization("event.registration.delete"), c.deleteRegistration)
 }
 
 // validateSemesterID validates and returns the semester UUID from the path parameter.
@@ -59,11 +77,41 @@ func (c *entriesController) validateEventID(ctx *gin.Context) (int32, error) {
 	return int32(eventIDInt), nil
 }
 
+// validateEntryPath validates the semester, event and entry (membership) IDs
+// from the path. It aborts the request and returns false if any is invalid.
+func (c *entriesController) validateEntryPath(ctx *gin.Context) (uuid.UUID, int32, uuid.UUID, bool) {
+	semesterID, err := c.validateSemesterID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		return uuid.Nil, 0, uuid.Nil, false
+	}
+
+	eventID, err := c.validateEventID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		return uuid.Nil, 0, uuid.Nil, false
+	}
+
+	entryID := ctx.Param("entryId")
+	membershipID, err := uuid.Parse(entryID)
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			apierrors.InvalidRequest(
+				fmt.Sprintf("Entry ID '%s' is not a valid UUID", entryID),
+			),
+		)
+		return uuid.Nil, 0, uuid.Nil, false
+	}
+
+	return semesterID, eventID, membershipID, true
+}
+
 // createEntry handles the creation of new participant entries for an event.
 // It expects an array of membership UUIDs in the request body and returns the results.
 //
 // @Summary Create Entries
-// @Description Create new participant entries for an event
+// @Description Create new participant entries for an event. Once the event is full, members are added to its waitlist instead
 // @Tags Entries
 // @Accept json
 // @Produce json
@@ -121,17 +169,13 @@ func (c *entriesController) createEntry(ctx *gin.Context) {
 		membershipIds = append(membershipIds, id)
 	}
 
-	// Create participants and collect results
-	svc := services.NewParticipantsService(c.db)
+	// Create participants and collect results. Members entered once the
+	// event is full are waitlisted
+	svc := services.NewRegistrationService(c.db)
 	results := make([]models.CreateEntryResult, 0, len(membershipIds))
 
 	for _, membershipId := range membershipIds {
-		req := models.CreateParticipantRequest{
-			MembershipID: membershipId,
-			EventID:      eventID,
-		}
-
-		participant, err := svc.CreateParticipant(&req)
+		participant, registration, err := svc.EnterOrWaitlist(eventID, membershipId)
 		if err != nil {
 			// Collect error but continue processing
 			errMsg := err.Error()
@@ -143,6 +187,12 @@ func (c *entriesController) createEntry(ctx *gin.Context) {
 				Status:       "error",
 				Error:        errMsg,
 			})
+		} else if registration != nil {
+			results = append(results, models.CreateEntryResult{
+				MembershipID: membershipId,
+				Status:       "waitlisted",
+				Registration: registration,
+			})
 		} else {
 			// Success
 			results = append(results, models.CreateEntryResult{
@@ -220,13 +270,14 @@ func (c *entriesController) listEntries(ctx *gin.Context) {
 // It expects the entry ID (membership ID) in the URL path.
 //
 // @Summary Sign Out Entry
-// @Description Sign out a participant from an event
+// @Description Sign out a participant from an event, optionally recording the entry that knocked them out
 // @Tags Entries
 // @Accept json
 // @Produce json
 // @Param semesterId path string true "Semester ID"
 // @Param eventId path string true "Event ID"
 // @Param entryId path string true "Membership ID (UUID format)"
+// @Param knockout body SignOutEntryRequest false "Entry that knocked the player out"
 // @Success 200 {object} Participant
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
@@ -261,13 +312,21 @@ func (c *entriesController) signOutEntry(ctx *gin.Context) {
 		return
 	}
 
+	// The body is optional, and records who eliminated the player
+	var body models.SignOutEntryRequest
+	if ctx.Request.ContentLength != 0 && !BindJSON(ctx, &body) {
+		return
+	}
+
 	// Update participant
 	svc := services.NewParticipantsService(c.db)
 	req := models.UpdateParticipantRequest{
-		MembershipID: membershipID,
-		EventID:      eventID,
-		SignOut:      true,
-		SignIn:       false,
+		MembershipID:        membershipID,
+		EventID:             eventID,
+		SignOut:             true,
+		SignIn:              false,
+		KnockedOutBy:        body.KnockedOutBy,
+		KnockedOutByEntryID: body.KnockedOutByEntry,
 	}
 
 	participant, err := svc.UpdateParticipant(&req)
@@ -423,3 +482,142 @@ func (c *entriesController) deleteEntry(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+// listRebuys handles listing the rebuys and add-ons of a participant.
+//
+// @Summary List Entry Rebuys
+// @Description List the rebuys and add-ons of a participant, oldest first
+// @Tags Entries
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Param entryId path string true "Membership ID (UUID format)"
+// @Success 200 {array} Rebuy
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/entries/{entryId}/rebuys [get]
+func (c *entriesController) listRebuys(ctx *gin.Context) {
+	semesterID, eventID, membershipID, ok := c.validateEntryPath(ctx)
+	if !ok {
+		return
+	}
+
+	svc := services.NewRebuyService(c.db)
+	rebuys, err := svc.ListRebuys(semesterID, eventID, membershipID)
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			apierrors.InternalServerError(err.Error()),
+		)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rebuys)
+}
+
+// createRebuy handles recording a rebuy or add-on for a participant.
+// The fee is added to the semester budget.
+//
+// @Summary Create Entry Rebuy
+// @Description Record a rebuy or add-on for a participant and add its fee to the semester budget. Rebuys are limited by the event's max rebuys, add-ons are not.
+// @Tags Entries
+// @Accept json
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Param entryId path string true "Membership ID (UUID format)"
+// @Param request body CreateRebuyRequest false "Type of the purchase"
+// @Success 201 {object} Rebuy
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/entries/{entryId}/rebuys [post]
+func (c *entriesController) createRebuy(ctx *gin.Context) {
+	semesterID, eventID, membershipID, ok := c.validateEntryPath(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.CreateRebuyRequest
+	if ctx.Request.ContentLength != 0 && !BindJSON(ctx, &req) {
+		return
+	}
+
+	svc := services.NewRebuyService(c.db)
+	rebuy, err := svc.CreateRebuy(semesterID, eventID, membershipID, req.Type)
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			apierrors.InternalServerError(err.Error()),
+		)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, rebuy)
+}
+
+// undoRebuy handles undoing a rebuy or add-on recorded by mistake.
+// The fee is taken back out of the semester budget.
+//
+// @Summary Undo Entry Rebuy
+// @Description Remove a rebuy or add-on of a participant and take its fee back out of the semester budget
+// @Tags Entries
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Param entryId path string true "Membership ID (UUID format)"
+// @Param rebuyId path int true "Rebuy ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/entries/{entryId}/rebuys/{rebuyId} [delete]
+func (c *entriesController) undoRebuy(ctx *gin.Context) {
+	semesterID, eventID, membershipID, ok := c.validateEntryPath(ctx)
+	if !ok {
+		return
+	}
+
+	rebuyIDStr := ctx.Param("rebuyId")
+	rebuyID, err := strconv.ParseInt(rebuyIDStr, 10, 32)
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			apierrors.InvalidRequest(
+				fmt.Sprintf("Rebuy ID '%s' is not a valid integer", rebuyIDStr),
+			),
+		)
+		return
+	}
+
+	svc := services.NewRebuyService(c.db)
+	err = svc.UndoRebuy(semesterID, eventID, membershipID, int32(rebuyID))
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			apierrors.InternalServerError(err.Error()),
+		)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
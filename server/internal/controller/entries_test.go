@@ -3,6 +3,7 @@ package controller_test
 import (
 	"api/internal/authorization"
 	"api/internal/models"
+	"api/internal/services"
 	"api/internal/testutils"
 	"context"
 	"encoding/json"
@@ -62,10 +63,17 @@ func TestCreateEntry(t *testing.T) {
 					"membershipId": testutils.TEST_MEMBERSHIPS[1].ID.String(),
 					"status":       "created",
 					"participant": map[string]any{
-						"membershipId": testutils.TEST_MEMBERSHIPS[1].ID.String(),
-						"eventId":      float64(2),
-						"placement":    float64(0),
-						"signedOutAt":  nil,
+						"membershipId":      testutils.TEST_MEMBERSHIPS[1].ID.String(),
+						"eventId":           float64(2),
+						"placement":         float64(0),
+						"placementOverride": nil,
+						"tableNumber":       nil,
+						"seatNumber":        nil,
+						"rebuys":            float64(0),
+						"addOns":            float64(0),
+						"knockedOutById":    nil,
+						"guestName":         nil,
+						"signedOutAt":       nil,
 					},
 				},
 			},
@@ -175,10 +183,17 @@ func TestCreateEntry(t *testing.T) {
 					"membershipId": testutils.TEST_MEMBERSHIPS[1].ID.String(),
 					"status":       "created",
 					"participant": map[string]any{
-						"membershipId": testutils.TEST_MEMBERSHIPS[1].ID.String(),
-						"eventId":      float64(2),
-						"placement":    float64(0),
-						"signedOutAt":  nil,
+						"membershipId":      testutils.TEST_MEMBERSHIPS[1].ID.String(),
+						"eventId":           float64(2),
+						"placement":         float64(0),
+						"placementOverride": nil,
+						"tableNumber":       nil,
+						"seatNumber":        nil,
+						"rebuys":            float64(0),
+						"addOns":            float64(0),
+						"knockedOutById":    nil,
+						"guestName":         nil,
+						"signedOutAt":       nil,
 					},
 				},
 			},
@@ -260,6 +275,9 @@ func TestCreateEntry(t *testing.T) {
 						if participant, ok := actualResponse[i]["participant"].(map[string]any); ok {
 							if expectedParticipant, ok := tc.expectedResponse[i]["participant"].(map[string]any); ok {
 								expectedParticipant["id"] = participant["id"]
+								// Seats are drawn at random
+								expectedParticipant["tableNumber"] = participant["tableNumber"]
+								expectedParticipant["seatNumber"] = participant["seatNumber"]
 							}
 						}
 					}
@@ -385,91 +403,107 @@ func TestListEntries(t *testing.T) {
 				tc.expectedResponse = map[string]any{
 					"total": float64(2),
 					"data": []map[string]any{
-					{
-						// Participant fields
-						"membershipId": testutils.TEST_MEMBERSHIPS[2].ID.String(),
-						"eventId":      float64(2),
-						"placement":    float64(0),
-						"signedOutAt":  nil,
-						// Nested membership with nested user
-						"membership": map[string]any{
-							"id":         testutils.TEST_MEMBERSHIPS[2].ID.String(),
-							"userId":     float64(testutils.TEST_USERS[2].ID),
-							"semesterId": testutils.TEST_SEMESTERS[0].ID.String(),
-							"paid":       testutils.TEST_MEMBERSHIPS[2].Paid,
-							"discounted": testutils.TEST_MEMBERSHIPS[2].Discounted,
-							"user": map[string]any{
-								"id":        float64(user2.ID),
-								"firstName": user2.FirstName,
-								"lastName":  user2.LastName,
-								"email":     user2.Email,
-								"faculty":   user2.Faculty,
-								"questId":   user2.QuestID,
-								"createdAt": user2.CreatedAt.Format("2006-01-02T15:04:05.999999999Z07:00"),
-							},
-							"semester": map[string]any{
-								"id":                    testutils.TEST_SEMESTERS[0].ID.String(),
-								"name":                  testutils.TEST_SEMESTERS[0].Name,
-								"meta":                  testutils.TEST_SEMESTERS[0].Meta,
-								"startDate":             testutils.TEST_SEMESTERS[0].StartDate.Format("2006-01-02T15:04:05.999999999Z07:00"),
-								"endDate":               testutils.TEST_SEMESTERS[0].EndDate.Format("2006-01-02T15:04:05.999999999Z07:00"),
-								"startingBudget":        float64(testutils.TEST_SEMESTERS[0].StartingBudget),
-								"currentBudget":         float64(testutils.TEST_SEMESTERS[0].CurrentBudget),
-								"membershipFee":         float64(testutils.TEST_SEMESTERS[0].MembershipFee),
-								"membershipDiscountFee": float64(testutils.TEST_SEMESTERS[0].MembershipDiscountFee),
-								"rebuyFee":              float64(testutils.TEST_SEMESTERS[0].RebuyFee),
-							},
-							"ranking": map[string]any{
-								"id":           float64(3),
-								"membershipId": testutils.TEST_MEMBERSHIPS[2].ID.String(),
-								"points":       float64(0),
-								"attendance":   float64(0),
+						{
+							// Participant fields
+							"membershipId":      testutils.TEST_MEMBERSHIPS[2].ID.String(),
+							"eventId":           float64(2),
+							"placement":         float64(0),
+							"placementOverride": nil,
+							"tableNumber":       nil,
+							"seatNumber":        nil,
+							"rebuys":            float64(0),
+							"addOns":            float64(0),
+							"knockedOutById":    nil,
+							"guestName":         nil,
+							"signedOutAt":       nil,
+							// Nested membership with nested user
+							"membership": map[string]any{
+								"id":         testutils.TEST_MEMBERSHIPS[2].ID.String(),
+								"userId":     float64(testutils.TEST_USERS[2].ID),
+								"semesterId": testutils.TEST_SEMESTERS[0].ID.String(),
+								"paid":       testutils.TEST_MEMBERSHIPS[2].Paid,
+								"discounted": testutils.TEST_MEMBERSHIPS[2].Discounted,
+								"user": map[string]any{
+									"id":        float64(user2.ID),
+									"firstName": user2.FirstName,
+									"lastName":  user2.LastName,
+									"email":     user2.Email,
+									"faculty":   user2.Faculty,
+									"questId":   user2.QuestID,
+									"createdAt": user2.CreatedAt.Format("2006-01-02T15:04:05.999999999Z07:00"),
+								},
+								"semester": map[string]any{
+									"id":                    testutils.TEST_SEMESTERS[0].ID.String(),
+									"name":                  testutils.TEST_SEMESTERS[0].Name,
+									"meta":                  testutils.TEST_SEMESTERS[0].Meta,
+									"startDate":             testutils.TEST_SEMESTERS[0].StartDate.Format("2006-01-02T15:04:05.999999999Z07:00"),
+									"endDate":               testutils.TEST_SEMESTERS[0].EndDate.Format("2006-01-02T15:04:05.999999999Z07:00"),
+									"startingBudget":        float64(testutils.TEST_SEMESTERS[0].StartingBudget),
+									"currentBudget":         float64(testutils.TEST_SEMESTERS[0].CurrentBudget),
+									"membershipFee":         float64(testutils.TEST_SEMESTERS[0].MembershipFee),
+									"membershipDiscountFee": float64(testutils.TEST_SEMESTERS[0].MembershipDiscountFee),
+									"rebuyFee":              float64(testutils.TEST_SEMESTERS[0].RebuyFee),
+									"addOnFee":              float64(testutils.TEST_SEMESTERS[0].AddOnFee),
+								},
+								"ranking": map[string]any{
+									"id":           float64(3),
+									"membershipId": testutils.TEST_MEMBERSHIPS[2].ID.String(),
+									"points":       float64(0),
+									"attendance":   float64(0),
+								},
 							},
 						},
-					},
-					{
-						// Participant fields
-						"membershipId": testutils.TEST_MEMBERSHIPS[0].ID.String(),
-						"eventId":      float64(2),
-						"placement":    float64(0),
-						"signedOutAt":  "2023-10-20T20:00:00-04:00",
-						// Nested membership with nested user
-						"membership": map[string]any{
-							"id":         testutils.TEST_MEMBERSHIPS[0].ID.String(),
-							"userId":     float64(testutils.TEST_USERS[0].ID),
-							"semesterId": testutils.TEST_SEMESTERS[0].ID.String(),
-							"paid":       testutils.TEST_MEMBERSHIPS[0].Paid,
-							"discounted": testutils.TEST_MEMBERSHIPS[0].Discounted,
-							"user": map[string]any{
-								"id":        float64(user0.ID),
-								"firstName": user0.FirstName,
-								"lastName":  user0.LastName,
-								"email":     user0.Email,
-								"faculty":   user0.Faculty,
-								"questId":   user0.QuestID,
-								"createdAt": user0.CreatedAt.Format("2006-01-02T15:04:05.999999999Z07:00"),
-							},
-							"semester": map[string]any{
-								"id":                    testutils.TEST_SEMESTERS[0].ID.String(),
-								"name":                  testutils.TEST_SEMESTERS[0].Name,
-								"meta":                  testutils.TEST_SEMESTERS[0].Meta,
-								"startDate":             testutils.TEST_SEMESTERS[0].StartDate.Format("2006-01-02T15:04:05.999999999Z07:00"),
-								"endDate":               testutils.TEST_SEMESTERS[0].EndDate.Format("2006-01-02T15:04:05.999999999Z07:00"),
-								"startingBudget":        float64(testutils.TEST_SEMESTERS[0].StartingBudget),
-								"currentBudget":         float64(testutils.TEST_SEMESTERS[0].CurrentBudget),
-								"membershipFee":         float64(testutils.TEST_SEMESTERS[0].MembershipFee),
-								"membershipDiscountFee": float64(testutils.TEST_SEMESTERS[0].MembershipDiscountFee),
-								"rebuyFee":              float64(testutils.TEST_SEMESTERS[0].RebuyFee),
-							},
-							"ranking": map[string]any{
-								"id":           float64(1),
-								"membershipId": testutils.TEST_MEMBERSHIPS[0].ID.String(),
-								"points":       float64(0),
-								"attendance":   float64(0),
+						{
+							// Participant fields
+							"membershipId":      testutils.TEST_MEMBERSHIPS[0].ID.String(),
+							"eventId":           float64(2),
+							"placement":         float64(0),
+							"placementOverride": nil,
+							"tableNumber":       nil,
+							"seatNumber":        nil,
+							"rebuys":            float64(0),
+							"addOns":            float64(0),
+							"knockedOutById":    nil,
+							"guestName":         nil,
+							"signedOutAt":       "2023-10-20T20:00:00-04:00",
+							// Nested membership with nested user
+							"membership": map[string]any{
+								"id":         testutils.TEST_MEMBERSHIPS[0].ID.String(),
+								"userId":     float64(testutils.TEST_USERS[0].ID),
+								"semesterId": testutils.TEST_SEMESTERS[0].ID.String(),
+								"paid":       testutils.TEST_MEMBERSHIPS[0].Paid,
+								"discounted": testutils.TEST_MEMBERSHIPS[0].Discounted,
+								"user": map[string]any{
+									"id":        float64(user0.ID),
+									"firstName": user0.FirstName,
+									"lastName":  user0.LastName,
+									"email":     user0.Email,
+									"faculty":   user0.Faculty,
+									"questId":   user0.QuestID,
+									"createdAt": user0.CreatedAt.Format("2006-01-02T15:04:05.999999999Z07:00"),
+								},
+								"semester": map[string]any{
+									"id":                    testutils.TEST_SEMESTERS[0].ID.String(),
+									"name":                  testutils.TEST_SEMESTERS[0].Name,
+									"meta":                  testutils.TEST_SEMESTERS[0].Meta,
+									"startDate":             testutils.TEST_SEMESTERS[0].StartDate.Format("2006-01-02T15:04:05.999999999Z07:00"),
+									"endDate":               testutils.TEST_SEMESTERS[0].EndDate.Format("2006-01-02T15:04:05.999999999Z07:00"),
+									"startingBudget":        float64(testutils.TEST_SEMESTERS[0].StartingBudget),
+									"currentBudget":         float64(testutils.TEST_SEMESTERS[0].CurrentBudget),
+									"membershipFee":         float64(testutils.TEST_SEMESTERS[0].MembershipFee),
+									"membershipDiscountFee": float64(testutils.TEST_SEMESTERS[0].MembershipDiscountFee),
+									"rebuyFee":              float64(testutils.TEST_SEMESTERS[0].RebuyFee),
+									"addOnFee":              float64(testutils.TEST_SEMESTERS[0].AddOnFee),
+								},
+								"ranking": map[string]any{
+									"id":           float64(1),
+									"membershipId": testutils.TEST_MEMBERSHIPS[0].ID.String(),
+									"points":       float64(0),
+									"attendance":   float64(0),
+								},
 							},
 						},
 					},
-				},
 				}
 			}
 
@@ -575,6 +609,7 @@ func TestSignOutEntry(t *testing.T) {
 		expectError      bool
 		expectedErrorMsg string
 		expectedResponse map[string]any
+		requestBody      map[string]any
 	}{
 		{
 			name:           "successful sign out",
@@ -585,9 +620,16 @@ func TestSignOutEntry(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectError:    false,
 			expectedResponse: map[string]any{
-				"membershipId": testutils.TEST_MEMBERSHIPS[2].ID.String(),
-				"eventId":      float64(2),
-				"placement":    float64(0),
+				"membershipId":      testutils.TEST_MEMBERSHIPS[2].ID.String(),
+				"eventId":           float64(2),
+				"placement":         float64(0),
+				"placementOverride": nil,
+				"tableNumber":       nil,
+				"seatNumber":        nil,
+				"rebuys":            float64(0),
+				"addOns":            float64(0),
+				"knockedOutById":    nil,
+				"guestName":         nil,
 				// signedOutAt will be set dynamically
 			},
 		},
@@ -621,6 +663,64 @@ func TestSignOutEntry(t *testing.T) {
 			expectError:      true,
 			expectedErrorMsg: "Entry ID 'invalid-uuid' is not a valid UUID",
 		},
+		{
+			name:             "knockout by a player already signed out",
+			userRole:         authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+			semesterID:       testutils.TEST_SEMESTERS[0].ID.String(),
+			eventID:          "2",
+			entryID:          testutils.TEST_MEMBERSHIPS[2].ID.String(),
+			requestBody:      map[string]any{"knockedOutBy": testutils.TEST_MEMBERSHIPS[0].ID.String()},
+			expectedStatus:   http.StatusBadRequest,
+			expectError:      true,
+			expectedErrorMsg: "A player cannot be knocked out by a player who has already been signed out",
+		},
+		{
+			name:             "knockout by an entry of another event",
+			userRole:         authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+			semesterID:       testutils.TEST_SEMESTERS[0].ID.String(),
+			eventID:          "2",
+			entryID:          testutils.TEST_MEMBERSHIPS[2].ID.String(),
+			requestBody:      map[string]any{"knockedOutByEntry": 9999},
+			expectedStatus:   http.StatusNotFound,
+			expectError:      true,
+			expectedErrorMsg: "Entry 9999 is not in this event",
+		},
+		{
+			name:       "knockout by both a membership and an entry",
+			userRole:   authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+			semesterID: testutils.TEST_SEMESTERS[0].ID.String(),
+			eventID:    "2",
+			entryID:    testutils.TEST_MEMBERSHIPS[2].ID.String(),
+			requestBody: map[string]any{
+				"knockedOutBy":      testutils.TEST_MEMBERSHIPS[0].ID.String(),
+				"knockedOutByEntry": 1,
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectError:      true,
+			expectedErrorMsg: "Only one of knockedOutBy and knockedOutByEntry can be set",
+		},
+		{
+			name:             "knockout by self",
+			userRole:         authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+			semesterID:       testutils.TEST_SEMESTERS[0].ID.String(),
+			eventID:          "2",
+			entryID:          testutils.TEST_MEMBERSHIPS[2].ID.String(),
+			requestBody:      map[string]any{"knockedOutBy": testutils.TEST_MEMBERSHIPS[2].ID.String()},
+			expectedStatus:   http.StatusBadRequest,
+			expectError:      true,
+			expectedErrorMsg: "A player cannot knock themselves out",
+		},
+		{
+			name:             "knockout by a membership without an entry",
+			userRole:         authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+			semesterID:       testutils.TEST_SEMESTERS[0].ID.String(),
+			eventID:          "2",
+			entryID:          testutils.TEST_MEMBERSHIPS[2].ID.String(),
+			requestBody:      map[string]any{"knockedOutBy": testutils.TEST_MEMBERSHIPS[1].ID.String()},
+			expectedStatus:   http.StatusNotFound,
+			expectError:      true,
+			expectedErrorMsg: fmt.Sprintf("Membership %s has no entry in this event", testutils.TEST_MEMBERSHIPS[1].ID),
+		},
 		{
 			name:             "event already ended - cannot sign out",
 			userRole:         authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
@@ -653,6 +753,7 @@ func TestSignOutEntry(t *testing.T) {
 			expectError      bool
 			expectedErrorMsg string
 			expectedResponse map[string]any
+			requestBody      map[string]any
 		}{
 			name:           fmt.Sprintf("successful sign out with role %s", role),
 			userRole:       role,
@@ -662,9 +763,16 @@ func TestSignOutEntry(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectError:    false,
 			expectedResponse: map[string]any{
-				"membershipId": testutils.TEST_MEMBERSHIPS[2].ID.String(),
-				"eventId":      float64(2),
-				"placement":    float64(0),
+				"membershipId":      testutils.TEST_MEMBERSHIPS[2].ID.String(),
+				"eventId":           float64(2),
+				"placement":         float64(0),
+				"placementOverride": nil,
+				"tableNumber":       nil,
+				"seatNumber":        nil,
+				"rebuys":            float64(0),
+				"addOns":            float64(0),
+				"knockedOutById":    nil,
+				"guestName":         nil,
 				// signedOutAt will be set dynamically
 			},
 		})
@@ -686,7 +794,7 @@ func TestSignOutEntry(t *testing.T) {
 			req, err := testutils.MakeJSONRequest(
 				"POST",
 				fmt.Sprintf("/api/v2/semesters/%s/events/%s/entries/%s/sign-out", tc.semesterID, tc.eventID, tc.entryID),
-				nil,
+				tc.requestBody,
 			)
 			require.NoError(t, err)
 
@@ -757,10 +865,17 @@ func TestSignInEntry(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectError:    false,
 			expectedResponse: map[string]any{
-				"membershipId": testutils.TEST_MEMBERSHIPS[0].ID.String(),
-				"eventId":      float64(2),
-				"placement":    float64(0),
-				"signedOutAt":  nil,
+				"membershipId":      testutils.TEST_MEMBERSHIPS[0].ID.String(),
+				"eventId":           float64(2),
+				"placement":         float64(0),
+				"placementOverride": nil,
+				"tableNumber":       nil,
+				"seatNumber":        nil,
+				"rebuys":            float64(0),
+				"addOns":            float64(0),
+				"knockedOutById":    nil,
+				"guestName":         nil,
+				"signedOutAt":       nil,
 			},
 		},
 		{
@@ -834,10 +949,17 @@ func TestSignInEntry(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectError:    false,
 			expectedResponse: map[string]any{
-				"membershipId": testutils.TEST_MEMBERSHIPS[0].ID.String(),
-				"eventId":      float64(2),
-				"placement":    float64(0),
-				"signedOutAt":  nil,
+				"membershipId":      testutils.TEST_MEMBERSHIPS[0].ID.String(),
+				"eventId":           float64(2),
+				"placement":         float64(0),
+				"placementOverride": nil,
+				"tableNumber":       nil,
+				"seatNumber":        nil,
+				"rebuys":            float64(0),
+				"addOns":            float64(0),
+				"knockedOutById":    nil,
+				"guestName":         nil,
+				"signedOutAt":       nil,
 			},
 		})
 	}
@@ -878,6 +1000,9 @@ func TestSignInEntry(t *testing.T) {
 
 				// Set the actual id in the expected response
 				tc.expectedResponse["id"] = actualResponse["id"]
+				// Players that sign back in are given a seat drawn at random
+				tc.expectedResponse["tableNumber"] = actualResponse["tableNumber"]
+				tc.expectedResponse["seatNumber"] = actualResponse["seatNumber"]
 
 				testutils.AssertSuccessResponse(t, w, tc.expectedStatus, tc.expectedResponse)
 			}
@@ -1148,3 +1273,304 @@ func TestListEntriesSearch(t *testing.T) {
 		})
 	}
 }
+
+func TestEntryRebuys(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	semester := testutils.TEST_SEMESTERS[0]
+	eventPath := fmt.Sprintf("/api/v2/semesters/%s/events/2", semester.ID)
+	rebuysPath := fmt.Sprintf("%s/entries/%s/rebuys", eventPath, testutils.TEST_MEMBERSHIPS[2].ID)
+
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "POST", rebuysPath, []string{"bot", "executive"})
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", rebuysPath, []string{"bot"})
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedAll(db))
+	require.NoError(t, db.Model(&models.Semester{}).Where("id = ?", semester.ID).Update("add_on_fee", 3).Error)
+
+	sessionID, err := testutils.CreateTestSession(db, "director", authorization.ROLE_TOURNAMENT_DIRECTOR.ToString())
+	require.NoError(t, err)
+
+	do := func(method string, path string, body any) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, body)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	requireBudget := func(t *testing.T, expected float32) {
+		var current models.Semester
+		require.NoError(t, db.Where("id = ?", semester.ID).First(&current).Error)
+		require.InDelta(t, expected, current.CurrentBudget, 0.001)
+	}
+
+	requireCounts := func(t *testing.T, rebuys uint8, addOns uint8, eventRebuys uint8) {
+		var participant models.Participant
+		require.NoError(t, db.Where("event_id = ? AND membership_id = ?", 2, testutils.TEST_MEMBERSHIPS[2].ID).First(&participant).Error)
+		require.Equal(t, rebuys, participant.Rebuys)
+		require.Equal(t, addOns, participant.AddOns)
+
+		var event models.Event
+		require.NoError(t, db.Where("id = ?", 2).First(&event).Error)
+		require.Equal(t, eventRebuys, event.Rebuys)
+	}
+
+	seededEventRebuys := testutils.TEST_EVENTS[1].Rebuys
+
+	var rebuyID int32
+	t.Run("rebuy", func(t *testing.T) {
+		w := do("POST", rebuysPath, nil)
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		var rebuy models.Rebuy
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rebuy))
+		require.Equal(t, models.RebuyTypeRebuy, rebuy.Type)
+		require.InDelta(t, float32(semester.RebuyFee), rebuy.Amount, 0.001)
+		rebuyID = rebuy.ID
+
+		requireBudget(t, semester.CurrentBudget+float32(semester.RebuyFee))
+		requireCounts(t, 1, 0, seededEventRebuys+1)
+	})
+
+	t.Run("add-on", func(t *testing.T) {
+		w := do("POST", rebuysPath, map[string]any{"type": "add_on"})
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		requireBudget(t, semester.CurrentBudget+float32(semester.RebuyFee)+3)
+		requireCounts(t, 1, 1, seededEventRebuys+1)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		w := do("POST", rebuysPath, map[string]any{"type": "reentry"})
+		require.Equal(t, http.StatusBadRequest, w.Code, "Response: %s", w.Body.String())
+	})
+
+	t.Run("max rebuys", func(t *testing.T) {
+		w := do("PATCH", eventPath, map[string]any{"maxRebuys": 1})
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		w = do("POST", rebuysPath, nil)
+		testutils.AssertErrorResponse(t, w, http.StatusForbidden, "This event allows at most 1 rebuys per player")
+
+		// Add-ons do not count towards the limit
+		w = do("POST", rebuysPath, map[string]any{"type": "add_on"})
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+		requireCounts(t, 1, 2, seededEventRebuys+1)
+	})
+
+	t.Run("entry listing includes counts", func(t *testing.T) {
+		w := do("GET", eventPath+"/entries", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var resp models.ListResponse[models.Participant]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		for _, p := range resp.Data {
+			if *p.MembershipID == testutils.TEST_MEMBERSHIPS[2].ID {
+				require.Equal(t, uint8(1), p.Rebuys)
+				require.Equal(t, uint8(2), p.AddOns)
+			}
+		}
+
+		w = do("GET", rebuysPath, nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var rebuys []models.Rebuy
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rebuys))
+		require.Len(t, rebuys, 3)
+		require.Equal(t, rebuyID, rebuys[0].ID)
+	})
+
+	t.Run("undo", func(t *testing.T) {
+		w := do("DELETE", fmt.Sprintf("%s/%d", rebuysPath, rebuyID), nil)
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+		requireBudget(t, semester.CurrentBudget+6)
+		requireCounts(t, 0, 2, seededEventRebuys)
+
+		w = do("DELETE", fmt.Sprintf("%s/%d", rebuysPath, rebuyID), nil)
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Rebuy not found")
+	})
+
+	t.Run("entry not in event", func(t *testing.T) {
+		path := fmt.Sprintf("%s/entries/%s/rebuys", eventPath, testutils.TEST_MEMBERSHIPS[1].ID)
+		w := do("POST", path, nil)
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Entry not found")
+	})
+
+	t.Run("ended event", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v2/semesters/%s/events/1/entries/%s/rebuys", semester.ID, testutils.TEST_MEMBERSHIPS[0].ID)
+		w := do("POST", path, nil)
+		testutils.AssertErrorResponse(t, w, http.StatusForbidden, "Modification of a completed event is forbidden")
+	})
+}
+
+func TestGuestEntries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	semester := testutils.TEST_SEMESTERS[0]
+	eventPath := fmt.Sprintf("/api/v2/semesters/%s/events/2", semester.ID)
+	guestsPath := eventPath + "/guests"
+
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "POST", guestsPath, []string{"bot", "executive"})
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedAll(db))
+
+	sessionID, err := testutils.CreateTestSession(db, "director", authorization.ROLE_TOURNAMENT_DIRECTOR.ToString())
+	require.NoError(t, err)
+
+	do := func(method string, path string, body any) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, body)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	var guest models.Participant
+	t.Run("create", func(t *testing.T) {
+		w := do("POST", guestsPath, map[string]any{"name": " Sam Walker "})
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &guest))
+		require.Nil(t, guest.MembershipID)
+		require.NotNil(t, guest.GuestName)
+		require.Equal(t, "Sam Walker", *guest.GuestName)
+		require.NotNil(t, guest.TableNumber, "Guests are seated like members")
+	})
+
+	t.Run("blank name", func(t *testing.T) {
+		w := do("POST", guestsPath, map[string]any{"name": "   "})
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "Guest name cannot be blank")
+	})
+
+	t.Run("search matches guests", func(t *testing.T) {
+		w := do("GET", eventPath+"/entries?search=walker", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var resp models.ListResponse[models.Participant]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 1)
+		require.Equal(t, guest.ID, resp.Data[0].ID)
+	})
+
+	t.Run("sign out and in", func(t *testing.T) {
+		w := do("POST", fmt.Sprintf("%s/%d/sign-out", guestsPath, guest.ID), map[string]any{
+			"knockedOutBy": testutils.TEST_MEMBERSHIPS[2].ID.String(),
+		})
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var signedOut models.Participant
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &signedOut))
+		require.NotNil(t, signedOut.SignedOutAt)
+		require.NotNil(t, signedOut.KnockedOutByID)
+
+		w = do("POST", fmt.Sprintf("%s/%d/sign-in", guestsPath, guest.ID), nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+	})
+
+	t.Run("knockout by a guest", func(t *testing.T) {
+		w := do("POST", fmt.Sprintf("%s/entries/%s/sign-out", eventPath, testutils.TEST_MEMBERSHIPS[2].ID), map[string]any{
+			"knockedOutByEntry": guest.ID,
+		})
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var signedOut models.Participant
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &signedOut))
+		require.NotNil(t, signedOut.KnockedOutByID)
+		require.Equal(t, guest.ID, *signedOut.KnockedOutByID)
+	})
+
+	t.Run("member entries are not guests", func(t *testing.T) {
+		var entry models.Participant
+		require.NoError(t, db.Where("event_id = ? AND membership_id = ?", 2, testutils.TEST_MEMBERSHIPS[2].ID).First(&entry).Error)
+
+		w := do("POST", fmt.Sprintf("%s/%d/sign-out", guestsPath, entry.ID), nil)
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Guest entry not found")
+	})
+
+	t.Run("convert to a membership already in the event", func(t *testing.T) {
+		w := do("POST", fmt.Sprintf("%s/%d/convert", guestsPath, guest.ID), map[string]any{
+			"membershipId": testutils.TEST_MEMBERSHIPS[0].ID.String(),
+		})
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, fmt.Sprintf(
+			"Membership %s already has an entry in this event", testutils.TEST_MEMBERSHIPS[0].ID,
+		))
+	})
+
+	t.Run("convert in the wrong semester", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v2/semesters/%s/events/2/guests/%d/convert", testutils.TEST_SEMESTERS[1].ID, guest.ID)
+		w := do("POST", path, map[string]any{"membershipId": testutils.TEST_MEMBERSHIPS[1].ID.String()})
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Event not found")
+	})
+
+	t.Run("convert", func(t *testing.T) {
+		w := do("POST", fmt.Sprintf("%s/%d/convert", guestsPath, guest.ID), map[string]any{
+			"membershipId": testutils.TEST_MEMBERSHIPS[1].ID.String(),
+		})
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var converted models.Participant
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &converted))
+		require.Nil(t, converted.GuestName)
+		require.Equal(t, testutils.TEST_MEMBERSHIPS[1].ID, *converted.MembershipID)
+
+		w = do("DELETE", fmt.Sprintf("%s/%d", guestsPath, guest.ID), nil)
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Entry not found")
+	})
+
+	t.Run("convert in an ended event back-fills points", func(t *testing.T) {
+		// A guest finished last in the ended event
+		name := "Alex Guest"
+		signedOutAt := testutils.TEST_EVENTS[2].StartDate
+		ended := models.Participant{EventID: 1, GuestName: &name, Placement: 3, SignedOutAt: &signedOutAt}
+		require.NoError(t, db.Create(&ended).Error)
+
+		var before models.Ranking
+		require.NoError(t, db.Where("membership_id = ?", testutils.TEST_MEMBERSHIPS[2].ID).First(&before).Error)
+
+		path := fmt.Sprintf("/api/v2/semesters/%s/events/1/guests/%d/convert", semester.ID, ended.ID)
+		w := do("POST", path, map[string]any{"membershipId": testutils.TEST_MEMBERSHIPS[2].ID.String()})
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var after models.Ranking
+		require.NoError(t, db.Where("membership_id = ?", testutils.TEST_MEMBERSHIPS[2].ID).First(&after).Error)
+		require.EqualValues(t, before.Points+int32(services.CalculatePoints(3, 3, testutils.TEST_EVENTS[2].PointsMultiplier)), after.Points)
+
+		var snapshots int64
+		require.NoError(t, db.Model(&models.RankingSnapshot{}).Where("reason = ?", models.RankingSnapshotReasonGuestConverted).Count(&snapshots).Error)
+		require.EqualValues(t, 1, snapshots)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		w := do("POST", guestsPath, map[string]any{"name": "Jo Visitor"})
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		var visitor models.Participant
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &visitor))
+
+		w = do("DELETE", fmt.Sprintf("%s/%d", guestsPath, visitor.ID), nil)
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+	})
+}
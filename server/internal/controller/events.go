@@ -7,6 +7,7 @@ import (
 	"api/internal/services"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"time"
@@ -31,6 +32,7 @@ func (s *eventsController) LoadRoutes(router *gin.RouterGroup) {
 	group.GET(":eventId", middleware.UseAuthorization("event.get"), s.getEvent)
 	group.PATCH(":eventId", middleware.UseAuthorization("event.edit"), s.updateEvent)
 	group.POST(":eventId/end", middleware.UseAuthorization("event.end"), s.endEvent)
+	group.PUT(":eventId/placements", middleware.UseAuthorization("event.end"), s.setPlacements)
 	group.POST(
 		":eventId/restart",
 		middleware.UseAuthorization("event.restart"),
@@ -377,6 +379,58 @@ func (controller *eventsController) validateAndCreateEventUpdateMap(
 				return nil, errors.New("pointsMultiplier must be a non-negative number")
 			}
 			updateMap["points_multiplier"] = float32(floatValue)
+		case "knockoutPoints":
+			if value == nil {
+				return nil, errors.New("knockoutPoints cannot be null")
+			}
+			floatValue, ok := value.(float64)
+			if !ok || floatValue != math.Trunc(floatValue) {
+				return nil, errors.New("knockoutPoints must be a whole number")
+			}
+			if floatValue < 0 || floatValue > math.MaxInt32 {
+				return nil, errors.New("knockoutPoints must be a non-negative whole number")
+			}
+			updateMap["knockout_points"] = int32(floatValue)
+		case "tableSize":
+			if value == nil {
+				return nil, errors.New("tableSize cannot be null")
+			}
+			floatValue, ok := value.(float64)
+			if !ok || floatValue != math.Trunc(floatValue) {
+				return nil, errors.New("tableSize must be a whole number")
+			}
+			if floatValue < models.MinTableSize || floatValue > models.MaxTableSize {
+				return nil, fmt.Errorf("tableSize must be between %d and %d", models.MinTableSize, models.MaxTableSize)
+			}
+			updateMap["table_size"] = uint8(floatValue)
+		case "maxRebuys":
+			// null removes the limit
+			if value != nil {
+				floatValue, ok := value.(float64)
+				if !ok || floatValue != math.Trunc(floatValue) {
+					return nil, errors.New("maxRebuys must be a whole number")
+				}
+				if floatValue < 0 || floatValue > math.MaxUint8 {
+					return nil, fmt.Errorf("maxRebuys must be between 0 and %d", math.MaxUint8)
+				}
+				updateMap["max_rebuys"] = uint8(floatValue)
+			} else {
+				updateMap["max_rebuys"] = nil
+			}
+		case "maxEntries":
+			// null removes the limit
+			if value != nil {
+				floatValue, ok := value.(float64)
+				if !ok || floatValue != math.Trunc(floatValue) {
+					return nil, errors.New("maxEntries must be a whole number")
+				}
+				if floatValue < 1 || floatValue > math.MaxUint16 {
+					return nil, fmt.Errorf("maxEntries must be between 1 and %d", math.MaxUint16)
+				}
+				updateMap["max_entries"] = uint16(floatValue)
+			} else {
+				updateMap["max_entries"] = nil
+			}
 		default:
 			return nil, fmt.Errorf(
 				"failed to validate event update request: unknown field: %s",
@@ -392,7 +446,7 @@ func (controller *eventsController) validateAndCreateEventUpdateMap(
 // It expects the semester ID in the URL path and the event ID as a URL parameter.
 //
 // @Summary End Event
-// @Description End an existing event
+// @Description End an existing event. Entries with a placement override take it, and the others are placed by the order they signed out in, with entries that signed out at the same time tied. Overrides which do not fit in the event are rejected.
 // @Tags Events
 // @Accept json
 // @Produce json
@@ -444,6 +498,70 @@ func (s *eventsController) endEvent(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// setPlacements handles setting the placement overrides of an event.
+// It expects a SetPlacementsRequest in the request body and returns the
+// placements of every entry, ordered by placement.
+//
+// @Summary Set Event Placements
+// @Description Replace the placement overrides of an event. Entries overridden to the same placement are tied and split the points of the placements they share, and entries without an override are placed by the order they signed out in. Placements of an ended event are corrected, and the rankings are updated.
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Param placements body SetPlacementsRequest true "Placement overrides"
+// @Success 200 {array} EventPlacement
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/placements [put]
+func (s *eventsController) setPlacements(ctx *gin.Context) {
+	semesterID, err := uuid.Parse(ctx.Param("semesterId"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			apierrors.InvalidRequest(
+				fmt.Sprintf("Semester ID '%s' is not a valid UUID", ctx.Param("semesterId")),
+			),
+		)
+		return
+	}
+
+	eventID, err := strconv.ParseInt(ctx.Param("eventId"), 10, 32)
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			apierrors.InvalidRequest(
+				fmt.Sprintf("Event ID '%s' is not a valid integer", ctx.Param("eventId")),
+			),
+		)
+		return
+	}
+
+	var req models.SetPlacementsRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	svc := services.NewEventService(s.db)
+	placements, err := svc.SetPlacements(semesterID, int32(eventID), &req)
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			apierrors.InternalServerError(err.Error()),
+		)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, placements)
+}
+
 // restartEvent handles the restarting of an event.
 // It expects the semester ID in the URL path and the event ID as a URL parameter.
 //
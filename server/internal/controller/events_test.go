@@ -78,6 +78,10 @@ func TestCreateEvent(t *testing.T) {
 				"structureId":      float64(structure.ID),
 				"state":            float64(models.EventStateStarted),
 				"rebuys":           float64(0),
+				"knockoutPoints":   float64(0),
+				"tableSize":        float64(models.DefaultTableSize),
+				"maxRebuys":        nil,
+				"maxEntries":       nil,
 				"pointsMultiplier": 1.0,
 			},
 		},
@@ -103,6 +107,10 @@ func TestCreateEvent(t *testing.T) {
 				"structureId":      float64(structure.ID),
 				"state":            float64(models.EventStateStarted),
 				"rebuys":           float64(0),
+				"knockoutPoints":   float64(0),
+				"tableSize":        float64(models.DefaultTableSize),
+				"maxRebuys":        nil,
+				"maxEntries":       nil,
 				"pointsMultiplier": 1.5,
 			},
 		},
@@ -300,6 +308,8 @@ func TestCreateEvent(t *testing.T) {
 				// Set the actual ID and startDate in the expected response (to handle timezone conversion)
 				tc.expectedResponse["id"] = actualResponse["id"]
 				tc.expectedResponse["startDate"] = actualResponse["startDate"]
+				// The current version of the structure is pinned when the event is created
+				tc.expectedResponse["structureVersionId"] = actualResponse["structureVersionId"]
 
 				testutils.AssertSuccessResponse(t, w, tc.expectedStatus, tc.expectedResponse)
 			}
@@ -685,6 +695,42 @@ func TestUpdateEvent(t *testing.T) {
 			setupEvent:       true,
 			useEventID:       "1",
 		},
+		{
+			name:     "successful update with knockoutPoints",
+			userRole: authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+			requestBody: map[string]any{
+				"knockoutPoints": float64(3),
+			},
+			expectedStatus:   http.StatusOK,
+			expectError:      false,
+			expectedResponse: nil, // Will be set dynamically
+			setupEvent:       true,
+			useEventID:       "1",
+		},
+		{
+			name:     "knockoutPoints must be a whole number",
+			userRole: authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+			requestBody: map[string]any{
+				"knockoutPoints": 1.5,
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectError:          true,
+			expectedErrorMessage: "Error converting request to update map: knockoutPoints must be a whole number",
+			setupEvent:           true,
+			useEventID:           "1",
+		},
+		{
+			name:     "knockoutPoints cannot be negative",
+			userRole: authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+			requestBody: map[string]any{
+				"knockoutPoints": float64(-2),
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectError:          true,
+			expectedErrorMessage: "Error converting request to update map: knockoutPoints must be a non-negative whole number",
+			setupEvent:           true,
+			useEventID:           "1",
+		},
 		{
 			name:     "pointsMultiplier cannot be null",
 			userRole: authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
@@ -801,11 +847,16 @@ func TestUpdateEvent(t *testing.T) {
 						"startDate": originalEvent.StartDate.Format(
 							"2006-01-02T15:04:05Z07:00",
 						),
-						"state":            float64(originalEvent.State),
-						"rebuys":           float64(originalEvent.Rebuys),
-						"pointsMultiplier": originalEvent.PointsMultiplier,
-						"structureId":      float64(originalEvent.StructureID),
-						"structure":        structureMap,
+						"state":              float64(originalEvent.State),
+						"rebuys":             float64(originalEvent.Rebuys),
+						"pointsMultiplier":   originalEvent.PointsMultiplier,
+						"knockoutPoints":     float64(originalEvent.KnockoutPoints),
+						"tableSize":          float64(models.DefaultTableSize),
+						"maxRebuys":          nil,
+						"maxEntries":         nil,
+						"structureId":        float64(originalEvent.StructureID),
+						"structureVersionId": nil,
+						"structure":          structureMap,
 					}
 
 					// Apply updates from request body
@@ -829,6 +880,9 @@ func TestUpdateEvent(t *testing.T) {
 						if pointsMultiplier, ok := tc.requestBody["pointsMultiplier"]; ok && pointsMultiplier != nil {
 							tc.expectedResponse["pointsMultiplier"] = pointsMultiplier
 						}
+						if knockoutPoints, ok := tc.requestBody["knockoutPoints"]; ok && knockoutPoints != nil {
+							tc.expectedResponse["knockoutPoints"] = knockoutPoints
+						}
 					}
 				}
 			}
@@ -1014,18 +1068,23 @@ func TestGetEvent(t *testing.T) {
 					require.NoError(t, err)
 
 					tc.expectedResponse = map[string]any{
-						"id":               event.ID,
-						"name":             event.Name,
-						"format":           event.Format,
-						"notes":            event.Notes,
-						"semesterId":       event.SemesterID.String(),
-						"semester":         semesterMap,
-						"startDate":        event.StartDate.Format("2006-01-02T15:04:05Z07:00"),
-						"state":            float64(event.State),
-						"rebuys":           float64(event.Rebuys),
-						"pointsMultiplier": event.PointsMultiplier,
-						"structureId":      float64(event.StructureID),
-						"structure":        structureMap,
+						"id":                 event.ID,
+						"name":               event.Name,
+						"format":             event.Format,
+						"notes":              event.Notes,
+						"semesterId":         event.SemesterID.String(),
+						"semester":           semesterMap,
+						"startDate":          event.StartDate.Format("2006-01-02T15:04:05Z07:00"),
+						"state":              float64(event.State),
+						"rebuys":             float64(event.Rebuys),
+						"pointsMultiplier":   event.PointsMultiplier,
+						"knockoutPoints":     float64(event.KnockoutPoints),
+						"tableSize":          float64(models.DefaultTableSize),
+						"maxRebuys":          nil,
+						"maxEntries":         nil,
+						"structureId":        float64(event.StructureID),
+						"structureVersionId": nil,
+						"structure":          structureMap,
 					}
 				}
 			}
@@ -1200,6 +1259,176 @@ func TestEndEvent(t *testing.T) {
 	}
 }
 
+func TestSetPlacements(t *testing.T) {
+	t.Parallel()
+
+	// Setup test database and API server once
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	// Setting placements requires the same roles as ending an event
+	unauthorizedRoles := []string{"bot", "executive", "tournament_director"}
+	testutils.TestInvalidAuthForEndpoint(
+		t,
+		container,
+		apiServer,
+		"PUT",
+		fmt.Sprintf("/api/v2/semesters/%s/events/2/placements", testutils.TEST_SEMESTERS[0].ID),
+		unauthorizedRoles,
+	)
+
+	placement := func(membershipIndex int, placement int) map[string]any {
+		return map[string]any{
+			"membershipId": testutils.TEST_MEMBERSHIPS[membershipIndex].ID.String(),
+			"placement":    placement,
+		}
+	}
+
+	testCases := []struct {
+		name               string
+		semesterID         string
+		eventID            string
+		placements         []map[string]any
+		expectedStatus     int
+		expectedErrorMsg   string
+		expectedPlacements map[int]uint16 // membership index to placement
+		expectedTied       bool
+	}{
+		{
+			name:             "invalid semester ID format",
+			semesterID:       "invalid-uuid",
+			eventID:          "2",
+			placements:       []map[string]any{},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "Semester ID 'invalid-uuid' is not a valid UUID",
+		},
+		{
+			name:             "invalid event ID format",
+			semesterID:       testutils.TEST_SEMESTERS[0].ID.String(),
+			eventID:          "invalid-id",
+			placements:       []map[string]any{},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "Event ID 'invalid-id' is not a valid integer",
+		},
+		{
+			name:             "event in another semester",
+			semesterID:       testutils.TEST_SEMESTERS[1].ID.String(),
+			eventID:          "2",
+			placements:       []map[string]any{},
+			expectedStatus:   http.StatusNotFound,
+			expectedErrorMsg: "Event not found",
+		},
+		{
+			name:           "membership without an entry",
+			semesterID:     testutils.TEST_SEMESTERS[0].ID.String(),
+			eventID:        "2",
+			placements:     []map[string]any{placement(1, 1)},
+			expectedStatus: http.StatusNotFound,
+			expectedErrorMsg: fmt.Sprintf(
+				"Membership %s has no entry in this event", testutils.TEST_MEMBERSHIPS[1].ID,
+			),
+		},
+		{
+			name:             "placement past the event size",
+			semesterID:       testutils.TEST_SEMESTERS[0].ID.String(),
+			eventID:          "2",
+			placements:       []map[string]any{placement(2, 3)},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "Placement 3 is outside the 2 entries of the event",
+		},
+		{
+			name:             "membership placed twice",
+			semesterID:       testutils.TEST_SEMESTERS[0].ID.String(),
+			eventID:          "2",
+			placements:       []map[string]any{placement(2, 1), placement(2, 2)},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: fmt.Sprintf("Membership %s is placed more than once", testutils.TEST_MEMBERSHIPS[2].ID),
+		},
+		{
+			name:               "chop in a running event",
+			semesterID:         testutils.TEST_SEMESTERS[0].ID.String(),
+			eventID:            "2",
+			placements:         []map[string]any{placement(0, 1), placement(2, 1)},
+			expectedStatus:     http.StatusOK,
+			expectedPlacements: map[int]uint16{0: 1, 2: 1},
+			expectedTied:       true,
+		},
+		{
+			name:               "correct an ended event",
+			semesterID:         testutils.TEST_SEMESTERS[0].ID.String(),
+			eventID:            "1",
+			placements:         []map[string]any{placement(1, 1)},
+			expectedStatus:     http.StatusOK,
+			expectedPlacements: map[int]uint16{0: 2, 1: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, container.ResetDatabase(ctx))
+			require.NoError(t, testutils.SeedAll(db))
+
+			sessionID, err := testutils.CreateTestSession(db, "testuser", authorization.ROLE_SECRETARY.ToString())
+			require.NoError(t, err)
+
+			req, err := testutils.MakeJSONRequest(
+				"PUT",
+				fmt.Sprintf("/api/v2/semesters/%s/events/%s/placements", tc.semesterID, tc.eventID),
+				map[string]any{"placements": tc.placements},
+			)
+			require.NoError(t, err)
+
+			testutils.SetAuthCookie(req, sessionID)
+
+			w := httptest.NewRecorder()
+			apiServer.ServeHTTP(w, req)
+
+			if tc.expectedErrorMsg != "" {
+				testutils.AssertErrorResponse(t, w, tc.expectedStatus, tc.expectedErrorMsg)
+				return
+			}
+
+			require.Equal(t, tc.expectedStatus, w.Code)
+
+			var placements []models.EventPlacement
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &placements))
+			require.Len(t, placements, len(tc.expectedPlacements))
+			for index, want := range tc.expectedPlacements {
+				membershipID := testutils.TEST_MEMBERSHIPS[index].ID
+				found := false
+				for _, placed := range placements {
+					if placed.MembershipID != nil && *placed.MembershipID == membershipID {
+						found = true
+						require.Equal(t, want, placed.Placement, "placement of membership %d", index)
+						require.Equal(t, tc.expectedTied, placed.Tied, "tie of membership %d", index)
+					}
+				}
+				require.True(t, found, "membership %d is placed", index)
+			}
+
+			// Placements of ended events are saved right away
+			eventID, err := strconv.ParseInt(tc.eventID, 10, 32)
+			require.NoError(t, err)
+			event, err := testutils.FindEventById(int32(eventID))
+			require.NoError(t, err)
+			if event.State == models.EventStateEnded {
+				for index, want := range tc.expectedPlacements {
+					var entry models.Participant
+					require.NoError(t, db.
+						Where("event_id = ? AND membership_id = ?", eventID, testutils.TEST_MEMBERSHIPS[index].ID).
+						First(&entry).Error)
+					require.Equal(t, want, entry.Placement, "saved placement of membership %d", index)
+				}
+			}
+		})
+	}
+}
+
 func TestRestartEvent(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,272 @@
+package controller
+
+import (
+	apierrors "api/internal/errors"
+	"api/internal/models"
+	"api/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// validateGuestPath validates the semester, event and guest entry IDs from
+// the path. It aborts the request and returns false if any is invalid.
+func (c *entriesController) validateGuestPath(ctx *gin.Context) (uuid.UUID, int32, int32, bool) {
+	semesterID, err := c.validateSemesterID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		return uuid.Nil, 0, 0, false
+	}
+
+	eventID, err := c.validateEventID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		return uuid.Nil, 0, 0, false
+	}
+
+	guestIDStr := ctx.Param("guestId")
+	guestID, err := strconv.ParseInt(guestIDStr, 10, 32)
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			apierrors.InvalidRequest(
+				fmt.Sprintf("Guest ID '%s' is not a valid integer", guestIDStr),
+			),
+		)
+		return uuid.Nil, 0, 0, false
+	}
+
+	return semesterID, eventID, int32(guestID), true
+}
+
+// createGuestEntry handles entering a walk-in player without a membership.
+//
+// @Summary Create Guest Entry
+// @Description Enter a walk-in player without a membership. Guests count towards the size of the event but do not earn ranking points
+// @Tags Entries
+// @Accept json
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Param guest body CreateGuestEntryRequest true "Name of the guest"
+// @Success 201 {object} Participant
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/guests [post]
+func (c *entriesController) createGuestEntry(ctx *gin.Context) {
+	if _, err := c.validateSemesterID(ctx); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		return
+	}
+
+	eventID, err := c.validateEventID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		return
+	}
+
+	var req models.CreateGuestEntryRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	svc := services.NewParticipantsService(c.db)
+	participant, err := svc.CreateGuestParticipant(eventID, &req)
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			apierrors.InternalServerError(err.Error()),
+		)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, participant)
+}
+
+// signOutGuestEntry handles signing out a guest from an event.
+//
+// @Summary Sign Out Guest Entry
+// @Description Sign out a guest from an event, optionally recording the entry that knocked them out
+// @Tags Entries
+// @Accept json
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Param guestId path int true "Guest entry ID"
+// @Param knockout body SignOutEntryRequest false "Entry that knocked the player out"
+// @Success 200 {object} Participant
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/guests/{guestId}/sign-out [post]
+func (c *entriesController) signOutGuestEntry(ctx *gin.Context) {
+	_, eventID, guestID, ok := c.validateGuestPath(ctx)
+	if !ok {
+		return
+	}
+
+	// The body is optional, and records who eliminated the player
+	var body models.SignOutEntryRequest
+	if ctx.Request.ContentLength != 0 && !BindJSON(ctx, &body) {
+		return
+	}
+
+	c.updateGuestEntry(ctx, &models.UpdateParticipantRequest{
+		GuestID:             guestID,
+		EventID:             eventID,
+		SignOut:             true,
+		KnockedOutBy:        body.KnockedOutBy,
+		KnockedOutByEntryID: body.KnockedOutByEntry,
+	})
+}
+
+// signInGuestEntry handles signing a guest back in to an event.
+//
+// @Summary Sign In Guest Entry
+// @Description Sign a guest back in to an event
+// @Tags Entries
+// @Accept json
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Param guestId path int true "Guest entry ID"
+// @Success 200 {object} Participant
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/guests/{guestId}/sign-in [post]
+func (c *entriesController) signInGuestEntry(ctx *gin.Context) {
+	_, eventID, guestID, ok := c.validateGuestPath(ctx)
+	if !ok {
+		return
+	}
+
+	c.updateGuestEntry(ctx, &models.UpdateParticipantRequest{
+		GuestID: guestID,
+		EventID: eventID,
+		SignIn:  true,
+	})
+}
+
+// updateGuestEntry signs a guest in or out and writes the updated entry
+func (c *entriesController) updateGuestEntry(ctx *gin.Context, req *models.UpdateParticipantRequest) {
+	svc := services.NewParticipantsService(c.db)
+	participant, err := svc.UpdateParticipant(req)
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			apierrors.InternalServerError(err.Error()),
+		)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, participant)
+}
+
+// deleteGuestEntry handles removing a guest from an event.
+//
+// @Summary Delete Guest Entry
+// @Description Remove a guest from an event
+// @Tags Entries
+// @Accept json
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Param guestId path int true "Guest entry ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/guests/{guestId} [delete]
+func (c *entriesController) deleteGuestEntry(ctx *gin.Context) {
+	_, eventID, guestID, ok := c.validateGuestPath(ctx)
+	if !ok {
+		return
+	}
+
+	svc := services.NewParticipantsService(c.db)
+	err := svc.DeleteParticipant(&models.DeleteParticipantRequest{
+		GuestID: guestID,
+		EventID: eventID,
+	})
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			apierrors.InternalServerError(err.Error()),
+		)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// convertGuestEntry handles giving a guest entry to the membership the player
+// took out afterwards.
+//
+// @Summary Convert Guest Entry
+// @Description Give a guest entry to a membership of the semester. If the event has ended, the membership earns the points of the entry
+// @Tags Entries
+// @Accept json
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Param guestId path int true "Guest entry ID"
+// @Param membership body ConvertGuestEntryRequest true "Membership of the player"
+// @Success 200 {object} Participant
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/guests/{guestId}/convert [post]
+func (c *entriesController) convertGuestEntry(ctx *gin.Context) {
+	semesterID, eventID, guestID, ok := c.validateGuestPath(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.ConvertGuestEntryRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	svc := services.NewParticipantsService(c.db)
+	participant, err := svc.ConvertGuestParticipant(semesterID, eventID, guestID, &req)
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			apierrors.InternalServerError(err.Error()),
+		)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, participant)
+}
@@ -0,0 +1,253 @@
+package controller
+
+import (
+	"api/internal/authentication"
+	apierrors "api/internal/errors"
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type kioskController struct {
+	db *gorm.DB
+	// checkInLimiter throttles failed check-ins of each kiosk token
+	checkInLimiter *authentication.LoginLimiter
+}
+
+// NewKioskController creates a new instance of kioskController
+func NewKioskController(db *gorm.DB, checkInLimiter *authentication.LoginLimiter) Controller {
+	return &kioskController{db: db, checkInLimiter: checkInLimiter}
+}
+
+func (c *kioskController) LoadRoutes(router *gin.RouterGroup) {
+	tokens := router.Group("semesters/:semesterId/events/:eventId/kiosk-tokens", middleware.UseAuthentication(c.db))
+	tokens.GET("", middleware.UseAuthorization("event.kiosk.list"), c.listKioskTokens)
+	tokens.POST("", middleware.UseAuthorization("event.kiosk.create"), c.createKioskToken)
+	tokens.DELETE(":tokenId", middleware.UseAuthorization("event.kiosk.delete"), c.revokeKioskToken)
+
+	// Kiosks authenticate with their token and may only check in to its event
+	kiosk := router.Group("kiosk", middleware.UseKioskAuthentication(c.db))
+	kiosk.GET("event", c.getKioskEvent)
+	kiosk.POST("check-in", c.checkIn)
+}
+
+// validateParams validates the semester and event IDs from the path. It aborts
+// the request and returns false if either is invalid.
+func (c *kioskController) validateParams(ctx *gin.Context) (uuid.UUID, int32, bool) {
+	semesterID, err := validateSemesterID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		return uuid.Nil, 0, false
+	}
+
+	eventIDStr := ctx.Param("eventId")
+	eventID, err := strconv.ParseInt(eventIDStr, 10, 32)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(
+			fmt.Sprintf("Event ID '%s' is not a valid integer", eventIDStr),
+		))
+		return uuid.Nil, 0, false
+	}
+
+	return semesterID, int32(eventID), true
+}
+
+// respond writes the response, or the error returned by the kiosk services.
+func (c *kioskController) respond(ctx *gin.Context, status int, response any, err error) {
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	if response == nil {
+		ctx.Status(status)
+		return
+	}
+
+	ctx.JSON(status, response)
+}
+
+// listKioskTokens handles listing the kiosk tokens of an event
+//
+// @Summary List kiosk tokens
+// @Description List the self check-in kiosk tokens of an event, newest first. Revoked tokens are included.
+// @Tags Kiosk
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path int true "Event ID"
+// @Success 200 {array} KioskToken
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/kiosk-tokens [get]
+func (c *kioskController) listKioskTokens(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateParams(ctx)
+	if !ok {
+		return
+	}
+
+	kioskTokens, err := authentication.NewKioskTokenManager(c.db).List(semesterID, eventID)
+	c.respond(ctx, http.StatusOK, kioskTokens, err)
+}
+
+// createKioskToken handles creating a kiosk token for an event
+//
+// @Summary Create a kiosk token
+// @Description Create a token for a self check-in kiosk of an event. The token is only returned once, and stops working when the event ends.
+// @Tags Kiosk
+// @Accept json
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path int true "Event ID"
+// @Param request body CreateKioskTokenRequest true "Kiosk token details"
+// @Success 201 {object} CreateKioskTokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/kiosk-tokens [post]
+func (c *kioskController) createKioskToken(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.CreateKioskTokenRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	kioskToken, err := authentication.NewKioskTokenManager(c.db).Create(semesterID, eventID, ctx.GetString("username"), &req)
+	c.respond(ctx, http.StatusCreated, kioskToken, err)
+}
+
+// revokeKioskToken handles revoking a kiosk token
+//
+// @Summary Revoke a kiosk token
+// @Description Revoke a kiosk token so that it can no longer be used. The token is kept in the list of tokens of the event.
+// @Tags Kiosk
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path int true "Event ID"
+// @Param tokenId path string true "Kiosk token ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/kiosk-tokens/{tokenId} [delete]
+func (c *kioskController) revokeKioskToken(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateParams(ctx)
+	if !ok {
+		return
+	}
+
+	tokenID, err := uuid.Parse(ctx.Param("tokenId"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(fmt.Sprintf("Kiosk token ID '%s' is not a valid UUID", ctx.Param("tokenId"))))
+		return
+	}
+
+	err = authentication.NewKioskTokenManager(c.db).Revoke(semesterID, eventID, tokenID)
+	c.respond(ctx, http.StatusNoContent, nil, err)
+}
+
+// getKioskEvent handles retrieving the event of a kiosk
+//
+// @Summary Get kiosk event
+// @Description Get the event that the kiosk checks members in to. Requires a kiosk token as a bearer token.
+// @Tags Kiosk
+// @Produce json
+// @Success 200 {object} KioskEventResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /kiosk/event [get]
+func (c *kioskController) getKioskEvent(ctx *gin.Context) {
+	event := ctx.MustGet("kioskEvent").(*models.Event)
+
+	ctx.JSON(http.StatusOK, models.KioskEventResponse{
+		ID:        event.ID,
+		Name:      event.Name,
+		StartDate: event.StartDate,
+		State:     event.State,
+	})
+}
+
+// checkIn handles a member checking in at a kiosk
+//
+// @Summary Check in at a kiosk
+// @Description Enter a member into the event of the kiosk by their student ID or QuestID, and tell them where to sit. The member must hold a membership for the semester of the event, and is added to the waitlist when the event is full. Check-ins which do not match a membership are throttled for each kiosk token. Requires a kiosk token as a bearer token.
+// @Tags Kiosk
+// @Accept json
+// @Produce json
+// @Param request body KioskCheckInRequest true "Student ID or QuestID of the member"
+// @Success 200 {object} KioskCheckInResponse "The member was already entered"
+// @Success 201 {object} KioskCheckInResponse "The member was entered"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /kiosk/check-in [post]
+func (c *kioskController) checkIn(ctx *gin.Context) {
+	var req models.KioskCheckInRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	// Refuse check-ins from kiosks with too many failures before looking the
+	// member up
+	tokenID := ctx.MustGet("kioskTokenId").(uuid.UUID).String()
+	wait, err := c.checkInLimiter.Check(tokenID, ctx.ClientIP())
+	if err != nil {
+		c.respond(ctx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	if wait > 0 {
+		seconds := int(math.Ceil(wait.Seconds()))
+		ctx.Header("Retry-After", strconv.Itoa(seconds))
+		ctx.AbortWithStatusJSON(
+			http.StatusTooManyRequests,
+			apierrors.TooManyRequests(fmt.Sprintf("Too many failed check-ins. Try again in %d seconds", seconds)),
+		)
+		return
+	}
+
+	event := ctx.MustGet("kioskEvent").(*models.Event)
+	checkIn, err := services.NewKioskService(c.db).CheckIn(event, &req)
+	if err == services.ErrKioskMemberNotFound {
+		// Lockouts of kiosks are not recorded in the audit log, which only
+		// tracks failed logins
+		if _, err := c.checkInLimiter.RecordFailure(tokenID, ctx.ClientIP()); err != nil {
+			c.respond(ctx, http.StatusInternalServerError, nil, err)
+			return
+		}
+	}
+
+	status := http.StatusCreated
+	if err == nil && checkIn.AlreadyCheckedIn {
+		status = http.StatusOK
+	}
+
+	// Record check-ins in the audit log against the kiosk that made them
+	ctx.Set("action", "event.kiosk.checkin")
+	c.respond(ctx, status, checkIn, err)
+}
@@ -0,0 +1,207 @@
+package controller_test
+
+import (
+	"api/internal/authorization"
+	"api/internal/models"
+	"api/internal/services"
+	"api/internal/testutils"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKiosk(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	semesterID := testutils.TEST_SEMESTERS[0].ID
+	tokensPath := fmt.Sprintf("/api/v2/semesters/%s/events/2/kiosk-tokens", semesterID)
+
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "POST", tokensPath, []string{"bot", "executive"})
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", tokensPath, []string{"bot", "executive"})
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedAll(db))
+
+	sessionID, err := testutils.CreateTestSession(db, "director", authorization.ROLE_TOURNAMENT_DIRECTOR.ToString())
+	require.NoError(t, err)
+
+	do := func(method string, path string, body any) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, body)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	doWithToken := func(method string, path string, token string, body any) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, body)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	var created models.CreateKioskTokenResponse
+	t.Run("create", func(t *testing.T) {
+		w := do("POST", tokensPath, map[string]any{"name": "Front desk"})
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+		require.Regexp(t, "^uwpsck_[0-9a-f]{64}$", created.Token)
+		require.Equal(t, created.Token[:13], created.Prefix)
+		require.Equal(t, int32(2), created.EventID)
+		require.Equal(t, "director", created.CreatedBy)
+
+		var stored models.KioskToken
+		require.NoError(t, db.Where("id = ?", created.ID).First(&stored).Error)
+		require.NotContains(t, w.Body.String(), stored.TokenHash)
+	})
+
+	t.Run("create rejects", func(t *testing.T) {
+		w := do("POST", fmt.Sprintf("/api/v2/semesters/%s/events/1/kiosk-tokens", semesterID), map[string]any{"name": "Kiosk"})
+		testutils.AssertErrorResponse(t, w, http.StatusForbidden, "Kiosk tokens cannot be created for an event that has ended")
+
+		w = do("POST", fmt.Sprintf("/api/v2/semesters/%s/events/3/kiosk-tokens", semesterID), map[string]any{"name": "Kiosk"})
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Event not found")
+	})
+
+	t.Run("list", func(t *testing.T) {
+		w := do("GET", tokensPath, nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var tokens []models.KioskToken
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+		require.Len(t, tokens, 1)
+		require.Equal(t, created.ID, tokens[0].ID)
+	})
+
+	t.Run("kiosk requires a token", func(t *testing.T) {
+		w := doWithToken("GET", "/api/v2/kiosk/event", "", nil)
+		testutils.AssertErrorResponse(t, w, http.StatusUnauthorized, "A kiosk token is required")
+
+		w = doWithToken("GET", "/api/v2/kiosk/event", "uwpsck_nope", nil)
+		testutils.AssertErrorResponse(t, w, http.StatusUnauthorized, "Invalid kiosk token")
+
+		// Sessions cannot use the kiosk
+		w = do("GET", "/api/v2/kiosk/event", nil)
+		testutils.AssertErrorResponse(t, w, http.StatusUnauthorized, "A kiosk token is required")
+
+		// Kiosk tokens cannot use the rest of the API
+		w = doWithToken("GET", "/api/v2/semesters", created.Token, nil)
+		testutils.AssertErrorResponse(t, w, http.StatusUnauthorized, "Invalid API key")
+	})
+
+	t.Run("event", func(t *testing.T) {
+		w := doWithToken("GET", "/api/v2/kiosk/event", created.Token, nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var event models.KioskEventResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &event))
+		require.Equal(t, int32(2), event.ID)
+		require.Equal(t, testutils.TEST_EVENTS[1].Name, event.Name)
+	})
+
+	t.Run("check in by QuestID", func(t *testing.T) {
+		w := doWithToken("POST", "/api/v2/kiosk/check-in", created.Token, map[string]any{"questId": "JSmith"})
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		var checkIn models.KioskCheckInResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &checkIn))
+		require.Equal(t, testutils.TEST_USERS[1].FirstName, checkIn.FirstName)
+		require.NotContains(t, w.Body.String(), testutils.TEST_USERS[1].LastName)
+		require.False(t, checkIn.AlreadyCheckedIn)
+		require.NotNil(t, checkIn.TableNumber)
+		require.NotNil(t, checkIn.SeatNumber)
+
+		var entry models.Participant
+		require.NoError(t, db.Where("event_id = ? AND membership_id = ?", 2, testutils.TEST_MEMBERSHIPS[1].ID).First(&entry).Error)
+		require.Equal(t, checkIn.TableNumber, entry.TableNumber)
+	})
+
+	t.Run("check in twice by student ID", func(t *testing.T) {
+		w := doWithToken("POST", "/api/v2/kiosk/check-in", created.Token, map[string]any{"studentId": testutils.TEST_USERS[1].ID})
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var checkIn models.KioskCheckInResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &checkIn))
+		require.True(t, checkIn.AlreadyCheckedIn)
+
+		var entries int64
+		require.NoError(t, db.Model(&models.Participant{}).Where("event_id = ? AND membership_id = ?", 2, testutils.TEST_MEMBERSHIPS[1].ID).Count(&entries).Error)
+		require.EqualValues(t, 1, entries)
+	})
+
+	t.Run("check in rejects", func(t *testing.T) {
+		w := doWithToken("POST", "/api/v2/kiosk/check-in", created.Token, map[string]any{})
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "Enter either a student ID or a QuestID")
+
+		w = doWithToken("POST", "/api/v2/kiosk/check-in", created.Token, map[string]any{"studentId": testutils.TEST_USERS[1].ID, "questId": "jsmith"})
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "Enter either a student ID or a QuestID")
+
+		// Unknown students and students without a membership for the semester
+		// get the same error
+		w = doWithToken("POST", "/api/v2/kiosk/check-in", created.Token, map[string]any{"studentId": 1})
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "No membership for this semester was found with that student ID or QuestID. Please see an executive")
+
+		w = doWithToken("POST", "/api/v2/kiosk/check-in", created.Token, map[string]any{"questId": testutils.TEST_USERS[3].QuestID})
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "No membership for this semester was found with that student ID or QuestID. Please see an executive")
+	})
+
+	t.Run("failed check ins are throttled", func(t *testing.T) {
+		// Two check-ins have already failed, and five are allowed
+		for studentID := range 4 {
+			w := doWithToken("POST", "/api/v2/kiosk/check-in", created.Token, map[string]any{"studentId": studentID + 2})
+			testutils.AssertErrorResponse(t, w, http.StatusNotFound, "No membership for this semester was found with that student ID or QuestID. Please see an executive")
+		}
+
+		w := doWithToken("POST", "/api/v2/kiosk/check-in", created.Token, map[string]any{"questId": "jsmith"})
+		testutils.AssertErrorResponse(t, w, http.StatusTooManyRequests, "Too many failed check-ins. Try again in 1 seconds")
+		require.Equal(t, "1", w.Header().Get("Retry-After"))
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		w := do("POST", tokensPath, map[string]any{"name": "Side door"})
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		var revoked models.CreateKioskTokenResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &revoked))
+
+		w = do("DELETE", fmt.Sprintf("%s/%s", tokensPath, revoked.ID), nil)
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+		w = doWithToken("GET", "/api/v2/kiosk/event", revoked.Token, nil)
+		testutils.AssertErrorResponse(t, w, http.StatusUnauthorized, "Kiosk token has been revoked")
+	})
+
+	t.Run("ending the event invalidates tokens", func(t *testing.T) {
+		require.NoError(t, services.NewEventService(db).EndEvent(2))
+
+		w := doWithToken("POST", "/api/v2/kiosk/check-in", created.Token, map[string]any{"questId": "jdoe"})
+		testutils.AssertErrorResponse(t, w, http.StatusUnauthorized, "Kiosk token has been revoked")
+
+		// Tokens stay invalid if the end of the event is undone
+		require.NoError(t, services.NewEventService(db).UndoEndEvent(2))
+
+		w = doWithToken("GET", "/api/v2/kiosk/event", created.Token, nil)
+		testutils.AssertErrorResponse(t, w, http.StatusUnauthorized, "Kiosk token has been revoked")
+	})
+}
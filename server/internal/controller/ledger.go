@@ -0,0 +1,102 @@
+package controller
+
+import (
+	apierrors "api/internal/errors"
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ledgerController struct {
+	db *gorm.DB
+}
+
+// NewLedgerController creates a new instance of ledgerController
+func NewLedgerController(db *gorm.DB) Controller {
+	return &ledgerController{db: db}
+}
+
+func (c *ledgerController) LoadRoutes(router *gin.RouterGroup) {
+	ledger := router.Group("semesters/:semesterId/ledger", middleware.UseAuthentication(c.db))
+	ledger.GET("", middleware.UseAuthorization("semester.ledger.list"), c.listLedgerEntries)
+	ledger.GET("reconciliation", middleware.UseAuthorization("semester.ledger.get"), c.reconcileLedger)
+}
+
+// respond writes the response, or the error returned by the ledger service.
+func (c *ledgerController) respond(ctx *gin.Context, response any, err error) {
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// listLedgerEntries handles listing the budget ledger of a semester
+//
+// @Summary List ledger entries
+// @Description List the entries of the budget ledger of a semester, newest first. Every membership fee, rebuy, add-on and transaction is an entry in cents, and the balance is the starting budget plus every entry.
+// @Tags Ledger
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param limit query int false "Maximum number of entries to return"
+// @Param offset query int false "Number of entries to skip"
+// @Success 200 {object} LedgerResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/ledger [get]
+func (c *ledgerController) listLedgerEntries(ctx *gin.Context) {
+	semesterID, err := validateSemesterID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		return
+	}
+
+	pagination, err := models.ParsePagination(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	svc := services.NewLedgerService(c.db)
+	ledger, err := svc.ListEntries(semesterID, &pagination)
+	c.respond(ctx, ledger, err)
+}
+
+// reconcileLedger handles comparing the ledger balance with the legacy budget
+//
+// @Summary Reconcile ledger
+// @Description Compare the balance computed from the ledger with the legacy current budget of a semester. Drift is the legacy balance minus the ledger balance, and is usually left over from adjustments made before the ledger existed.
+// @Tags Ledger
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Success 200 {object} LedgerReconciliation
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/ledger/reconciliation [get]
+func (c *ledgerController) reconcileLedger(ctx *gin.Context) {
+	semesterID, err := validateSemesterID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		return
+	}
+
+	svc := services.NewLedgerService(c.db)
+	reconciliation, err := svc.Reconcile(semesterID)
+	c.respond(ctx, reconciliation, err)
+}
@@ -0,0 +1,138 @@
+package controller_test
+
+import (
+	"api/internal/authorization"
+	"api/internal/models"
+	"api/internal/testutils"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemesterLedger(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	semester := testutils.TEST_SEMESTERS[0]
+	semesterPath := fmt.Sprintf("/api/v2/semesters/%s", semester.ID)
+
+	unauthorizedRoles := []string{"bot", "executive", "tournament_director"}
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", semesterPath+"/ledger", unauthorizedRoles)
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", semesterPath+"/ledger/reconciliation", unauthorizedRoles)
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedAll(db))
+
+	sessionID, err := testutils.CreateTestSession(db, "webmaster", authorization.ROLE_WEBMASTER.ToString())
+	require.NoError(t, err)
+
+	do := func(method string, path string, body any) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, body)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	reconcile := func(t *testing.T) models.LedgerReconciliation {
+		w := do("GET", semesterPath+"/ledger/reconciliation", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var reconciliation models.LedgerReconciliation
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &reconciliation))
+		return reconciliation
+	}
+
+	startingCents := models.DollarsToCents(semester.StartingBudget)
+	membershipCents := int64(semester.MembershipFee) * 100
+	rebuyCents := int64(semester.RebuyFee) * 100
+
+	t.Run("empty ledger", func(t *testing.T) {
+		reconciliation := reconcile(t)
+		require.Equal(t, startingCents, reconciliation.BalanceCents)
+		require.False(t, reconciliation.Drifted)
+	})
+
+	t.Run("fees are recorded", func(t *testing.T) {
+		w := do("POST", semesterPath+"/memberships", map[string]any{"userId": testutils.TEST_USERS[3].ID, "paid": true})
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		w = do("POST", fmt.Sprintf("%s/events/2/entries/%s/rebuys", semesterPath, testutils.TEST_MEMBERSHIPS[2].ID), nil)
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		w = do("GET", semesterPath+"/ledger", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var ledger models.LedgerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &ledger))
+		require.Equal(t, int64(2), ledger.Total)
+		require.Equal(t, startingCents, ledger.StartingBudgetCents)
+		require.Equal(t, startingCents+membershipCents+rebuyCents, ledger.BalanceCents)
+
+		// Newest first
+		require.Equal(t, models.LedgerEntryTypeRebuy, ledger.Data[0].Type)
+		require.Equal(t, rebuyCents, ledger.Data[0].AmountCents)
+		require.NotNil(t, ledger.Data[0].EventID)
+		require.Equal(t, int32(2), *ledger.Data[0].EventID)
+		require.Equal(t, models.LedgerEntryTypeMembershipFee, ledger.Data[1].Type)
+		require.Equal(t, membershipCents, ledger.Data[1].AmountCents)
+		require.NotNil(t, ledger.Data[1].MembershipID)
+
+		reconciliation := reconcile(t)
+		require.False(t, reconciliation.Drifted)
+		require.Equal(t, reconciliation.BalanceCents, reconciliation.LegacyBalanceCents)
+		require.Equal(t, map[string]int64{
+			models.LedgerEntryTypeMembershipFee: membershipCents,
+			models.LedgerEntryTypeRebuy:         rebuyCents,
+		}, reconciliation.Totals)
+	})
+
+	t.Run("undo appends a reversing entry", func(t *testing.T) {
+		rebuysPath := fmt.Sprintf("%s/events/2/entries/%s/rebuys", semesterPath, testutils.TEST_MEMBERSHIPS[2].ID)
+		w := do("GET", rebuysPath, nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var rebuys []models.Rebuy
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rebuys))
+		require.Len(t, rebuys, 1)
+
+		w = do("DELETE", fmt.Sprintf("%s/%d", rebuysPath, rebuys[0].ID), nil)
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+		reconciliation := reconcile(t)
+		require.Equal(t, startingCents+membershipCents, reconciliation.BalanceCents)
+		require.Equal(t, int64(0), reconciliation.Totals[models.LedgerEntryTypeRebuy])
+		require.False(t, reconciliation.Drifted)
+
+		var count int64
+		require.NoError(t, db.Model(&models.LedgerEntry{}).Where("semester_id = ?", semester.ID).Count(&count).Error)
+		require.Equal(t, int64(3), count)
+	})
+
+	t.Run("drift from the legacy budget", func(t *testing.T) {
+		require.NoError(t, db.Exec("UPDATE semesters SET current_budget = current_budget + 1.25 WHERE id = ?", semester.ID).Error)
+
+		reconciliation := reconcile(t)
+		require.True(t, reconciliation.Drifted)
+		require.Equal(t, int64(125), reconciliation.DriftCents)
+	})
+
+	t.Run("semester not found", func(t *testing.T) {
+		w := do("GET", "/api/v2/semesters/00000000-0000-0000-0000-000000000000/ledger", nil)
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Semester not found")
+	})
+}
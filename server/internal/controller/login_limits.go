@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"api/internal/authentication"
+	e "api/internal/errors"
+	"api/internal/services"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AbortLoginThrottled responds to a login which must wait before it is
+// attempted, telling the client how long to wait in the Retry-After header.
+func AbortLoginThrottled(ctx *gin.Context, wait time.Duration) {
+	seconds := int(math.Ceil(wait.Seconds()))
+
+	ctx.Header("Retry-After", strconv.Itoa(seconds))
+	ctx.AbortWithStatusJSON(
+		http.StatusTooManyRequests,
+		e.TooManyRequests(fmt.Sprintf("Too many failed login attempts. Try again in %d seconds", seconds)),
+	)
+}
+
+// RecordLoginFailure counts a failed login for a username from the client's
+// IP address, and records any lockout it causes in the audit log.
+func RecordLoginFailure(ctx *gin.Context, db *gorm.DB, loginLimiter *authentication.LoginLimiter, username string) error {
+	lockouts, err := loginLimiter.RecordFailure(username, ctx.ClientIP())
+	if err != nil {
+		return err
+	}
+
+	auditSvc := services.NewAuditService(db)
+	for _, lockout := range lockouts {
+		event := lockout.AuditEvent(username, ctx.Request.Method, ctx.Request.URL.Path)
+		if err := auditSvc.RecordAuditEvent(&event); err != nil {
+			// The lockout applies even if it cannot be recorded
+			log.Printf("Failed to record login lockout of %s: %s", lockout.Value, err.Error())
+		}
+	}
+
+	return nil
+}
@@ -1,24 +1,28 @@
 package controller
 
 import (
+	"api/internal/authentication"
 	apierrors "api/internal/errors"
 	"api/internal/middleware"
 	"api/internal/models"
 	"api/internal/services"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type loginsController struct {
-	db *gorm.DB
+	db           *gorm.DB
+	loginLimiter *authentication.LoginLimiter
 }
 
 // NewLoginsController creates a new instance of loginsController
-func NewLoginsController(db *gorm.DB) Controller {
-	return &loginsController{db: db}
+func NewLoginsController(db *gorm.DB, loginLimiter *authentication.LoginLimiter) Controller {
+	return &loginsController{db: db, loginLimiter: loginLimiter}
 }
 
 func (c *loginsController) LoadRoutes(router *gin.RouterGroup) {
@@ -28,6 +32,13 @@ func (c *loginsController) LoadRoutes(router *gin.RouterGroup) {
 	logins.POST("", middleware.UseAuthorization("login.create"), c.createLogin)
 	logins.DELETE("/:username", middleware.UseAuthorization("login.delete"), c.deleteLogin)
 	logins.PATCH("/:username", middleware.UseAuthorization("login.edit"), c.updateLogin)
+	logins.DELETE("/:username/sessions", middleware.UseAuthorization("login.edit"), c.logoutLogin)
+	logins.DELETE("/:username/lockout", middleware.UseAuthorization("login.edit"), c.unlockLogin)
+	logins.POST("/:username/password-reset", middleware.UseAuthorization("login.edit"), c.createPasswordResetToken)
+	logins.DELETE("/:username/2fa", middleware.UseAuthorization("login.edit"), c.resetTwoFactor)
+	logins.GET("/:username/api-keys", middleware.UseAuthorization("login.apiKey.list"), c.listAPIKeys)
+	logins.POST("/:username/api-keys", middleware.UseAuthorization("login.apiKey.create"), c.createAPIKey)
+	logins.DELETE("/:username/api-keys/:keyId", middleware.UseAuthorization("login.apiKey.delete"), c.revokeAPIKey)
 }
 
 // listLogins handles listing all logins with linked member information
@@ -261,3 +272,212 @@ func (c *loginsController) updateLogin(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+// logoutLogin handles ending every session of a login
+//
+// @Summary Force logout a login
+// @Description End every session of a login, e.g. when it should no longer have access. Sessions are also ended when the password or role of a login is updated.
+// @Tags Logins
+// @Param username path string true "Login username"
+// @Success 204 "No Content"
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /logins/{username}/sessions [delete]
+func (c *loginsController) logoutLogin(ctx *gin.Context) {
+	svc := services.NewLoginService(c.db)
+	if err := svc.LogoutLogin(ctx.Param("username")); err != nil {
+		if errors.Is(err, services.ErrLoginNotFound) {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, apierrors.NotFound(err.Error()))
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// unlockLogin handles lifting the login lockout of a username
+//
+// @Summary Unlock a login
+// @Description Forget the failed logins of a username, lifting any delay or lockout on logging in as it. Lockouts of IP addresses are not lifted.
+// @Tags Logins
+// @Param username path string true "Login username"
+// @Success 204 "No Content"
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /logins/{username}/lockout [delete]
+func (c *loginsController) unlockLogin(ctx *gin.Context) {
+	if err := c.loginLimiter.Unlock(ctx.Param("username")); err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// createPasswordResetToken handles issuing a password reset token for a login
+//
+// @Summary Create a password reset token
+// @Description Issue a single-use token the user redeems with POST /session/password-reset to set a new password. The token is only returned once, and any unused token previously issued for the login stops working.
+// @Tags Logins
+// @Produce json
+// @Param username path string true "Login username"
+// @Success 201 {object} CreatePasswordResetTokenResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /logins/{username}/password-reset [post]
+func (c *loginsController) createPasswordResetToken(ctx *gin.Context) {
+	passwordManager := authentication.NewPasswordManager(c.db)
+	token, err := passwordManager.CreateResetToken(ctx.Param("username"), ctx.GetString("username"))
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, token)
+}
+
+// resetTwoFactor handles turning off two-factor authentication for a login
+//
+// @Summary Reset two-factor authentication
+// @Description Turn off two-factor authentication for a login, e.g. when its authenticator app and recovery codes are lost. If its role requires two-factor authentication, it must set it up again after logging in.
+// @Tags Logins
+// @Param username path string true "Login username"
+// @Success 204 "No Content"
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /logins/{username}/2fa [delete]
+func (c *loginsController) resetTwoFactor(ctx *gin.Context) {
+	twoFactorManager := authentication.NewTwoFactorManager(c.db)
+	if err := twoFactorManager.Reset(ctx.Param("username")); err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// listAPIKeys handles listing the API keys of a login
+//
+// @Summary List API keys
+// @Description List the API keys of a login, newest first, including revoked keys. The keys themselves are never returned.
+// @Tags Logins
+// @Produce json
+// @Param username path string true "Login username"
+// @Success 200 {array} APIKey
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /logins/{username}/api-keys [get]
+func (c *loginsController) listAPIKeys(ctx *gin.Context) {
+	svc := authentication.NewAPIKeyManager(c.db)
+	apiKeys, err := svc.List(ctx.Param("username"))
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, apiKeys)
+}
+
+// createAPIKey handles creating an API key for a bot login
+//
+// @Summary Create an API key
+// @Description Create a long-lived API key for a bot login, scoped to a list of actions such as event.participant.signin. Send it in an "Authorization: Bearer" header. The key is only returned in this response.
+// @Tags Logins
+// @Accept json
+// @Produce json
+// @Param username path string true "Login username"
+// @Param request body CreateAPIKeyRequest true "API key details"
+// @Success 201 {object} CreateAPIKeyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /logins/{username}/api-keys [post]
+func (c *loginsController) createAPIKey(ctx *gin.Context) {
+	var req models.CreateAPIKeyRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	svc := authentication.NewAPIKeyManager(c.db)
+	apiKey, err := svc.Create(ctx.Param("username"), &req)
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, apiKey)
+}
+
+// revokeAPIKey handles revoking an API key
+//
+// @Summary Revoke an API key
+// @Description Revoke an API key so that it can no longer be used. The key is kept in the list of keys of the login.
+// @Tags Logins
+// @Param username path string true "Login username"
+// @Param keyId path string true "API key ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /logins/{username}/api-keys/{keyId} [delete]
+func (c *loginsController) revokeAPIKey(ctx *gin.Context) {
+	keyID, err := uuid.Parse(ctx.Param("keyId"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(fmt.Sprintf("API key ID '%s' is not a valid UUID", ctx.Param("keyId"))))
+		return
+	}
+
+	svc := authentication.NewAPIKeyManager(c.db)
+	if err := svc.Revoke(ctx.Param("username"), keyID); err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -11,6 +11,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -704,3 +705,150 @@ func TestListLoginsSearch(t *testing.T) {
 		})
 	}
 }
+
+func TestLoginAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", "/api/v2/logins/discord/api-keys", loginsUnauthorizedRoles)
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "POST", "/api/v2/logins/discord/api-keys", loginsUnauthorizedRoles)
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "DELETE", "/api/v2/logins/discord/api-keys/00000000-0000-0000-0000-000000000000", loginsUnauthorizedRoles)
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedAll(db))
+	require.NoError(t, db.Create(&models.Login{Username: "discord", Password: "hash", Role: "bot"}).Error)
+	require.NoError(t, db.Create(&models.Login{Username: "alice", Password: "hash", Role: "executive"}).Error)
+
+	sessionID, err := testutils.CreateTestSession(db, "admin", authorization.ROLE_WEBMASTER.ToString())
+	require.NoError(t, err)
+
+	do := func(method string, path string, body any) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, body)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	doWithKey := func(method string, path string, header string) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", header)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	semesterID := testutils.TEST_SEMESTERS[0].ID
+	var created models.CreateAPIKeyResponse
+
+	t.Run("create", func(t *testing.T) {
+		w := do("POST", "/api/v2/logins/discord/api-keys", map[string]any{
+			"name":   "Discord bot",
+			"scopes": []string{"semester.list", "semester.list"},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+		require.Regexp(t, "^uwpsc_[0-9a-f]{64}$", created.Key)
+		require.Equal(t, created.Key[:12], created.Prefix)
+		require.Equal(t, []string{"semester.list"}, created.Scopes)
+		require.Nil(t, created.ExpiresAt)
+
+		var stored models.APIKey
+		require.NoError(t, db.Where("id = ?", created.ID).First(&stored).Error)
+		require.NotEqual(t, created.Key, stored.KeyHash)
+		require.NotContains(t, w.Body.String(), stored.KeyHash)
+	})
+
+	t.Run("create rejects", func(t *testing.T) {
+		w := do("POST", "/api/v2/logins/alice/api-keys", map[string]any{"name": "Key", "scopes": []string{"semester.list"}})
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "API keys can only be created for bot logins")
+
+		w = do("POST", "/api/v2/logins/discord/api-keys", map[string]any{"name": "Key", "scopes": []string{"event.teleport"}})
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "Unknown scope 'event.teleport'")
+
+		w = do("POST", "/api/v2/logins/discord/api-keys", map[string]any{"name": "Key", "scopes": []string{}})
+		require.Equal(t, http.StatusBadRequest, w.Code, "Response: %s", w.Body.String())
+
+		w = do("POST", "/api/v2/logins/discord/api-keys", map[string]any{
+			"name":      "Key",
+			"scopes":    []string{"semester.list"},
+			"expiresAt": time.Now().Add(-time.Hour),
+		})
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "Expiry must be in the future")
+
+		w = do("POST", "/api/v2/logins/nobody/api-keys", map[string]any{"name": "Key", "scopes": []string{"semester.list"}})
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Login not found")
+	})
+
+	t.Run("authenticate with bearer token", func(t *testing.T) {
+		w := doWithKey("GET", "/api/v2/semesters", "Bearer "+created.Key)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var stored models.APIKey
+		require.NoError(t, db.Where("id = ?", created.ID).First(&stored).Error)
+		require.NotNil(t, stored.LastUsedAt)
+
+		// The bot role may create memberships, but the key is not scoped to it
+		w = doWithKey("POST", fmt.Sprintf("/api/v2/semesters/%s/memberships", semesterID), "Bearer "+created.Key)
+		testutils.AssertErrorResponse(t, w, http.StatusForbidden, "This API key is not allowed to perform this action.")
+
+		w = doWithKey("GET", "/api/v2/semesters", "Bearer uwpsc_nope")
+		testutils.AssertErrorResponse(t, w, http.StatusUnauthorized, "Invalid API key")
+
+		w = doWithKey("GET", "/api/v2/semesters", "Basic "+created.Key)
+		testutils.AssertErrorResponse(t, w, http.StatusUnauthorized, "Authorization header must be a bearer token")
+	})
+
+	t.Run("expired key", func(t *testing.T) {
+		w := do("POST", "/api/v2/logins/discord/api-keys", map[string]any{
+			"name":      "Short lived",
+			"scopes":    []string{"semester.list"},
+			"expiresAt": time.Now().Add(time.Hour),
+		})
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		var expiring models.CreateAPIKeyResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &expiring))
+		require.NoError(t, db.Model(&models.APIKey{}).Where("id = ?", expiring.ID).Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+		w = doWithKey("GET", "/api/v2/semesters", "Bearer "+expiring.Key)
+		testutils.AssertErrorResponse(t, w, http.StatusUnauthorized, "API key has expired")
+	})
+
+	t.Run("list", func(t *testing.T) {
+		w := do("GET", "/api/v2/logins/discord/api-keys", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+		require.NotContains(t, w.Body.String(), created.Key)
+
+		var apiKeys []models.APIKey
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &apiKeys))
+		require.Len(t, apiKeys, 2)
+		require.Equal(t, "Short lived", apiKeys[0].Name)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		w := do("DELETE", fmt.Sprintf("/api/v2/logins/discord/api-keys/%s", created.ID), nil)
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+		w = doWithKey("GET", "/api/v2/semesters", "Bearer "+created.Key)
+		testutils.AssertErrorResponse(t, w, http.StatusUnauthorized, "API key has been revoked")
+
+		w = do("DELETE", fmt.Sprintf("/api/v2/logins/alice/api-keys/%s", created.ID), nil)
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "API key not found")
+
+		w = do("DELETE", "/api/v2/logins/discord/api-keys/abc", nil)
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "API key ID 'abc' is not a valid UUID")
+	})
+}
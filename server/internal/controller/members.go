@@ -4,13 +4,17 @@ import (
 	apierrors "api/internal/errors"
 	"api/internal/middleware"
 	"api/internal/models"
+	"api/internal/services"
 	"api/internal/store"
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -30,7 +34,9 @@ func NewMembersController(db *gorm.DB, st store.Store) Controller {
 func (c *membersController) LoadRoutes(router *gin.RouterGroup) {
 	members := router.Group("members", middleware.UseAuthentication(c.db))
 	members.POST("", middleware.UseAuthorization("user.create"), c.createMember)
+	members.POST("/import", middleware.UseAuthorization("user.import"), c.importMembers)
 	members.GET("", middleware.UseAuthorization("user.list"), c.listMembers)
+	members.GET("/export", middleware.UseAuthorization("user.export"), c.exportMembers)
 	members.GET("/:id", middleware.UseAuthorization("user.get"), c.getMember)
 	members.PATCH("/:id", middleware.UseAuthorization("user.edit"), c.updateMember)
 	members.DELETE("/:id", middleware.UseAuthorization("user.delete"), c.deleteMember)
@@ -92,6 +98,119 @@ func (c *membersController) createMember(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, member)
 }
 
+// importMembers handles creating and updating Members from a CSV file, e.g.
+// the sign up sheet of a club fair
+//
+// @Summary Import Members
+// @Description Create or update Members from CSV, matching existing Members by student ID. The CSV file needs a header row naming the columns: id (or student id), first name, last name, email and faculty are required, quest id, paid and discounted are optional and unknown columns are ignored. Every row is validated with the same rules as Create Member, and all invalid rows are reported together without importing anything. With a semesterId, Members without a membership in the semester are given one, paid and discounted as set by the query or the paid and discounted columns of their row. Existing memberships are left unchanged. Every row is imported in a single transaction, and dry runs report what would be done without saving anything.
+// @Tags Members
+// @Accept text/csv
+// @Produce json
+// @Param semesterId query string false "Semester to create memberships in"
+// @Param paid query bool false "Whether created memberships are paid (default false)"
+// @Param discounted query bool false "Whether created memberships are discounted (default false)"
+// @Param dryRun query bool false "Validate the import and report what it would do without saving anything"
+// @Param members body string true "CSV file of Members"
+// @Success 200 {object} models.MemberImportResponse
+// @Failure 400 {object} models.MemberImportErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /members/import [post]
+func (c *membersController) importMembers(ctx *gin.Context) {
+	opts := models.MemberImportOptions{}
+
+	if semesterID, exists := ctx.GetQuery("semesterId"); exists {
+		id, err := uuid.Parse(semesterID)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(
+				fmt.Sprintf("Semester ID '%s' is not a valid UUID", semesterID),
+			))
+			return
+		}
+		opts.SemesterID = &id
+	}
+
+	for _, flag := range []struct {
+		name string
+		dest *bool
+	}{
+		{name: "paid", dest: &opts.Paid},
+		{name: "discounted", dest: &opts.Discounted},
+		{name: "dryRun", dest: &opts.DryRun},
+	} {
+		value, exists := ctx.GetQuery(flag.name)
+		if !exists {
+			continue
+		}
+
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(
+				fmt.Sprintf("%s must be true or false", flag.name),
+			))
+			return
+		}
+		*flag.dest = b
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, apierrors.RequestEntityTooLarge("request body too large"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	rows, rowErrors, err := services.ParseMemberCSV(bytes.NewReader(body))
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	rowErrors = append(rowErrors, services.ValidateMembershipImport(rows, opts)...)
+	if len(rowErrors) > 0 {
+		invalidRows := make(map[int]bool)
+		for _, rowErr := range rowErrors {
+			invalidRows[rowErr.Row] = true
+		}
+		message := fmt.Sprintf("The import has %d invalid rows", len(invalidRows))
+		if len(invalidRows) == 1 {
+			message = "The import has 1 invalid row"
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, models.MemberImportErrorResponse{
+			Code:    http.StatusBadRequest,
+			Type:    "INVALID_REQUEST",
+			Message: message,
+			Errors:  rowErrors,
+		})
+		return
+	}
+
+	memberImportService := services.NewMemberImportService(c.db)
+	result, err := memberImportService.Import(rows, opts)
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
 // parseListMembersQueryParams parses query parameters for listing members
 func (c *membersController) parseListMembersQueryParams(ctx *gin.Context) *models.ListUsersFilter {
 	filter := &models.ListUsersFilter{}
@@ -158,6 +277,30 @@ func (c *membersController) listMembers(ctx *gin.Context) {
 	})
 }
 
+// exportMembers handles exporting Members as CSV
+//
+// @Summary Export Members
+// @Description Download every Member matching the filters as a CSV file, newest first. The file is streamed as it is read, so it is not limited in size.
+// @Tags Members
+// @Produce text/csv
+// @Param id query int false "Filter by Member ID"
+// @Param email query string false "Filter by Member Email"
+// @Param name query string false "Filter by Member Name"
+// @Param faculty query string false "Filter by Member Faculty"
+// @Success 200 {file} file "CSV file with the columns id, first_name, last_name, email, faculty, quest_id and created_at"
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /members/export [get]
+func (c *membersController) exportMembers(ctx *gin.Context) {
+	filter := c.parseListMembersQueryParams(ctx)
+
+	svc := services.NewUserService(c.db)
+	StreamCSV(ctx, "members.csv", func(w io.Writer) error {
+		return svc.ExportUsers(w, filter)
+	})
+}
+
 // getMember handles retrieving a Member by ID
 //
 // @Summary Get Member by ID
@@ -5,10 +5,12 @@ import (
 	"api/internal/models"
 	"api/internal/testutils"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -559,3 +561,176 @@ func TestDeleteMember(t *testing.T) {
 		require.Error(t, err, "membership should have been cascade deleted")
 	})
 }
+
+func TestImportMembers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	unauthorizedRoles := []string{authorization.ROLE_BOT.ToString(), authorization.ROLE_EXECUTIVE.ToString()}
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "POST", "/api/v2/members/import", unauthorizedRoles)
+
+	existing := testutils.TEST_USERS[0]
+	semesterID := testutils.TEST_SEMESTERS[0].ID
+	validCSV := "student id,first name,last name,email,faculty,quest id\n" +
+		fmt.Sprintf("%d,%s,%s,%s,%s,%s\n", existing.ID, existing.FirstName, existing.LastName, existing.Email, existing.Faculty, existing.QuestID) +
+		"20801234,Jane,Doe,jdoe@uwaterloo.ca,math,jdoe\n"
+
+	doImport := func(query string, body string) *httptest.ResponseRecorder {
+		sessionID, err := testutils.CreateTestSession(db, "testuser", authorization.ROLE_TOURNAMENT_DIRECTOR.ToString())
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/api/v2/members/import"+query, strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/csv")
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("dry run saves nothing", func(t *testing.T) {
+		require.NoError(t, container.ResetDatabase(ctx))
+		require.NoError(t, testutils.SeedAll(db))
+
+		w := doImport(fmt.Sprintf("?semesterId=%s&paid=true&dryRun=true", semesterID), validCSV)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var response models.MemberImportResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.True(t, response.DryRun)
+		require.Equal(t, 1, response.Created)
+		require.Equal(t, 1, response.Unchanged)
+		require.Equal(t, 1, response.MembershipsCreated)
+
+		var count int64
+		require.NoError(t, db.Model(&models.User{}).Where("id = ?", 20801234).Count(&count).Error)
+		require.Zero(t, count)
+	})
+
+	t.Run("creates members and memberships", func(t *testing.T) {
+		require.NoError(t, container.ResetDatabase(ctx))
+		require.NoError(t, testutils.SeedAll(db))
+
+		w := doImport(fmt.Sprintf("?semesterId=%s&paid=true", semesterID), validCSV)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var response models.MemberImportResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.False(t, response.DryRun)
+		require.Equal(t, []models.MemberImportRowResult{
+			{Row: 1, ID: existing.ID, Member: models.MemberImportStatusUnchanged, Membership: models.MembershipImportStatusExists},
+			{Row: 2, ID: 20801234, Member: models.MemberImportStatusCreated, Membership: models.MembershipImportStatusCreated},
+		}, response.Rows)
+
+		var member models.User
+		require.NoError(t, db.First(&member, 20801234).Error)
+		require.Equal(t, models.FacultyMath, member.Faculty)
+
+		var membership models.Membership
+		require.NoError(t, db.Where("user_id = ? AND semester_id = ?", 20801234, semesterID).First(&membership).Error)
+		require.True(t, membership.Paid)
+	})
+
+	t.Run("reports invalid rows", func(t *testing.T) {
+		require.NoError(t, container.ResetDatabase(ctx))
+
+		w := doImport("", "id,first name,last name,email,faculty\n"+
+			"20801234,Jane,Doe,jdoe@uwaterloo.ca,Math\n"+
+			"20805678,John,Smith,not-an-email,Law\n")
+		require.Equal(t, http.StatusBadRequest, w.Code, "Response: %s", w.Body.String())
+
+		var response models.MemberImportErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, "The import has 1 invalid row", response.Message)
+		require.Equal(t, []models.MemberImportRowError{
+			{Row: 2, Field: "email", Message: "email must be a valid email address"},
+			{Row: 2, Field: "faculty", Message: "faculty must be one of AHS, Arts, Engineering, Environment, Math, Science"},
+		}, response.Errors)
+
+		// Valid rows are not imported either
+		var count int64
+		require.NoError(t, db.Model(&models.User{}).Count(&count).Error)
+		require.Zero(t, count)
+	})
+
+	t.Run("non-existent semester", func(t *testing.T) {
+		require.NoError(t, container.ResetDatabase(ctx))
+
+		w := doImport("?semesterId=00000000-0000-0000-0000-000000000000", validCSV)
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Semester not found")
+	})
+}
+
+func TestExportMembers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	unauthorizedRoles := []string{
+		authorization.ROLE_BOT.ToString(),
+		authorization.ROLE_EXECUTIVE.ToString(),
+		authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+	}
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", "/api/v2/members/export", unauthorizedRoles)
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedAll(db))
+
+	sessionID, err := testutils.CreateTestSession(db, "testuser", authorization.ROLE_SECRETARY.ToString())
+	require.NoError(t, err)
+
+	doExport := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v2/members/export"+query, nil)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	header := []string{"id", "first_name", "last_name", "email", "faculty", "quest_id", "created_at"}
+
+	t.Run("exports every member", func(t *testing.T) {
+		w := doExport("")
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+		require.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Equal(t, "attachment; filename=\"members.csv\"", w.Header().Get("Content-Disposition"))
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, header, records[0])
+		require.Len(t, records, len(testutils.TEST_USERS)+1)
+	})
+
+	t.Run("applies filters", func(t *testing.T) {
+		w := doExport("?faculty=Engineering")
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.Equal(t, []string{"20780649", "Jane", "Smith", "jane.smith@example.com", "Engineering", "jsmith"}, records[1][:6])
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		w := doExport("?name=nobody")
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, [][]string{header}, records)
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"api/internal/middleware"
 	"api/internal/models"
 	"api/internal/services"
+	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -25,6 +27,7 @@ func (c *membershipsController) LoadRoutes(router *gin.RouterGroup) {
 	memberships := router.Group("semesters/:semesterId/memberships", middleware.UseAuthentication(c.db))
 	memberships.POST("", middleware.UseAuthorization("membership.create"), c.createMembership)
 	memberships.GET("", middleware.UseAuthorization("membership.list"), c.listMemberships)
+	memberships.GET("/export", middleware.UseAuthorization("membership.export"), c.exportMemberships)
 	memberships.GET("/:id", middleware.UseAuthorization("membership.get"), c.getMembership)
 	memberships.PATCH(
 		"/:id",
@@ -114,6 +117,38 @@ func (c *membershipsController) createMembership(ctx *gin.Context) {
 }
 
 
+// parseListMembershipsQueryParams parses the query parameters that filter the
+// memberships of a semester
+func parseListMembershipsQueryParams(ctx *gin.Context, semesterID uuid.UUID) *models.ListMembershipsFilter {
+	filter := &models.ListMembershipsFilter{
+		SemesterID: &semesterID,
+		Search:     ctx.Query("search"),
+	}
+
+	if name := ctx.Query("name"); name != "" {
+		filter.Name = &name
+	}
+	if email := ctx.Query("email"); email != "" {
+		filter.Email = &email
+	}
+	if faculty := ctx.Query("faculty"); faculty != "" {
+		filter.Faculty = &faculty
+	}
+	if studentID := ctx.Query("studentId"); studentID != "" {
+		filter.StudentID = &studentID
+	}
+	if paid := ctx.Query("paid"); paid == "true" || paid == "false" {
+		v := paid == "true"
+		filter.Paid = &v
+	}
+	if discounted := ctx.Query("discounted"); discounted == "true" || discounted == "false" {
+		v := discounted == "true"
+		filter.Discounted = &v
+	}
+
+	return filter
+}
+
 // listMemberships handles listing all memberships
 //
 // @Summary List all Memberships
@@ -150,34 +185,8 @@ func (c *membershipsController) listMemberships(ctx *gin.Context) {
 		return
 	}
 
-	search := ctx.Query("search")
-
-	filter := &models.ListMembershipsFilter{
-		Pagination: pagination,
-		SemesterID: &semesterID,
-		Search:     search,
-	}
-
-	if name := ctx.Query("name"); name != "" {
-		filter.Name = &name
-	}
-	if email := ctx.Query("email"); email != "" {
-		filter.Email = &email
-	}
-	if faculty := ctx.Query("faculty"); faculty != "" {
-		filter.Faculty = &faculty
-	}
-	if studentID := ctx.Query("studentId"); studentID != "" {
-		filter.StudentID = &studentID
-	}
-	if paid := ctx.Query("paid"); paid == "true" || paid == "false" {
-		v := paid == "true"
-		filter.Paid = &v
-	}
-	if discounted := ctx.Query("discounted"); discounted == "true" || discounted == "false" {
-		v := discounted == "true"
-		filter.Discounted = &v
-	}
+	filter := parseListMembershipsQueryParams(ctx, semesterID)
+	filter.Pagination = pagination
 
 	svc := services.NewMembershipService(c.db)
 	memberships, total, err := svc.ListMembershipsV2(filter)
@@ -200,6 +209,42 @@ func (c *membershipsController) listMemberships(ctx *gin.Context) {
 	})
 }
 
+// exportMemberships handles exporting the memberships of a semester as CSV
+//
+// @Summary Export Memberships
+// @Description Download every Membership of a semester matching the filters as a CSV file, with each member's paid and discounted status, the fee they paid and their attendance, so payments can be reconciled. The file is streamed as it is read, so it is not limited in size.
+// @Tags Memberships
+// @Produce text/csv
+// @Param semesterId path string true "Semester ID"
+// @Param search query string false "Search by first name, last name, email, or full name"
+// @Param name query string false "Filter by first name, last name, or full name (case-insensitive partial match)"
+// @Param email query string false "Filter by email (case-insensitive partial match)"
+// @Param faculty query string false "Filter by faculty (exact match)" Enums(AHS, Arts, Engineering, Environment, Math, Science)
+// @Param studentId query string false "Filter by student ID (exact match)"
+// @Param paid query bool false "Filter by paid status"
+// @Param discounted query bool false "Filter by discounted status"
+// @Success 200 {file} file "CSV file with the columns membership_id, user_id, first_name, last_name, email, faculty, quest_id, paid, discounted, fee and attendance"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/memberships/export [get]
+func (c *membershipsController) exportMemberships(ctx *gin.Context) {
+	semesterID, err := validateSemesterID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	filter := parseListMembershipsQueryParams(ctx, semesterID)
+
+	svc := services.NewMembershipService(c.db)
+	StreamCSV(ctx, fmt.Sprintf("memberships-%s.csv", semesterID), func(w io.Writer) error {
+		return svc.ExportMemberships(w, filter)
+	})
+}
+
 // getMembership handles retrieving a specific membership
 //
 // @Summary Get a Membership
@@ -5,6 +5,7 @@ import (
 	"api/internal/models"
 	"api/internal/testutils"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"net/url"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -1044,3 +1046,89 @@ func TestListMembershipsSearch(t *testing.T) {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+func TestExportMemberships(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	semesterID := testutils.TEST_SEMESTERS[0].ID
+	unauthorizedRoles := []string{
+		authorization.ROLE_BOT.ToString(),
+		authorization.ROLE_EXECUTIVE.ToString(),
+		authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+	}
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", fmt.Sprintf("/api/v2/semesters/%s/memberships/export", semesterID), unauthorizedRoles)
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedAll(db))
+
+	sessionID, err := testutils.CreateTestSession(db, "testuser", authorization.ROLE_TREASURER.ToString())
+	require.NoError(t, err)
+
+	doExport := func(semesterID string, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/semesters/%s/memberships/export%s", semesterID, query), nil)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	// The seeded memberships have no ledger entries, so record their fees.
+	// Jane was charged the full fee before her discount was applied, and
+	// John's rebuy is not part of his fee
+	bob := uuid.MustParse("33333333-3333-3333-3333-333333333333")
+	jane := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	john := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	require.NoError(t, db.Create(&[]models.LedgerEntry{
+		{SemesterID: semesterID, Type: models.LedgerEntryTypeMembershipFee, AmountCents: 800, MembershipID: &bob},
+		{SemesterID: semesterID, Type: models.LedgerEntryTypeMembershipFee, AmountCents: 800, MembershipID: &jane},
+		{SemesterID: semesterID, Type: models.LedgerEntryTypeMembershipFee, AmountCents: -450, MembershipID: &jane},
+		{SemesterID: semesterID, Type: models.LedgerEntryTypeMembershipFee, AmountCents: 800, MembershipID: &john},
+		{SemesterID: semesterID, Type: models.LedgerEntryTypeRebuy, AmountCents: 500, MembershipID: &john},
+	}).Error)
+
+	t.Run("exports every membership", func(t *testing.T) {
+		w := doExport(semesterID.String(), "")
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+		require.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Equal(t, fmt.Sprintf("attachment; filename=\"memberships-%s.csv\"", semesterID), w.Header().Get("Content-Disposition"))
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, [][]string{
+			{"membership_id", "user_id", "first_name", "last_name", "email", "faculty", "quest_id", "paid", "discounted", "fee", "attendance"},
+			{"33333333-3333-3333-3333-333333333333", "20780650", "Bob", "Johnson", "bob.johnson@example.com", "Science", "bjohnson", "true", "false", "8.00", "1"},
+			{"22222222-2222-2222-2222-222222222222", "20780649", "Jane", "Smith", "jane.smith@example.com", "Engineering", "jsmith", "true", "true", "3.50", "1"},
+			{"11111111-1111-1111-1111-111111111111", "20780648", "John", "Doe", "john.doe@example.com", "Math", "jdoe", "true", "false", "8.00", "2"},
+		}, records)
+	})
+
+	t.Run("applies filters", func(t *testing.T) {
+		w := doExport(semesterID.String(), "?discounted=true")
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.Equal(t, "20780649", records[1][1])
+	})
+
+	t.Run("semester not found", func(t *testing.T) {
+		w := doExport(uuid.New().String(), "")
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Semester not found")
+		require.Empty(t, w.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("invalid semester ID", func(t *testing.T) {
+		w := doExport("not-a-uuid", "")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
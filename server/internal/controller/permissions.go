@@ -0,0 +1,119 @@
+package controller
+
+import (
+	apierrors "api/internal/errors"
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type permissionsController struct {
+	db *gorm.DB
+}
+
+// NewPermissionsController creates a new instance of permissionsController
+func NewPermissionsController(db *gorm.DB) Controller {
+	return &permissionsController{db: db}
+}
+
+func (c *permissionsController) LoadRoutes(router *gin.RouterGroup) {
+	permissions := router.Group("permissions", middleware.UseAuthentication(c.db))
+	permissions.GET("", middleware.UseAuthorization("permission.list"), c.listPermissions)
+	permissions.PUT(":action", middleware.UseAuthorization("permission.edit"), c.updatePermission)
+	permissions.DELETE(":action", middleware.UseAuthorization("permission.edit"), c.resetPermission)
+}
+
+// listPermissions handles listing the permission policy
+//
+// @Summary List permissions
+// @Description List the roles allowed to perform every action, such as event.end or semester.rankings.export, along with the roles allowed by default
+// @Tags Permissions
+// @Produce json
+// @Success 200 {array} RolePermission
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /permissions [get]
+func (c *permissionsController) listPermissions(ctx *gin.Context) {
+	svc := services.NewPermissionService(c.db)
+	permissions, err := svc.ListPermissions()
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, permissions)
+}
+
+// updatePermission handles changing the roles allowed to perform an action
+//
+// @Summary Update permission
+// @Description Change the roles allowed to perform an action. The change applies to the next request. The permission actions themselves cannot be changed.
+// @Tags Permissions
+// @Accept json
+// @Produce json
+// @Param action path string true "Action, e.g. event.end"
+// @Param request body UpdateRolePermissionRequest true "Roles allowed to perform the action"
+// @Success 200 {object} RolePermission
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /permissions/{action} [put]
+func (c *permissionsController) updatePermission(ctx *gin.Context) {
+	var req models.UpdateRolePermissionRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	svc := services.NewPermissionService(c.db)
+	permission, err := svc.UpdatePermission(ctx.Param("action"), req.Roles)
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, permission)
+}
+
+// resetPermission handles restoring the default roles of an action
+//
+// @Summary Reset permission
+// @Description Restore the roles allowed to perform an action to the default policy
+// @Tags Permissions
+// @Param action path string true "Action, e.g. event.end"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /permissions/{action} [delete]
+func (c *permissionsController) resetPermission(ctx *gin.Context) {
+	svc := services.NewPermissionService(c.db)
+	if _, err := svc.ResetPermission(ctx.Param("action")); err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
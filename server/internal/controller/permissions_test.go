@@ -0,0 +1,140 @@
+package controller_test
+
+import (
+	"api/internal/authorization"
+	"api/internal/models"
+	"api/internal/testutils"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPermissions is not run in parallel, as it edits the policy shared by
+// every API server in the process.
+func TestPermissions(t *testing.T) {
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+	t.Cleanup(authorization.ActivePolicy.Reset)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	unauthorizedRoles := []string{"bot", "executive", "tournament_director", "secretary", "treasurer", "vice_president", "president"}
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", "/api/v2/permissions", unauthorizedRoles)
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "PUT", "/api/v2/permissions/event.end", unauthorizedRoles)
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "DELETE", "/api/v2/permissions/event.end", unauthorizedRoles)
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedAll(db))
+
+	adminSession, err := testutils.CreateTestSession(db, "admin", authorization.ROLE_WEBMASTER.ToString())
+	require.NoError(t, err)
+	directorSession, err := testutils.CreateTestSession(db, "director", authorization.ROLE_TOURNAMENT_DIRECTOR.ToString())
+	require.NoError(t, err)
+
+	do := func(sessionID uuid.UUID, method string, path string, body any) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, body)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	ledgerPath := fmt.Sprintf("/api/v2/semesters/%s/ledger", testutils.TEST_SEMESTERS[0].ID)
+	defaultRoles, _ := authorization.ActivePolicy.DefaultRoles("semester.ledger.list")
+
+	t.Run("list", func(t *testing.T) {
+		w := do(adminSession, "GET", "/api/v2/permissions", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var permissions []models.RolePermission
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &permissions))
+		require.Len(t, permissions, len(authorization.ActivePolicy.Actions()))
+
+		found := false
+		for _, permission := range permissions {
+			if permission.Action == "semester.ledger.list" {
+				found = true
+				require.Equal(t, defaultRoles, permission.Roles)
+				require.Equal(t, defaultRoles, permission.DefaultRoles)
+				require.False(t, permission.Protected)
+			}
+		}
+		require.True(t, found)
+	})
+
+	t.Run("update applies to the next request", func(t *testing.T) {
+		w := do(directorSession, "GET", ledgerPath, nil)
+		require.Equal(t, http.StatusForbidden, w.Code, "Response: %s", w.Body.String())
+
+		w = do(adminSession, "PUT", "/api/v2/permissions/semester.ledger.list", map[string]any{
+			"roles": []string{"webmaster", "tournament_director"},
+		})
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var permission models.RolePermission
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &permission))
+		require.Equal(t, []string{"tournament_director", "webmaster"}, permission.Roles)
+
+		var stored models.RolePermission
+		require.NoError(t, db.Where("action = ?", "semester.ledger.list").First(&stored).Error)
+		require.Equal(t, permission.Roles, stored.Roles)
+
+		w = do(directorSession, "GET", ledgerPath, nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		w = do(directorSession, "GET", "/api/v2/session", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var session models.GetSessionResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &session))
+		require.Equal(t, true, session.Permissions["semester"]["ledger"].(map[string]any)["list"])
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		w := do(adminSession, "DELETE", "/api/v2/permissions/semester.ledger.list", nil)
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+		w = do(directorSession, "GET", ledgerPath, nil)
+		require.Equal(t, http.StatusForbidden, w.Code, "Response: %s", w.Body.String())
+	})
+
+	t.Run("stored policy is loaded at startup", func(t *testing.T) {
+		require.NoError(t, db.Exec(`UPDATE role_permissions SET roles = '["tournament_director"]' WHERE action = ?`, "semester.ledger.list").Error)
+
+		restarted := testutils.NewTestAPIServer(db)
+		req, err := testutils.MakeJSONRequest("GET", ledgerPath, nil)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, directorSession)
+
+		w := httptest.NewRecorder()
+		restarted.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		authorization.ActivePolicy.Reset()
+	})
+
+	t.Run("invalid updates", func(t *testing.T) {
+		w := do(adminSession, "PUT", "/api/v2/permissions/permission.edit", map[string]any{"roles": []string{}})
+		testutils.AssertErrorResponse(t, w, http.StatusForbidden, "The roles of permission actions cannot be changed")
+
+		w = do(adminSession, "PUT", "/api/v2/permissions/event.teleport", map[string]any{"roles": []string{"webmaster"}})
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Action not found")
+
+		w = do(adminSession, "PUT", "/api/v2/permissions/event.end", map[string]any{"roles": []string{"admin"}})
+		require.Equal(t, http.StatusBadRequest, w.Code, "Response: %s", w.Body.String())
+
+		w = do(adminSession, "DELETE", "/api/v2/permissions/event.teleport", nil)
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Action not found")
+	})
+}
@@ -0,0 +1,141 @@
+package controller
+
+import (
+	apierrors "api/internal/errors"
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type pointsSchemesController struct {
+	db *gorm.DB
+}
+
+// NewPointsSchemesController creates a new instance of pointsSchemesController
+func NewPointsSchemesController(db *gorm.DB) Controller {
+	return &pointsSchemesController{db: db}
+}
+
+func (c *pointsSchemesController) LoadRoutes(router *gin.RouterGroup) {
+	pointsScheme := router.Group("semesters/:semesterId/points-scheme", middleware.UseAuthentication(c.db))
+	pointsScheme.GET("", middleware.UseAuthorization("semester.pointsScheme.get"), c.getPointsScheme)
+	pointsScheme.PUT("", middleware.UseAuthorization("semester.pointsScheme.edit"), c.upsertPointsScheme)
+	pointsScheme.DELETE("", middleware.UseAuthorization("semester.pointsScheme.delete"), c.deletePointsScheme)
+}
+
+// getPointsScheme handles retrieving the points scheme for a semester
+//
+// @Summary Get points scheme
+// @Description Get the points scheme used to award points for events in a semester. Semesters without a configured scheme return the default scheme.
+// @Tags Points Schemes
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Success 200 {object} PointsScheme
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/points-scheme [get]
+func (c *pointsSchemesController) getPointsScheme(ctx *gin.Context) {
+	semesterID, err := validateSemesterID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	svc := services.NewPointsSchemeService(c.db)
+	scheme, err := svc.GetPointsScheme(semesterID)
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, scheme)
+}
+
+// upsertPointsScheme handles creating or replacing the points scheme for a semester
+//
+// @Summary Create or replace points scheme
+// @Description Create or replace the points scheme for a semester. Payouts are listed in placement order, starting with 1st place. The scheme cannot be changed once an event of the semester has ended.
+// @Tags Points Schemes
+// @Accept json
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param pointsScheme body UpsertPointsSchemeRequest true "Points scheme"
+// @Success 200 {object} PointsScheme
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/points-scheme [put]
+func (c *pointsSchemesController) upsertPointsScheme(ctx *gin.Context) {
+	semesterID, err := validateSemesterID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	var req models.UpsertPointsSchemeRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	svc := services.NewPointsSchemeService(c.db)
+	scheme, err := svc.UpsertPointsScheme(semesterID, &req)
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, scheme)
+}
+
+// deletePointsScheme handles removing the points scheme for a semester
+//
+// @Summary Delete points scheme
+// @Description Delete the configured points scheme for a semester, reverting it to the default scheme. The scheme cannot be changed once an event of the semester has ended.
+// @Tags Points Schemes
+// @Param semesterId path string true "Semester ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/points-scheme [delete]
+func (c *pointsSchemesController) deletePointsScheme(ctx *gin.Context) {
+	semesterID, err := validateSemesterID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	svc := services.NewPointsSchemeService(c.db)
+	if err := svc.DeletePointsScheme(semesterID); err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
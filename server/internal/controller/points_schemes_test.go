@@ -0,0 +1,314 @@
+package controller_test
+
+import (
+	"api/internal/authorization"
+	"api/internal/models"
+	"api/internal/testutils"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPointsScheme(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	unauthorizedRoles := []string{"bot"}
+	testutils.TestInvalidAuthForEndpoint(
+		t,
+		container,
+		apiServer,
+		"GET",
+		fmt.Sprintf("/api/v2/semesters/%s/points-scheme", testutils.TEST_SEMESTERS[0].ID),
+		unauthorizedRoles,
+	)
+
+	testCases := []struct {
+		name                 string
+		userRole             string
+		semesterID           string
+		expectedStatus       int
+		expectedErrorMessage string
+	}{
+		{
+			name:           "returns default scheme - EXECUTIVE role",
+			userRole:       authorization.ROLE_EXECUTIVE.ToString(),
+			semesterID:     testutils.TEST_SEMESTERS[0].ID.String(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:                 "semester not found",
+			userRole:             authorization.ROLE_EXECUTIVE.ToString(),
+			semesterID:           "550e8400-e29b-41d4-a716-446655440099",
+			expectedStatus:       http.StatusNotFound,
+			expectedErrorMessage: "Semester not found",
+		},
+		{
+			name:                 "invalid semester ID format",
+			userRole:             authorization.ROLE_EXECUTIVE.ToString(),
+			semesterID:           "invalid-uuid",
+			expectedStatus:       http.StatusBadRequest,
+			expectedErrorMessage: "Semester ID 'invalid-uuid' is not a valid UUID",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, container.ResetDatabase(ctx))
+			require.NoError(t, testutils.SeedAll(db))
+
+			sessionID, err := testutils.CreateTestSession(db, "testuser", tc.userRole)
+			require.NoError(t, err)
+
+			req, err := testutils.MakeJSONRequest(
+				"GET",
+				fmt.Sprintf("/api/v2/semesters/%s/points-scheme", tc.semesterID),
+				nil,
+			)
+			require.NoError(t, err)
+			testutils.SetAuthCookie(req, sessionID)
+
+			w := httptest.NewRecorder()
+			apiServer.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedStatus, w.Code, "Response: %s", w.Body.String())
+
+			if tc.expectedErrorMessage != "" {
+				require.Contains(t, w.Body.String(), tc.expectedErrorMessage)
+				return
+			}
+
+			var scheme models.PointsScheme
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &scheme))
+			require.Equal(t, tc.semesterID, scheme.SemesterID.String())
+			require.Equal(t, 50.0, scheme.SizeFactor)
+			require.Equal(t, models.PointsRoundingCeil, scheme.RoundingMode)
+			require.Equal(t, int32(1), scheme.DefaultPayout)
+			require.Len(t, scheme.Payouts, 40)
+			require.Equal(t, int32(32), scheme.Payouts[0].Points)
+		})
+	}
+}
+
+func TestUpsertPointsScheme(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	validBody := map[string]any{
+		"sizeFactor":    25.0,
+		"roundingMode":  "floor",
+		"minimumPoints": 1,
+		"defaultPayout": 0,
+		"payouts":       []int{10, 5, 2},
+	}
+
+	unauthorizedRoles := []string{"bot", "executive", "tournament_director", "secretary", "treasurer"}
+	testutils.TestInvalidAuthForEndpoint(
+		t,
+		container,
+		apiServer,
+		"PUT",
+		fmt.Sprintf("/api/v2/semesters/%s/points-scheme", testutils.TEST_SEMESTERS[0].ID),
+		unauthorizedRoles,
+		validBody,
+	)
+
+	testCases := []struct {
+		name                 string
+		userRole             string
+		semesterID           string
+		requestBody          map[string]any
+		expectedStatus       int
+		expectedErrorMessage string
+	}{
+		{
+			name:           "creates scheme - VICE_PRESIDENT role",
+			userRole:       authorization.ROLE_VICE_PRESIDENT.ToString(),
+			semesterID:     testutils.TEST_SEMESTERS[1].ID.String(),
+			requestBody:    validBody,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "creates scheme - WEBMASTER role",
+			userRole:       authorization.ROLE_WEBMASTER.ToString(),
+			semesterID:     testutils.TEST_SEMESTERS[1].ID.String(),
+			requestBody:    validBody,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid rounding mode",
+			userRole:   authorization.ROLE_WEBMASTER.ToString(),
+			semesterID: testutils.TEST_SEMESTERS[0].ID.String(),
+			requestBody: map[string]any{
+				"sizeFactor":   50.0,
+				"roundingMode": "up",
+				"payouts":      []int{10},
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedErrorMessage: "Field validation for 'RoundingMode' failed on the 'oneof' tag",
+		},
+		{
+			name:       "empty payout table",
+			userRole:   authorization.ROLE_WEBMASTER.ToString(),
+			semesterID: testutils.TEST_SEMESTERS[0].ID.String(),
+			requestBody: map[string]any{
+				"sizeFactor":   50.0,
+				"roundingMode": "ceil",
+				"payouts":      []int{},
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedErrorMessage: "Field validation for 'Payouts' failed on the 'min' tag",
+		},
+		{
+			name:                 "semester not found",
+			userRole:             authorization.ROLE_WEBMASTER.ToString(),
+			semesterID:           "550e8400-e29b-41d4-a716-446655440099",
+			requestBody:          validBody,
+			expectedStatus:       http.StatusNotFound,
+			expectedErrorMessage: "Semester not found",
+		},
+		{
+			name:                 "semester has ended events",
+			userRole:             authorization.ROLE_WEBMASTER.ToString(),
+			semesterID:           testutils.TEST_SEMESTERS[0].ID.String(),
+			requestBody:          validBody,
+			expectedStatus:       http.StatusForbidden,
+			expectedErrorMessage: "The points scheme cannot be changed once an event of the semester has ended",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, container.ResetDatabase(ctx))
+			require.NoError(t, testutils.SeedAll(db))
+
+			sessionID, err := testutils.CreateTestSession(db, "testuser", tc.userRole)
+			require.NoError(t, err)
+
+			// Upsert twice to ensure replacing an existing scheme works
+			for range 2 {
+				req, err := testutils.MakeJSONRequest(
+					"PUT",
+					fmt.Sprintf("/api/v2/semesters/%s/points-scheme", tc.semesterID),
+					tc.requestBody,
+				)
+				require.NoError(t, err)
+				testutils.SetAuthCookie(req, sessionID)
+
+				w := httptest.NewRecorder()
+				apiServer.ServeHTTP(w, req)
+
+				require.Equal(t, tc.expectedStatus, w.Code, "Response: %s", w.Body.String())
+
+				if tc.expectedErrorMessage != "" {
+					require.Contains(t, w.Body.String(), tc.expectedErrorMessage)
+					return
+				}
+
+				var scheme models.PointsScheme
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &scheme))
+				require.Equal(t, 25.0, scheme.SizeFactor)
+				require.Equal(t, models.PointsRoundingFloor, scheme.RoundingMode)
+				require.Equal(t, int32(1), scheme.MinimumPoints)
+				require.Equal(t, int32(0), scheme.DefaultPayout)
+				require.Len(t, scheme.Payouts, 3)
+			}
+
+			var payoutCount int64
+			require.NoError(t, db.Model(&models.PointsPayout{}).Count(&payoutCount).Error)
+			require.Equal(t, int64(3), payoutCount)
+		})
+	}
+}
+
+func TestDeletePointsScheme(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	endpoint := fmt.Sprintf("/api/v2/semesters/%s/points-scheme", testutils.TEST_SEMESTERS[1].ID)
+
+	unauthorizedRoles := []string{"bot", "executive", "tournament_director", "secretary", "treasurer"}
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "DELETE", endpoint, unauthorizedRoles)
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedAll(db))
+
+	sessionID, err := testutils.CreateTestSession(db, "testuser", authorization.ROLE_PRESIDENT.ToString())
+	require.NoError(t, err)
+
+	req, err := testutils.MakeJSONRequest("PUT", endpoint, map[string]any{
+		"sizeFactor":   10.0,
+		"roundingMode": "round",
+		"payouts":      []int{5},
+	})
+	require.NoError(t, err)
+	testutils.SetAuthCookie(req, sessionID)
+	w := httptest.NewRecorder()
+	apiServer.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+	req, err = testutils.MakeJSONRequest("DELETE", endpoint, nil)
+	require.NoError(t, err)
+	testutils.SetAuthCookie(req, sessionID)
+	w = httptest.NewRecorder()
+	apiServer.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+	// The semester should fall back to the default scheme
+	req, err = testutils.MakeJSONRequest("GET", endpoint, nil)
+	require.NoError(t, err)
+	testutils.SetAuthCookie(req, sessionID)
+	w = httptest.NewRecorder()
+	apiServer.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+	var scheme models.PointsScheme
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &scheme))
+	require.Equal(t, 50.0, scheme.SizeFactor)
+	require.Len(t, scheme.Payouts, 40)
+
+	var payoutCount int64
+	require.NoError(t, db.Model(&models.PointsPayout{}).Count(&payoutCount).Error)
+	require.Equal(t, int64(0), payoutCount)
+
+	// The scheme of a semester with ended events cannot be changed
+	req, err = testutils.MakeJSONRequest("DELETE", fmt.Sprintf("/api/v2/semesters/%s/points-scheme", testutils.TEST_SEMESTERS[0].ID), nil)
+	require.NoError(t, err)
+	testutils.SetAuthCookie(req, sessionID)
+	w = httptest.NewRecorder()
+	apiServer.ServeHTTP(w, req)
+	testutils.AssertErrorResponse(t, w, http.StatusForbidden, "The points scheme cannot be changed once an event of the semester has ended")
+
+	req, err = testutils.MakeJSONRequest("DELETE", "/api/v2/semesters/550e8400-e29b-41d4-a716-446655440099/points-scheme", nil)
+	require.NoError(t, err)
+	testutils.SetAuthCookie(req, sessionID)
+	w = httptest.NewRecorder()
+	apiServer.ServeHTTP(w, req)
+	testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Semester not found")
+}
@@ -6,10 +6,11 @@ import (
 	"api/internal/models"
 	"api/internal/services"
 	"errors"
-	"log"
+	"fmt"
+	"io"
 	"net/http"
-	"os"
-	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -29,7 +30,10 @@ func (c *rankingsController) LoadRoutes(router *gin.RouterGroup) {
 	rankings := router.Group("semesters/:semesterId/rankings", middleware.UseAuthentication(c.db))
 	rankings.GET("", middleware.UseAuthorization("semester.rankings.list"), c.listRankings)
 	rankings.GET("export", middleware.UseAuthorization("semester.rankings.export"), c.exportRankings)
+	rankings.GET("history", middleware.UseAuthorization("semester.rankings.list"), c.listRankingHistory)
 	rankings.GET(":membershipId", middleware.UseAuthorization("semester.rankings.get"), c.getRanking)
+	rankings.GET(":membershipId/history", middleware.UseAuthorization("semester.rankings.get"), c.getMembershipRankingHistory)
+	rankings.POST("rebuild", middleware.UseAuthorization("semester.rankings.rebuild"), c.rebuildRankings)
 }
 
 func validateUUIDParam(ctx *gin.Context, paramName string) (uuid.UUID, error) {
@@ -133,17 +137,167 @@ func (c *rankingsController) getRanking(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, ranking)
 }
 
+// listRankingHistory handles listing the ranking snapshots of a semester
+//
+// @Summary List rankings history
+// @Description List the snapshots of a semester's rankings, oldest first, to show how the leaderboard moved over the semester. A snapshot is recorded whenever an event is ended or its end is undone. Use top to only include the rankings at or above a position in each snapshot.
+// @Tags Rankings
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param top query int false "Only include rankings at or above this position"
+// @Param limit query int false "Maximum number of snapshots to return"
+// @Param offset query int false "Number of snapshots to skip"
+// @Success 200 {object} models.ListResponse[models.RankingHistorySnapshot]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/rankings/history [get]
+func (c *rankingsController) listRankingHistory(ctx *gin.Context) {
+	semesterID, err := validateSemesterID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	pagination, err := models.ParsePagination(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	top := 0
+	if topParam, ok := ctx.GetQuery("top"); ok {
+		top, err = strconv.Atoi(topParam)
+		if err != nil || top <= 0 {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest("top must be a positive integer"))
+			return
+		}
+	}
+
+	svc := services.NewRankingService(c.db)
+	snapshots, total, err := svc.GetRankingHistory(semesterID, &pagination, top)
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.ListResponse[models.RankingHistorySnapshot]{
+		Data:  snapshots,
+		Total: total,
+	})
+}
+
+// getMembershipRankingHistory handles retrieving the ranking history of a membership
+//
+// @Summary Get ranking history
+// @Description Get the points and position of a membership in every ranking snapshot of its semester, oldest first, as a time series for charts. Snapshots from before the membership was first ranked are left out.
+// @Tags Rankings
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param membershipId path string true "Membership ID"
+// @Success 200 {array} models.RankingHistoryPoint
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/rankings/{membershipId}/history [get]
+func (c *rankingsController) getMembershipRankingHistory(ctx *gin.Context) {
+	semesterID, err := validateSemesterID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	membershipID, err := validateUUIDParam(ctx, "membershipId")
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	svc := services.NewRankingService(c.db)
+	history, err := svc.GetMembershipRankingHistory(semesterID, membershipID)
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, history)
+}
+
+// rankingExportContentTypes maps each rankings export format to the content
+// type of its files
+var rankingExportContentTypes = map[string]string{
+	models.RankingExportFormatCSV:  "text/csv; charset=utf-8",
+	models.RankingExportFormatJSON: "application/json; charset=utf-8",
+	models.RankingExportFormatTSV:  "text/tab-separated-values; charset=utf-8",
+}
+
+// parseRankingExportOptions parses the query parameters of a rankings export
+func parseRankingExportOptions(ctx *gin.Context) (models.RankingExportOptions, error) {
+	opts := models.RankingExportOptions{Format: ctx.DefaultQuery("format", models.RankingExportFormatCSV)}
+	if _, ok := rankingExportContentTypes[opts.Format]; !ok {
+		return opts, fmt.Errorf("Export format '%s' is not supported, use csv, json or tsv", opts.Format)
+	}
+
+	if eventIDParam, ok := ctx.GetQuery("eventId"); ok {
+		eventID, err := strconv.ParseInt(eventIDParam, 10, 32)
+		if err != nil || eventID <= 0 {
+			return opts, fmt.Errorf("Event ID '%s' is not a valid ID", eventIDParam)
+		}
+		id := int32(eventID)
+		opts.EventID = &id
+	}
+
+	if asOfParam, ok := ctx.GetQuery("asOf"); ok {
+		if opts.EventID != nil {
+			return opts, errors.New("Only one of eventId and asOf can be set")
+		}
+
+		// A date includes every event that started on that day
+		asOf, err := time.Parse(time.RFC3339, asOfParam)
+		if err != nil {
+			day, dayErr := time.ParseInLocation(time.DateOnly, asOfParam, time.Local)
+			if dayErr != nil {
+				return opts, fmt.Errorf("asOf '%s' is not a valid date or RFC 3339 time", asOfParam)
+			}
+			asOf = day.AddDate(0, 0, 1).Add(-time.Microsecond)
+		}
+		opts.AsOf = &asOf
+	}
+
+	return opts, nil
+}
+
 // exportRankings handles exporting the rankings for a semester
 //
 // @Summary Export rankings
-// @Description Export the rankings for a semester
+// @Description Download every ranking of a semester with its position, points, attendance and the number of ended events played. The file is streamed as it is read, so it is not limited in size. The format is csv by default, json for an array of rankings, or tsv for tab separated values that spreadsheet programs such as Excel open directly. With eventId or asOf, the rankings are computed as they stood once that event ended, or from the ended events that started by that time. A date for asOf includes every event that started on that day.
 // @Tags Rankings
-// @Produce octet-stream
+// @Produce text/csv
+// @Produce json
+// @Produce text/tab-separated-values
 // @Param semesterId path string true "Semester ID"
-// @Success 200 {file} file "Rankings exported successfully"
+// @Param format query string false "Format of the export (default csv)" Enums(csv, json, tsv)
+// @Param eventId query int false "Export the rankings as they stood once this ended event ended"
+// @Param asOf query string false "Export the rankings as of this date (YYYY-MM-DD) or RFC 3339 time"
+// @Success 200 {array} models.RankingExportRow "Rankings in the requested format"
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /semesters/{semesterId}/rankings/export [get]
 func (c *rankingsController) exportRankings(ctx *gin.Context) {
@@ -153,8 +307,58 @@ func (c *rankingsController) exportRankings(ctx *gin.Context) {
 		return
 	}
 
-	svc := services.NewSemesterService(c.db)
-	fp, err := svc.ExportRankings(semesterID)
+	opts, err := parseRankingExportOptions(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	filename := "rankings-" + semesterID.String()
+	if opts.EventID != nil {
+		filename += fmt.Sprintf("-event-%d", *opts.EventID)
+	} else if opts.AsOf != nil {
+		filename += "-" + opts.AsOf.Format(time.DateOnly)
+	}
+
+	svc := services.NewRankingService(c.db)
+	StreamAttachment(ctx, filename+"."+opts.Format, rankingExportContentTypes[opts.Format], func(w io.Writer) error {
+		return svc.ExportRankings(w, semesterID, opts)
+	})
+}
+
+// rebuildRankings handles recalculating the rankings for a semester
+//
+// @Summary Rebuild rankings
+// @Description Recalculate every ranking's points and attendance in a semester from the placements of its ended events. With dryRun the changes are reported without being saved.
+// @Tags Rankings
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param dryRun query bool false "Report the changes without saving them"
+// @Success 200 {object} RebuildRankingsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/rankings/rebuild [post]
+func (c *rankingsController) rebuildRankings(ctx *gin.Context) {
+	semesterID, err := validateSemesterID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	dryRun := false
+	if value := ctx.Query("dryRun"); value != "" {
+		if value != "true" && value != "false" {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest("dryRun must be true or false"))
+			return
+		}
+		dryRun = value == "true"
+	}
+
+	svc := services.NewRankingService(c.db)
+	result, err := svc.RebuildRankings(semesterID, dryRun)
 	if err != nil {
 		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
 			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
@@ -165,14 +369,5 @@ func (c *rankingsController) exportRankings(ctx *gin.Context) {
 		return
 	}
 
-	filename := filepath.Base(fp)
-	defer func() {
-		err := os.Remove(fp)
-		if err != nil {
-			// Log the error but do not interrupt the response
-			log.Println("Failed to remove temporary file:", err)
-		}
-	}()
-
-	ctx.FileAttachment(fp, filename)
+	ctx.JSON(http.StatusOK, result)
 }
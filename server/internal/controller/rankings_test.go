@@ -3,6 +3,7 @@ package controller_test
 import (
 	"api/internal/authorization"
 	"api/internal/models"
+	"api/internal/services"
 	"api/internal/testutils"
 	"bytes"
 	"context"
@@ -12,8 +13,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -538,7 +541,7 @@ func TestExportRankings(t *testing.T) {
 				require.NotEmpty(t, records, "CSV should have at least a header row")
 
 				// Validate header row (now includes position column)
-				require.Equal(t, []string{"position", "id", "first_name", "last_name", "points"}, records[0],
+				require.Equal(t, []string{"position", "id", "first_name", "last_name", "points", "attendance", "events_played"}, records[0],
 					"CSV header should match expected format")
 
 				// For semesters with rankings, validate data rows
@@ -548,7 +551,7 @@ func TestExportRankings(t *testing.T) {
 
 					// Validate each data row has correct number of columns
 					for i := 1; i < len(records); i++ {
-						require.Len(t, records[i], 5, "Each CSV row should have 5 columns")
+						require.Len(t, records[i], 7, "Each CSV row should have 7 columns")
 						require.NotEmpty(t, records[i][0], "Position should not be empty")
 						require.NotEmpty(t, records[i][1], "ID should not be empty")
 						require.NotEmpty(t, records[i][2], "First name should not be empty")
@@ -569,3 +572,290 @@ func TestExportRankings(t *testing.T) {
 		})
 	}
 }
+
+func TestExportRankingsFormatsAndSnapshots(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	require.NoError(t, testutils.SeedAll(db))
+	sessionID, err := testutils.CreateTestSession(db, "testuser", authorization.ROLE_SECRETARY.ToString())
+	require.NoError(t, err)
+
+	semesterID := testutils.TEST_SEMESTERS[0].ID
+	doExport := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/semesters/%s/rankings/export%s", semesterID, query), nil)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("json", func(t *testing.T) {
+		w := doExport("?format=json")
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+		require.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Contains(t, w.Header().Get("Content-Disposition"), fmt.Sprintf("rankings-%s.json", semesterID))
+
+		var rows []models.RankingExportRow
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rows))
+		require.Len(t, rows, len(testutils.TEST_RANKINGS))
+		require.EqualValues(t, 1, rows[0].Position)
+	})
+
+	t.Run("tsv", func(t *testing.T) {
+		w := doExport("?format=tsv")
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+		require.Equal(t, "text/tab-separated-values; charset=utf-8", w.Header().Get("Content-Type"))
+		require.True(t, strings.HasPrefix(w.Body.String(), "\ufeffposition\tid\t"))
+	})
+
+	t.Run("as of an event", func(t *testing.T) {
+		// Only the first two memberships played the ended event
+		w := doExport("?eventId=1")
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+		require.Contains(t, w.Header().Get("Content-Disposition"), "-event-1.csv")
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		require.Equal(t, []string{"1", "1"}, records[1][5:])
+	})
+
+	t.Run("as of a date before the semester", func(t *testing.T) {
+		w := doExport("?asOf=2023-01-01")
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+	})
+
+	t.Run("event in another semester", func(t *testing.T) {
+		w := doExport("?eventId=3")
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Event 3 not found in this semester")
+	})
+
+	t.Run("event that has not ended", func(t *testing.T) {
+		w := doExport("?eventId=2")
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "Event 2 has not ended")
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		for _, query := range []string{"?format=xlsx", "?eventId=abc", "?asOf=yesterday", "?eventId=1&asOf=2023-10-01"} {
+			w := doExport(query)
+			require.Equal(t, http.StatusBadRequest, w.Code, "Query %s: %s", query, w.Body.String())
+		}
+	})
+}
+
+func TestRankingHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	semesterID := testutils.TEST_SEMESTERS[0].ID
+	membershipID := testutils.TEST_MEMBERSHIPS[0].ID
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", fmt.Sprintf("/api/v2/semesters/%s/rankings/history", semesterID), []string{})
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", fmt.Sprintf("/api/v2/semesters/%s/rankings/%s/history", semesterID, membershipID), []string{})
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedAll(db))
+
+	// Ending the started event records the first snapshot
+	require.NoError(t, services.NewEventService(db).EndEvent(testutils.TEST_EVENTS[1].ID))
+
+	sessionID, err := testutils.CreateTestSession(db, "testuser", authorization.ROLE_BOT.ToString())
+	require.NoError(t, err)
+
+	doGet := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/v2/semesters/%s/rankings/%s", semesterID, path), nil)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("semester history", func(t *testing.T) {
+		w := doGet("history")
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var response models.ListResponse[models.RankingHistorySnapshot]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.EqualValues(t, 1, response.Total)
+		require.Len(t, response.Data, 1)
+		require.Equal(t, models.RankingSnapshotReasonEventEnded, response.Data[0].Reason)
+		require.Equal(t, testutils.TEST_EVENTS[1].ID, *response.Data[0].EventID)
+		require.Len(t, response.Data[0].Rankings, len(testutils.TEST_RANKINGS))
+		require.EqualValues(t, 1, response.Data[0].Rankings[0].Position)
+	})
+
+	t.Run("top positions only", func(t *testing.T) {
+		w := doGet("history?top=1")
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var response models.ListResponse[models.RankingHistorySnapshot]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		for _, ranking := range response.Data[0].Rankings {
+			require.EqualValues(t, 1, ranking.Position)
+		}
+	})
+
+	t.Run("invalid top", func(t *testing.T) {
+		w := doGet("history?top=0")
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "top must be a positive integer")
+	})
+
+	t.Run("membership history", func(t *testing.T) {
+		w := doGet(membershipID.String() + "/history")
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var history []models.RankingHistoryPoint
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+		require.Len(t, history, 1)
+		require.Equal(t, testutils.TEST_EVENTS[1].Name, history[0].EventName)
+	})
+
+	t.Run("membership not found", func(t *testing.T) {
+		w := doGet(uuid.New().String() + "/history")
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Membership not found")
+	})
+}
+
+func TestRebuildRankings(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	unauthorizedRoles := []string{"bot", "executive", "tournament_director", "secretary", "treasurer", "vice_president"}
+	testutils.TestInvalidAuthForEndpoint(
+		t,
+		container,
+		apiServer,
+		"POST",
+		fmt.Sprintf("/api/v2/semesters/%s/rankings/rebuild", testutils.TEST_SEMESTERS[0].ID),
+		unauthorizedRoles,
+	)
+
+	testCases := []struct {
+		name                 string
+		userRole             string
+		semesterID           string
+		query                string
+		expectedStatus       int
+		expectedErrorMessage string
+		expectedDryRun       bool
+	}{
+		{
+			name:           "dry run - PRESIDENT role",
+			userRole:       authorization.ROLE_PRESIDENT.ToString(),
+			semesterID:     testutils.TEST_SEMESTERS[0].ID.String(),
+			query:          "?dryRun=true",
+			expectedStatus: http.StatusOK,
+			expectedDryRun: true,
+		},
+		{
+			name:           "rebuild - WEBMASTER role",
+			userRole:       authorization.ROLE_WEBMASTER.ToString(),
+			semesterID:     testutils.TEST_SEMESTERS[0].ID.String(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:                 "invalid dryRun value",
+			userRole:             authorization.ROLE_WEBMASTER.ToString(),
+			semesterID:           testutils.TEST_SEMESTERS[0].ID.String(),
+			query:                "?dryRun=yes",
+			expectedStatus:       http.StatusBadRequest,
+			expectedErrorMessage: "dryRun must be true or false",
+		},
+		{
+			name:                 "semester not found",
+			userRole:             authorization.ROLE_WEBMASTER.ToString(),
+			semesterID:           "550e8400-e29b-41d4-a716-446655440099",
+			expectedStatus:       http.StatusNotFound,
+			expectedErrorMessage: "Semester not found",
+		},
+		{
+			name:                 "invalid semester ID format",
+			userRole:             authorization.ROLE_WEBMASTER.ToString(),
+			semesterID:           "invalid-uuid",
+			expectedStatus:       http.StatusBadRequest,
+			expectedErrorMessage: "Semester ID 'invalid-uuid' is not a valid UUID",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, container.ResetDatabase(ctx))
+			require.NoError(t, testutils.SeedAll(db))
+
+			sessionID, err := testutils.CreateTestSession(db, "testuser", tc.userRole)
+			require.NoError(t, err)
+
+			req, err := testutils.MakeJSONRequest(
+				"POST",
+				fmt.Sprintf("/api/v2/semesters/%s/rankings/rebuild%s", tc.semesterID, tc.query),
+				nil,
+			)
+			require.NoError(t, err)
+			testutils.SetAuthCookie(req, sessionID)
+
+			w := httptest.NewRecorder()
+			apiServer.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedStatus, w.Code, "Response: %s", w.Body.String())
+
+			if tc.expectedErrorMessage != "" {
+				require.Contains(t, w.Body.String(), tc.expectedErrorMessage)
+				return
+			}
+
+			var resp models.RebuildRankingsResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			require.Equal(t, tc.semesterID, resp.SemesterID.String())
+			require.Equal(t, tc.expectedDryRun, resp.DryRun)
+
+			// Only the first seeded event has ended, with two entries
+			expectedPoints := map[uuid.UUID]int32{
+				testutils.TEST_MEMBERSHIPS[0].ID: int32(services.CalculatePoints(2, 1, 1.0)),
+				testutils.TEST_MEMBERSHIPS[1].ID: int32(services.CalculatePoints(2, 2, 1.0)),
+			}
+			require.Equal(t, 1, resp.EventsProcessed)
+			for _, diff := range resp.Diffs {
+				require.Equal(t, expectedPoints[diff.MembershipID], diff.Points, "Membership %s", diff.MembershipID)
+			}
+
+			for _, diff := range resp.Diffs {
+				var ranking models.Ranking
+				require.NoError(t, db.Where("membership_id = ?", diff.MembershipID).First(&ranking).Error)
+				if tc.expectedDryRun {
+					require.Equal(t, diff.PreviousPoints, ranking.Points)
+				} else {
+					require.Equal(t, diff.Points, ranking.Points)
+					require.Equal(t, diff.Attendance, ranking.Attendance)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	apierrors "api/internal/errors"
+	"api/internal/models"
+	"api/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// validateEventPath validates the semester and event IDs from the path. It
+// aborts the request and returns false if either is invalid.
+func (c *entriesController) validateEventPath(ctx *gin.Context) (uuid.UUID, int32, bool) {
+	semesterID, err := c.validateSemesterID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		return uuid.Nil, 0, false
+	}
+
+	eventID, err := c.validateEventID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		return uuid.Nil, 0, false
+	}
+
+	return semesterID, eventID, true
+}
+
+// abortWithError aborts the request with the error returned by a service.
+func (c *entriesController) abortWithError(ctx *gin.Context, err error) {
+	if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+		ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+		return
+	}
+	ctx.AbortWithStatusJSON(
+		http.StatusInternalServerError,
+		apierrors.InternalServerError(err.Error()),
+	)
+}
+
+// listRegistrations handles listing the registrations and waitlist of an event.
+//
+// @Summary List Registrations
+// @Description List the members registered for an event, followed by its waitlist in order
+// @Tags Entries
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Success 200 {array} Registration
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/registrations [get]
+func (c *entriesController) listRegistrations(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateEventPath(ctx)
+	if !ok {
+		return
+	}
+
+	svc := services.NewRegistrationService(c.db)
+	registrations, err := svc.ListRegistrations(semesterID, eventID)
+	if err != nil {
+		c.abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, registrations)
+}
+
+// createRegistration handles registering a member for an event before it starts.
+//
+// @Summary Create Registration
+// @Description Register a member for an event before it starts. Once the event is full, the member is added to the end of its waitlist
+// @Tags Entries
+// @Accept json
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Param registration body CreateRegistrationRequest true "Membership to register"
+// @Success 201 {object} Registration
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/registrations [post]
+func (c *entriesController) createRegistration(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateEventPath(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.CreateRegistrationRequest
+	if !BindJSON(ctx, &req) {
+		return
+	}
+
+	svc := services.NewRegistrationService(c.db)
+	registration, err := svc.Register(semesterID, eventID, &req)
+	if err != nil {
+		c.abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, registration)
+}
+
+// convertRegistrations handles entering the registered members into an event.
+//
+// @Summary Convert Registrations
+// @Description Enter every member holding a spot in the event and draw their seats. Waitlisted members stay on the waitlist
+// @Tags Entries
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Success 200 {array} Participant
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/registrations/convert [post]
+func (c *entriesController) convertRegistrations(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateEventPath(ctx)
+	if !ok {
+		return
+	}
+
+	svc := services.NewRegistrationService(c.db)
+	participants, err := svc.ConvertRegistrations(semesterID, eventID)
+	if err != nil {
+		c.abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, participants)
+}
+
+// deleteRegistration handles withdrawing a registration from an event.
+//
+// @Summary Delete Registration
+// @Description Withdraw a registration from an event. The spot it held goes to the first member on the waitlist
+// @Tags Entries
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Param registrationId path int true "Registration ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/registrations/{registrationId} [delete]
+func (c *entriesController) deleteRegistration(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateEventPath(ctx)
+	if !ok {
+		return
+	}
+
+	registrationIDStr := ctx.Param("registrationId")
+	registrationID, err := strconv.ParseInt(registrationIDStr, 10, 32)
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			apierrors.InvalidRequest(
+				fmt.Sprintf("Registration ID '%s' is not a valid integer", registrationIDStr),
+			),
+		)
+		return
+	}
+
+	svc := services.NewRegistrationService(c.db)
+	if err := svc.Withdraw(semesterID, eventID, int32(registrationID)); err != nil {
+		c.abortWithError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,211 @@
+package controller_test
+
+import (
+	"api/internal/authorization"
+	"api/internal/models"
+	"api/internal/testutils"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistrations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	semester := testutils.TEST_SEMESTERS[0]
+	eventPath := fmt.Sprintf("/api/v2/semesters/%s/events/2", semester.ID)
+	registrationsPath := eventPath + "/registrations"
+
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "POST", registrationsPath, []string{"bot", "executive"})
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", registrationsPath, []string{"bot"})
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "POST", registrationsPath+"/convert", []string{"bot", "executive"})
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedAll(db))
+
+	sessionID, err := testutils.CreateTestSession(db, "director", authorization.ROLE_TOURNAMENT_DIRECTOR.ToString())
+	require.NoError(t, err)
+
+	do := func(method string, path string, body any) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, body)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	list := func(t *testing.T) []models.Registration {
+		w := do("GET", registrationsPath, nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var registrations []models.Registration
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &registrations))
+		return registrations
+	}
+
+	// Event 2 already has entries for the first and third memberships. The
+	// fourth member joins the semester so that the event can overflow
+	late := models.Membership{UserID: testutils.TEST_USERS[3].ID, SemesterID: semester.ID}
+	require.NoError(t, db.Create(&late).Error)
+	require.NoError(t, db.Model(&models.Event{}).Where("id = ?", 2).Update("start_date", time.Now().Add(24*time.Hour)).Error)
+
+	t.Run("set max entries", func(t *testing.T) {
+		w := do("PATCH", eventPath, map[string]any{"maxEntries": 3})
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var event models.Event
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &event))
+		require.NotNil(t, event.MaxEntries)
+		require.EqualValues(t, 3, *event.MaxEntries)
+
+		w = do("PATCH", eventPath, map[string]any{"maxEntries": 0})
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "Error converting request to update map: maxEntries must be between 1 and 65535")
+	})
+
+	t.Run("register", func(t *testing.T) {
+		w := do("POST", registrationsPath, map[string]any{"membershipId": testutils.TEST_MEMBERSHIPS[1].ID})
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		var registration models.Registration
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &registration))
+		require.Equal(t, models.RegistrationStatusRegistered, registration.Status)
+		require.Zero(t, registration.Position)
+	})
+
+	t.Run("register once full", func(t *testing.T) {
+		w := do("POST", registrationsPath, map[string]any{"membershipId": late.ID})
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		var registration models.Registration
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &registration))
+		require.Equal(t, models.RegistrationStatusWaitlisted, registration.Status)
+		require.Equal(t, 1, registration.Position)
+	})
+
+	t.Run("register rejects", func(t *testing.T) {
+		w := do("POST", registrationsPath, map[string]any{"membershipId": testutils.TEST_MEMBERSHIPS[1].ID})
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, fmt.Sprintf("Membership %s is already registered for this event", testutils.TEST_MEMBERSHIPS[1].ID))
+
+		w = do("POST", registrationsPath, map[string]any{"membershipId": testutils.TEST_MEMBERSHIPS[0].ID})
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, fmt.Sprintf("Membership %s already has an entry in this event", testutils.TEST_MEMBERSHIPS[0].ID))
+
+		missing := uuid.New()
+		w = do("POST", registrationsPath, map[string]any{"membershipId": missing})
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, fmt.Sprintf("Membership %s not found", missing))
+
+		w = do("POST", fmt.Sprintf("/api/v2/semesters/%s/events/1/registrations", semester.ID), map[string]any{"membershipId": late.ID})
+		testutils.AssertErrorResponse(t, w, http.StatusForbidden, "Registration is closed because the event has ended")
+	})
+
+	t.Run("list", func(t *testing.T) {
+		registrations := list(t)
+		require.Len(t, registrations, 2)
+
+		require.Equal(t, testutils.TEST_MEMBERSHIPS[1].ID, registrations[0].MembershipID)
+		require.Equal(t, models.RegistrationStatusRegistered, registrations[0].Status)
+		require.NotNil(t, registrations[0].Membership)
+		require.NotNil(t, registrations[0].Membership.User)
+
+		require.Equal(t, late.ID, registrations[1].MembershipID)
+		require.Equal(t, models.RegistrationStatusWaitlisted, registrations[1].Status)
+		require.Equal(t, 1, registrations[1].Position)
+	})
+
+	t.Run("deleting an entry promotes the waitlist", func(t *testing.T) {
+		w := do("DELETE", fmt.Sprintf("%s/entries/%s", eventPath, testutils.TEST_MEMBERSHIPS[0].ID), nil)
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+
+		registrations := list(t)
+		require.Len(t, registrations, 2)
+		for _, registration := range registrations {
+			require.Equal(t, models.RegistrationStatusRegistered, registration.Status)
+		}
+	})
+
+	t.Run("convert", func(t *testing.T) {
+		w := do("POST", registrationsPath+"/convert", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var participants []models.Participant
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &participants))
+		require.Len(t, participants, 2)
+		for _, participant := range participants {
+			require.NotNil(t, participant.TableNumber)
+			require.NotNil(t, participant.SeatNumber)
+		}
+
+		require.Empty(t, list(t))
+
+		var entries int64
+		require.NoError(t, db.Model(&models.Participant{}).Where("event_id = ?", 2).Count(&entries).Error)
+		require.EqualValues(t, 3, entries)
+	})
+
+	var waitlisted models.Registration
+	t.Run("entering a full event waitlists", func(t *testing.T) {
+		w := do("POST", eventPath+"/entries", []string{testutils.TEST_MEMBERSHIPS[0].ID.String()})
+		require.Equal(t, http.StatusMultiStatus, w.Code, "Response: %s", w.Body.String())
+
+		var results []models.CreateEntryResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &results))
+		require.Len(t, results, 1)
+		require.Equal(t, "waitlisted", results[0].Status)
+		require.Nil(t, results[0].Participant)
+		require.NotNil(t, results[0].Registration)
+		require.Equal(t, 1, results[0].Registration.Position)
+		waitlisted = *results[0].Registration
+
+		w = do("POST", eventPath+"/guests", map[string]any{"name": "Sam Walker"})
+		testutils.AssertErrorResponse(t, w, http.StatusForbidden, "The event is full")
+	})
+
+	t.Run("registration closes when the event starts", func(t *testing.T) {
+		require.NoError(t, db.Model(&models.Event{}).Where("id = ?", 2).Update("start_date", time.Now().Add(-time.Hour)).Error)
+
+		w := do("POST", registrationsPath, map[string]any{"membershipId": testutils.TEST_MEMBERSHIPS[1].ID})
+		testutils.AssertErrorResponse(t, w, http.StatusForbidden, "Registration is closed because the event has started")
+	})
+
+	t.Run("withdraw", func(t *testing.T) {
+		w := do("DELETE", fmt.Sprintf("%s/%d", registrationsPath, waitlisted.ID), nil)
+		require.Equal(t, http.StatusNoContent, w.Code, "Response: %s", w.Body.String())
+		require.Empty(t, list(t))
+
+		w = do("DELETE", fmt.Sprintf("%s/%d", registrationsPath, waitlisted.ID), nil)
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Registration not found")
+	})
+
+	t.Run("removing max entries promotes the waitlist", func(t *testing.T) {
+		w := do("POST", eventPath+"/entries", []string{testutils.TEST_MEMBERSHIPS[0].ID.String()})
+		require.Equal(t, http.StatusMultiStatus, w.Code, "Response: %s", w.Body.String())
+
+		w = do("PATCH", eventPath, map[string]any{"maxEntries": nil})
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		registrations := list(t)
+		require.Len(t, registrations, 1)
+		require.Equal(t, models.RegistrationStatusRegistered, registrations[0].Status)
+	})
+
+	t.Run("convert in an ended event", func(t *testing.T) {
+		w := do("POST", fmt.Sprintf("/api/v2/semesters/%s/events/1/registrations/convert", semester.ID), nil)
+		testutils.AssertErrorResponse(t, w, http.StatusForbidden, "Modification of a completed event is forbidden")
+	})
+}
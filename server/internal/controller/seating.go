@@ -0,0 +1,166 @@
+package controller
+
+import (
+	apierrors "api/internal/errors"
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type seatingController struct {
+	db *gorm.DB
+}
+
+// NewSeatingController creates a new instance of seatingController
+func NewSeatingController(db *gorm.DB) Controller {
+	return &seatingController{db: db}
+}
+
+func (c *seatingController) LoadRoutes(router *gin.RouterGroup) {
+	seating := router.Group("semesters/:semesterId/events/:eventId/seating", middleware.UseAuthentication(c.db))
+	seating.GET("", middleware.UseAuthorization("event.seating.get"), c.getSeating)
+	seating.GET("break-plan", middleware.UseAuthorization("event.seating.get"), c.getTableBreakPlan)
+	seating.POST("break-table", middleware.UseAuthorization("event.seating.edit"), c.breakTable)
+}
+
+// validateParams validates the semester and event IDs from the path. It aborts
+// the request and returns false if either is invalid.
+func (c *seatingController) validateParams(ctx *gin.Context) (uuid.UUID, int32, bool) {
+	semesterID, err := validateSemesterID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		return uuid.Nil, 0, false
+	}
+
+	eventIDStr := ctx.Param("eventId")
+	eventID, err := strconv.ParseInt(eventIDStr, 10, 32)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(
+			fmt.Sprintf("Event ID '%s' is not a valid integer", eventIDStr),
+		))
+		return uuid.Nil, 0, false
+	}
+
+	return semesterID, int32(eventID), true
+}
+
+// respond writes the response, or the error returned by the seating service.
+func (c *seatingController) respond(ctx *gin.Context, response any, err error) {
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// getSeating handles retrieving the tables of an event and who is seated at them
+//
+// @Summary Get event seating
+// @Description Get the tables of an event with the seat of every player still in. Seats are drawn when players are entered or sign back in, and tables are rebalanced when players sign out.
+// @Tags Seating
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Success 200 {object} SeatingResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/seating [get]
+func (c *seatingController) getSeating(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateParams(ctx)
+	if !ok {
+		return
+	}
+
+	svc := services.NewSeatingService(c.db)
+	seating, err := svc.GetSeating(semesterID, eventID)
+	c.respond(ctx, seating, err)
+}
+
+// getTableBreakPlan handles previewing how a table would be broken
+//
+// @Summary Get table break plan
+// @Description Get the seat each player of a table would move to if the table was broken. The plan is the same one Break Table applies, as long as no players enter or sign out in between.
+// @Tags Seating
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Param table query int false "Table to break. Defaults to the table with the fewest players"
+// @Success 200 {object} TableBreakPlan
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/seating/break-plan [get]
+func (c *seatingController) getTableBreakPlan(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateParams(ctx)
+	if !ok {
+		return
+	}
+
+	var table *int32
+	if tableStr := ctx.Query("table"); tableStr != "" {
+		number, err := strconv.ParseInt(tableStr, 10, 32)
+		if err != nil || number <= 0 {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(
+				fmt.Sprintf("Table '%s' is not a valid table number", tableStr),
+			))
+			return
+		}
+		t := int32(number)
+		table = &t
+	}
+
+	svc := services.NewSeatingService(c.db)
+	plan, err := svc.GetTableBreakPlan(semesterID, eventID, table)
+	c.respond(ctx, plan, err)
+}
+
+// breakTable handles breaking a table and moving its players to the other tables
+//
+// @Summary Break table
+// @Description Break a table, moving each of its players to an open seat at the other tables
+// @Tags Seating
+// @Accept json
+// @Produce json
+// @Param semesterId path string true "Semester ID"
+// @Param eventId path string true "Event ID"
+// @Param request body BreakTableRequest false "Table to break"
+// @Success 200 {object} TableBreakPlan
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /semesters/{semesterId}/events/{eventId}/seating/break-table [post]
+func (c *seatingController) breakTable(ctx *gin.Context) {
+	semesterID, eventID, ok := c.validateParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.BreakTableRequest
+	if ctx.Request.ContentLength != 0 && !BindJSON(ctx, &req) {
+		return
+	}
+
+	svc := services.NewSeatingService(c.db)
+	plan, err := svc.BreakTable(semesterID, eventID, req.Table)
+	c.respond(ctx, plan, err)
+}
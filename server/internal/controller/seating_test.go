@@ -0,0 +1,127 @@
+package controller_test
+
+import (
+	"api/internal/authorization"
+	"api/internal/models"
+	"api/internal/testutils"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSeating(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	eventPath := fmt.Sprintf("/api/v2/semesters/%s/events/2", testutils.TEST_SEMESTERS[0].ID)
+
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", eventPath+"/seating", []string{})
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "POST", eventPath+"/seating/break-table", []string{"bot", "executive"})
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedAll(db))
+
+	// Seat the seeded players of event 2 alone at two tables of three
+	require.NoError(t, db.Model(&models.Event{}).Where("id = ?", 2).Update("table_size", 3).Error)
+	require.NoError(t, db.Model(&models.Participant{}).
+		Where("event_id = ? AND membership_id = ?", 2, testutils.TEST_MEMBERSHIPS[2].ID).
+		Updates(map[string]any{"table_number": 1, "seat_number": 1}).Error)
+	require.NoError(t, db.Model(&models.Participant{}).
+		Where("event_id = ? AND membership_id = ?", 2, testutils.TEST_MEMBERSHIPS[0].ID).
+		Updates(map[string]any{"signed_out_at": nil, "table_number": 2, "seat_number": 1}).Error)
+
+	sessionID, err := testutils.CreateTestSession(db, "director", authorization.ROLE_TOURNAMENT_DIRECTOR.ToString())
+	require.NoError(t, err)
+
+	do := func(method string, path string, body any) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, body)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	getSeating := func(t *testing.T) models.SeatingResponse {
+		w := do("GET", eventPath+"/seating", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var seating models.SeatingResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &seating))
+		return seating
+	}
+
+	t.Run("new entry is seated", func(t *testing.T) {
+		w := do("POST", eventPath+"/entries", []string{testutils.TEST_MEMBERSHIPS[1].ID.String()})
+		require.Equal(t, http.StatusMultiStatus, w.Code, "Response: %s", w.Body.String())
+
+		var results []map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &results))
+		require.Len(t, results, 1)
+		participant := results[0]["participant"].(map[string]any)
+		require.NotNil(t, participant["tableNumber"])
+		require.NotNil(t, participant["seatNumber"])
+		require.NotEqual(t, float64(1), participant["seatNumber"])
+
+		seating := getSeating(t)
+		require.Equal(t, uint8(3), seating.TableSize)
+		require.Len(t, seating.Tables, 2)
+		players := 0
+		for _, table := range seating.Tables {
+			players += len(table.Seats)
+		}
+		require.Equal(t, 3, players)
+	})
+
+	t.Run("invalid table", func(t *testing.T) {
+		w := do("GET", eventPath+"/seating/break-plan?table=abc", nil)
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "Table 'abc' is not a valid table number")
+	})
+
+	t.Run("break plan", func(t *testing.T) {
+		w := do("GET", eventPath+"/seating/break-plan", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var plan models.TableBreakPlan
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &plan))
+		require.Len(t, plan.Moves, 1)
+		require.Equal(t, plan.Table, plan.Moves[0].FromTable)
+		require.NotEqual(t, plan.Table, plan.Moves[0].ToTable)
+
+		// Planning a break does not move anyone
+		require.Len(t, getSeating(t).Tables, 2)
+	})
+
+	t.Run("break table", func(t *testing.T) {
+		w := do("POST", eventPath+"/seating/break-table", nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		seating := getSeating(t)
+		require.Len(t, seating.Tables, 1)
+		require.Len(t, seating.Tables[0].Seats, 3)
+	})
+
+	t.Run("break the last table", func(t *testing.T) {
+		w := do("POST", eventPath+"/seating/break-table", nil)
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "There are fewer than two tables, so no table can be broken")
+	})
+
+	t.Run("ended event", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v2/semesters/%s/events/1/seating/break-table", testutils.TEST_SEMESTERS[0].ID)
+		w := do("POST", path, nil)
+		testutils.AssertErrorResponse(t, w, http.StatusForbidden, "Modification of a completed event is forbidden")
+	})
+}
@@ -61,6 +61,7 @@ func (s *semestersController) createSemester(ctx *gin.Context) {
 		MembershipFee:         req.MembershipFee,
 		MembershipDiscountFee: req.MembershipDiscountFee,
 		RebuyFee:              req.RebuyFee,
+		AddOnFee:              req.AddOnFee,
 	}
 
 	if err := s.store.Semesters().Create(&semester); err != nil {
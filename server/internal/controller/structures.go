@@ -4,11 +4,15 @@ import (
 	apierrors "api/internal/errors"
 	"api/internal/middleware"
 	"api/internal/models"
+	"api/internal/services"
 	"api/internal/store"
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -27,7 +31,12 @@ func (s *structuresController) LoadRoutes(router *gin.RouterGroup) {
 	group := router.Group("structures", middleware.UseAuthentication(s.db))
 	group.GET("", middleware.UseAuthorization("structure.list"), s.listStructures)
 	group.POST("", middleware.UseAuthorization("structure.create"), s.createStructure)
+	group.POST("import", middleware.UseAuthorization("structure.create"), s.importStructure)
 	group.GET(":id", middleware.UseAuthorization("structure.get"), s.getStructure)
+	group.GET(":id/export", middleware.UseAuthorization("structure.get"), s.exportStructure)
+	group.GET(":id/versions", middleware.UseAuthorization("structure.get"), s.listStructureVersions)
+	group.GET(":id/versions/:version", middleware.UseAuthorization("structure.get"), s.getStructureVersion)
+	group.POST(":id/clone", middleware.UseAuthorization("structure.create"), s.cloneStructure)
 	group.PATCH(":id", middleware.UseAuthorization("structure.edit"), s.updateStructure)
 	group.DELETE(":id", middleware.UseAuthorization("structure.delete"), s.deleteStructure)
 }
@@ -88,23 +97,12 @@ func (s *structuresController) createStructure(ctx *gin.Context) {
 		return
 	}
 
-  blinds := make([]models.Blind, len(req.Blinds))
-  for i, blind := range req.Blinds {
-    blinds[i] = models.Blind{
-      Small: blind.Small,
-      Big:   blind.Big,
-      Ante:  blind.Ante,
-      Time:  blind.Time,
-      Index: int8(i),
-    }
-  }
-
   structure := models.Structure{
     Name: req.Name,
-    Blinds: blinds,
+    Blinds: models.NewBlindsFromJSON(0, req.Blinds),
   }
 
-  if err := s.store.Structures().Create(&structure); err != nil {
+  if err := s.createStructureWithVersion(&structure); err != nil {
 		ctx.AbortWithStatusJSON(
 			http.StatusInternalServerError,
 			apierrors.InternalServerError(err.Error()),
@@ -115,6 +113,174 @@ func (s *structuresController) createStructure(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, structure)
 }
 
+// importStructure handles creating a structure from a CSV or JSON file exported
+// by another tournament clock.
+//
+// @Summary Import Structure
+// @Description Create a new structure from CSV or the JSON interchange format. CSV files need a header row naming the columns: small, big and time (in minutes) are required, type, label and ante are optional and unknown columns are ignored. Every level is validated with the same rules as Create Structure, and all invalid levels are reported together.
+// @Tags Structures
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Param format query string false "Format of the request body, csv or json. Defaults to csv when the Content-Type is text/csv, otherwise json"
+// @Param name query string false "Name of the structure. Required for CSV, overrides the name in JSON"
+// @Param structure body models.StructureInterchange true "Structure to import"
+// @Success 201 {object} models.Structure
+// @Failure 400 {object} models.StructureImportErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /structures/import [post]
+func (s *structuresController) importStructure(ctx *gin.Context) {
+	format := ctx.Query("format")
+	if format == "" {
+		format = models.StructureFormatJSON
+		if ctx.ContentType() == "text/csv" {
+			format = models.StructureFormatCSV
+		}
+	}
+
+	name := strings.TrimSpace(ctx.Query("name"))
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, apierrors.RequestEntityTooLarge("request body too large"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	var (
+		levels    []models.BlindJSON
+		rowErrors []models.StructureImportRowError
+	)
+	switch format {
+	case models.StructureFormatCSV:
+		if name == "" {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest("name is required when importing CSV"))
+			return
+		}
+		levels, rowErrors, err = services.ParseStructureCSV(bytes.NewReader(body))
+	case models.StructureFormatJSON:
+		var structure *models.StructureInterchange
+		structure, rowErrors, err = services.ParseStructureJSON(bytes.NewReader(body))
+		if structure != nil {
+			levels = structure.Levels
+			if name == "" {
+				name = strings.TrimSpace(structure.Name)
+			}
+		}
+	default:
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest("format must be csv or json"))
+		return
+	}
+
+	if err != nil {
+		if apiErr, ok := err.(apierrors.APIErrorResponse); ok {
+			ctx.AbortWithStatusJSON(apiErr.Code, apiErr)
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	if len(rowErrors) > 0 {
+		invalidRows := make(map[int]bool)
+		for _, rowErr := range rowErrors {
+			invalidRows[rowErr.Row] = true
+		}
+		message := fmt.Sprintf("The structure has %d invalid levels", len(invalidRows))
+		if len(invalidRows) == 1 {
+			message = "The structure has 1 invalid level"
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, models.StructureImportErrorResponse{
+			Code:    http.StatusBadRequest,
+			Type:    "INVALID_REQUEST",
+			Message: message,
+			Errors:  rowErrors,
+		})
+		return
+	}
+
+	if name == "" {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest("The structure must have a name"))
+		return
+	}
+
+	structure := models.Structure{
+		Name:   name,
+		Blinds: models.NewBlindsFromJSON(0, levels),
+	}
+
+	if err := s.createStructureWithVersion(&structure); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, structure)
+}
+
+// exportStructure handles downloading a structure as CSV or JSON so that it
+// can be used in another tournament clock.
+//
+// @Summary Export Structure
+// @Description Download a structure as CSV or in the JSON interchange format accepted by Import Structure
+// @Tags Structures
+// @Produce json
+// @Produce text/csv
+// @Param id path string true "Structure ID"
+// @Param format query string false "Export format, csv or json (default json)"
+// @Success 200 {object} models.StructureInterchange
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /structures/{id}/export [get]
+func (s *structuresController) exportStructure(ctx *gin.Context) {
+	id, err := s.parseStructureID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	format := ctx.DefaultQuery("format", models.StructureFormatJSON)
+	if format != models.StructureFormatCSV && format != models.StructureFormatJSON {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest("format must be csv or json"))
+		return
+	}
+
+	structure, err := s.store.Structures().FindByID(id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, apierrors.NotFound("Structure not found"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="structure-%d.%s"`, id, format))
+
+	if format == models.StructureFormatJSON {
+		ctx.JSON(http.StatusOK, services.NewStructureInterchange(structure))
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := services.WriteStructureCSV(&buf, structure); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
 // getStructure handles the retrieval of a specific structure by its ID.
 // It expects the structure ID as a URL parameter.
 //
@@ -220,24 +386,27 @@ func (s *structuresController) updateStructure(ctx *gin.Context) {
 	}
 
 	if blindsData, ok := updateMap["blinds"]; ok {
-		blindsJSON := blindsData.([]models.BlindJSON)
-		blinds := make([]models.Blind, len(blindsJSON))
-		for i, b := range blindsJSON {
-			blinds[i] = models.Blind{
-				Small:       b.Small,
-				Big:         b.Big,
-				Ante:        b.Ante,
-				Time:        b.Time,
-				StructureId: id,
-				Index:       int8(i),
-			}
-		}
+		blinds := models.NewBlindsFromJSON(id, blindsData.([]models.BlindJSON))
 		if err := tx.Structures().ReplaceBlindsByStructureID(id, blinds); err != nil {
 			ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
 			return
 		}
 	}
 
+	// Record the edit as a new version, so that events pinned to an earlier
+	// version keep their levels
+	updated, err := tx.Structures().FindByID(id)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+	updated.Version++
+	version := models.NewStructureVersion(updated)
+	if err := tx.Structures().CreateVersion(&version); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
 	if err := tx.Commit(); err != nil {
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
 		return
@@ -260,7 +429,7 @@ func (s *structuresController) updateStructure(ctx *gin.Context) {
 // It expects the structure ID as a URL parameter.
 //
 // @Summary Delete Structure
-// @Description Delete an existing structure
+// @Description Delete an existing structure. Structures used by an event cannot be deleted.
 // @Tags Structures
 // @Accept json
 // @Produce json
@@ -279,7 +448,10 @@ func (s *structuresController) deleteStructure(ctx *gin.Context) {
 	}
 
 	err = s.store.Structures().Delete(id)
-	if err != nil && !errors.Is(err, store.ErrNotFound) {
+	if errors.Is(err, store.ErrInUse) {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, apierrors.Forbidden("Structures used by an event cannot be deleted"))
+		return
+	} else if err != nil && !errors.Is(err, store.ErrNotFound) {
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
 		return
 	}
@@ -287,6 +459,207 @@ func (s *structuresController) deleteStructure(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// cloneStructure handles copying a structure, or one of its versions, into a
+// new structure that can be edited separately.
+//
+// @Summary Clone Structure
+// @Description Create a new structure with the levels of an existing structure. The current version is copied unless a version is given.
+// @Tags Structures
+// @Accept json
+// @Produce json
+// @Param id path string true "Structure ID"
+// @Param clone body models.CloneStructureRequest false "Clone options"
+// @Success 201 {object} models.Structure
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /structures/{id}/clone [post]
+func (s *structuresController) cloneStructure(ctx *gin.Context) {
+	id, err := s.parseStructureID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	// The body is optional
+	var req models.CloneStructureRequest
+	if ctx.Request.ContentLength != 0 && !BindJSON(ctx, &req) {
+		return
+	}
+
+	original, err := s.store.Structures().FindByID(id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, apierrors.NotFound("Structure not found"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	if req.Version != nil {
+		version, err := s.store.Structures().FindVersion(id, *req.Version)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				ctx.AbortWithStatusJSON(http.StatusNotFound, apierrors.NotFound("Structure version not found"))
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+			return
+		}
+		original = version.AsStructure()
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = fmt.Sprintf("%s (copy)", original.Name)
+	}
+
+	blinds := make([]models.Blind, len(original.Blinds))
+	for i, blind := range original.Blinds {
+		blinds[i] = models.Blind{
+			Type:  blind.Type,
+			Label: blind.Label,
+			Small: blind.Small,
+			Big:   blind.Big,
+			Ante:  blind.Ante,
+			Time:  blind.Time,
+			Index: int8(i),
+		}
+	}
+
+	structure := models.Structure{
+		Name:   name,
+		Blinds: blinds,
+	}
+
+	if err := s.createStructureWithVersion(&structure); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, structure)
+}
+
+// listStructureVersions handles the retrieval of the version history of a
+// structure, newest first.
+//
+// @Summary List Structure Versions
+// @Description Retrieve every version of a structure with its levels, newest first. A version is recorded each time the structure is created or edited.
+// @Tags Structures
+// @Produce json
+// @Param id path string true "Structure ID"
+// @Param limit query int false "Maximum number of versions to return"
+// @Param offset query int false "Number of versions to skip"
+// @Success 200 {object} models.ListResponse[models.StructureVersion]
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /structures/{id}/versions [get]
+func (s *structuresController) listStructureVersions(ctx *gin.Context) {
+	id, err := s.parseStructureID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	pagination, err := models.ParsePagination(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	if _, err := s.store.Structures().FindByID(id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, apierrors.NotFound("Structure not found"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	versions, total, err := s.store.Structures().ListVersions(id, &pagination)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.ListResponse[models.StructureVersion]{
+		Data:  versions,
+		Total: total,
+	})
+}
+
+// getStructureVersion handles the retrieval of a single version of a structure.
+//
+// @Summary Get Structure Version
+// @Description Retrieve a version of a structure with the levels it had at that version
+// @Tags Structures
+// @Produce json
+// @Param id path string true "Structure ID"
+// @Param version path int true "Version number"
+// @Success 200 {object} models.StructureVersion
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /structures/{id}/versions/{version} [get]
+func (s *structuresController) getStructureVersion(ctx *gin.Context) {
+	id, err := s.parseStructureID(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(err.Error()))
+		return
+	}
+
+	versionParam := ctx.Param("version")
+	versionNumber, err := strconv.ParseInt(versionParam, 10, 32)
+	if err != nil || versionNumber <= 0 {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, apierrors.InvalidRequest(
+			fmt.Sprintf("Version '%s' is not a valid version number", versionParam),
+		))
+		return
+	}
+
+	version, err := s.store.Structures().FindVersion(id, int32(versionNumber))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, apierrors.NotFound("Structure version not found"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierrors.InternalServerError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, version)
+}
+
+// createStructureWithVersion creates a structure and records it as the first
+// version of the structure.
+func (s *structuresController) createStructureWithVersion(structure *models.Structure) error {
+	tx, err := s.store.BeginTx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	structure.Version = 1
+	if err := tx.Structures().Create(structure); err != nil {
+		return err
+	}
+
+	version := models.NewStructureVersion(*structure)
+	if err := tx.Structures().CreateVersion(&version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 // parseStructureID parses and validates the structure ID from the URL parameter
 func (s *structuresController) parseStructureID(ctx *gin.Context) (int32, error) {
 	idParam := ctx.Param("id")
@@ -352,48 +725,67 @@ func (s *structuresController) validateAndCreateStructureUpdateMap(
 	return updateMap, nil
 }
 
-// parseBlindJSON parses and validates a blind JSON object
+// parseBlindJSON parses and validates a blind JSON object. Levels without a
+// type are blind levels; breaks and chip races cannot have blinds or antes.
 func (s *structuresController) parseBlindJSON(blindMap map[string]any, index int) (models.BlindJSON, error) {
-	blind := models.BlindJSON{}
+	blind := models.BlindJSON{Type: models.BlindLevelTypeBlind}
 
-	// Parse small
-	smallVal, ok := blindMap["small"]
-	if !ok {
-		return blind, fmt.Errorf("blind at index %d is missing 'small' field", index)
-	}
-	smallFloat, ok := smallVal.(float64)
-	if !ok {
-		return blind, fmt.Errorf("blind at index %d: 'small' must be a number", index)
-	}
-	if smallFloat < 0 {
-		return blind, fmt.Errorf("blind at index %d: 'small' must be >= 0", index)
+	// Parse type (optional, defaults to blind)
+	if typeVal, ok := blindMap["type"]; ok && typeVal != nil {
+		typeStr, ok := typeVal.(string)
+		if !ok {
+			return blind, fmt.Errorf("blind at index %d: 'type' must be a string", index)
+		}
+		switch typeStr {
+		case models.BlindLevelTypeBlind, models.BlindLevelTypeBreak, models.BlindLevelTypeChipRace:
+			blind.Type = typeStr
+		default:
+			return blind, fmt.Errorf("blind at index %d: 'type' must be one of blind, break, chip_race", index)
+		}
 	}
-	blind.Small = int32(smallFloat)
 
-	// Parse big
-	bigVal, ok := blindMap["big"]
-	if !ok {
-		return blind, fmt.Errorf("blind at index %d is missing 'big' field", index)
-	}
-	bigFloat, ok := bigVal.(float64)
-	if !ok {
-		return blind, fmt.Errorf("blind at index %d: 'big' must be a number", index)
-	}
-	if bigFloat < 0 {
-		return blind, fmt.Errorf("blind at index %d: 'big' must be >= 0", index)
+	// Parse label (optional)
+	if labelVal, ok := blindMap["label"]; ok && labelVal != nil {
+		labelStr, ok := labelVal.(string)
+		if !ok {
+			return blind, fmt.Errorf("blind at index %d: 'label' must be a string", index)
+		}
+		if len(labelStr) > 100 {
+			return blind, fmt.Errorf("blind at index %d: 'label' must be at most 100 characters", index)
+		}
+		blind.Label = labelStr
 	}
-	blind.Big = int32(bigFloat)
 
-	// Parse ante (optional, defaults to 0)
-	if anteVal, ok := blindMap["ante"]; ok {
-		anteFloat, ok := anteVal.(float64)
+	// Parse small, big and ante. They are required for blind levels and must
+	// be omitted or zero for breaks and chip races.
+	isBlindLevel := blind.Type == models.BlindLevelTypeBlind
+	for _, field := range []struct {
+		name     string
+		dest     *int32
+		required bool
+	}{
+		{name: "small", dest: &blind.Small, required: isBlindLevel},
+		{name: "big", dest: &blind.Big, required: isBlindLevel},
+		{name: "ante", dest: &blind.Ante, required: false},
+	} {
+		val, ok := blindMap[field.name]
 		if !ok {
-			return blind, fmt.Errorf("blind at index %d: 'ante' must be a number", index)
+			if field.required {
+				return blind, fmt.Errorf("blind at index %d is missing '%s' field", index, field.name)
+			}
+			continue
+		}
+		floatVal, ok := val.(float64)
+		if !ok {
+			return blind, fmt.Errorf("blind at index %d: '%s' must be a number", index, field.name)
+		}
+		if floatVal < 0 {
+			return blind, fmt.Errorf("blind at index %d: '%s' must be >= 0", index, field.name)
 		}
-		if anteFloat < 0 {
-			return blind, fmt.Errorf("blind at index %d: 'ante' must be >= 0", index)
+		if !isBlindLevel && floatVal != 0 {
+			return blind, fmt.Errorf("blind at index %d: a %s level cannot have '%s'", index, blind.Type, field.name)
 		}
-		blind.Ante = int32(anteFloat)
+		*field.dest = int32(floatVal)
 	}
 
 	// Parse time
@@ -405,10 +797,10 @@ func (s *structuresController) parseBlindJSON(blindMap map[string]any, index int
 	if !ok {
 		return blind, fmt.Errorf("blind at index %d: 'time' must be a number", index)
 	}
-	if timeFloat <= 0 || timeFloat > 60 {
-		return blind, fmt.Errorf("blind at index %d: 'time' must be between 1 and 60", index)
+	if timeFloat <= 0 || timeFloat > 1440 {
+		return blind, fmt.Errorf("blind at index %d: 'time' must be between 1 and 1440", index)
 	}
-	blind.Time = int8(timeFloat)
+	blind.Time = int32(timeFloat)
 
 	return blind, nil
 }
@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -165,6 +167,47 @@ func TestCreateStructure(t *testing.T) {
 			expectedStatus: http.StatusCreated,
 			expectError:    false,
 		},
+		{
+			name:     "successful request with typed levels",
+			userRole: authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+			requestBody: map[string]any{
+				"name": "Deep Stack",
+				"blinds": []map[string]any{
+					{"small": 25, "big": 50, "time": 75},
+					{"type": "chip_race", "label": "Colour up 25s", "time": 10},
+					{"type": "break", "label": "Dinner break", "time": 60},
+					{"type": "blind", "small": 100, "big": 200, "ante": 200, "time": 75},
+				},
+			},
+			expectedStatus: http.StatusCreated,
+			expectError:    false,
+		},
+		{
+			name:     "break with blinds",
+			userRole: authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+			requestBody: map[string]any{
+				"name": "Test Structure",
+				"blinds": []map[string]any{
+					{"type": "break", "small": 25, "big": 50, "time": 15},
+				},
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectError:          true,
+			expectedErrorMessage: "Key: 'CreateStructureRequest.Blinds[0].Small' Error:Field validation for 'Small' failed on the 'excluded_if' tag\nKey: 'CreateStructureRequest.Blinds[0].Big' Error:Field validation for 'Big' failed on the 'excluded_if' tag",
+		},
+		{
+			name:     "blind level without blinds",
+			userRole: authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+			requestBody: map[string]any{
+				"name": "Test Structure",
+				"blinds": []map[string]any{
+					{"type": "blind", "time": 15},
+				},
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectError:          true,
+			expectedErrorMessage: "Key: 'CreateStructureRequest.Blinds[0].Small' Error:Field validation for 'Small' failed on the 'required_if' tag\nKey: 'CreateStructureRequest.Blinds[0].Big' Error:Field validation for 'Big' failed on the 'required_if' tag",
+		},
 		{
 			name:     "missing name field",
 			userRole: authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
@@ -613,13 +656,57 @@ func TestUpdateStructure(t *testing.T) {
 			structureID: "1",
 			requestBody: map[string]any{
 				"blinds": []map[string]any{
-					{"small": 25, "big": 50, "ante": 0, "time": 61},
+					{"small": 25, "big": 50, "ante": 0, "time": 1441},
+				},
+			},
+			seedStructures:       true,
+			expectedStatus:       http.StatusBadRequest,
+			expectError:          true,
+			expectedErrorMessage: "blind at index 0: 'time' must be between 1 and 1440",
+		},
+		{
+			name:        "successful update with breaks and chip races",
+			userRole:    authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+			structureID: "1",
+			requestBody: map[string]any{
+				"blinds": []map[string]any{
+					{"small": 100, "big": 200, "time": 90},
+					{"type": "break", "label": "Dinner break", "time": 45},
+					{"type": "chip_race", "label": "Colour up 25s", "time": 5},
+					{"type": "blind", "small": 200, "big": 400, "ante": 400, "time": 30},
+				},
+			},
+			seedStructures: true,
+			expectedStatus: http.StatusOK,
+			expectError:    false,
+		},
+		{
+			name:        "invalid level type",
+			userRole:    authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+			structureID: "1",
+			requestBody: map[string]any{
+				"blinds": []map[string]any{
+					{"type": "lunch", "time": 30},
+				},
+			},
+			seedStructures:       true,
+			expectedStatus:       http.StatusBadRequest,
+			expectError:          true,
+			expectedErrorMessage: "blind at index 0: 'type' must be one of blind, break, chip_race",
+		},
+		{
+			name:        "break with blinds",
+			userRole:    authorization.ROLE_TOURNAMENT_DIRECTOR.ToString(),
+			structureID: "1",
+			requestBody: map[string]any{
+				"blinds": []map[string]any{
+					{"type": "break", "small": 25, "time": 15},
 				},
 			},
 			seedStructures:       true,
 			expectedStatus:       http.StatusBadRequest,
 			expectError:          true,
-			expectedErrorMessage: "blind at index 0: 'time' must be between 1 and 60",
+			expectedErrorMessage: "blind at index 0: a break level cannot have 'small'",
 		},
 	}
 
@@ -696,6 +783,15 @@ func TestUpdateStructure(t *testing.T) {
 						responseBlinds := response["blinds"].([]any)
 						requestBlinds := blinds.([]map[string]any)
 						require.Equal(t, len(requestBlinds), len(responseBlinds))
+						for i, requestBlind := range requestBlinds {
+							responseBlind := responseBlinds[i].(map[string]any)
+							expectedType, ok := requestBlind["type"]
+							if !ok {
+								expectedType = models.BlindLevelTypeBlind
+							}
+							require.Equal(t, expectedType, responseBlind["type"])
+							require.Equal(t, float64(requestBlind["time"].(int)), responseBlind["time"])
+						}
 					}
 				}
 			}
@@ -819,4 +915,502 @@ func TestDeleteStructure(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("structure used by an event", func(t *testing.T) {
+		require.NoError(t, container.ResetDatabase(ctx))
+		require.NoError(t, testutils.SeedAll(db))
+
+		sessionID, err := testutils.CreateTestSession(db, "testuser", authorization.ROLE_TOURNAMENT_DIRECTOR.ToString())
+		require.NoError(t, err)
+
+		req, err := testutils.MakeJSONRequest("DELETE", fmt.Sprintf("/api/v2/structures/%d", testutils.TEST_STRUCTURES[0].ID), nil)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		testutils.AssertErrorResponse(t, w, http.StatusForbidden, "Structures used by an event cannot be deleted")
+
+		var events int64
+		require.NoError(t, db.Model(&models.Event{}).Count(&events).Error)
+		require.EqualValues(t, len(testutils.TEST_EVENTS), events)
+	})
+}
+
+func TestImportStructure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	// Run default tests for authentication and authorization
+	unauthorizedRoles := []string{"bot", "executive"}
+	testutils.TestInvalidAuthForEndpoint(
+		t,
+		container,
+		apiServer,
+		"POST",
+		"/api/v2/structures/import",
+		unauthorizedRoles,
+		map[string]any{"name": "Turbo", "levels": []map[string]any{{"small": 25, "big": 50, "time": 10}}},
+	)
+
+	validCSV := "type,label,small,big,ante,time\n" +
+		"blind,,25,50,0,90\n" +
+		"break,Dinner break,0,0,0,45\n" +
+		"blind,,50,100,100,90\n"
+
+	testCases := []struct {
+		name                 string
+		query                string
+		contentType          string
+		body                 string
+		expectedStatus       int
+		expectedErrorMessage string
+		expectedRowErrors    []models.StructureImportRowError
+		expectedName         string
+		expectedLevels       int
+	}{
+		{
+			name:           "imports CSV",
+			query:          "?name=Deep%20Stack",
+			contentType:    "text/csv",
+			body:           validCSV,
+			expectedStatus: http.StatusCreated,
+			expectedName:   "Deep Stack",
+			expectedLevels: 3,
+		},
+		{
+			name:           "imports CSV with format parameter",
+			query:          "?format=csv&name=Deep%20Stack",
+			contentType:    "text/plain",
+			body:           validCSV,
+			expectedStatus: http.StatusCreated,
+			expectedName:   "Deep Stack",
+			expectedLevels: 3,
+		},
+		{
+			name:                 "CSV requires a name",
+			contentType:          "text/csv",
+			body:                 validCSV,
+			expectedStatus:       http.StatusBadRequest,
+			expectedErrorMessage: "name is required when importing CSV",
+		},
+		{
+			name:                 "CSV missing a column",
+			query:                "?name=Turbo",
+			contentType:          "text/csv",
+			body:                 "small,big\n25,50\n",
+			expectedStatus:       http.StatusBadRequest,
+			expectedErrorMessage: "The CSV header is missing the 'time' column",
+		},
+		{
+			name:        "CSV with invalid rows",
+			query:       "?name=Turbo",
+			contentType: "text/csv",
+			body: "small,big,time\n" +
+				"25,50,10\n" +
+				",50,10\n" +
+				"50,100,x\n",
+			expectedStatus:       http.StatusBadRequest,
+			expectedErrorMessage: "The structure has 2 invalid levels",
+			expectedRowErrors: []models.StructureImportRowError{
+				{Row: 2, Field: "small", Message: "small is required"},
+				{Row: 3, Field: "time", Message: "time must be a whole number"},
+			},
+		},
+		{
+			name:        "imports JSON",
+			contentType: "application/json",
+			body: `{"version":1,"name":"Turbo","levels":[
+				{"small":25,"big":50,"time":10},
+				{"type":"chip_race","label":"Colour up 25s","time":5},
+				{"small":50,"big":100,"time":10}
+			]}`,
+			expectedStatus: http.StatusCreated,
+			expectedName:   "Turbo",
+			expectedLevels: 3,
+		},
+		{
+			name:           "name parameter overrides JSON name",
+			query:          "?name=Renamed",
+			contentType:    "application/json",
+			body:           `{"name":"Turbo","levels":[{"small":25,"big":50,"time":10}]}`,
+			expectedStatus: http.StatusCreated,
+			expectedName:   "Renamed",
+			expectedLevels: 1,
+		},
+		{
+			name:                 "JSON without a name",
+			contentType:          "application/json",
+			body:                 `{"levels":[{"small":25,"big":50,"time":10}]}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedErrorMessage: "The structure must have a name",
+		},
+		{
+			name:                 "JSON with an invalid level",
+			contentType:          "application/json",
+			body:                 `{"name":"Turbo","levels":[{"type":"break","small":25,"time":10}]}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedErrorMessage: "The structure has 1 invalid level",
+			expectedRowErrors: []models.StructureImportRowError{
+				{Row: 1, Field: "small", Message: "small must be empty for break levels"},
+			},
+		},
+		{
+			name:                 "unknown format",
+			query:                "?format=xml",
+			contentType:          "application/xml",
+			body:                 "<structure/>",
+			expectedStatus:       http.StatusBadRequest,
+			expectedErrorMessage: "format must be csv or json",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, container.ResetDatabase(ctx))
+
+			sessionID, err := testutils.CreateTestSession(db, "testuser", authorization.ROLE_TOURNAMENT_DIRECTOR.ToString())
+			require.NoError(t, err)
+
+			req := httptest.NewRequest("POST", "/api/v2/structures/import"+tc.query, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			testutils.SetAuthCookie(req, sessionID)
+
+			w := httptest.NewRecorder()
+			apiServer.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedStatus, w.Code, "Response: %s", w.Body.String())
+
+			if tc.expectedRowErrors != nil {
+				var response models.StructureImportErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, tc.expectedErrorMessage, response.Message)
+				require.Equal(t, tc.expectedRowErrors, response.Errors)
+				return
+			}
+
+			if tc.expectedErrorMessage != "" {
+				testutils.AssertErrorResponse(t, w, tc.expectedStatus, tc.expectedErrorMessage)
+				return
+			}
+
+			var structure models.Structure
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &structure))
+			require.NotZero(t, structure.ID)
+			require.Equal(t, tc.expectedName, structure.Name)
+			require.Len(t, structure.Blinds, tc.expectedLevels)
+		})
+	}
+}
+
+func TestExportStructure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	// Run default tests for authentication and authorization
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", "/api/v2/structures/1/export", []string{"bot"})
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedStructures(db))
+
+	sessionID, err := testutils.CreateTestSession(db, "testuser", authorization.ROLE_TOURNAMENT_DIRECTOR.ToString())
+	require.NoError(t, err)
+
+	doRequest := func(method, path, contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	expected := testutils.TEST_STRUCTURES[0]
+
+	t.Run("exports JSON by default", func(t *testing.T) {
+		w := doRequest("GET", "/api/v2/structures/1/export", "", "")
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+		require.Contains(t, w.Header().Get("Content-Disposition"), `filename="structure-1.json"`)
+
+		var response models.StructureInterchange
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, models.StructureInterchangeVersion, response.Version)
+		require.Equal(t, expected.Name, response.Name)
+		require.Len(t, response.Levels, len(expected.Blinds))
+		for i, level := range response.Levels {
+			require.Equal(t, models.BlindLevelTypeBlind, level.Type)
+			require.Equal(t, expected.Blinds[i].Small, level.Small)
+			require.Equal(t, expected.Blinds[i].Big, level.Big)
+			require.Equal(t, expected.Blinds[i].Time, level.Time)
+		}
+	})
+
+	t.Run("exports CSV", func(t *testing.T) {
+		w := doRequest("GET", "/api/v2/structures/1/export?format=csv", "", "")
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+		require.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+		require.Contains(t, w.Header().Get("Content-Disposition"), `filename="structure-1.csv"`)
+
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		require.Equal(t, "type,label,small,big,ante,time", lines[0])
+		require.Equal(t, "blind,,10,20,0,15", lines[1])
+		require.Len(t, lines, len(expected.Blinds)+1)
+	})
+
+	t.Run("exported files can be imported", func(t *testing.T) {
+		for _, format := range []string{models.StructureFormatCSV, models.StructureFormatJSON} {
+			w := doRequest("GET", "/api/v2/structures/1/export?format="+format, "", "")
+			require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+			w = doRequest("POST", "/api/v2/structures/import?name=Copy&format="+format, "", w.Body.String())
+			require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+			var structure models.Structure
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &structure))
+			require.Len(t, structure.Blinds, len(expected.Blinds))
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		w := doRequest("GET", "/api/v2/structures/1/export?format=xml", "", "")
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "format must be csv or json")
+	})
+
+	t.Run("structure not found", func(t *testing.T) {
+		w := doRequest("GET", "/api/v2/structures/999/export", "", "")
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Structure not found")
+	})
+}
+
+func TestStructureVersions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	// Run default tests for authentication and authorization
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", "/api/v2/structures/1/versions", []string{"bot"})
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "GET", "/api/v2/structures/1/versions/1", []string{"bot"})
+
+	require.NoError(t, container.ResetDatabase(ctx))
+	require.NoError(t, testutils.SeedSemesters(db))
+
+	sessionID, err := testutils.CreateTestSession(db, "testuser", authorization.ROLE_TOURNAMENT_DIRECTOR.ToString())
+	require.NoError(t, err)
+
+	doRequest := func(method, path string, body any) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, body)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	// Create a structure and an event that uses it
+	w := doRequest("POST", "/api/v2/structures", models.CreateStructureRequest{
+		Name:   "Turbo",
+		Blinds: []models.BlindJSON{{Small: 25, Big: 50, Time: 10}, {Small: 50, Big: 100, Time: 10}},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+	var structure models.Structure
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &structure))
+	require.Equal(t, int32(1), structure.Version)
+
+	semesterID := testutils.TEST_SEMESTERS[0].ID
+	w = doRequest("POST", fmt.Sprintf("/api/v2/semesters/%s/events", semesterID), models.CreateEventRequest{
+		Name:             "Monday Turbo",
+		Format:           "No Limit Hold'em",
+		SemesterID:       semesterID.String(),
+		StartDate:        time.Now().UTC(),
+		StructureID:      structure.ID,
+		PointsMultiplier: 1,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+	var event models.Event
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &event))
+	require.NotNil(t, event.StructureVersionID)
+
+	// Editing the structure records a new version
+	w = doRequest("PATCH", fmt.Sprintf("/api/v2/structures/%d", structure.ID), map[string]any{
+		"name":   "Turbo (slower)",
+		"blinds": []map[string]any{{"small": 25, "big": 50, "time": 20}},
+	})
+	require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &structure))
+	require.Equal(t, int32(2), structure.Version)
+	require.Len(t, structure.Blinds, 1)
+
+	t.Run("lists versions newest first", func(t *testing.T) {
+		w := doRequest("GET", fmt.Sprintf("/api/v2/structures/%d/versions", structure.ID), nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var response models.ListResponse[models.StructureVersion]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, int64(2), response.Total)
+		require.Equal(t, int32(2), response.Data[0].Version)
+		require.Equal(t, "Turbo (slower)", response.Data[0].Name)
+		require.Len(t, response.Data[0].Blinds, 1)
+		require.Equal(t, int32(1), response.Data[1].Version)
+		require.Equal(t, "Turbo", response.Data[1].Name)
+		require.Len(t, response.Data[1].Blinds, 2)
+	})
+
+	t.Run("gets a version", func(t *testing.T) {
+		w := doRequest("GET", fmt.Sprintf("/api/v2/structures/%d/versions/1", structure.ID), nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var version models.StructureVersion
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &version))
+		require.Equal(t, *event.StructureVersionID, version.ID)
+		require.Equal(t, models.BlindLevelTypeBlind, version.Blinds[0].Type)
+		require.Equal(t, int32(10), version.Blinds[0].Time)
+	})
+
+	t.Run("events keep the version they were created with", func(t *testing.T) {
+		w := doRequest("GET", fmt.Sprintf("/api/v2/semesters/%s/events/%d", semesterID, event.ID), nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var response models.Event
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotNil(t, response.Structure)
+		require.Equal(t, "Turbo", response.Structure.Name)
+		require.Equal(t, int32(1), response.Structure.Version)
+		require.Len(t, response.Structure.Blinds, 2)
+		require.Equal(t, int32(10), response.Structure.Blinds[0].Time)
+	})
+
+	t.Run("version not found", func(t *testing.T) {
+		w := doRequest("GET", fmt.Sprintf("/api/v2/structures/%d/versions/3", structure.ID), nil)
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Structure version not found")
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		w := doRequest("GET", fmt.Sprintf("/api/v2/structures/%d/versions/latest", structure.ID), nil)
+		testutils.AssertErrorResponse(t, w, http.StatusBadRequest, "Version 'latest' is not a valid version number")
+	})
+
+	t.Run("structure not found", func(t *testing.T) {
+		w := doRequest("GET", "/api/v2/structures/999/versions", nil)
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Structure not found")
+	})
+}
+
+func TestCloneStructure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container, err := testutils.NewPostgresContainer(ctx, testutils.PostgresConfig{})
+	require.NoError(t, err)
+	defer container.Close(ctx)
+
+	db := container.GetDB()
+	apiServer := testutils.NewTestAPIServer(db)
+
+	// Run default tests for authentication and authorization
+	unauthorizedRoles := []string{"bot", "executive"}
+	testutils.TestInvalidAuthForEndpoint(t, container, apiServer, "POST", "/api/v2/structures/1/clone", unauthorizedRoles)
+
+	require.NoError(t, container.ResetDatabase(ctx))
+
+	sessionID, err := testutils.CreateTestSession(db, "testuser", authorization.ROLE_TOURNAMENT_DIRECTOR.ToString())
+	require.NoError(t, err)
+
+	doRequest := func(method, path string, body any) *httptest.ResponseRecorder {
+		req, err := testutils.MakeJSONRequest(method, path, body)
+		require.NoError(t, err)
+		testutils.SetAuthCookie(req, sessionID)
+
+		w := httptest.NewRecorder()
+		apiServer.ServeHTTP(w, req)
+		return w
+	}
+
+	w := doRequest("POST", "/api/v2/structures", models.CreateStructureRequest{
+		Name: "Deep Stack",
+		Blinds: []models.BlindJSON{
+			{Small: 25, Big: 50, Time: 30},
+			{Type: models.BlindLevelTypeBreak, Label: "Dinner break", Time: 45},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+	var original models.Structure
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &original))
+
+	w = doRequest("PATCH", fmt.Sprintf("/api/v2/structures/%d", original.ID), map[string]any{
+		"blinds": []map[string]any{{"small": 100, "big": 200, "time": 20}},
+	})
+	require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+	t.Run("clones the current version", func(t *testing.T) {
+		w := doRequest("POST", fmt.Sprintf("/api/v2/structures/%d/clone", original.ID), nil)
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		var clone models.Structure
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &clone))
+		require.NotEqual(t, original.ID, clone.ID)
+		require.Equal(t, "Deep Stack (copy)", clone.Name)
+		require.Equal(t, int32(1), clone.Version)
+		require.Len(t, clone.Blinds, 1)
+		require.Equal(t, int32(100), clone.Blinds[0].Small)
+	})
+
+	t.Run("clones an earlier version with a new name", func(t *testing.T) {
+		w := doRequest("POST", fmt.Sprintf("/api/v2/structures/%d/clone", original.ID), map[string]any{
+			"name":    "Deep Stack (original)",
+			"version": 1,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, "Response: %s", w.Body.String())
+
+		var clone models.Structure
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &clone))
+		require.Equal(t, "Deep Stack (original)", clone.Name)
+		require.Len(t, clone.Blinds, 2)
+		require.Equal(t, models.BlindLevelTypeBreak, clone.Blinds[1].Type)
+		require.Equal(t, "Dinner break", clone.Blinds[1].Label)
+
+		// The clone has a history of its own
+		w = doRequest("GET", fmt.Sprintf("/api/v2/structures/%d/versions", clone.ID), nil)
+		require.Equal(t, http.StatusOK, w.Code, "Response: %s", w.Body.String())
+
+		var versions models.ListResponse[models.StructureVersion]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &versions))
+		require.Equal(t, int64(1), versions.Total)
+	})
+
+	t.Run("version not found", func(t *testing.T) {
+		w := doRequest("POST", fmt.Sprintf("/api/v2/structures/%d/clone", original.ID), map[string]any{"version": 5})
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Structure version not found")
+	})
+
+	t.Run("structure not found", func(t *testing.T) {
+		w := doRequest("POST", "/api/v2/structures/999/clone", nil)
+		testutils.AssertErrorResponse(t, w, http.StatusNotFound, "Structure not found")
+	})
 }
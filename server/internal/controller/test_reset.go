@@ -3,6 +3,7 @@
 package controller
 
 import (
+	"api/internal/services"
 	_ "embed"
 	"net/http"
 	"os"
@@ -33,8 +34,9 @@ func (c *testResetController) resetDatabase(ctx *gin.Context) {
 		return
 	}
 
-	truncateSQL := `TRUNCATE blinds, events, memberships, participants,
-		rankings, semesters, structures, transactions, users
+	truncateSQL := `TRUNCATE api_keys, audit_events, blinds, event_clocks, event_registrations, events, kiosk_tokens, ledger_entries, memberships, participants,
+		password_reset_tokens, points_payouts, points_schemes, ranking_snapshot_entries, ranking_snapshots, rankings,
+		rebuys, role_permissions, semesters, structure_versions, structures, transactions, two_factor_challenges, users
 		RESTART IDENTITY CASCADE`
 
 	err := c.db.Transaction(func(tx *gorm.DB) error {
@@ -43,6 +45,10 @@ func (c *testResetController) resetDatabase(ctx *gin.Context) {
 		}
 		return tx.Exec(seedSQL).Error
 	})
+	if err == nil {
+		// Restore the default permission policy
+		err = services.NewPermissionService(c.db).LoadPolicy()
+	}
 
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
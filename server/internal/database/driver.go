@@ -103,6 +103,22 @@ func WipeDB(db *gorm.DB) error {
 	if err := res.Error; err != nil {
 		return err
 	}
+	res = db.Delete(&models.RankingSnapshotEntry{})
+	if err := res.Error; err != nil {
+		return err
+	}
+	res = db.Delete(&models.RankingSnapshot{})
+	if err := res.Error; err != nil {
+		return err
+	}
+	res = db.Delete(&models.Rebuy{})
+	if err := res.Error; err != nil {
+		return err
+	}
+	res = db.Delete(&models.Registration{})
+	if err := res.Error; err != nil {
+		return err
+	}
 	res = db.Delete(&models.Participant{})
 	if err := res.Error; err != nil {
 		return err
@@ -115,14 +131,30 @@ func WipeDB(db *gorm.DB) error {
 	if err := res.Error; err != nil {
 		return err
 	}
+	res = db.Delete(&models.LedgerEntry{})
+	if err := res.Error; err != nil {
+		return err
+	}
 	res = db.Delete(&models.Transaction{})
 	if err := res.Error; err != nil {
 		return err
 	}
+	res = db.Delete(&models.EventClock{})
+	if err := res.Error; err != nil {
+		return err
+	}
+	res = db.Delete(&models.KioskToken{})
+	if err := res.Error; err != nil {
+		return err
+	}
 	res = db.Delete(&models.Event{})
 	if err := res.Error; err != nil {
 		return err
 	}
+	res = db.Delete(&models.StructureVersion{})
+	if err := res.Error; err != nil {
+		return err
+	}
 	res = db.Delete(&models.Blind{})
 	if err := res.Error; err != nil {
 		return err
@@ -131,10 +163,30 @@ func WipeDB(db *gorm.DB) error {
 	if err := res.Error; err != nil {
 		return err
 	}
+	res = db.Delete(&models.PointsPayout{})
+	if err := res.Error; err != nil {
+		return err
+	}
+	res = db.Delete(&models.PointsScheme{})
+	if err := res.Error; err != nil {
+		return err
+	}
 	res = db.Delete(&models.Semester{})
 	if err := res.Error; err != nil {
 		return err
 	}
+	res = db.Delete(&models.APIKey{})
+	if err := res.Error; err != nil {
+		return err
+	}
+	res = db.Delete(&models.PasswordResetToken{})
+	if err := res.Error; err != nil {
+		return err
+	}
+	res = db.Delete(&models.TwoFactorChallenge{})
+	if err := res.Error; err != nil {
+		return err
+	}
 	res = db.Delete(&models.Login{})
 	if err := res.Error; err != nil {
 		return err
@@ -143,6 +195,14 @@ func WipeDB(db *gorm.DB) error {
 	if err := res.Error; err != nil {
 		return err
 	}
+	res = db.Delete(&models.AuditEvent{})
+	if err := res.Error; err != nil {
+		return err
+	}
+	res = db.Delete(&models.RolePermission{})
+	if err := res.Error; err != nil {
+		return err
+	}
 
 	return nil
 }
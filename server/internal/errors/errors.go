@@ -67,3 +67,11 @@ func InternalServerError(message string) error {
 		Message: message,
 	}
 }
+
+func TooManyRequests(message string) error {
+	return APIErrorResponse{
+		Code:    http.StatusTooManyRequests,
+		Type:    "TOO_MANY_REQUESTS",
+		Message: message,
+	}
+}
@@ -0,0 +1,297 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"api/internal/models"
+	"api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// redactedFields are request body keys whose values are never written to the audit log.
+var redactedFields = []string{"password", "newpassword", "currentpassword", "token", "code"}
+
+// auditCaptureKey is the context key of the function UseAuthorization calls to
+// capture the state of the target of an audited action before it is handled.
+const auditCaptureKey = "auditCapture"
+
+// auditTarget loads the resource an audited action changes from the path
+// parameters of the request, so that its state before and after the action
+// can be compared. It returns nil if the resource does not exist.
+type auditTarget func(db *gorm.DB, ctx *gin.Context) (any, error)
+
+// auditTargets are the resources changed by each action. Actions without a
+// target, such as those creating a resource, only record their request.
+var auditTargets = map[string]auditTarget{
+	"login.edit":                   findAuditTarget[models.Login]("", "username = ?", "username"),
+	"login.delete":                 findAuditTarget[models.Login]("", "username = ?", "username"),
+	"user.edit":                    findAuditTarget[models.User]("", "id = ?", "id"),
+	"user.delete":                  findAuditTarget[models.User]("", "id = ?", "id"),
+	"membership.edit":              findAuditTarget[models.Membership]("", "id = ?", "id"),
+	"membership.delete":            findAuditTarget[models.Membership]("", "id = ?", "id"),
+	"event.edit":                   findAuditTarget[models.Event]("", "id = ?", "eventId"),
+	"event.end":                    findAuditTarget[models.Event]("", "id = ?", "eventId"),
+	"event.restart":                findAuditTarget[models.Event]("", "id = ?", "eventId"),
+	"event.participant.signout":    findAuditEntry,
+	"event.participant.signin":     findAuditEntry,
+	"event.participant.delete":     findAuditEntry,
+	"structure.edit":               findAuditTarget[models.Structure]("Blinds", "id = ?", "id"),
+	"structure.delete":             findAuditTarget[models.Structure]("Blinds", "id = ?", "id"),
+	"semester.transaction.edit":    findAuditTarget[models.Transaction]("", "id = ?", "transactionId"),
+	"semester.transaction.delete":  findAuditTarget[models.Transaction]("", "id = ?", "transactionId"),
+	"semester.pointsScheme.edit":   findAuditTarget[models.PointsScheme]("Payouts", "semester_id = ?", "semesterId"),
+	"semester.pointsScheme.delete": findAuditTarget[models.PointsScheme]("Payouts", "semester_id = ?", "semesterId"),
+	"permission.edit":              findAuditTarget[models.RolePermission]("", "action = ?", "action"),
+}
+
+// UseAudit records an audit event for every successful mutating request that
+// passed through UseAuthorization. It must be registered before the route
+// handlers so that it can capture the request body.
+func UseAudit(db *gorm.DB) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !isMutatingMethod(ctx.Request.Method) {
+			ctx.Next()
+			return
+		}
+
+		// Buffer the request body so it can be read again by the handler
+		var body []byte
+		if ctx.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(ctx.Request.Body)
+			if err != nil {
+				// Replay the error (e.g. body too large) to the handler
+				ctx.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{err}))
+			} else {
+				ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+			}
+		}
+
+		// Capture the target of the action once UseAuthorization has named it
+		var target auditTarget
+		var before any
+		ctx.Set(auditCaptureKey, func() {
+			target = auditTargets[ctx.GetString("action")]
+			if target != nil {
+				before = loadAuditTarget(db, ctx, target)
+			}
+		})
+
+		ctx.Next()
+
+		action := ctx.GetString("action")
+		status := ctx.Writer.Status()
+		if action == "" || status >= http.StatusBadRequest {
+			return
+		}
+
+		var changes map[string]models.AuditChange
+		if target != nil {
+			changes = diffAuditStates(before, loadAuditTarget(db, ctx, target))
+		}
+
+		targets := make(map[string]string, len(ctx.Params))
+		for _, param := range ctx.Params {
+			targets[param.Key] = param.Value
+		}
+
+		event := models.AuditEvent{
+			Actor:      ctx.GetString("username"),
+			Role:       ctx.GetString("role"),
+			Action:     action,
+			Method:     ctx.Request.Method,
+			Path:       ctx.Request.URL.Path,
+			Targets:    targets,
+			Request:    parseAuditRequest(body),
+			Changes:    changes,
+			StatusCode: status,
+		}
+
+		if err := services.NewAuditService(db).RecordAuditEvent(&event); err != nil {
+			// Never fail a request which has already been handled
+			log.Printf("Failed to record audit event for %s: %s", action, err.Error())
+		}
+	}
+}
+
+// captureAuditState captures the state of the target of the authorized action
+// before it is handled, if the request is audited
+func captureAuditState(ctx *gin.Context) {
+	if capture, ok := ctx.Get(auditCaptureKey); ok {
+		capture.(func())()
+	}
+}
+
+// findAuditTarget returns an auditTarget loading the row of T matching query,
+// whose arguments are the named path parameters, along with the association
+// in preload, if any
+func findAuditTarget[T any](preload string, query string, params ...string) auditTarget {
+	return func(db *gorm.DB, ctx *gin.Context) (any, error) {
+		args := make([]any, len(params))
+		for i, param := range params {
+			args[i] = ctx.Param(param)
+		}
+
+		if preload != "" {
+			db = db.Preload(preload)
+		}
+
+		var target T
+		if err := db.Where(query, args...).First(&target).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		return &target, nil
+	}
+}
+
+// findAuditEntry loads the entry of an event targeted by a request, which is
+// either the entry of a membership or a guest entry
+func findAuditEntry(db *gorm.DB, ctx *gin.Context) (any, error) {
+	switch {
+	case ctx.Param("guestId") != "":
+		return findAuditTarget[models.Participant]("", "id = ? AND event_id = ?", "guestId", "eventId")(db, ctx)
+	case ctx.Param("entryId") != "":
+		return findAuditTarget[models.Participant]("", "membership_id = ? AND event_id = ?", "entryId", "eventId")(db, ctx)
+	}
+
+	// The legacy routes take the entry from the request body
+	return nil, nil
+}
+
+// loadAuditTarget loads the state of the target of an audited action. Targets
+// which cannot be loaded, e.g. because of a malformed ID, have no state.
+func loadAuditTarget(db *gorm.DB, ctx *gin.Context, target auditTarget) any {
+	state, err := target(db, ctx)
+	if err != nil {
+		log.Printf("Failed to load the audit target of %s: %s", ctx.GetString("action"), err.Error())
+		return nil
+	}
+
+	return state
+}
+
+// diffAuditStates compares the JSON fields of a resource before and after an
+// audited action. A resource which did not exist has no fields.
+func diffAuditStates(before any, after any) map[string]models.AuditChange {
+	beforeFields, afterFields := auditFields(before), auditFields(after)
+
+	changes := make(map[string]models.AuditChange)
+	for _, fields := range []map[string]any{beforeFields, afterFields} {
+		for key := range fields {
+			from, to := beforeFields[key], afterFields[key]
+			if _, ok := changes[key]; ok || reflect.DeepEqual(from, to) {
+				continue
+			}
+
+			// Sensitive fields only show that they changed
+			if isRedactedField(key) {
+				from, to = redactValue(from), redactValue(to)
+			}
+			changes[key] = models.AuditChange{From: redact(from), To: redact(to)}
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return changes
+}
+
+// auditFields decodes the JSON fields of the state of an audited resource
+func auditFields(state any) map[string]any {
+	fields := map[string]any{}
+	if state == nil {
+		return fields
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fields
+	}
+	_ = json.Unmarshal(data, &fields)
+
+	return fields
+}
+
+func redactValue(value any) any {
+	if value == nil {
+		return nil
+	}
+
+	return "[REDACTED]"
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// parseAuditRequest decodes a JSON request body and redacts sensitive fields.
+// Bodies which are empty or not JSON are not recorded.
+func parseAuditRequest(body []byte) any {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	var request any
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil
+	}
+
+	return redact(request)
+}
+
+func redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if isRedactedField(key) {
+				v[key] = "[REDACTED]"
+			} else {
+				v[key] = redact(child)
+			}
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = redact(child)
+		}
+	}
+
+	return value
+}
+
+func isRedactedField(key string) bool {
+	key = strings.ToLower(key)
+	for _, field := range redactedFields {
+		if key == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
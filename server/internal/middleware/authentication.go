@@ -22,8 +22,32 @@ func UseAuthentication(db *gorm.DB) func(ctx *gin.Context) {
 	}
 
 	sessionManager := authentication.NewSessionManager(db)
+	apiKeyManager := authentication.NewAPIKeyManager(db)
 
 	return func(ctx *gin.Context) {
+		// Bots authenticate with an API key instead of a session cookie
+		if header := ctx.GetHeader("Authorization"); header != "" {
+			key, found := strings.CutPrefix(header, "Bearer ")
+			if !found || key == "" {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, e.Unauthorized("Authorization header must be a bearer token"))
+				return
+			}
+
+			apiKey, role, err := apiKeyManager.Authenticate(key)
+			if err != nil {
+				ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+				return
+			}
+
+			ctx.Set("username", apiKey.Username)
+			ctx.Set("role", role)
+			// Limit the key to its scopes in the authorization middleware
+			ctx.Set("scopes", apiKey.Scopes)
+
+			ctx.Next()
+			return
+		}
+
 		cookie, err := ctx.Cookie(cookieKey)
 		if err != nil {
 			// Cookie not present in the request. Return 401
@@ -48,6 +72,8 @@ func UseAuthentication(db *gorm.DB) func(ctx *gin.Context) {
 
 		ctx.Set("username", session.Username)
 		ctx.Set("role", session.Role)
+		ctx.Set("sessionId", session.ID)
+		ctx.Set("twoFactorVerified", session.TwoFactorVerified)
 
 		ctx.Next()
 	}
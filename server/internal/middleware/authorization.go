@@ -4,6 +4,7 @@ import (
 	"api/internal/authorization"
 	"api/internal/errors"
 	"net/http"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 )
@@ -18,6 +19,13 @@ func UseAuthorization(action string) func(ctx *gin.Context) {
 			return
 		}
 
+		// Sessions of roles which must use two-factor authentication may only
+		// set it up until they have used it
+		if TwoFactorSetupRequired(ctx) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errors.Forbidden("Two-factor authentication is required for your role. Set it up to continue."))
+			return
+		}
+
 		authSvc := authorization.NewAuthorizationService(role, authorizerMap)
 
 		authorized := authSvc.IsAuthorized(action)
@@ -26,6 +34,28 @@ func UseAuthorization(action string) func(ctx *gin.Context) {
 			return
 		}
 
+		// API keys may only perform the actions they are scoped to
+		if scopes, ok := ctx.Get("scopes"); ok && !slices.Contains(scopes.([]string), action) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errors.Forbidden("This API key is not allowed to perform this action."))
+			return
+		}
+
+		// Make the authorized action available to later handlers, e.g. for auditing
+		ctx.Set("action", action)
+		captureAuditState(ctx)
+
 		ctx.Next()
 	}
 }
+
+// TwoFactorSetupRequired checks if the request was made from a session whose
+// role must use two-factor authentication, but which has not used it. API keys
+// are never required to use two-factor authentication.
+func TwoFactorSetupRequired(ctx *gin.Context) bool {
+	verified, ok := ctx.Get("twoFactorVerified")
+	if !ok {
+		return false
+	}
+
+	return !verified.(bool) && authorization.RequiresTwoFactor(ctx.GetString("role"))
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"api/internal/authentication"
+	e "api/internal/errors"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// UseKioskAuthentication authenticates a self check-in kiosk by its bearer
+// token. Kiosks have no login or role, so the kiosk endpoints are only
+// available to them and may only act on the event of the token.
+func UseKioskAuthentication(db *gorm.DB) func(ctx *gin.Context) {
+	kioskTokenManager := authentication.NewKioskTokenManager(db)
+
+	return func(ctx *gin.Context) {
+		token, found := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if !found || token == "" {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, e.Unauthorized("A kiosk token is required"))
+			return
+		}
+
+		kioskToken, err := kioskTokenManager.Authenticate(token)
+		if err != nil {
+			ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+			return
+		}
+
+		// Audit events are recorded against the kiosk
+		ctx.Set("username", "kiosk:"+kioskToken.Prefix)
+		ctx.Set("kioskTokenId", kioskToken.ID)
+		ctx.Set("kioskEvent", kioskToken.Event)
+
+		ctx.Next()
+	}
+}
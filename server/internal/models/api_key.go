@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey is a long-lived credential for a bot login, sent in an
+// "Authorization: Bearer" header. Only a hash of the key is stored, and the
+// key may only perform the actions in its scopes, on top of the permissions
+// of the login's role.
+type APIKey struct {
+	ID       uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Username string    `json:"username" gorm:"not null;index"`
+	Login    *Login    `json:"-" gorm:"foreignKey:Username;references:Username;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Name     string    `json:"name" gorm:"not null" example:"Discord bot"`
+	// Prefix is the start of the key, to tell keys apart without storing them.
+	Prefix  string   `json:"prefix" gorm:"not null" example:"uwpsc_3f9a1c"`
+	KeyHash string   `json:"-" gorm:"not null;uniqueIndex"`
+	Scopes  []string `json:"scopes" gorm:"type:jsonb;not null;serializer:json" example:"event.list,event.participant.signin"`
+	// ExpiresAt is when the key stops working. Null keys do not expire.
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedAt  time.Time  `json:"createdAt" gorm:"not null;default:CURRENT_TIMESTAMP"`
+} //@name APIKey
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"Discord bot"`
+	// Scopes are the actions the key may perform, e.g. event.participant.signin
+	Scopes []string `json:"scopes" binding:"required,min=1,dive,required" example:"event.list,event.participant.signin"`
+	// ExpiresAt is optional. Keys without an expiry last until revoked
+	ExpiresAt *time.Time `json:"expiresAt"`
+} //@name CreateAPIKeyRequest
+
+// CreateAPIKeyResponse contains the key itself, which is only ever returned
+// when it is created.
+type CreateAPIKeyResponse struct {
+	APIKey
+	Key string `json:"key" example:"uwpsc_3f9a1c0d2b4e6f8a0c1e3b5d7f9a1c3e5b7d9f1a3c5e7b9d1f3a5c7e9b1d3f5a"`
+} //@name CreateAPIKeyResponse
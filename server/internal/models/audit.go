@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+)
+
+// AuditEvent records a mutating API action performed by an authenticated user.
+type AuditEvent struct {
+	ID int64 `json:"id" gorm:"primaryKey;autoIncrement"`
+	// Actor is the username of the login that performed the action.
+	Actor string `json:"actor" gorm:"not null;index" example:"jdoe"`
+	// Role is the role the actor held when performing the action.
+	Role string `json:"role" gorm:"size:20;not null" example:"president"`
+	// Action is the authorization action string, e.g. "event.end".
+	Action string `json:"action" gorm:"not null;index" example:"event.end"`
+	Method string `json:"method" gorm:"not null" example:"POST"`
+	Path   string `json:"path" gorm:"not null" example:"/api/v2/semesters/550e8400-e29b-41d4-a716-446655440000/events/1/end"`
+	// Targets contains the resource IDs taken from the request's path parameters.
+	Targets map[string]string `json:"targets" gorm:"type:jsonb;serializer:json"`
+	// Request contains the JSON request body with any sensitive fields redacted.
+	// It is what was asked for, while Changes is what changed.
+	Request any `json:"request" gorm:"type:jsonb;serializer:json" swaggertype:"object"`
+	// Changes contains the fields of the targeted resource which the action
+	// changed, keyed by field. It is only recorded for actions whose target
+	// can be loaded from the path, such as "login.edit".
+	Changes    map[string]AuditChange `json:"changes" gorm:"type:jsonb;serializer:json"`
+	StatusCode int                    `json:"statusCode" gorm:"not null" example:"200"`
+	CreatedAt  time.Time              `json:"createdAt" gorm:"not null;default:CURRENT_TIMESTAMP;index"`
+} //@name AuditEvent
+
+// AuditChange is the value of a field of an audited resource before and after
+// the action. A resource which was created or deleted has no value on the side
+// where it did not exist, and sensitive fields only show that they changed.
+type AuditChange struct {
+	From any `json:"from" swaggertype:"object"`
+	To   any `json:"to" swaggertype:"object"`
+} //@name AuditChange
+
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
+
+// ListAuditEventsFilter is the set of parameters used to filter the list audit
+// events query. The zero value for ListAuditEventsFilter does not filter the
+// result.
+type ListAuditEventsFilter struct {
+	Pagination
+
+	// Actor filters events by the username that performed them.
+	Actor string
+
+	// Action filters events by action. A value ending in "." matches every
+	// action with that prefix, e.g. "event." matches "event.end".
+	Action string
+
+	// TargetID filters events to those targeting a resource with the given ID.
+	TargetID string
+
+	// From and To filter events to those created within the range.
+	From *time.Time
+	To   *time.Time
+}
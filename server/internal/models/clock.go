@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ClockStatePaused  = 0
+	ClockStateRunning = 1
+)
+
+// EventClock is the persisted state of an event's tournament clock. The clock
+// does not tick in the database; instead the time remaining in the current
+// level is stored as of SnapshotAt, and the live state is derived from the
+// event's structure and the elapsed time whenever it is read.
+type EventClock struct {
+	EventID    int32  `json:"eventId" gorm:"type:integer;primaryKey;autoIncrement:false"`
+	Event      *Event `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	State      uint8  `json:"state" gorm:"not null;default:0"`
+	LevelIndex int32  `json:"levelIndex" gorm:"type:integer;not null;default:0"`
+	// RemainingMs is the time remaining in the current level as of SnapshotAt
+	RemainingMs int64     `json:"remainingMs" gorm:"not null"`
+	SnapshotAt  time.Time `json:"snapshotAt" gorm:"not null"`
+} //@name EventClock
+
+func (EventClock) TableName() string {
+	return "event_clocks"
+}
+
+type ClockLevel struct {
+	Type  string `json:"type" enums:"blind,break,chip_race" example:"blind"`
+	Label string `json:"label" example:""`
+	Small int32  `json:"small" example:"100"`
+	Big   int32  `json:"big" example:"200"`
+	Ante  int32  `json:"ante" example:"0"`
+	// Duration is the length of the level in seconds
+	Duration int64 `json:"duration" example:"1200"`
+} //@name ClockLevel
+
+// ClockResponse is the live state of an event's tournament clock.
+type ClockResponse struct {
+	EventID int32 `json:"eventId" example:"1"`
+	// Running is false while the clock is paused or after the final level has elapsed
+	Running bool `json:"running"`
+	// Finished is true once the final level has elapsed
+	Finished bool `json:"finished"`
+	// Level is the 1-based number of the current level
+	Level        int32       `json:"level" example:"1"`
+	TotalLevels  int32       `json:"totalLevels" example:"20"`
+	CurrentLevel ClockLevel  `json:"currentLevel"`
+	NextLevel    *ClockLevel `json:"nextLevel"`
+	// TimeRemaining is the time left in the current level in milliseconds
+	TimeRemaining int64     `json:"timeRemaining" example:"754000"`
+	ServerTime    time.Time `json:"serverTime"`
+} //@name ClockResponse
+
+type SkipClockLevelRequest struct {
+	// Levels is the number of levels to move; negative values move backwards. Defaults to 1.
+	Levels *int32 `json:"levels" binding:"omitempty,ne=0" example:"1"`
+} //@name SkipClockLevelRequest
+
+type AdjustClockTimeRequest struct {
+	// Seconds is added to the time remaining in the current level; negative values remove time.
+	Seconds int64 `json:"seconds" binding:"required,ne=0" example:"60"`
+} //@name AdjustClockTimeRequest
@@ -13,19 +13,25 @@ const (
 )
 
 type Event struct {
-	ID               int32         `json:"id"                  gorm:"type:integer;primaryKey;autoIncrement"`
-	Name             string        `json:"name"`
-	Format           string        `json:"format"`
-	Notes            string        `json:"notes"`
-	SemesterID       uuid.UUID     `json:"semesterId"          gorm:"type:uuid"`
-	Semester         *Semester     `json:"semester,omitempty"`
-	StartDate        time.Time     `json:"startDate"           gorm:"not null;default:CURRENT_TIMESTAMP"`
-	State            uint8         `json:"state"               gorm:"default:0"`
-	StructureID      int32         `json:"structureId"         gorm:"type:integer;not null"`
-	Structure        *Structure    `json:"structure,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Rebuys           uint8         `json:"rebuys"              gorm:"not null;default:0"`
-	PointsMultiplier float32       `json:"pointsMultiplier"    gorm:"not null;default:1"`
-	Entries          []Participant `json:"entries,omitempty"   gorm:"foreignKey:EventID"`
+	ID                 int32             `json:"id"                  gorm:"type:integer;primaryKey;autoIncrement"`
+	Name               string            `json:"name"`
+	Format             string            `json:"format"`
+	Notes              string            `json:"notes"`
+	SemesterID         uuid.UUID         `json:"semesterId"          gorm:"type:uuid"`
+	Semester           *Semester         `json:"semester,omitempty"`
+	StartDate          time.Time         `json:"startDate"           gorm:"not null;default:CURRENT_TIMESTAMP"`
+	State              uint8             `json:"state"               gorm:"default:0"`
+	StructureID        int32             `json:"structureId"         gorm:"type:integer;not null"`
+	Structure          *Structure        `json:"structure,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+	StructureVersionID *int32            `json:"structureVersionId"  gorm:"type:integer"`
+	StructureVersion   *StructureVersion `json:"-"                   gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Rebuys             uint8             `json:"rebuys"              gorm:"not null;default:0"`
+	PointsMultiplier   float32           `json:"pointsMultiplier"    gorm:"not null;default:1"`
+	KnockoutPoints     int32             `json:"knockoutPoints"      gorm:"not null;default:0"`
+	TableSize          uint8             `json:"tableSize"           gorm:"not null;default:9"`
+	MaxRebuys          *uint8            `json:"maxRebuys"           gorm:"type:smallint"`
+	MaxEntries         *uint16           `json:"maxEntries"          gorm:"type:integer"`
+	Entries            []Participant     `json:"entries,omitempty"   gorm:"foreignKey:EventID"`
 } //@name Event
 
 func (Event) TableName() string {
@@ -48,7 +54,7 @@ func (Event) Preload(tx *gorm.DB, options EventPreloadOptions) *gorm.DB {
 	if options.Structure {
 		ret = ret.Preload("Structure", func(db *gorm.DB) *gorm.DB {
 			return Structure{}.Preload(db, StructurePreloadOptions{Blinds: true})
-		})
+		}).Preload("StructureVersion")
 	}
 
 	if options.Entries {
@@ -60,6 +66,19 @@ func (Event) Preload(tx *gorm.DB, options EventPreloadOptions) *gorm.DB {
 	return ret
 }
 
+// AfterFind replaces a preloaded structure with the version pinned by the
+// event, so that later edits to the structure do not change past events.
+// Events created before structures were versioned have no pinned version and
+// keep the current version of their structure.
+func (e *Event) AfterFind(tx *gorm.DB) error {
+	if e.Structure != nil && e.StructureVersion != nil {
+		structure := e.StructureVersion.AsStructure()
+		e.Structure = &structure
+	}
+
+	return nil
+}
+
 type CreateEventRequest struct {
 	Name             string    `json:"name"             binding:"required"`
 	Format           string    `json:"format"           binding:"required"`
@@ -68,6 +87,16 @@ type CreateEventRequest struct {
 	StartDate        time.Time `json:"startDate"        binding:"required"`
 	StructureID      int32     `json:"structureId"      binding:"required"`
 	PointsMultiplier float32   `json:"pointsMultiplier" binding:"required"`
+	// KnockoutPoints are the bonus points awarded for each knockout once the
+	// event ends. They are not scaled by the points multiplier
+	KnockoutPoints int32 `json:"knockoutPoints" binding:"omitempty,min=0" example:"2"`
+	// TableSize is the number of seats at each table. Defaults to 9
+	TableSize uint8 `json:"tableSize" binding:"omitempty,min=2,max=12"`
+	// MaxRebuys is the number of times each player may rebuy. Unlimited when null
+	MaxRebuys *uint8 `json:"maxRebuys"`
+	// MaxEntries is the number of players the event has room for. Members
+	// entered or registered once it is full are waitlisted. Unlimited when null
+	MaxEntries *uint16 `json:"maxEntries" binding:"omitempty,min=1" example:"45"`
 } //@name CreateEventRequest
 
 type UpdateEventRequest struct {
@@ -76,6 +105,14 @@ type UpdateEventRequest struct {
 	Notes            *string    `json:"notes"`
 	StartDate        *time.Time `json:"startDate"`
 	PointsMultiplier *float32   `json:"pointsMultiplier"`
+	KnockoutPoints   *int32     `json:"knockoutPoints" binding:"omitempty,min=0"`
+	TableSize        *uint8     `json:"tableSize" binding:"omitempty,min=2,max=12"`
+	// MaxRebuys is the number of times each player may rebuy. Unchanged when
+	// null, and 0 removes the limit
+	MaxRebuys *uint8 `json:"maxRebuys"`
+	// MaxEntries is the number of players the event has room for. Unchanged
+	// when null, and 0 removes the limit, giving every waitlisted member a spot
+	MaxEntries *uint16 `json:"maxEntries"`
 } //@name UpdateEventRequest
 
 type UpdateEventRequestV2 struct {
@@ -84,6 +121,7 @@ type UpdateEventRequestV2 struct {
 	Notes            *string    `json:"notes,omitempty"            binding:"omitempty"                                    example:"Some notes about the event"`
 	StartDate        *time.Time `json:"startDate,omitempty"        binding:"omitempty" example:"2023-10-01T18:00:00Z"`
 	PointsMultiplier *float32   `json:"pointsMultiplier,omitempty" binding:"omitempty,gte=0"                              example:"1.5"`
+	KnockoutPoints   *int32     `json:"knockoutPoints,omitempty"   binding:"omitempty,gte=0"                              example:"2"`
 } //@name UpdateEventRequestV2
 
 // ListEventsFilter is the set of parameters that will be used to filter the
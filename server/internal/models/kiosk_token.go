@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KioskToken lets a self check-in kiosk enter members into a single event,
+// sent in an "Authorization: Bearer" header to the kiosk endpoints. Only a
+// hash of the token is stored. Tokens stop working when the event ends.
+type KioskToken struct {
+	ID      uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID int32     `json:"eventId" gorm:"type:integer;not null;index"`
+	Event   *Event    `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Name    string    `json:"name" gorm:"not null" example:"Front desk"`
+	// Prefix is the start of the token, to tell tokens apart without storing them.
+	Prefix    string `json:"prefix" gorm:"not null" example:"uwpsck_8b2e41"`
+	TokenHash string `json:"-" gorm:"not null;uniqueIndex"`
+	// CreatedBy is the username of the login that created the token
+	CreatedBy string `json:"createdBy" gorm:"not null" example:"director"`
+	// ExpiresAt is when the token stops working, if the event has not ended by then.
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedAt  time.Time  `json:"createdAt" gorm:"not null;default:CURRENT_TIMESTAMP"`
+} //@name KioskToken
+
+func (KioskToken) TableName() string {
+	return "kiosk_tokens"
+}
+
+type CreateKioskTokenRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"Front desk"`
+	// ExpiresAt is optional. Tokens without an expiry last until the event ends
+	ExpiresAt *time.Time `json:"expiresAt"`
+} //@name CreateKioskTokenRequest
+
+// CreateKioskTokenResponse contains the token itself, which is only ever
+// returned when it is created.
+type CreateKioskTokenResponse struct {
+	KioskToken
+	Token string `json:"token" example:"uwpsck_8b2e41c0d2b4e6f8a0c1e3b5d7f9a1c3e5b7d9f1a3c5e7b9d1f3a5c7e9b1d3f5"`
+} //@name CreateKioskTokenResponse
+
+// KioskEventResponse is the event a kiosk checks members in to
+type KioskEventResponse struct {
+	ID        int32     `json:"id" example:"12"`
+	Name      string    `json:"name" example:"Fall 2025 Event #3"`
+	StartDate time.Time `json:"startDate"`
+	State     uint8     `json:"state" example:"0"`
+} //@name KioskEventResponse
+
+// KioskCheckInRequest identifies the member checking in by their student ID
+// or their QuestID. Exactly one of them must be set.
+type KioskCheckInRequest struct {
+	StudentID uint64 `json:"studentId" example:"20780648"`
+	QuestID   string `json:"questId" binding:"omitempty,alphanum,max=20" example:"jdoe"`
+} //@name KioskCheckInRequest
+
+// KioskCheckInResponse tells a member where to sit. Only what the member needs
+// is returned, since kiosks are used by the public.
+type KioskCheckInResponse struct {
+	// FirstName greets the member. Their last name is not shown on the kiosk
+	FirstName   string `json:"firstName" example:"Jane"`
+	TableNumber *int32 `json:"tableNumber" example:"2"`
+	SeatNumber  *int32 `json:"seatNumber" example:"7"`
+	// AlreadyCheckedIn is true when the member had already been entered
+	AlreadyCheckedIn bool `json:"alreadyCheckedIn" example:"false"`
+	// WaitlistPosition is the place of the member on the waitlist when the
+	// event is full. They have no seat until a spot frees up
+	WaitlistPosition *int `json:"waitlistPosition,omitempty" example:"3"`
+} //@name KioskCheckInResponse
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	LedgerEntryTypeMembershipFee = "membership_fee"
+	LedgerEntryTypeRebuy         = "rebuy"
+	LedgerEntryTypeAddOn         = "add_on"
+	LedgerEntryTypeTransaction   = "transaction"
+)
+
+// LedgerEntry is a change to the budget of a semester. Entries are never
+// updated or deleted: a fee that is refunded or a transaction that is edited
+// appends another entry for the difference. The balance of a semester is its
+// starting budget plus the sum of its entries.
+//
+// MembershipID, EventID and TransactionID link the entry to its source. They
+// are not foreign keys, so that deleting the source does not rewrite history.
+type LedgerEntry struct {
+	ID            int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	SemesterID    uuid.UUID  `json:"semesterId" gorm:"type:uuid;not null;index"`
+	Semester      *Semester  `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Type          string     `json:"type" gorm:"not null;index" enums:"membership_fee,rebuy,add_on,transaction" example:"membership_fee"`
+	AmountCents   int64      `json:"amountCents" gorm:"not null" example:"1000"`
+	Description   string     `json:"description" gorm:"not null;default:''" example:"Membership fee"`
+	MembershipID  *uuid.UUID `json:"membershipId" gorm:"type:uuid;index"`
+	EventID       *int32     `json:"eventId" gorm:"type:integer"`
+	TransactionID *int32     `json:"transactionId" gorm:"type:integer"`
+	CreatedAt     time.Time  `json:"createdAt" gorm:"not null;default:CURRENT_TIMESTAMP"`
+} //@name LedgerEntry
+
+func (LedgerEntry) TableName() string {
+	return "ledger_entries"
+}
+
+// LedgerResponse is a page of the ledger of a semester, newest first, with the
+// balance of the whole ledger.
+type LedgerResponse struct {
+	StartingBudgetCents int64         `json:"startingBudgetCents" example:"10000"`
+	BalanceCents        int64         `json:"balanceCents" example:"12500"`
+	Data                []LedgerEntry `json:"data"`
+	Total               int64         `json:"total"`
+} //@name LedgerResponse
+
+// LedgerReconciliation compares the balance computed from the ledger with the
+// legacy current budget of a semester, which was adjusted in place before the
+// ledger existed.
+type LedgerReconciliation struct {
+	SemesterID          uuid.UUID `json:"semesterId"`
+	StartingBudgetCents int64     `json:"startingBudgetCents" example:"10000"`
+	BalanceCents        int64     `json:"balanceCents" example:"12500"`
+	LegacyBalanceCents  int64     `json:"legacyBalanceCents" example:"12498"`
+	// DriftCents is the legacy balance minus the ledger balance
+	DriftCents int64 `json:"driftCents" example:"-2"`
+	Drifted    bool  `json:"drifted" example:"true"`
+	// Totals is the sum of the entries of each type
+	Totals map[string]int64 `json:"totals"`
+} //@name LedgerReconciliation
+
+// DollarsToCents converts a dollar amount to whole cents, rounding to the
+// nearest cent.
+func DollarsToCents(amount float32) int64 {
+	return int64(math.Round(float64(amount) * 100))
+}
@@ -0,0 +1,27 @@
+package models_test
+
+import (
+	"api/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDollarsToCents(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		amount   float32
+		expected int64
+	}{
+		{amount: 0, expected: 0},
+		{amount: 10, expected: 1000},
+		{amount: 15.57, expected: 1557},
+		{amount: 0.1, expected: 10},
+		{amount: -2.35, expected: -235},
+		{amount: 123456.78, expected: 12345678},
+	}
+	for _, tC := range testCases {
+		require.Equal(t, tC.expected, models.DollarsToCents(tC.amount), "amount %v", tC.amount)
+	}
+}
@@ -1,10 +1,22 @@
 package models
 
+import "time"
+
 type Login struct {
 	Username string    `json:"username" binding:"required" gorm:"primaryKey"`
 	Password string    `json:"password" binding:"required" gorm:"not null"`
 	Role     string    `json:"role" binding:"oneof=bot executive tournament_director secretary treasurer vice_president president" gorm:"size:20;not null;default:executive"`
 	Sessions []Session `json:"-" gorm:"foreignKey:Username;references:Username;constraint:OnDelete:CASCADE,OnUpdate:CASCADE"`
+	// TOTPSecret is the base32 secret shared with the login's authenticator
+	// app. It is set when enrollment starts, and only used once
+	// TwoFactorEnabledAt is set.
+	TOTPSecret string `json:"-" gorm:"not null;default:''"`
+	// TOTPLastStep is the time step of the last accepted code, so that a code
+	// cannot be used twice.
+	TOTPLastStep       int64      `json:"-" gorm:"not null;default:0"`
+	TwoFactorEnabledAt *time.Time `json:"-"`
+	// RecoveryCodes are the SHA-256 hashes of the unused recovery codes.
+	RecoveryCodes []string `json:"-" gorm:"type:jsonb;not null;default:'[]';serializer:json"`
 } //@name Login
 
 type NewSessionRequest struct {
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// LoginAttempt tracks the failed logins for a username or an IP address. It is
+// kept in process by the login limiter rather than in the database.
+type LoginAttempt struct {
+	// Key identifies what the failures are counted for, e.g. "username:jdoe"
+	// or "ip:192.0.2.1".
+	Key string
+	// Failures is the number of failed logins since the failures were last
+	// reset.
+	Failures      int
+	LastFailureAt time.Time
+	// LockedUntil is when logins are allowed again. It is in the past when
+	// logins are not delayed.
+	LockedUntil time.Time
+}
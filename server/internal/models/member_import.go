@@ -0,0 +1,74 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	MemberImportStatusCreated   = "created"
+	MemberImportStatusUpdated   = "updated"
+	MemberImportStatusUnchanged = "unchanged"
+
+	MembershipImportStatusCreated = "created"
+	MembershipImportStatusExists  = "exists"
+)
+
+// MemberImportRow is a member read from an imported CSV file.
+type MemberImportRow struct {
+	// Row is the 1-based number of the row in the file, not counting the header
+	Row    int
+	Member CreateUserRequest
+	// Paid and Discounted override the flags of the import for the membership
+	// of this row when the file has paid or discounted columns
+	Paid       *bool
+	Discounted *bool
+}
+
+// MemberImportOptions controls how imported members are written.
+type MemberImportOptions struct {
+	// SemesterID is the semester to create memberships in. No memberships are
+	// created when it is nil.
+	SemesterID *uuid.UUID
+	Paid       bool
+	Discounted bool
+	// DryRun reports what the import would do without saving anything
+	DryRun bool
+}
+
+// MemberImportRowError describes why a row of an imported CSV file is invalid.
+type MemberImportRowError struct {
+	// Row is the 1-based number of the row in the file, not counting the header
+	Row     int    `json:"row" example:"3"`
+	Field   string `json:"field,omitempty" example:"email"`
+	Message string `json:"message" example:"email must be a valid email address"`
+} //@name MemberImportRowError
+
+// MemberImportErrorResponse is returned when an imported CSV file has invalid
+// rows. Every invalid row is reported, and nothing is imported.
+type MemberImportErrorResponse struct {
+	Code    int                    `json:"code" example:"400"`
+	Type    string                 `json:"type" example:"INVALID_REQUEST"`
+	Message string                 `json:"message" example:"The import has 1 invalid row"`
+	Errors  []MemberImportRowError `json:"errors"`
+} //@name MemberImportErrorResponse
+
+// MemberImportRowResult reports what was done with a row of an imported CSV
+// file.
+type MemberImportRowResult struct {
+	Row int    `json:"row" example:"1"`
+	ID  uint64 `json:"id" example:"20801234"`
+	// Member is created, updated or unchanged
+	Member string `json:"member" example:"created"`
+	// Membership is created or exists, and empty when the import has no semester
+	Membership string `json:"membership,omitempty" example:"created"`
+} //@name MemberImportRowResult
+
+// MemberImportResponse reports the result of a member import.
+type MemberImportResponse struct {
+	// DryRun is true when nothing was saved
+	DryRun             bool                    `json:"dryRun"`
+	SemesterID         *uuid.UUID              `json:"semesterId"`
+	Created            int                     `json:"created"`
+	Updated            int                     `json:"updated"`
+	Unchanged          int                     `json:"unchanged"`
+	MembershipsCreated int                     `json:"membershipsCreated"`
+	Rows               []MemberImportRowResult `json:"rows"`
+} //@name MemberImportResponse
@@ -11,9 +11,28 @@ type Participant struct {
 	ID           int32       `json:"id" gorm:"type:integer;primaryKey;autoIncrement"`
 	MembershipID *uuid.UUID  `json:"membershipId" gorm:"type:uuid;uniqueIndex:idx_membership_event"`
 	Membership   *Membership `json:"membership,omitempty" gorm:"constraint:OnDelete:SET NULL,OnUpdate:CASCADE"`
-	EventID      int32       `json:"eventId" gorm:"type:integer;not null;uniqueIndex:idx_membership_event"`
+	EventID      int32       `json:"eventId" gorm:"type:integer;not null;uniqueIndex:idx_membership_event;uniqueIndex:idx_participant_seat"`
 	Placement    uint16      `json:"placement"`
-	SignedOutAt  *time.Time  `json:"signedOutAt"`
+	// PlacementOverride is the placement set by the director, which takes
+	// precedence over the order players signed out in when the event ends.
+	// Entries overridden to the same placement are tied.
+	PlacementOverride *uint16    `json:"placementOverride" gorm:"type:integer" example:"2"`
+	SignedOutAt       *time.Time `json:"signedOutAt"`
+	// TableNumber and SeatNumber are the seat of a player still in the event.
+	// Both are null once the player signs out.
+	TableNumber *int32 `json:"tableNumber" gorm:"type:integer;uniqueIndex:idx_participant_seat" example:"2"`
+	SeatNumber  *int32 `json:"seatNumber" gorm:"type:integer;uniqueIndex:idx_participant_seat" example:"7"`
+	// Rebuys and AddOns count the rebuys and add-ons the player has bought
+	Rebuys uint8 `json:"rebuys" gorm:"not null;default:0" example:"1"`
+	AddOns uint8 `json:"addOns" gorm:"not null;default:0" example:"0"`
+	// KnockedOutByID is the entry that eliminated the player, recorded when
+	// they sign out. It is cleared when they sign back in.
+	KnockedOutByID *int32       `json:"knockedOutById" gorm:"type:integer;index" example:"14"`
+	KnockedOutBy   *Participant `json:"-" gorm:"foreignKey:KnockedOutByID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	// GuestName is the name of a walk-in player without a membership. Guests
+	// count towards the size of the event but do not earn ranking points
+	// until they are converted to a membership.
+	GuestName *string `json:"guestName" gorm:"type:text" example:"Sam Walker"`
 } //@name Participant
 
 func (Participant) TableName() string {
@@ -38,13 +57,43 @@ type UpdateParticipantRequest struct {
 	EventID      int32     `json:"eventId" binding:"required"`
 	SignIn       bool
 	SignOut      bool
+	// KnockedOutBy is the membership of the entry that eliminated the player
+	// when signing out
+	KnockedOutBy *uuid.UUID
+	// KnockedOutByEntryID is the entry that eliminated the player when
+	// signing out, which may be a guest
+	KnockedOutByEntryID *int32
+	// GuestID is the guest entry to update instead of the entry of MembershipID
+	GuestID int32
 }
 
+// SignOutEntryRequest is the optional body of a sign out
+type SignOutEntryRequest struct {
+	// KnockedOutBy is the membership of the entry that eliminated the player
+	KnockedOutBy *uuid.UUID `json:"knockedOutBy"`
+	// KnockedOutByEntry is the entry that eliminated the player, used instead
+	// of KnockedOutBy when the eliminator is a guest
+	KnockedOutByEntry *int32 `json:"knockedOutByEntry" example:"14"`
+} //@name SignOutEntryRequest
+
 type DeleteParticipantRequest struct {
 	MembershipID uuid.UUID `json:"membershipId" binding:"required"`
 	EventID      int32     `json:"eventId" binding:"required"`
+	// GuestID is the guest entry to delete instead of the entry of MembershipID
+	GuestID int32
 }
 
+// CreateGuestEntryRequest enters a walk-in player without a membership
+type CreateGuestEntryRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"Sam Walker"`
+} //@name CreateGuestEntryRequest
+
+// ConvertGuestEntryRequest gives a guest entry to the membership the player
+// took out afterwards
+type ConvertGuestEntryRequest struct {
+	MembershipID uuid.UUID `json:"membershipId" binding:"required"`
+} //@name ConvertGuestEntryRequest
+
 // ListParticipantsFilter is the set of parameters that will be used to filter the
 // list entries query. EventID must be set by the caller; the zero value for the
 // embedded Pagination is the same as not paginating the result. Filtering by
@@ -58,18 +107,50 @@ type ListParticipantsFilter struct {
 	EventID int32
 }
 
+// PlacementOverride sets the placement of the entry of a membership
+type PlacementOverride struct {
+	MembershipID uuid.UUID `json:"membershipId" binding:"required"`
+	Placement    uint16    `json:"placement" binding:"required,min=1" example:"2"`
+} //@name PlacementOverride
+
+// SetPlacementsRequest replaces the placement overrides of an event. Entries
+// which are not listed lose their override and are placed by the order they
+// signed out in.
+type SetPlacementsRequest struct {
+	Placements []PlacementOverride `json:"placements" binding:"required,dive"`
+} //@name SetPlacementsRequest
+
+// EventPlacement is the placement and points an entry has, or will have once
+// the event ends
+type EventPlacement struct {
+	EntryID      int32      `json:"entryId" example:"12"`
+	MembershipID *uuid.UUID `json:"membershipId"`
+	FirstName    string     `json:"firstName" example:"Jane"`
+	LastName     string     `json:"lastName" example:"Doe"`
+	Placement    uint16     `json:"placement" example:"2"`
+	Tied         bool       `json:"tied" example:"true"`
+	Overridden   bool       `json:"overridden" example:"true"`
+	Points       int        `json:"points" example:"18"`
+} //@name EventPlacement
+
 type ListParticipantsResult struct {
-	ID           int32      `json:"id"`
-	MembershipId uuid.UUID  `json:"membershipId"`
-	FirstName    string     `json:"firstName"`
-	LastName     string     `json:"lastName"`
-	SignedOutAt  *time.Time `json:"signedOutAt"`
-	Placement    uint16     `json:"placement"`
+	ID                int32      `json:"id"`
+	MembershipId      uuid.UUID  `json:"membershipId"`
+	FirstName         string     `json:"firstName"`
+	LastName          string     `json:"lastName"`
+	SignedOutAt       *time.Time `json:"signedOutAt"`
+	Placement         uint16     `json:"placement"`
+	PlacementOverride *uint16    `json:"placementOverride"`
+	Rebuys            uint8      `json:"rebuys"`
+	AddOns            uint8      `json:"addOns"`
+	KnockedOutByID    *int32     `json:"knockedOutById"`
 } //@name ListParticipantsResult
 
 type CreateEntryResult struct {
 	MembershipID uuid.UUID    `json:"membershipId"`
-	Status       string       `json:"status"` // "created" or "error"
+	Status       string       `json:"status"` // "created", "waitlisted" or "error"
 	Participant  *Participant `json:"participant,omitempty"`
-	Error        string       `json:"error,omitempty"`
+	// Registration is the place of the member on the waitlist of a full event
+	Registration *Registration `json:"registration,omitempty"`
+	Error        string        `json:"error,omitempty"`
 } //@name CreateEntryResult
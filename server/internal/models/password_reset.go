@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordResetToken is a single-use credential an administrator issues so
+// that a user can set a new password without knowing the current one. Only a
+// hash of the token is stored.
+type PasswordResetToken struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Username  string    `json:"username" gorm:"not null;index"`
+	Login     *Login    `json:"-" gorm:"foreignKey:Username;references:Username;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	TokenHash string    `json:"-" gorm:"not null;uniqueIndex"`
+	// CreatedBy is the username of the administrator who issued the token.
+	CreatedBy string     `json:"createdBy" gorm:"not null"`
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"not null"`
+	UsedAt    *time.Time `json:"usedAt"`
+	CreatedAt time.Time  `json:"createdAt" gorm:"not null;default:CURRENT_TIMESTAMP"`
+} //@name PasswordResetToken
+
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
+
+// CreatePasswordResetTokenResponse contains the token itself, which is only
+// ever returned when it is created.
+type CreatePasswordResetTokenResponse struct {
+	Username  string    `json:"username" example:"jdoe"`
+	Token     string    `json:"token" example:"3f9a1c0d2b4e6f8a0c1e3b5d7f9a1c3e5b7d9f1a3c5e7b9d1f3a5c7e9b1d3f5a"`
+	ExpiresAt time.Time `json:"expiresAt"`
+} //@name CreatePasswordResetTokenResponse
+
+// ChangePasswordRequest represents the request body for changing the password
+// of the logged-in user.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" binding:"required"`
+	NewPassword     string `json:"newPassword" binding:"required,min=8"`
+} //@name ChangePasswordRequest
+
+// ResetPasswordRequest represents the request body for redeeming a password
+// reset token.
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"newPassword" binding:"required,min=8"`
+} //@name ResetPasswordRequest
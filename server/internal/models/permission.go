@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// RolePermission is the set of roles allowed to perform an action, such as
+// "event.end". Actions without a row use the roles of the default policy.
+type RolePermission struct {
+	Action string   `json:"action" gorm:"primaryKey" example:"event.end"`
+	Roles  []string `json:"roles" gorm:"type:jsonb;not null;serializer:json" example:"secretary,treasurer,vice_president,president,webmaster"`
+	// DefaultRoles are the roles allowed by the default policy.
+	DefaultRoles []string `json:"defaultRoles" gorm:"-" example:"secretary,treasurer,vice_president,president,webmaster"`
+	// Protected actions manage the policy itself and cannot be changed.
+	Protected bool      `json:"protected" gorm:"-" example:"false"`
+	UpdatedAt time.Time `json:"updatedAt" gorm:"not null;default:CURRENT_TIMESTAMP"`
+} //@name RolePermission
+
+func (RolePermission) TableName() string {
+	return "role_permissions"
+}
+
+type UpdateRolePermissionRequest struct {
+	// Roles allowed to perform the action. An empty list allows no one
+	Roles []string `json:"roles" binding:"required,dive,oneof=bot executive tournament_director secretary treasurer vice_president president webmaster" example:"tournament_director,webmaster"`
+} //@name UpdateRolePermissionRequest
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Rounding modes applied to a payout after it has been scaled by the event size.
+const (
+	PointsRoundingCeil  = "ceil"
+	PointsRoundingFloor = "floor"
+	PointsRoundingRound = "round"
+)
+
+// PointsScheme describes how points are awarded for events within a semester.
+// A placement's payout is scaled by eventSize / SizeFactor, rounded using
+// RoundingMode, raised to at least MinimumPoints and finally multiplied by the
+// event's points multiplier. Placements beyond the payout table receive
+// DefaultPayout.
+type PointsScheme struct {
+	ID            int32          `json:"id" gorm:"type:integer;primaryKey;autoIncrement"`
+	SemesterID    uuid.UUID      `json:"semesterId" gorm:"type:uuid;not null;uniqueIndex" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SizeFactor    float64        `json:"sizeFactor" gorm:"not null;default:50" example:"50"`
+	RoundingMode  string         `json:"roundingMode" gorm:"not null;default:'ceil'" example:"ceil"`
+	MinimumPoints int32          `json:"minimumPoints" gorm:"not null;default:0" example:"0"`
+	DefaultPayout int32          `json:"defaultPayout" gorm:"not null;default:1" example:"1"`
+	Payouts       []PointsPayout `json:"payouts" gorm:"foreignKey:PointsSchemeID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+} //@name PointsScheme
+
+func (PointsScheme) TableName() string {
+	return "points_schemes"
+}
+
+type PointsSchemePreloadOptions struct {
+	Payouts bool
+}
+
+func (PointsScheme) Preload(tx *gorm.DB, options ...PointsSchemePreloadOptions) *gorm.DB {
+	ret := tx
+
+	// Default options if none provided
+	opts := PointsSchemePreloadOptions{Payouts: false}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	if opts.Payouts {
+		ret = ret.Preload("Payouts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("placement ASC")
+		})
+	}
+
+	return ret
+}
+
+// Payout returns the unscaled payout for the given placement.
+func (s PointsScheme) Payout(placement int) int32 {
+	if placement < 1 || placement > len(s.Payouts) {
+		return s.DefaultPayout
+	}
+
+	return s.Payouts[placement-1].Points
+}
+
+type PointsPayout struct {
+	ID             int32 `json:"-" gorm:"type:integer;primaryKey;autoIncrement"`
+	PointsSchemeID int32 `json:"-" gorm:"type:integer;not null;uniqueIndex:idx_points_scheme_placement"`
+	Placement      int32 `json:"placement" gorm:"not null;uniqueIndex:idx_points_scheme_placement" example:"1"`
+	Points         int32 `json:"points" gorm:"not null" example:"32"`
+} //@name PointsPayout
+
+func (PointsPayout) TableName() string {
+	return "points_payouts"
+}
+
+type UpsertPointsSchemeRequest struct {
+	SizeFactor    float64 `json:"sizeFactor" binding:"required,gt=0" example:"50"`
+	RoundingMode  string  `json:"roundingMode" binding:"required,oneof=ceil floor round" example:"ceil"`
+	MinimumPoints int32   `json:"minimumPoints" binding:"gte=0" example:"0"`
+	DefaultPayout int32   `json:"defaultPayout" binding:"gte=0" example:"1"`
+	Payouts       []int32 `json:"payouts" binding:"required,min=1,dive,gte=0" example:"32,28,24"`
+} //@name UpsertPointsSchemeRequest
@@ -1,6 +1,10 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // SemesterRankingsView is the name of the database view that computes rankings
 // with proper tie handling using RANK(). All ranking queries should use this
@@ -25,9 +29,68 @@ type RankingResponse struct {
 	LastName  string `json:"lastName"`
 	Points    int32  `json:"points"`
 	Position  int32  `json:"position"`
+	// Knockouts is the number of players the member eliminated in ended events
+	Knockouts int32 `json:"knockouts"`
 } //@name RankingResponse
 
 type GetRankingResponse struct {
 	Points   int32 `json:"points"`
 	Position int32 `json:"position"`
 } //@name GetRankingResponse
+
+const (
+	RankingExportFormatCSV  = "csv"
+	RankingExportFormatJSON = "json"
+	// RankingExportFormatTSV is tab separated with a UTF-8 byte order mark and
+	// CRLF line endings, so spreadsheet programs such as Excel open it directly
+	RankingExportFormatTSV = "tsv"
+)
+
+// RankingExportOptions controls the format of a rankings export and the point
+// in the semester it is taken at. At most one of EventID and AsOf is set, and
+// the current rankings are exported when neither is.
+type RankingExportOptions struct {
+	Format string
+	// EventID exports the rankings as they stood once this event ended, from
+	// the ended events that started no later than it
+	EventID *int32
+	// AsOf exports the rankings from the ended events that started at or
+	// before this time
+	AsOf *time.Time
+}
+
+// RankingExportRow is a row of a rankings export.
+type RankingExportRow struct {
+	Position  int32  `json:"position" example:"1"`
+	ID        uint64 `json:"id" example:"20780648"`
+	FirstName string `json:"firstName" example:"Jane"`
+	LastName  string `json:"lastName" example:"Doe"`
+	Points    int32  `json:"points" example:"120"`
+	// Attendance is the number of events the member was entered in, counting
+	// events that have not ended
+	Attendance int32 `json:"attendance" example:"6"`
+	// EventsPlayed is the number of ended events the member was entered in,
+	// which are the events their points come from
+	EventsPlayed int32 `json:"eventsPlayed" example:"5"`
+} //@name RankingExportRow
+
+// RankingDiff describes how a membership's ranking changed (or would change)
+// when rankings are rebuilt from the stored event placements.
+type RankingDiff struct {
+	MembershipID       uuid.UUID `json:"membershipId"`
+	FirstName          string    `json:"firstName"`
+	LastName           string    `json:"lastName"`
+	PreviousPoints     int32     `json:"previousPoints"`
+	Points             int32     `json:"points"`
+	PreviousAttendance int32     `json:"previousAttendance"`
+	Attendance         int32     `json:"attendance"`
+} //@name RankingDiff
+
+type RebuildRankingsResponse struct {
+	SemesterID uuid.UUID `json:"semesterId"`
+	DryRun     bool      `json:"dryRun"`
+	// EventsProcessed is the number of ended events the rankings were rebuilt from
+	EventsProcessed int `json:"eventsProcessed"`
+	// Diffs contains only the memberships whose ranking changed
+	Diffs []RankingDiff `json:"diffs"`
+} //@name RebuildRankingsResponse
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RankingSnapshotReasonEventEnded     = "event_ended"
+	RankingSnapshotReasonEventEndUndone = "event_end_undone"
+	// RankingSnapshotReasonPlacementsCorrected is recorded when the placements
+	// of an ended event are corrected
+	RankingSnapshotReasonPlacementsCorrected = "placements_corrected"
+	// RankingSnapshotReasonGuestConverted is recorded when a guest entry of an
+	// ended event is given to a membership, which earns its points
+	RankingSnapshotReasonGuestConverted = "guest_converted"
+)
+
+// RankingSnapshot records the rankings of a semester right after an event was
+// ended, its end was undone, its placements were corrected or one of its guests
+// became a member, so the history of the leaderboard can be shown.
+// Snapshots are never updated. EventID is not a foreign key, so that deleting
+// an event does not rewrite history.
+type RankingSnapshot struct {
+	ID         int64                  `json:"id" gorm:"primaryKey;autoIncrement"`
+	SemesterID uuid.UUID              `json:"semesterId" gorm:"type:uuid;not null;index"`
+	Semester   *Semester              `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	EventID    *int32                 `json:"eventId" gorm:"type:integer"`
+	Reason     string                 `json:"reason" gorm:"not null" enums:"event_ended,event_end_undone,placements_corrected,guest_converted" example:"event_ended"`
+	CreatedAt  time.Time              `json:"createdAt" gorm:"not null;default:CURRENT_TIMESTAMP"`
+	Entries    []RankingSnapshotEntry `json:"-" gorm:"foreignKey:SnapshotID"`
+} //@name RankingSnapshot
+
+func (RankingSnapshot) TableName() string {
+	return "ranking_snapshots"
+}
+
+// RankingSnapshotEntry is the points and position of a membership in a
+// ranking snapshot.
+type RankingSnapshotEntry struct {
+	SnapshotID   int64            `json:"snapshotId" gorm:"primaryKey;autoIncrement:false"`
+	Snapshot     *RankingSnapshot `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	MembershipID uuid.UUID        `json:"membershipId" gorm:"type:uuid;primaryKey;index"`
+	Membership   *Membership      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Points       int32            `json:"points" gorm:"not null"`
+	Position     int32            `json:"position" gorm:"not null"`
+} //@name RankingSnapshotEntry
+
+func (RankingSnapshotEntry) TableName() string {
+	return "ranking_snapshot_entries"
+}
+
+// RankingHistoryEntry is a ranking in a snapshot of the rankings history.
+type RankingHistoryEntry struct {
+	Position     int32     `json:"position" example:"1"`
+	MembershipID uuid.UUID `json:"membershipId"`
+	UserID       uint64    `json:"userId" example:"20780648"`
+	FirstName    string    `json:"firstName" example:"Jane"`
+	LastName     string    `json:"lastName" example:"Doe"`
+	Points       int32     `json:"points" example:"120"`
+} //@name RankingHistoryEntry
+
+// RankingHistorySnapshot is a snapshot of the rankings of a semester, ordered
+// by position and then name.
+type RankingHistorySnapshot struct {
+	ID        int64                 `json:"id" example:"12"`
+	EventID   *int32                `json:"eventId" example:"4"`
+	EventName string                `json:"eventName" example:"Week 4 Tournament"`
+	Reason    string                `json:"reason" enums:"event_ended,event_end_undone,placements_corrected" example:"event_ended"`
+	CreatedAt time.Time             `json:"createdAt"`
+	Rankings  []RankingHistoryEntry `json:"rankings" gorm:"-"`
+} //@name RankingHistorySnapshot
+
+// RankingHistoryPoint is the points and position of a membership in one
+// snapshot, a point in the time series of its ranking.
+type RankingHistoryPoint struct {
+	SnapshotID int64     `json:"snapshotId" example:"12"`
+	EventID    *int32    `json:"eventId" example:"4"`
+	EventName  string    `json:"eventName" example:"Week 4 Tournament"`
+	Reason     string    `json:"reason" enums:"event_ended,event_end_undone,placements_corrected" example:"event_ended"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Points     int32     `json:"points" example:"120"`
+	Position   int32     `json:"position" example:"3"`
+} //@name RankingHistoryPoint
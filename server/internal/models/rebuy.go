@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	RebuyTypeRebuy = "rebuy"
+	RebuyTypeAddOn = "add_on"
+)
+
+// Rebuy is a rebuy or add-on bought by a player in an event. Amount is the fee
+// that was added to the semester budget, so that undoing the rebuy takes back
+// exactly what was charged even if the semester fees change in the meantime.
+type Rebuy struct {
+	ID            int32        `json:"id" gorm:"type:integer;primaryKey;autoIncrement"`
+	ParticipantID int32        `json:"participantId" gorm:"type:integer;not null;index"`
+	Participant   *Participant `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Type          string       `json:"type" gorm:"not null;default:'rebuy'" enums:"rebuy,add_on" example:"rebuy"`
+	Amount        float32      `json:"amount" gorm:"not null;default:0" example:"2"`
+	CreatedAt     time.Time    `json:"createdAt" gorm:"not null;default:CURRENT_TIMESTAMP"`
+} //@name Rebuy
+
+func (Rebuy) TableName() string {
+	return "rebuys"
+}
+
+type CreateRebuyRequest struct {
+	// Type of the purchase. Defaults to rebuy
+	Type string `json:"type" binding:"omitempty,oneof=rebuy add_on" enums:"rebuy,add_on" example:"add_on"`
+} //@name CreateRebuyRequest
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RegistrationStatusRegistered holds a spot in the event until the
+	// registration is converted into an entry
+	RegistrationStatusRegistered = "registered"
+	// RegistrationStatusWaitlisted waits for a spot to free up in a full event
+	RegistrationStatusWaitlisted = "waitlisted"
+)
+
+// Registration is a membership registered for an event before it starts, or
+// waiting for a spot once the event is full. Waitlisted registrations are
+// promoted in the order they were made.
+type Registration struct {
+	ID           int32       `json:"id" gorm:"type:integer;primaryKey;autoIncrement"`
+	EventID      int32       `json:"eventId" gorm:"type:integer;not null;uniqueIndex:idx_registration_event_membership"`
+	Event        *Event      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	MembershipID uuid.UUID   `json:"membershipId" gorm:"type:uuid;not null;uniqueIndex:idx_registration_event_membership"`
+	Membership   *Membership `json:"membership,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Status       string      `json:"status" gorm:"not null;default:registered" enums:"registered,waitlisted" example:"waitlisted"`
+	// Position is the place of a waitlisted registration in the waitlist,
+	// starting at 1. It is omitted for registrations holding a spot.
+	Position  int       `json:"position,omitempty" gorm:"-" example:"2"`
+	CreatedAt time.Time `json:"createdAt" gorm:"not null;default:CURRENT_TIMESTAMP"`
+} //@name Registration
+
+func (Registration) TableName() string {
+	return "event_registrations"
+}
+
+// CreateRegistrationRequest registers a membership for an event
+type CreateRegistrationRequest struct {
+	MembershipID uuid.UUID `json:"membershipId" binding:"required"`
+} //@name CreateRegistrationRequest
@@ -0,0 +1,52 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	// DefaultTableSize is the number of seats at each table of an event,
+	// unless the event sets its own table size.
+	DefaultTableSize = 9
+	MinTableSize     = 2
+	MaxTableSize     = 12
+)
+
+// SeatingSeat is an occupied seat at a table.
+type SeatingSeat struct {
+	Seat        int32       `json:"seat" example:"4"`
+	Participant Participant `json:"participant"`
+} //@name SeatingSeat
+
+// SeatingTable is a table of an event with its occupied seats, in seat order.
+type SeatingTable struct {
+	Number int32         `json:"number" example:"1"`
+	Seats  []SeatingSeat `json:"seats"`
+} //@name SeatingTable
+
+// SeatingResponse is the seating of the players still in an event.
+type SeatingResponse struct {
+	TableSize uint8          `json:"tableSize" example:"9"`
+	Tables    []SeatingTable `json:"tables"`
+} //@name SeatingResponse
+
+// SeatMove moves a player to another seat, when tables are balanced or broken.
+type SeatMove struct {
+	ParticipantID int32      `json:"participantId" example:"12"`
+	MembershipID  *uuid.UUID `json:"membershipId"`
+	FromTable     int32      `json:"fromTable" example:"3"`
+	FromSeat      int32      `json:"fromSeat" example:"5"`
+	ToTable       int32      `json:"toTable" example:"1"`
+	ToSeat        int32      `json:"toSeat" example:"2"`
+} //@name SeatMove
+
+// TableBreakPlan describes how the players of a table are moved to the other
+// tables when it is broken.
+type TableBreakPlan struct {
+	// Table is the table that is broken
+	Table int32      `json:"table" example:"3"`
+	Moves []SeatMove `json:"moves"`
+} //@name TableBreakPlan
+
+type BreakTableRequest struct {
+	// Table to break. Defaults to the table with the fewest players
+	Table *int32 `json:"table" binding:"omitempty,gt=0" example:"3"`
+} //@name BreakTableRequest
@@ -10,12 +10,38 @@ type Session struct {
 	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
 	StartedAt time.Time `json:"startedAt" gorm:"not null;default:CURRENT_TIMESTAMP"`
 	ExpiresAt time.Time `json:"expiresAt" gorm:"not null"`
-	Username  string    `json:"username" gorm:"not null"`
+	Username  string    `json:"username" gorm:"not null;index"`
 	Role      string    `json:"role" gorm:"size:20;not null;default:executive"`
+	// LastSeenAt is when the session last authenticated a request. The
+	// expiry slides forward from it, up to the maximum session lifetime.
+	LastSeenAt time.Time `json:"lastSeenAt" gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UserAgent  string    `json:"userAgent" gorm:"not null;default:''"`
+	IPAddress  string    `json:"ipAddress" gorm:"not null;default:''"`
+	// TwoFactorVerified is true for sessions created with a two-factor code,
+	// or in which two-factor authentication was enabled.
+	TwoFactorVerified bool `json:"twoFactorVerified" gorm:"not null;default:false"`
 } //@name Session
 
+// SessionInfo describes an active session of the current user. ID is a
+// handle derived from the session ID, as the session ID itself is a
+// credential.
+type SessionInfo struct {
+	ID         string    `json:"id" example:"9f86d081884c7d65"`
+	StartedAt  time.Time `json:"startedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	UserAgent  string    `json:"userAgent" example:"Mozilla/5.0"`
+	IPAddress  string    `json:"ipAddress" example:"203.0.113.7"`
+	// Current is true for the session making the request.
+	Current bool `json:"current"`
+} //@name SessionInfo
+
 type GetSessionResponse struct {
 	Username    string                    `json:"username"`
 	Role        string                    `json:"role"`
 	Permissions map[string]map[string]any `json:"permissions"`
+	// TwoFactorSetupRequired is true when the role must use two-factor
+	// authentication and the session has not. Such sessions may only set up
+	// two-factor authentication.
+	TwoFactorSetupRequired bool `json:"twoFactorSetupRequired"`
 } //@name GetSessionResponse
@@ -0,0 +1,43 @@
+package models
+
+const (
+	StructureFormatCSV  = "csv"
+	StructureFormatJSON = "json"
+
+	// StructureInterchangeVersion is the current version of the JSON
+	// structure interchange format.
+	StructureInterchangeVersion = 1
+)
+
+// StructureCSVHeader is the header row written when exporting a structure as
+// CSV. Imports also accept the columns in any order, and only require the
+// small, big and time columns.
+var StructureCSVHeader = []string{"type", "label", "small", "big", "ante", "time"}
+
+// StructureInterchange is the JSON format used to import and export
+// structures. Levels use the same fields, defaults and rules as the levels of
+// CreateStructureRequest.
+type StructureInterchange struct {
+	// Version of the interchange format. Defaults to 1 on import.
+	Version int         `json:"version" example:"1"`
+	Name    string      `json:"name" example:"Monday Turbo"`
+	Levels  []BlindJSON `json:"levels"`
+} //@name StructureInterchange
+
+// StructureImportRowError describes why a level of an imported structure is
+// invalid.
+type StructureImportRowError struct {
+	// Row is the 1-based number of the level in the import, not counting the CSV header
+	Row     int    `json:"row" example:"3"`
+	Field   string `json:"field,omitempty" example:"big"`
+	Message string `json:"message" example:"big is required"`
+} //@name StructureImportRowError
+
+// StructureImportErrorResponse is returned when an imported structure has
+// invalid levels. Every invalid level is reported.
+type StructureImportErrorResponse struct {
+	Code    int                       `json:"code" example:"400"`
+	Type    string                    `json:"type" example:"INVALID_REQUEST"`
+	Message string                    `json:"message" example:"The structure has 1 invalid level"`
+	Errors  []StructureImportRowError `json:"errors"`
+} //@name StructureImportErrorResponse
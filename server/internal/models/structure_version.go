@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// StructureVersion is an immutable snapshot of a structure. A new version is
+// recorded every time a structure is created or edited, and events keep
+// playing the version that was current when they were created.
+type StructureVersion struct {
+	ID          int32      `json:"id"          gorm:"type:integer;primaryKey;autoIncrement"`
+	StructureID int32      `json:"structureId" gorm:"type:integer;not null;uniqueIndex:idx_structure_versions_structure_version"`
+	Structure   *Structure `json:"-"           gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Version     int32      `json:"version"     gorm:"type:integer;not null;uniqueIndex:idx_structure_versions_structure_version" example:"2"`
+	Name        string     `json:"name"        gorm:"not null" example:"Monday Turbo"`
+	Blinds      []Blind    `json:"blinds"      gorm:"type:jsonb;not null;serializer:json"`
+	CreatedAt   time.Time  `json:"createdAt"   gorm:"not null;default:CURRENT_TIMESTAMP"`
+} //@name StructureVersion
+
+func (StructureVersion) TableName() string {
+	return "structure_versions"
+}
+
+// NewStructureVersion snapshots the name and levels of a structure as its
+// current version.
+func NewStructureVersion(structure Structure) StructureVersion {
+	blinds := make([]Blind, len(structure.Blinds))
+	for i, blind := range structure.Blinds {
+		blind.ID = 0
+		blind.StructureId = 0
+		if blind.Type == "" {
+			blind.Type = BlindLevelTypeBlind
+		}
+		blinds[i] = blind
+	}
+
+	return StructureVersion{
+		StructureID: structure.ID,
+		Version:     structure.Version,
+		Name:        structure.Name,
+		Blinds:      blinds,
+	}
+}
+
+// AsStructure returns the structure as it was at this version, with its levels
+// in order.
+func (v StructureVersion) AsStructure() Structure {
+	blinds := make([]Blind, len(v.Blinds))
+	for i, blind := range v.Blinds {
+		blind.Index = int8(i)
+		blind.StructureId = v.StructureID
+		blinds[i] = blind
+	}
+
+	return Structure{
+		ID:      v.StructureID,
+		Name:    v.Name,
+		Version: v.Version,
+		Blinds:  blinds,
+	}
+}
+
+type CloneStructureRequest struct {
+	// Name of the new structure. Defaults to the name of the original followed by "(copy)"
+	Name string `json:"name" binding:"omitempty,max=255" example:"Monday Turbo (deep)"`
+	// Version of the original to copy. Defaults to the current version
+	Version *int32 `json:"version" binding:"omitempty,gt=0" example:"2"`
+} //@name CloneStructureRequest
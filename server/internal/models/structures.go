@@ -3,9 +3,12 @@ package models
 import "gorm.io/gorm"
 
 type Structure struct {
-	ID     int32   `json:"id" gorm:"type:integer;primaryKey;autoIncrement"`
-	Name   string  `json:"name" gorm:"not null"`
-	Blinds []Blind `json:"blinds" gorm:"foreignKey:StructureId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ID     int32  `json:"id" gorm:"type:integer;primaryKey;autoIncrement"`
+	Name   string `json:"name" gorm:"not null"`
+	// Version is the number of the current version of the structure. It
+	// increases every time the structure is edited.
+	Version int32   `json:"version" gorm:"type:integer;not null;default:1" example:"1"`
+	Blinds  []Blind `json:"blinds" gorm:"foreignKey:StructureId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 } //@name Structure
 
 func (Structure) TableName() string {
@@ -34,21 +37,66 @@ func (Structure) Preload(tx *gorm.DB, options ...StructurePreloadOptions) *gorm.
 	return ret
 }
 
+const (
+	// BlindLevelTypeBlind is a regular level with blinds and antes
+	BlindLevelTypeBlind = "blind"
+	// BlindLevelTypeBreak is a break in play, such as a dinner break
+	BlindLevelTypeBreak = "break"
+	// BlindLevelTypeChipRace is a break used to colour up and race off chips
+	BlindLevelTypeChipRace = "chip_race"
+)
+
+// Blind is a single level of a structure. Despite the name, a level may also be
+// a break or a chip race, in which case it has no blinds.
 type Blind struct {
-	ID          int32 `json:"-" gorm:"type:integer;primaryKey;autoIncrement"`
-	Small       int32 `json:"small" gorm:"not null"`
-	Big         int32 `json:"big" gorm:"not null"`
-	Ante        int32 `json:"ante" gorm:"not null"`
-	Time        int8  `json:"time" gorm:"not null"`
+	ID    int32  `json:"-" gorm:"type:integer;primaryKey;autoIncrement"`
+	Type  string `json:"type" gorm:"not null;default:'blind'" enums:"blind,break,chip_race" example:"blind"`
+	Label string `json:"label" gorm:"not null;default:''" example:"Dinner break"`
+	Small int32  `json:"small" gorm:"not null"`
+	Big   int32  `json:"big" gorm:"not null"`
+	Ante  int32  `json:"ante" gorm:"not null"`
+	// Time is the length of the level in minutes
+	Time        int32 `json:"time" gorm:"type:integer;not null"`
 	Index       int8  `json:"-" gorm:"not null"`
 	StructureId int32 `json:"-" gorm:"type:integer;not null"`
 }//@name Blind
 
+// BlindJSON is a level of a structure in a create or update request. Levels
+// without a type are blind levels, which keeps requests written before level
+// types existed valid. Breaks and chip races cannot have blinds or antes.
 type BlindJSON struct {
-	Small int32 `json:"small" binding:"required,gte=0"`
-	Big   int32 `json:"big" binding:"required,gte=0"`
-	Ante  int32 `json:"ante" binding:"omitempty,gte=0"`
-	Time  int8  `json:"time" binding:"required,gt=0,lte=60"`
+	Type  string `json:"type" binding:"omitempty,oneof=blind break chip_race" enums:"blind,break,chip_race"`
+	Label string `json:"label" binding:"omitempty,max=100"`
+	Small int32  `json:"small" binding:"required_without=Type,required_if=Type blind,excluded_if=Type break,excluded_if=Type chip_race,gte=0"`
+	Big   int32  `json:"big" binding:"required_without=Type,required_if=Type blind,excluded_if=Type break,excluded_if=Type chip_race,gte=0"`
+	Ante  int32  `json:"ante" binding:"omitempty,excluded_if=Type break,excluded_if=Type chip_race,gte=0"`
+	// Time is the length of the level in minutes
+	Time int32 `json:"time" binding:"required,gt=0,lte=1440"`
+}
+
+// NewBlindsFromJSON converts the levels of a request into the blinds of a
+// structure, in order.
+func NewBlindsFromJSON(structureID int32, levels []BlindJSON) []Blind {
+	blinds := make([]Blind, len(levels))
+	for i, level := range levels {
+		levelType := level.Type
+		if levelType == "" {
+			levelType = BlindLevelTypeBlind
+		}
+
+		blinds[i] = Blind{
+			Type:        levelType,
+			Label:       level.Label,
+			Small:       level.Small,
+			Big:         level.Big,
+			Ante:        level.Ante,
+			Time:        level.Time,
+			StructureId: structureID,
+			Index:       int8(i),
+		}
+	}
+
+	return blinds
 }
 
 type CreateStructureRequest struct {
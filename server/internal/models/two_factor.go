@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TwoFactorChallenge is the second step of logging in to a login with
+// two-factor authentication. It is created once the password is checked, and
+// is redeemed with a code for a session. Only a hash of its token is stored.
+type TwoFactorChallenge struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Username  string    `json:"username" gorm:"not null;index"`
+	Login     *Login    `json:"-" gorm:"foreignKey:Username;references:Username;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	TokenHash string    `json:"-" gorm:"not null;uniqueIndex"`
+	// Attempts is the number of incorrect codes submitted for the challenge.
+	Attempts  int       `json:"attempts" gorm:"not null;default:0"`
+	ExpiresAt time.Time `json:"expiresAt" gorm:"not null"`
+	CreatedAt time.Time `json:"createdAt" gorm:"not null;default:CURRENT_TIMESTAMP"`
+} //@name TwoFactorChallenge
+
+func (TwoFactorChallenge) TableName() string {
+	return "two_factor_challenges"
+}
+
+// TwoFactorChallengeResponse is returned instead of a session when a login
+// needs a two-factor code.
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool      `json:"twoFactorRequired" example:"true"`
+	ChallengeToken    string    `json:"challengeToken" example:"3f9a1c0d2b4e6f8a0c1e3b5d7f9a1c3e5b7d9f1a3c5e7b9d1f3a5c7e9b1d3f5a"`
+	ExpiresAt         time.Time `json:"expiresAt"`
+} //@name TwoFactorChallengeResponse
+
+// VerifyTwoFactorRequest completes a login with a code from an
+// authenticator app or a recovery code.
+type VerifyTwoFactorRequest struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+	Code           string `json:"code" binding:"required" example:"123456"`
+} //@name VerifyTwoFactorRequest
+
+// TwoFactorStatus describes the two-factor authentication of the current user.
+type TwoFactorStatus struct {
+	Enabled bool `json:"enabled"`
+	// Required is true when the role of the user must use two-factor
+	// authentication.
+	Required               bool `json:"required"`
+	RecoveryCodesRemaining int  `json:"recoveryCodesRemaining" example:"10"`
+} //@name TwoFactorStatus
+
+// TwoFactorSetupResponse contains the secret to add to an authenticator app.
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	OTPAuthURL string `json:"otpauthUrl" example:"otpauth://totp/UW%20Poker%20Studies%20Club:jdoe?secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+} //@name TwoFactorSetupResponse
+
+// TwoFactorCodeRequest contains a code from an authenticator app.
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+} //@name TwoFactorCodeRequest
+
+// DisableTwoFactorRequest confirms disabling two-factor authentication with
+// the current password.
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+} //@name DisableTwoFactorRequest
+
+// TwoFactorRecoveryCodesResponse contains recovery codes, which are only
+// returned when they are generated. Each code can be used once instead of a
+// code from an authenticator app.
+type TwoFactorRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes" example:"k3f9a-2bq7x"`
+} //@name TwoFactorRecoveryCodesResponse
@@ -25,17 +25,17 @@ type CreateUserRequest struct {
 	ID        uint64 `json:"id" binding:"required"`
 	FirstName string `json:"firstName" binding:"required"`
 	LastName  string `json:"lastName" binding:"required"`
-	Email     string `json:"email" binding:"required"`
+	Email     string `json:"email" binding:"required,email"`
 	Faculty   string `json:"faculty" binding:"oneof=AHS Arts Engineering Environment Math Science"`
-	QuestID   string `json:"questId"`
+	QuestID   string `json:"questId" binding:"omitempty,alphanum,max=20"`
 } //@name CreateMemberRequest
 
 type UpdateUserRequest struct {
 	FirstName string `json:"firstName"`
 	LastName  string `json:"lastName"`
-	Email     string `json:"email"`
+	Email     string `json:"email" binding:"omitempty,email"`
 	Faculty   string `json:"faculty" binding:"omitempty,oneof=AHS Arts Engineering Environment Math Science"`
-	QuestID   string `json:"questId"`
+	QuestID   string `json:"questId" binding:"omitempty,alphanum,max=20"`
 } //@name UpdateMemberRequest
 
 type ListUsersFilter struct {
@@ -3,12 +3,13 @@ package server
 import (
 	"api/internal/authentication"
 	"api/internal/authorization"
+	"api/internal/controller"
 	e "api/internal/errors"
+	"api/internal/middleware"
 	"api/internal/models"
 	"net/http"
 	"os"
 	"strings"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -32,6 +33,18 @@ func (s *apiServer) SessionLoginHandler(ctx *gin.Context) {
 		return
 	}
 
+	// Refuse logins with too many failures before checking the password
+	wait, err := s.loginLimiter.Check(req.Username, ctx.ClientIP())
+	if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	if wait > 0 {
+		controller.AbortLoginThrottled(ctx, wait)
+		return
+	}
+
 	// Valdiate that the credentials provided are valid credentials
 	credentialSvc := authentication.NewCredentialService(s.db)
 	valid, role, err := credentialSvc.Validate(req.Username, req.Password)
@@ -41,26 +54,50 @@ func (s *apiServer) SessionLoginHandler(ctx *gin.Context) {
 	}
 
 	if !valid {
+		if err := controller.RecordLoginFailure(ctx, s.db, s.loginLimiter, req.Username); err != nil {
+			ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+			return
+		}
+
 		ctx.AbortWithStatusJSON(http.StatusUnauthorized, e.Unauthorized("Invalid username/password provided"))
 		return
 	}
 
+	// Logins with two-factor authentication complete logging in with a code
+	// through the v2 API
+	twoFactorManager := authentication.NewTwoFactorManager(s.db)
+	challenge, err := twoFactorManager.CreateChallenge(req.Username)
+	if err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
+	if challenge != nil {
+		ctx.JSON(http.StatusAccepted, challenge)
+		return
+	}
+
+	if err := s.loginLimiter.RecordSuccess(req.Username); err != nil {
+		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
+		return
+	}
+
 	// Once credentials have been validated, create a new session in the database
 	sessionManager := authentication.NewSessionManager(s.db)
-	token, err := sessionManager.Create(req.Username, role)
+	token, err := sessionManager.Create(req.Username, role, ctx.Request.UserAgent(), ctx.ClientIP())
 	if err != nil {
 		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
 		return
 	}
 
 	// Set cookie in response
-	eightHoursInSeconds := int((time.Hour * 8).Seconds())
+	maxAgeInSeconds := int(sessionManager.Config().MaxLifetime.Seconds())
 	if strings.ToLower(os.Getenv("ENVIRONMENT")) == "production" {
 		ctx.SetSameSite(http.SameSiteStrictMode)
-		ctx.SetCookie("uwpsc-session-id", token.String(), eightHoursInSeconds, "/", "uwpokerclub.com", true, true)
+		ctx.SetCookie("uwpsc-session-id", token.String(), maxAgeInSeconds, "/", "uwpokerclub.com", true, true)
 	} else {
 		ctx.SetSameSite(http.SameSiteStrictMode)
-		ctx.SetCookie("uwpsc-dev-session-id", token.String(), eightHoursInSeconds, "/", "localhost", false, true)
+		ctx.SetCookie("uwpsc-dev-session-id", token.String(), maxAgeInSeconds, "/", "localhost", false, true)
 	}
 
 	// Returm an empty response with status code 201
@@ -103,8 +140,9 @@ func (s *apiServer) GetSessionHandler(ctx *gin.Context) {
 	svc := authorization.NewAuthorizationService(role, authorization.DefaultAuthorizerMap)
 
 	ctx.JSON(http.StatusOK, models.GetSessionResponse{
-		Username:    username,
-		Role:        svc.Role(),
-		Permissions: svc.GetPermissions(),
+		Username:               username,
+		Role:                   svc.Role(),
+		Permissions:            svc.GetPermissions(),
+		TwoFactorSetupRequired: middleware.TwoFactorSetupRequired(ctx),
 	})
 }
@@ -1,12 +1,12 @@
 package server
 
 import (
+	"api/internal/controller"
 	e "api/internal/errors"
 	"api/internal/models"
 	"api/internal/services"
+	"io"
 	"net/http"
-	"os"
-	"path/filepath"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -112,16 +112,8 @@ func (s *apiServer) ExportRankings(ctx *gin.Context) {
 		return
 	}
 
-	svc := services.NewSemesterService(s.db)
-
-	fp, err := svc.ExportRankings(id)
-	if err != nil {
-		ctx.AbortWithStatusJSON(err.(e.APIErrorResponse).Code, err)
-		return
-	}
-
-	filename := filepath.Base(fp)
-	defer os.Remove(filename)
-
-	ctx.FileAttachment(fp, filename)
+	svc := services.NewRankingService(s.db)
+	controller.StreamCSV(ctx, "rankings-"+id.String()+".csv", func(w io.Writer) error {
+		return svc.ExportRankings(w, id, models.RankingExportOptions{Format: models.RankingExportFormatCSV})
+	})
 }
@@ -1,9 +1,13 @@
 package server
 
 import (
+	"api/internal/authentication"
+	"api/internal/authorization"
 	"api/internal/controller"
 	"api/internal/middleware"
+	"api/internal/store/inmemory"
 	"api/internal/store/postgres"
+	"log"
 	"net/http"
 	"os"
 	"strings"
@@ -18,6 +22,11 @@ import (
 type apiServer struct {
 	Router *gin.Engine
 	db     *gorm.DB
+	// loginLimiter throttles failed logins. Its attempts are kept in process,
+	// so every instance of the API limits logins separately
+	loginLimiter *authentication.LoginLimiter
+	// kioskCheckInLimiter throttles failed check-ins of each kiosk token
+	kioskCheckInLimiter *authentication.LoginLimiter
 }
 
 func NewAPIServer(db *gorm.DB) *apiServer {
@@ -28,6 +37,14 @@ func NewAPIServer(db *gorm.DB) *apiServer {
 	// Initialize a gin router without any middleware
 	r := gin.New()
 
+	// Only take the client IP from X-Forwarded-For when the request came from
+	// a trusted proxy, so that clients cannot choose the IP that logins are
+	// throttled by
+	if err := r.SetTrustedProxies(trustedProxies()); err != nil {
+		log.Printf("Invalid TRUSTED_PROXIES, no proxy is trusted: %s", err.Error())
+		_ = r.SetTrustedProxies(nil)
+	}
+
 	// Use the default gin logger
 	r.Use(gin.Logger())
 
@@ -40,6 +57,9 @@ func NewAPIServer(db *gorm.DB) *apiServer {
 	// Limit request body size to 1MB
 	r.Use(middleware.MaxBodySize(1 << 20))
 
+	// Record mutating actions in the audit log
+	r.Use(middleware.UseAudit(db))
+
 	r.Static("/assets", "./public/assets")
 	r.StaticFile("/crest.svg", "./public/crest.svg")
 	r.StaticFile("/root.css", "./public/root.css")
@@ -48,7 +68,18 @@ func NewAPIServer(db *gorm.DB) *apiServer {
 		c.File("./public/index.html")
 	})
 
-	s := &apiServer{Router: r, db: db}
+	// Require two-factor authentication for the configured role and every
+	// role above it
+	if err := authorization.SetTwoFactorRequiredRole(os.Getenv("TWO_FACTOR_REQUIRED_ROLE")); err != nil {
+		log.Printf("Invalid TWO_FACTOR_REQUIRED_ROLE, two-factor authentication is required for every role: %s", err.Error())
+	}
+
+	s := &apiServer{
+		Router:              r,
+		db:                  db,
+		loginLimiter:        authentication.NewLoginLimiter(inmemory.NewLoginAttemptRepository(), authentication.LoadLoginLimiterConfig()),
+		kioskCheckInLimiter: authentication.NewLoginLimiter(inmemory.NewLoginAttemptRepository(), authentication.KioskCheckInLimiterConfig()),
+	}
 
 	// Initialize all routes
 	s.SetupRoutes()
@@ -149,15 +180,22 @@ func (s *apiServer) SetupV2Routes() {
 	// Load routes from controllers
 	controllers := []controller.Controller{
 		controller.NewHealthController(),
-		controller.NewAuthenticationController(s.db),
+		controller.NewAuthenticationController(s.db, s.loginLimiter),
 		controller.NewSemestersController(s.db, store),
 		controller.NewEventsController(s.db),
 		controller.NewEntriesController(s.db),
+		controller.NewClocksController(s.db),
+		controller.NewSeatingController(s.db),
+		controller.NewKioskController(s.db, s.kioskCheckInLimiter),
 		controller.NewMembersController(s.db, store),
 		controller.NewMembershipsController(s.db),
 		controller.NewRankingsController(s.db),
+		controller.NewPointsSchemesController(s.db),
+		controller.NewLedgerController(s.db),
 		controller.NewStructuresController(s.db, store),
-		controller.NewLoginsController(s.db),
+		controller.NewLoginsController(s.db, s.loginLimiter),
+		controller.NewAuditController(s.db),
+		controller.NewPermissionsController(s.db),
 	}
 
 	controllers = append(controllers, registerTestControllers(s.db)...)
@@ -166,3 +204,17 @@ func (s *apiServer) SetupV2Routes() {
 		controller.LoadRoutes(apiV2Route)
 	}
 }
+
+// trustedProxies returns the IP addresses and CIDR ranges of the proxies in
+// front of the server, from the comma separated TRUSTED_PROXIES. No proxy is
+// trusted when it is empty.
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+
+	return proxies
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type auditService struct {
+	db *gorm.DB
+}
+
+func NewAuditService(db *gorm.DB) *auditService {
+	return &auditService{
+		db: db,
+	}
+}
+
+// RecordAuditEvent saves an audit event.
+func (svc *auditService) RecordAuditEvent(event *models.AuditEvent) error {
+	if err := svc.db.Create(event).Error; err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+// ListAuditEvents lists audit events matching the filter, newest first.
+func (svc *auditService) ListAuditEvents(filter *models.ListAuditEventsFilter) ([]models.AuditEvent, int64, error) {
+	query := svc.db.Model(&models.AuditEvent{})
+
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		if strings.HasSuffix(filter.Action, ".") {
+			query = query.Where("action LIKE ?", sanitizeLikeInput(filter.Action)+"%")
+		} else {
+			query = query.Where("action = ?", filter.Action)
+		}
+	}
+	if filter.TargetID != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM jsonb_each_text(targets) AS t WHERE t.value = ?)", filter.TargetID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, e.InternalServerError(err.Error())
+	}
+
+	events := []models.AuditEvent{}
+	query = filter.Pagination.Apply(query.Order("created_at DESC, id DESC"))
+	if err := query.Find(&events).Error; err != nil {
+		return nil, 0, e.InternalServerError(err.Error())
+	}
+
+	return events, total, nil
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type clockService struct {
+	db *gorm.DB
+}
+
+func NewClockService(db *gorm.DB) *clockService {
+	return &clockService{
+		db: db,
+	}
+}
+
+// GetClock returns the live state of an event's clock.
+func (svc *clockService) GetClock(semesterID uuid.UUID, eventID int32) (*models.ClockResponse, error) {
+	event, err := getEventWithStructure(svc.db, semesterID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	clock := models.EventClock{}
+	res := svc.db.Where("event_id = ?", eventID).First(&clock)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, e.NotFound("The clock for this event has not been started")
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return buildClockResponse(clock, event.Structure.Blinds, time.Now().UTC()), nil
+}
+
+// StartClock starts an event's clock at the first level of its structure.
+func (svc *clockService) StartClock(semesterID uuid.UUID, eventID int32) (*models.ClockResponse, error) {
+	return svc.updateClock(semesterID, eventID, true, func(clock *models.EventClock, levels []models.Blind) error {
+		clock.State = models.ClockStateRunning
+		clock.LevelIndex = 0
+		clock.RemainingMs = levelDuration(levels[0]).Milliseconds()
+		return nil
+	})
+}
+
+// PauseClock pauses a running clock.
+func (svc *clockService) PauseClock(semesterID uuid.UUID, eventID int32) (*models.ClockResponse, error) {
+	return svc.updateClock(semesterID, eventID, false, func(clock *models.EventClock, levels []models.Blind) error {
+		if clock.State == models.ClockStatePaused {
+			return e.Forbidden("The clock is already paused")
+		}
+
+		clock.State = models.ClockStatePaused
+		return nil
+	})
+}
+
+// ResumeClock resumes a paused clock.
+func (svc *clockService) ResumeClock(semesterID uuid.UUID, eventID int32) (*models.ClockResponse, error) {
+	return svc.updateClock(semesterID, eventID, false, func(clock *models.EventClock, levels []models.Blind) error {
+		if clock.State == models.ClockStateRunning {
+			return e.Forbidden("The clock is already running")
+		}
+
+		clock.State = models.ClockStateRunning
+		return nil
+	})
+}
+
+// SkipLevel moves the clock forwards (or backwards for a negative count) by the
+// given number of levels. The new level starts with its full duration.
+func (svc *clockService) SkipLevel(semesterID uuid.UUID, eventID int32, levels int32) (*models.ClockResponse, error) {
+	return svc.updateClock(semesterID, eventID, false, func(clock *models.EventClock, structure []models.Blind) error {
+		index := min(max(clock.LevelIndex+levels, 0), int32(len(structure)-1))
+
+		clock.LevelIndex = index
+		clock.RemainingMs = levelDuration(structure[index]).Milliseconds()
+		return nil
+	})
+}
+
+// AdjustTime adds the given number of seconds to the time remaining in the
+// current level. Negative values remove time, down to zero.
+func (svc *clockService) AdjustTime(semesterID uuid.UUID, eventID int32, seconds int64) (*models.ClockResponse, error) {
+	return svc.updateClock(semesterID, eventID, false, func(clock *models.EventClock, levels []models.Blind) error {
+		clock.RemainingMs = max(clock.RemainingMs+seconds*1000, 0)
+		return nil
+	})
+}
+
+// updateClock locks and loads an event's clock, brings it up to date and
+// applies the update function before saving it. When create is set the clock
+// must not exist yet and is created instead.
+func (svc *clockService) updateClock(
+	semesterID uuid.UUID,
+	eventID int32,
+	create bool,
+	update func(clock *models.EventClock, levels []models.Blind) error,
+) (*models.ClockResponse, error) {
+	tx := svc.db.Begin()
+	if err := tx.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+	defer tx.Rollback()
+
+	event, err := getEventWithStructure(tx, semesterID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := event.Structure.Blinds
+	if len(levels) == 0 {
+		return nil, e.InvalidRequest("The event's structure has no levels")
+	}
+
+	now := time.Now().UTC()
+	clock := models.EventClock{}
+	res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("event_id = ?", eventID).First(&clock)
+	if err := res.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, e.InternalServerError(err.Error())
+	}
+	exists := res.Error == nil
+
+	if create {
+		if exists {
+			return nil, e.Forbidden("The clock for this event has already been started")
+		}
+		if event.State == models.EventStateEnded {
+			return nil, e.Forbidden("This event has ended, its clock cannot be started")
+		}
+		clock.EventID = eventID
+	} else {
+		if !exists {
+			return nil, e.NotFound("The clock for this event has not been started")
+		}
+		advanceClock(&clock, levels, now)
+	}
+
+	if err := update(&clock, levels); err != nil {
+		return nil, err
+	}
+	clock.SnapshotAt = now
+
+	if err := tx.Save(&clock).Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return buildClockResponse(clock, levels, now), nil
+}
+
+func getEventWithStructure(db *gorm.DB, semesterID uuid.UUID, eventID int32) (*models.Event, error) {
+	event := models.Event{}
+	res := event.Preload(db, models.EventPreloadOptions{Structure: true}).
+		Where("events.id = ? AND events.semester_id = ?", eventID, semesterID).
+		First(&event)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, e.NotFound("Event not found")
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	if event.Structure == nil {
+		event.Structure = &models.Structure{}
+	}
+
+	return &event, nil
+}
+
+// levelDuration returns how long a level of a structure lasts.
+func levelDuration(level models.Blind) time.Duration {
+	return time.Duration(level.Time) * time.Minute
+}
+
+// advanceClock rolls a clock forward to now, moving through as many levels as
+// have elapsed since its last snapshot. A running clock stops at the end of the
+// final level.
+func advanceClock(clock *models.EventClock, levels []models.Blind, now time.Time) {
+	// The structure may have been shortened since the clock was started
+	if last := int32(len(levels) - 1); clock.LevelIndex > last {
+		clock.LevelIndex = last
+		clock.RemainingMs = 0
+	}
+
+	if clock.State == models.ClockStateRunning {
+		remaining := clock.RemainingMs - now.Sub(clock.SnapshotAt).Milliseconds()
+		for remaining <= 0 && int(clock.LevelIndex) < len(levels)-1 {
+			clock.LevelIndex++
+			remaining += levelDuration(levels[clock.LevelIndex]).Milliseconds()
+		}
+		clock.RemainingMs = max(remaining, 0)
+	}
+
+	clock.SnapshotAt = now
+}
+
+func buildClockResponse(clock models.EventClock, levels []models.Blind, now time.Time) *models.ClockResponse {
+	advanceClock(&clock, levels, now)
+
+	toClockLevel := func(level models.Blind) models.ClockLevel {
+		return models.ClockLevel{
+			Type:     level.Type,
+			Label:    level.Label,
+			Small:    level.Small,
+			Big:      level.Big,
+			Ante:     level.Ante,
+			Duration: int64(levelDuration(level).Seconds()),
+		}
+	}
+
+	ret := models.ClockResponse{
+		EventID:       clock.EventID,
+		Level:         clock.LevelIndex + 1,
+		TotalLevels:   int32(len(levels)),
+		TimeRemaining: clock.RemainingMs,
+		ServerTime:    now,
+	}
+
+	if len(levels) == 0 {
+		return &ret
+	}
+
+	ret.CurrentLevel = toClockLevel(levels[clock.LevelIndex])
+	if int(clock.LevelIndex) < len(levels)-1 {
+		next := toClockLevel(levels[clock.LevelIndex+1])
+		ret.NextLevel = &next
+	}
+
+	ret.Finished = int(clock.LevelIndex) == len(levels)-1 && clock.RemainingMs == 0
+	ret.Running = clock.State == models.ClockStateRunning && !ret.Finished
+
+	return &ret
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"api/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildClockResponse(t *testing.T) {
+	levels := []models.Blind{
+		{Small: 25, Big: 50, Ante: 0, Time: 10},
+		{Small: 50, Big: 100, Ante: 0, Time: 10},
+		{Small: 100, Big: 200, Ante: 25, Time: 15},
+	}
+	snapshotAt := time.Date(2026, 10, 17, 19, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name              string
+		clock             models.EventClock
+		elapsed           time.Duration
+		wantLevel         int32
+		wantTimeRemaining int64
+		wantRunning       bool
+		wantFinished      bool
+		wantNextLevel     bool
+	}{
+		{
+			name: "running clock counts down",
+			clock: models.EventClock{
+				State:       models.ClockStateRunning,
+				RemainingMs: (10 * time.Minute).Milliseconds(),
+			},
+			elapsed:           4 * time.Minute,
+			wantLevel:         1,
+			wantTimeRemaining: (6 * time.Minute).Milliseconds(),
+			wantRunning:       true,
+			wantNextLevel:     true,
+		},
+		{
+			name: "paused clock does not count down",
+			clock: models.EventClock{
+				State:       models.ClockStatePaused,
+				RemainingMs: (10 * time.Minute).Milliseconds(),
+			},
+			elapsed:           4 * time.Minute,
+			wantLevel:         1,
+			wantTimeRemaining: (10 * time.Minute).Milliseconds(),
+			wantNextLevel:     true,
+		},
+		{
+			name: "running clock rolls over into later levels",
+			clock: models.EventClock{
+				State:       models.ClockStateRunning,
+				RemainingMs: (2 * time.Minute).Milliseconds(),
+			},
+			elapsed:           13 * time.Minute,
+			wantLevel:         3,
+			wantTimeRemaining: (14 * time.Minute).Milliseconds(),
+			wantRunning:       true,
+		},
+		{
+			name: "running clock stops at the end of the final level",
+			clock: models.EventClock{
+				State:       models.ClockStateRunning,
+				LevelIndex:  1,
+				RemainingMs: (5 * time.Minute).Milliseconds(),
+			},
+			elapsed:           time.Hour,
+			wantLevel:         3,
+			wantTimeRemaining: 0,
+			wantFinished:      true,
+		},
+		{
+			name: "level beyond a shortened structure is clamped",
+			clock: models.EventClock{
+				State:       models.ClockStatePaused,
+				LevelIndex:  7,
+				RemainingMs: (5 * time.Minute).Milliseconds(),
+			},
+			wantLevel:         3,
+			wantTimeRemaining: 0,
+			wantFinished:      true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			tC.clock.SnapshotAt = snapshotAt
+			got := buildClockResponse(tC.clock, levels, snapshotAt.Add(tC.elapsed))
+
+			assert.Equal(t, tC.wantLevel, got.Level)
+			assert.Equal(t, int32(len(levels)), got.TotalLevels)
+			assert.Equal(t, tC.wantTimeRemaining, got.TimeRemaining)
+			assert.Equal(t, tC.wantRunning, got.Running)
+			assert.Equal(t, tC.wantFinished, got.Finished)
+			assert.Equal(t, levels[tC.wantLevel-1].Big, got.CurrentLevel.Big)
+			assert.Equal(t, int64(levels[tC.wantLevel-1].Time)*60, got.CurrentLevel.Duration)
+			if tC.wantNextLevel {
+				assert.NotNil(t, got.NextLevel)
+				assert.Equal(t, levels[tC.wantLevel].Big, got.NextLevel.Big)
+			} else if tC.wantLevel == int32(len(levels)) {
+				assert.Nil(t, got.NextLevel)
+			}
+		})
+	}
+}
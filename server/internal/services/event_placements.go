@@ -0,0 +1,308 @@
+package services
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// resolvePlacements places the entries of an event, returning the placement of
+// each entry in the same order as entries. Entries with a placement override
+// take it, and entries overridden to the same placement are tied. The other
+// entries fill the remaining placements by the order they signed out in, the
+// last to sign out placing first, and entries which signed out at the same
+// time, such as players who were never signed out, are tied. Entries which
+// have not signed out are placed as if they signed out at notSignedOutAt.
+//
+// An error is returned if the overrides do not fit in the event, so that the
+// event is not ended with placements that cannot be paid out.
+func resolvePlacements(entries []models.Participant, notSignedOutAt time.Time) ([]uint16, error) {
+	eventSize := len(entries)
+	placements := make([]uint16, eventSize)
+
+	// Entries tied at a placement take it and the placements after it
+	tied := make(map[uint16]int)
+	for _, entry := range entries {
+		if entry.PlacementOverride != nil {
+			tied[*entry.PlacementOverride]++
+		}
+	}
+
+	overridden := make([]uint16, 0, len(tied))
+	for placement := range tied {
+		overridden = append(overridden, placement)
+	}
+	sort.Slice(overridden, func(i, j int) bool { return overridden[i] < overridden[j] })
+
+	taken := make([]bool, eventSize+1)
+	last := 0
+	for _, placement := range overridden {
+		if placement == 0 || int(placement) > eventSize {
+			return nil, e.InvalidRequest(fmt.Sprintf("Placement %d is outside the %d entries of the event", placement, eventSize))
+		}
+		if int(placement) <= last {
+			return nil, e.InvalidRequest(fmt.Sprintf("Placement %d is taken by a tie for a higher placement", placement))
+		}
+
+		last = int(placement) + tied[placement] - 1
+		if last > eventSize {
+			return nil, e.InvalidRequest(fmt.Sprintf(
+				"The %d entries tied at placement %d need placements past the %d entries of the event",
+				tied[placement], placement, eventSize,
+			))
+		}
+		for i := int(placement); i <= last; i++ {
+			taken[i] = true
+		}
+	}
+
+	// Place the other entries, the last to sign out first
+	remaining := make([]int, 0, eventSize)
+	for i, entry := range entries {
+		if entry.PlacementOverride != nil {
+			placements[i] = *entry.PlacementOverride
+		} else {
+			remaining = append(remaining, i)
+		}
+	}
+
+	signedOutAt := func(i int) time.Time {
+		if entries[i].SignedOutAt == nil {
+			return notSignedOutAt
+		}
+		return *entries[i].SignedOutAt
+	}
+	sort.SliceStable(remaining, func(a, b int) bool {
+		return signedOutAt(remaining[a]).After(signedOutAt(remaining[b]))
+	})
+
+	next := 1
+	for start := 0; start < len(remaining); {
+		end := start + 1
+		for end < len(remaining) && signedOutAt(remaining[end]).Equal(signedOutAt(remaining[start])) {
+			end++
+		}
+
+		for taken[next] {
+			next++
+		}
+
+		// A tie shares consecutive placements, so it cannot be split by an
+		// override
+		placement := next
+		for i := start; i < end; i++ {
+			if taken[next] {
+				return nil, e.InvalidRequest(fmt.Sprintf(
+					"The %d entries that signed out together are tied at placement %d, which is split by an override. Set their placements explicitly",
+					end-start, placement,
+				))
+			}
+			taken[next] = true
+			placements[remaining[i]] = uint16(placement)
+			next++
+		}
+
+		start = end
+	}
+
+	return placements, nil
+}
+
+// countTies counts the entries at each placement
+func countTies(placements []uint16) map[uint16]int {
+	ties := make(map[uint16]int, len(placements))
+	for _, placement := range placements {
+		ties[placement]++
+	}
+
+	return ties
+}
+
+// entryPoints calculates the points of the ranked entries of an event from
+// their placements, splitting the payouts of tied placements, and adds the
+// knockout points of the event for every player each entry eliminated
+func entryPoints(scheme *models.PointsScheme, event *models.Event, entries []models.Participant, placements []uint16) map[uuid.UUID]int {
+	ties := countTies(placements)
+	points := make(map[uuid.UUID]int, len(entries))
+	for i, entry := range entries {
+		if entry.MembershipID == nil || placements[i] == 0 {
+			continue
+		}
+
+		points[*entry.MembershipID] = CalculateTiedSchemePoints(
+			scheme, len(entries), int(placements[i]), ties[placements[i]], event.PointsMultiplier,
+		)
+	}
+
+	if event.KnockoutPoints > 0 {
+		eliminators := make(map[int32]*uuid.UUID, len(entries))
+		for _, entry := range entries {
+			eliminators[entry.ID] = entry.MembershipID
+		}
+
+		for _, entry := range entries {
+			if entry.KnockedOutByID == nil {
+				continue
+			}
+			if membershipID := eliminators[*entry.KnockedOutByID]; membershipID != nil {
+				points[*membershipID] += int(event.KnockoutPoints)
+			}
+		}
+	}
+
+	return points
+}
+
+// storedPlacements returns the placements saved when the event ended
+func storedPlacements(entries []models.Participant) []uint16 {
+	placements := make([]uint16, len(entries))
+	for i, entry := range entries {
+		placements[i] = entry.Placement
+	}
+
+	return placements
+}
+
+// SetPlacements replaces the placement overrides of an event and returns the
+// placements of its entries. The overrides are checked before they are saved.
+// When the event has already ended its placements are corrected, the rankings
+// are updated by the difference in points, and a ranking snapshot is recorded.
+func (es *eventService) SetPlacements(semesterID uuid.UUID, eventID int32, req *models.SetPlacementsRequest) ([]models.EventPlacement, error) {
+	var placed []models.EventPlacement
+	err := es.db.Transaction(func(tx *gorm.DB) error {
+		event, err := lockEventForSeating(tx, eventID)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && event.SemesterID != semesterID) {
+			return e.NotFound("Event not found")
+		} else if err != nil {
+			return err
+		}
+
+		entries := []models.Participant{}
+		res := tx.
+			Preload("Membership.User").
+			Where("event_id = ?", eventID).
+			Order("signed_out_at DESC, id ASC").
+			Find(&entries)
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		scheme, err := NewPointsSchemeService(tx).resolvePointsScheme(event.SemesterID)
+		if err != nil {
+			return err
+		}
+
+		ended := event.State == models.EventStateEnded
+		rankingUpdates := make(map[uuid.UUID]int, len(entries))
+		if ended {
+			for membershipID, points := range entryPoints(scheme, event, entries, storedPlacements(entries)) {
+				rankingUpdates[membershipID] -= points
+			}
+		}
+
+		byMembership := make(map[uuid.UUID]int, len(entries))
+		for i := range entries {
+			entries[i].PlacementOverride = nil
+			if entries[i].MembershipID != nil {
+				byMembership[*entries[i].MembershipID] = i
+			}
+		}
+
+		for _, override := range req.Placements {
+			i, ok := byMembership[override.MembershipID]
+			if !ok {
+				return e.NotFound(fmt.Sprintf("Membership %s has no entry in this event", override.MembershipID))
+			}
+			if entries[i].PlacementOverride != nil {
+				return e.InvalidRequest(fmt.Sprintf("Membership %s is placed more than once", override.MembershipID))
+			}
+
+			placement := override.Placement
+			entries[i].PlacementOverride = &placement
+		}
+
+		placements, err := resolvePlacements(entries, event.StartDate)
+		if err != nil {
+			return err
+		}
+
+		res = tx.Model(&models.Participant{}).
+			Where("event_id = ? AND placement_override IS NOT NULL", eventID).
+			Update("placement_override", nil)
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		// Placements are only saved once the event ends
+		for i, entry := range entries {
+			if !ended && entry.PlacementOverride == nil {
+				continue
+			}
+
+			updates := map[string]any{"placement_override": entry.PlacementOverride}
+			if ended {
+				updates["placement"] = placements[i]
+			}
+
+			if err := tx.Model(&models.Participant{}).Where("id = ?", entry.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		points := entryPoints(scheme, event, entries, placements)
+		if ended {
+			for membershipID, p := range points {
+				rankingUpdates[membershipID] += p
+			}
+
+			if err := NewRankingService(tx).BatchUpdateRankings(rankingUpdates); err != nil {
+				return err
+			}
+
+			err := recordRankingSnapshot(tx, event.SemesterID, event.ID, models.RankingSnapshotReasonPlacementsCorrected)
+			if err != nil {
+				return err
+			}
+		}
+
+		ties := countTies(placements)
+		placed = make([]models.EventPlacement, len(entries))
+		for i, entry := range entries {
+			placed[i] = models.EventPlacement{
+				EntryID:      entry.ID,
+				MembershipID: entry.MembershipID,
+				Placement:    placements[i],
+				Tied:         ties[placements[i]] > 1,
+				Overridden:   entry.PlacementOverride != nil,
+			}
+			if entry.MembershipID != nil {
+				placed[i].Points = points[*entry.MembershipID]
+			}
+			if entry.Membership != nil && entry.Membership.User != nil {
+				placed[i].FirstName = entry.Membership.User.FirstName
+				placed[i].LastName = entry.Membership.User.LastName
+			} else if entry.GuestName != nil {
+				placed[i].FirstName = *entry.GuestName
+			}
+		}
+
+		sort.SliceStable(placed, func(i, j int) bool { return placed[i].Placement < placed[j].Placement })
+
+		return nil
+	})
+
+	var apiErr e.APIErrorResponse
+	if errors.As(err, &apiErr) {
+		return nil, apiErr
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return placed, nil
+}
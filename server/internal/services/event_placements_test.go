@@ -0,0 +1,146 @@
+package services
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var placementsStart = time.Date(2025, 9, 1, 19, 0, 0, 0, time.UTC)
+
+// entry builds an entry that signed out the given number of minutes after
+// placementsStart, or that never signed out if minutes is negative, with an
+// optional placement override.
+func entry(minutes int, override uint16) models.Participant {
+	membershipID := uuid.New()
+	p := models.Participant{MembershipID: &membershipID}
+	if minutes >= 0 {
+		signedOutAt := placementsStart.Add(time.Duration(minutes) * time.Minute)
+		p.SignedOutAt = &signedOutAt
+	}
+	if override > 0 {
+		p.PlacementOverride = &override
+	}
+	return p
+}
+
+func TestResolvePlacements(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []models.Participant
+		want    []uint16
+	}{
+		{
+			name:    "last to sign out places first",
+			entries: []models.Participant{entry(10, 0), entry(30, 0), entry(20, 0)},
+			want:    []uint16{3, 1, 2},
+		},
+		{
+			name:    "entries that never signed out tie for last",
+			entries: []models.Participant{entry(-1, 0), entry(30, 0), entry(-1, 0)},
+			want:    []uint16{2, 1, 2},
+		},
+		{
+			name:    "simultaneous sign outs tie",
+			entries: []models.Participant{entry(20, 0), entry(20, 0), entry(40, 0), entry(10, 0)},
+			want:    []uint16{2, 2, 1, 4},
+		},
+		{
+			name:    "override takes precedence over sign out order",
+			entries: []models.Participant{entry(10, 1), entry(30, 0), entry(20, 0)},
+			want:    []uint16{1, 2, 3},
+		},
+		{
+			name:    "chop ties the final table and places the others after it",
+			entries: []models.Participant{entry(50, 1), entry(40, 1), entry(60, 1), entry(30, 0), entry(20, 0)},
+			want:    []uint16{1, 1, 1, 4, 5},
+		},
+		{
+			name:    "tie in the middle",
+			entries: []models.Participant{entry(50, 0), entry(40, 2), entry(30, 2), entry(20, 0)},
+			want:    []uint16{1, 2, 2, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolvePlacements(tt.entries, placementsStart)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	errorTests := []struct {
+		name    string
+		entries []models.Participant
+		message string
+	}{
+		{
+			name:    "placement past the event size",
+			entries: []models.Participant{entry(10, 3), entry(20, 0)},
+			message: "Placement 3 is outside the 2 entries of the event",
+		},
+		{
+			name:    "tie past the event size",
+			entries: []models.Participant{entry(10, 2), entry(20, 2)},
+			message: "The 2 entries tied at placement 2 need placements past the 2 entries of the event",
+		},
+		{
+			name:    "placement inside a tie",
+			entries: []models.Participant{entry(10, 1), entry(20, 1), entry(30, 2)},
+			message: "Placement 2 is taken by a tie for a higher placement",
+		},
+		{
+			name:    "sign out tie split by an override",
+			entries: []models.Participant{entry(10, 0), entry(10, 0), entry(30, 2)},
+			message: "The 2 entries that signed out together are tied at placement 1, which is split by an override. Set their placements explicitly",
+		},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolvePlacements(tt.entries, placementsStart)
+			var apiErr e.APIErrorResponse
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tt.message, apiErr.Message)
+		})
+	}
+}
+
+func TestEntryPointsSplitsTies(t *testing.T) {
+	scheme := DefaultPointsScheme(uuid.Nil)
+	event := models.Event{PointsMultiplier: 1.0}
+	entries := []models.Participant{entry(50, 1), entry(40, 1), entry(30, 0)}
+
+	placements, err := resolvePlacements(entries, placementsStart)
+	require.NoError(t, err)
+
+	points := entryPoints(&scheme, &event, entries, placements)
+	tied := CalculateTiedSchemePoints(&scheme, 3, 1, 2, 1.0)
+	assert.Equal(t, tied, points[*entries[0].MembershipID])
+	assert.Equal(t, tied, points[*entries[1].MembershipID])
+	assert.Equal(t, CalculateSchemePoints(&scheme, 3, 3, 1.0), points[*entries[2].MembershipID])
+}
+
+func TestEntryPointsAddsKnockoutPoints(t *testing.T) {
+	scheme := DefaultPointsScheme(uuid.Nil)
+	event := models.Event{PointsMultiplier: 1.0, KnockoutPoints: 5}
+	entries := []models.Participant{entry(50, 0), entry(40, 0), entry(30, 0)}
+	for i := range entries {
+		entries[i].ID = int32(i + 1)
+	}
+	// The winner knocked out both other players
+	entries[1].KnockedOutByID = &entries[0].ID
+	entries[2].KnockedOutByID = &entries[0].ID
+
+	placements, err := resolvePlacements(entries, placementsStart)
+	require.NoError(t, err)
+
+	points := entryPoints(&scheme, &event, entries, placements)
+	assert.Equal(t, CalculateSchemePoints(&scheme, 3, 1, 1.0)+10, points[*entries[0].MembershipID])
+	assert.Equal(t, CalculateSchemePoints(&scheme, 3, 2, 1.0), points[*entries[1].MembershipID])
+	assert.Equal(t, CalculateSchemePoints(&scheme, 3, 3, 1.0), points[*entries[2].MembershipID])
+}
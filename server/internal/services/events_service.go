@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -38,8 +39,24 @@ func (es *eventService) CreateEvent(req *models.CreateEventRequest) (*models.Eve
 		StructureID:      req.StructureID,
 		Rebuys:           0,
 		PointsMultiplier: req.PointsMultiplier,
+		KnockoutPoints:   req.KnockoutPoints,
+		TableSize:        req.TableSize,
+		MaxRebuys:        req.MaxRebuys,
+		MaxEntries:       req.MaxEntries,
 	}
 
+	if event.TableSize == 0 {
+		event.TableSize = models.DefaultTableSize
+	}
+
+	// Pin the current version of the structure, so that later edits to the
+	// structure do not change the event
+	structureVersionID, err := latestStructureVersionID(es.db, req.StructureID)
+	if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+	event.StructureVersionID = structureVersionID
+
 	res := es.db.Create(&event)
 	if err := res.Error; err != nil {
 		return nil, e.InternalServerError(err.Error())
@@ -142,10 +159,37 @@ func (svc *eventService) UpdateEvent(
 		event.PointsMultiplier = *req.PointsMultiplier
 	}
 
-	// Save the changes to the database
-	res = svc.db.Save(&event)
-	if res.Error != nil {
-		return nil, e.InternalServerError(res.Error.Error())
+	if req.KnockoutPoints != nil {
+		event.KnockoutPoints = *req.KnockoutPoints
+	}
+
+	if req.TableSize != nil {
+		event.TableSize = *req.TableSize
+	}
+
+	// Since null leaves the limits unchanged, they are removed with 0
+	if req.MaxRebuys != nil && *req.MaxRebuys == 0 {
+		event.MaxRebuys = nil
+	} else if req.MaxRebuys != nil {
+		event.MaxRebuys = req.MaxRebuys
+	}
+
+	if req.MaxEntries != nil && *req.MaxEntries == 0 {
+		event.MaxEntries = nil
+	} else if req.MaxEntries != nil {
+		event.MaxEntries = req.MaxEntries
+	}
+
+	// Save the changes to the database, giving any spots added to the waitlist
+	err := svc.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&event).Error; err != nil {
+			return err
+		}
+
+		return promoteWaitlist(tx, &event)
+	})
+	if err != nil {
+		return nil, e.InternalServerError(err.Error())
 	}
 
 	// Return the updated event
@@ -197,29 +241,37 @@ func (es *eventService) EndEvent(eventId int32) error {
 
 	// Retrieve list of entries for the event
 	entries := []models.Participant{}
-	res = tx.Where("event_id = ?", eventId).Order("signed_out_at DESC").Find(&entries)
+	res = tx.Where("event_id = ?", eventId).Order("signed_out_at DESC, id ASC").Find(&entries)
 	if err := res.Error; err != nil {
 		tx.Rollback()
 		return e.InternalServerError(err.Error())
 	}
 
+	// Resolve the points scheme for the event's semester
+	scheme, err := NewPointsSchemeService(tx).resolvePointsScheme(event.SemesterID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Place the entries, rejecting overrides which do not fit in the event
+	placements, err := resolvePlacements(entries, event.StartDate)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Calculate points and placements for each entry
 	eventSize := len(entries)
-	rankingUpdates := make(map[uuid.UUID]int, eventSize)
+	rankingUpdates := entryPoints(scheme, &event, entries, placements)
 	caseExprs := make([]string, 0, eventSize)
 	args := make([]interface{}, 0, eventSize*3)
 
 	argIdx := 1
 	for i, entry := range entries {
-		placement := i + 1
 		caseExprs = append(caseExprs, fmt.Sprintf("WHEN id = $%d THEN $%d::integer", argIdx, argIdx+1))
-		args = append(args, entry.ID, placement)
+		args = append(args, entry.ID, placements[i])
 		argIdx += 2
-
-		if entry.MembershipID != nil {
-			points := CalculatePoints(eventSize, placement, event.PointsMultiplier)
-			rankingUpdates[*entry.MembershipID] = points
-		}
 	}
 
 	// Batch update placements in a single SQL statement
@@ -247,6 +299,20 @@ func (es *eventService) EndEvent(eventId int32) error {
 		return err
 	}
 
+	if err := recordRankingSnapshot(tx, event.SemesterID, event.ID, models.RankingSnapshotReasonEventEnded); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Kiosks can no longer check members in once the event has ended
+	res = tx.Model(&models.KioskToken{}).
+		Where("event_id = ? AND revoked_at IS NULL", event.ID).
+		Update("revoked_at", time.Now().UTC())
+	if err := res.Error; err != nil {
+		tx.Rollback()
+		return e.InternalServerError(err.Error())
+	}
+
 	// Save all changes to the database
 	res = tx.Commit()
 	if err := res.Error; err != nil {
@@ -299,14 +365,17 @@ func (es *eventService) UndoEndEvent(eventId int32) error {
 		return e.InternalServerError(err.Error())
 	}
 
+	// Resolve the points scheme for the event's semester
+	scheme, err := NewPointsSchemeService(tx).resolvePointsScheme(event.SemesterID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Reverse rankings using stored placements from EndEvent
-	eventSize := len(entries)
-	rankingUpdates := make(map[uuid.UUID]int, eventSize)
-	for _, entry := range entries {
-		if entry.MembershipID != nil && entry.Placement > 0 {
-			points := CalculatePoints(eventSize, int(entry.Placement), event.PointsMultiplier)
-			rankingUpdates[*entry.MembershipID] = -points
-		}
+	rankingUpdates := entryPoints(scheme, &event, entries, storedPlacements(entries))
+	for membershipID, points := range rankingUpdates {
+		rankingUpdates[membershipID] = -points
 	}
 
 	// Batch reverse rankings in a single UPSERT
@@ -316,6 +385,11 @@ func (es *eventService) UndoEndEvent(eventId int32) error {
 		return err
 	}
 
+	if err := recordRankingSnapshot(tx, event.SemesterID, event.ID, models.RankingSnapshotReasonEventEndUndone); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Save all changes to the database
 	res = tx.Commit()
 	if err := res.Error; err != nil {
@@ -355,7 +429,13 @@ func (es *eventService) NewRebuy(eventId int32) error {
 		return err
 	}
 
-	err = semesterService.UpdateBudget(event.SemesterID, float32(semester.RebuyFee))
+	err = recordLedgerEntry(tx, &models.LedgerEntry{
+		SemesterID:  event.SemesterID,
+		Type:        models.LedgerEntryTypeRebuy,
+		AmountCents: int64(semester.RebuyFee) * 100,
+		Description: "Rebuy",
+		EventID:     &event.ID,
+	})
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -390,8 +470,24 @@ func (svc *eventService) CreateEventV2(
 		StructureID:      req.StructureID,
 		Rebuys:           0,
 		PointsMultiplier: req.PointsMultiplier,
+		KnockoutPoints:   req.KnockoutPoints,
+		TableSize:        req.TableSize,
+		MaxRebuys:        req.MaxRebuys,
+		MaxEntries:       req.MaxEntries,
 	}
 
+	if event.TableSize == 0 {
+		event.TableSize = models.DefaultTableSize
+	}
+
+	// Pin the current version of the structure, so that later edits to the
+	// structure do not change the event
+	structureVersionID, err := latestStructureVersionID(svc.db, req.StructureID)
+	if err != nil {
+		return nil, err
+	}
+	event.StructureVersionID = structureVersionID
+
 	res := svc.db.Create(&event)
 	if err := res.Error; err != nil {
 		return nil, err
@@ -451,10 +547,17 @@ func (svc *eventService) GetEventByID(semesterID uuid.UUID, eventID int32) (*mod
 }
 
 func (svc *eventService) UpdateEventV2(event *models.Event, updateValues map[string]any) error {
-	result := svc.db.Omit(clause.Associations).Model(event).Updates(updateValues)
-	if result.Error != nil {
-		return fmt.Errorf("failed to update event: %w", result.Error)
-	}
+	return svc.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit(clause.Associations).Model(event).Updates(updateValues)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update event: %w", result.Error)
+		}
 
-	return nil
+		// Spots added to the event go to the waitlist
+		if _, ok := updateValues["max_entries"]; ok && event.State != models.EventStateEnded {
+			return promoteWaitlist(tx, event)
+		}
+
+		return nil
+	})
 }
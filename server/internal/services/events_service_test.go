@@ -257,6 +257,79 @@ func TestEventsService(s *testing.T) {
 			assert.Equal(f, *updateReq.PointsMultiplier, updatedEvent.PointsMultiplier)
 		})
 
+		t.Run("Should remove the entry limit", func(f *testing.T) {
+			f.Cleanup(wipeDB)
+
+			seedRes, err := testhelpers.SetupSemester(db, "Winter 2025")
+			if !assert.NoError(f, err, "Seeding the semester should not fail") {
+				f.FailNow()
+			}
+
+			event, err := testhelpers.CreateEvent(db, "Event #9", seedRes.Semester.ID, time.Now())
+			if !assert.NoError(f, err, "Seeding the event should not fail") {
+				f.FailNow()
+			}
+
+			// The event is full, and every membership is on its waitlist
+			maxEntries := uint16(1)
+			assert.NoError(f, db.Model(event).Update("max_entries", maxEntries).Error)
+			_, err = testhelpers.CreateParticipant(db, seedRes.Memberships[0].ID, event.ID, 0, nil)
+			assert.NoError(f, err)
+			for _, membership := range seedRes.Memberships[1:] {
+				registration := models.Registration{EventID: event.ID, MembershipID: membership.ID, Status: models.RegistrationStatusWaitlisted}
+				assert.NoError(f, db.Create(&registration).Error)
+			}
+
+			svc := NewEventService(db)
+
+			noLimit := uint16(0)
+			updatedEvent, err := svc.UpdateEvent(event.ID, &models.UpdateEventRequest{MaxEntries: &noLimit})
+			if !assert.NoError(f, err, "UpdatingEvent should not error") {
+				f.FailNow()
+			}
+			assert.Nil(f, updatedEvent.MaxEntries)
+
+			var waitlisted int64
+			assert.NoError(f, db.Model(&models.Registration{}).
+				Where("event_id = ? AND status = ?", event.ID, models.RegistrationStatusWaitlisted).
+				Count(&waitlisted).Error)
+			assert.Zero(f, waitlisted)
+		})
+
+		t.Run("Should remove the rebuy limit", func(f *testing.T) {
+			f.Cleanup(wipeDB)
+
+			seedRes, err := testhelpers.SetupSemester(db, "Winter 2025")
+			if !assert.NoError(f, err, "Seeding the semester should not fail") {
+				f.FailNow()
+			}
+
+			event, err := testhelpers.CreateEvent(db, "Event #9", seedRes.Semester.ID, time.Now())
+			if !assert.NoError(f, err, "Seeding the event should not fail") {
+				f.FailNow()
+			}
+
+			svc := NewEventService(db)
+
+			maxRebuys := uint8(2)
+			updatedEvent, err := svc.UpdateEvent(event.ID, &models.UpdateEventRequest{MaxRebuys: &maxRebuys})
+			if !assert.NoError(f, err, "UpdatingEvent should not error") {
+				f.FailNow()
+			}
+			assert.Equal(f, &maxRebuys, updatedEvent.MaxRebuys)
+
+			noLimit := uint8(0)
+			updatedEvent, err = svc.UpdateEvent(event.ID, &models.UpdateEventRequest{MaxRebuys: &noLimit})
+			if !assert.NoError(f, err, "UpdatingEvent should not error") {
+				f.FailNow()
+			}
+			assert.Nil(f, updatedEvent.MaxRebuys)
+
+			var saved models.Event
+			assert.NoError(f, db.First(&saved, event.ID).Error)
+			assert.Nil(f, saved.MaxRebuys)
+		})
+
 		t.Run("Should fail when event has ended", func(f *testing.T) {
 			f.Cleanup(wipeDB)
 
@@ -371,6 +444,40 @@ func TestEventsService(s *testing.T) {
 			assert.NoError(t, res.Error, "Getting third entry from DB")
 			assert.WithinDuration(t, event.StartDate, *foundEntry.SignedOutAt, time.Second, "Signout time check")
 		})
+		t.Run("Entries still seated tie", func(t *testing.T) {
+			t.Cleanup(wipeDB)
+
+			set, err := testhelpers.SetupSemester(db, "Fall 2022")
+			assert.NoError(t, err, "Semester setup")
+
+			event, err := testhelpers.CreateEvent(db, "Event 1", set.Semester.ID, time.Now())
+			assert.NoError(t, err, "Event creation")
+
+			for i, membership := range set.Memberships {
+				_, err := testhelpers.CreateParticipant(db, membership.ID, event.ID, 0, nil)
+				assert.NoError(t, err, "Adding entry %d", i)
+			}
+
+			err = eventService.EndEvent(event.ID)
+			if !assert.NoError(t, err, "EventService.EndEvent()") {
+				t.FailNow()
+			}
+
+			// They all sign out at the start of the event, so they share first
+			// place and split its payouts
+			tied := CalculateTiedPoints(len(set.Memberships), 1, len(set.Memberships), event.PointsMultiplier)
+			for i, membership := range set.Memberships {
+				var entry models.Participant
+				res := db.Where("event_id = ? AND membership_id = ?", event.ID, membership.ID).First(&entry)
+				assert.NoError(t, res.Error, "Retrieve entry %d", i)
+				assert.EqualValues(t, 1, entry.Placement, "Entry %d placement", i)
+
+				var ranking models.Ranking
+				res = db.Where("membership_id = ?", membership.ID).First(&ranking)
+				assert.NoError(t, res.Error, "Retrieve ranking %d", i)
+				assert.EqualValues(t, tied, ranking.Points, "Ranking %d points", i)
+			}
+		})
 		t.Run("Placements and rankings are updated", func(t *testing.T) {
 			t.Cleanup(wipeDB)
 
@@ -442,6 +549,131 @@ func TestEventsService(s *testing.T) {
 			assert.EqualValues(t, 2, ranking2.Points, "Ranking 2 points")
 			assert.EqualValues(t, 2, ranking3.Points, "Ranking 3 points")
 		})
+		t.Run("Placement overrides tie entries and can be corrected", func(t *testing.T) {
+			t.Cleanup(wipeDB)
+
+			set, err := testhelpers.SetupSemester(db, "Fall 2022")
+			assert.NoError(t, err, "Semester setup")
+
+			event, err := testhelpers.CreateEvent(db, "Event 1", set.Semester.ID, time.Now().UTC())
+			assert.NoError(t, err, "Event creation")
+
+			// Sign out order places memberships 2, 1, 0
+			now := time.Now().UTC()
+			for i, membership := range set.Memberships {
+				signedOutAt := now.Add(time.Duration(i) * time.Minute)
+				_, err := testhelpers.CreateParticipant(db, membership.ID, event.ID, 0, &signedOutAt)
+				assert.NoError(t, err, "Adding entry %d", i)
+			}
+
+			// Chop first place between memberships 0 and 1
+			placed, err := eventService.SetPlacements(set.Semester.ID, event.ID, &models.SetPlacementsRequest{
+				Placements: []models.PlacementOverride{
+					{MembershipID: set.Memberships[0].ID, Placement: 1},
+					{MembershipID: set.Memberships[1].ID, Placement: 1},
+				},
+			})
+			if !assert.NoError(t, err, "EventService.SetPlacements()") {
+				t.FailNow()
+			}
+			assert.Len(t, placed, 3)
+			assert.EqualValues(t, 3, placed[2].Placement, "Entry without an override")
+			assert.False(t, placed[2].Tied)
+
+			// Overrides that do not fit are rejected
+			_, err = eventService.SetPlacements(set.Semester.ID, event.ID, &models.SetPlacementsRequest{
+				Placements: []models.PlacementOverride{{MembershipID: set.Memberships[0].ID, Placement: 4}},
+			})
+			assert.Error(t, err, "Placement past the event size")
+
+			err = eventService.EndEvent(event.ID)
+			if !assert.NoError(t, err, "EventService.EndEvent()") {
+				t.FailNow()
+			}
+
+			assertPlacements := func(want []uint16, points []int) {
+				t.Helper()
+				for i, membership := range set.Memberships {
+					var entry models.Participant
+					res := db.Where("event_id = ? AND membership_id = ?", event.ID, membership.ID).First(&entry)
+					assert.NoError(t, res.Error, "Retrieve entry %d", i)
+					assert.EqualValues(t, want[i], entry.Placement, "Entry %d placement", i)
+
+					var ranking models.Ranking
+					res = db.Where("membership_id = ?", membership.ID).First(&ranking)
+					assert.NoError(t, res.Error, "Retrieve ranking %d", i)
+					assert.EqualValues(t, points[i], ranking.Points, "Ranking %d points", i)
+				}
+			}
+
+			tied := CalculateTiedPoints(3, 1, 2, event.PointsMultiplier)
+			assertPlacements(
+				[]uint16{1, 1, 3},
+				[]int{tied, tied, CalculatePoints(3, 3, event.PointsMultiplier)},
+			)
+
+			// Clearing the overrides of an ended event places it by sign out order
+			_, err = eventService.SetPlacements(set.Semester.ID, event.ID, &models.SetPlacementsRequest{
+				Placements: []models.PlacementOverride{},
+			})
+			if !assert.NoError(t, err, "EventService.SetPlacements() after end") {
+				t.FailNow()
+			}
+			assertPlacements(
+				[]uint16{3, 2, 1},
+				[]int{
+					CalculatePoints(3, 3, event.PointsMultiplier),
+					CalculatePoints(3, 2, event.PointsMultiplier),
+					CalculatePoints(3, 1, event.PointsMultiplier),
+				},
+			)
+
+			err = eventService.UndoEndEvent(event.ID)
+			assert.NoError(t, err, "EventService.UndoEndEvent()")
+			assertPlacements([]uint16{3, 2, 1}, []int{0, 0, 0})
+		})
+
+		t.Run("Knockouts award bonus points", func(t *testing.T) {
+			t.Cleanup(wipeDB)
+
+			set, err := testhelpers.SetupSemester(db, "Fall 2022")
+			assert.NoError(t, err, "Semester setup")
+
+			event, err := testhelpers.CreateEvent(db, "Event 1", set.Semester.ID, time.Now().UTC())
+			assert.NoError(t, err, "Event creation")
+			res := db.Model(event).Update("knockout_points", 5)
+			assert.NoError(t, res.Error, "Set knockout points")
+
+			// Membership 2 wins and knocks out both other players
+			now := time.Now().UTC()
+			entries := make([]*models.Participant, len(set.Memberships))
+			for i, membership := range set.Memberships {
+				signedOutAt := now.Add(time.Duration(i) * time.Minute)
+				entries[i], err = testhelpers.CreateParticipant(db, membership.ID, event.ID, 0, &signedOutAt)
+				assert.NoError(t, err, "Adding entry %d", i)
+			}
+			res = db.Model(&models.Participant{}).
+				Where("id IN ?", []int32{entries[0].ID, entries[1].ID}).
+				Update("knocked_out_by_id", entries[2].ID)
+			assert.NoError(t, res.Error, "Record knockouts")
+
+			err = eventService.EndEvent(event.ID)
+			if !assert.NoError(t, err, "EventService.EndEvent()") {
+				t.FailNow()
+			}
+
+			var ranking models.Ranking
+			res = db.Where("membership_id = ?", set.Memberships[2].ID).First(&ranking)
+			assert.NoError(t, res.Error, "Retrieve winner ranking")
+			assert.EqualValues(t, CalculatePoints(3, 1, event.PointsMultiplier)+10, ranking.Points)
+
+			err = eventService.UndoEndEvent(event.ID)
+			assert.NoError(t, err, "EventService.UndoEndEvent()")
+
+			res = db.Where("membership_id = ?", set.Memberships[2].ID).First(&ranking)
+			assert.NoError(t, res.Error, "Retrieve winner ranking after undo")
+			assert.EqualValues(t, 0, ranking.Points)
+		})
 	})
 
 	s.Run("NewRebuy", func(t *testing.T) {
@@ -627,6 +859,67 @@ func TestEventsService(s *testing.T) {
 		}
 	})
 
+	s.Run("EndEvent uses the semester points scheme", func(t *testing.T) {
+		t.Cleanup(wipeDB)
+
+		set, err := testhelpers.SetupSemester(db, "Spring 2025")
+		if !assert.NoError(t, err, "Semester setup") {
+			t.FailNow()
+		}
+
+		_, err = NewPointsSchemeService(db).UpsertPointsScheme(set.Semester.ID, &models.UpsertPointsSchemeRequest{
+			SizeFactor:    1,
+			RoundingMode:  models.PointsRoundingFloor,
+			MinimumPoints: 0,
+			DefaultPayout: 0,
+			Payouts:       []int32{100, 10},
+		})
+		if !assert.NoError(t, err, "PointsSchemeService.UpsertPointsScheme()") {
+			t.FailNow()
+		}
+
+		event, err := testhelpers.CreateEvent(db, "Scheme Event", set.Semester.ID, time.Now().UTC())
+		if !assert.NoError(t, err, "Event creation") {
+			t.FailNow()
+		}
+
+		baseTime := time.Now().UTC()
+		for i, membership := range set.Memberships {
+			signOutTime := baseTime.Add(time.Duration(i) * time.Minute)
+			_, err := testhelpers.CreateParticipant(db, membership.ID, event.ID, 0, &signOutTime)
+			if !assert.NoError(t, err, "Create participant %d", i+1) {
+				t.FailNow()
+			}
+		}
+
+		err = eventService.EndEvent(event.ID)
+		if !assert.NoError(t, err, "EventService.EndEvent()") {
+			t.FailNow()
+		}
+
+		// Last sign-out wins, 3 players with a size factor of 1
+		eventSize := len(set.Memberships)
+		expected := []int32{0, 10 * int32(eventSize), 100 * int32(eventSize)}
+		for i, membership := range set.Memberships {
+			var ranking models.Ranking
+			res := db.Where("membership_id = ?", membership.ID).First(&ranking)
+			assert.NoError(t, res.Error, "Retrieve ranking for participant %d", i)
+			assert.EqualValues(t, expected[i], ranking.Points, "Ranking points for participant %d", i)
+		}
+
+		err = eventService.UndoEndEvent(event.ID)
+		if !assert.NoError(t, err, "EventService.UndoEndEvent()") {
+			t.FailNow()
+		}
+
+		for i, membership := range set.Memberships {
+			var ranking models.Ranking
+			res := db.Where("membership_id = ?", membership.ID).First(&ranking)
+			assert.NoError(t, res.Error, "Retrieve ranking after undo for participant %d", i)
+			assert.EqualValues(t, 0, ranking.Points, "Ranking points after undo for participant %d", i)
+		}
+	})
+
 	s.Run("UndoEndEvent with nil sign-out participants", func(t *testing.T) {
 		t.Cleanup(wipeDB)
 
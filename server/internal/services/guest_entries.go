@@ -0,0 +1,98 @@
+package services
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ConvertGuestParticipant gives a guest entry to the membership the player
+// took out afterwards. When the event has already ended, the membership is
+// back-filled with the points the entry earned and a ranking snapshot is
+// recorded.
+func (svc *participantsService) ConvertGuestParticipant(semesterID uuid.UUID, eventID int32, guestID int32, req *models.ConvertGuestEntryRequest) (*models.Participant, error) {
+	participant := models.Participant{}
+	err := svc.db.Transaction(func(tx *gorm.DB) error {
+		event, err := lockEventForSeating(tx, eventID)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && event.SemesterID != semesterID) {
+			return e.NotFound("Event not found")
+		} else if err != nil {
+			return err
+		}
+
+		res := tx.Where("id = ? AND event_id = ? AND membership_id IS NULL AND guest_name IS NOT NULL", guestID, eventID).First(&participant)
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			return e.NotFound("Guest entry not found")
+		} else if res.Error != nil {
+			return res.Error
+		}
+
+		membership := models.Membership{}
+		res = tx.Where("id = ?", req.MembershipID).First(&membership)
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			return e.NotFound(fmt.Sprintf("Membership %s not found", req.MembershipID))
+		} else if res.Error != nil {
+			return res.Error
+		}
+		if membership.SemesterID != event.SemesterID {
+			return e.InvalidRequest(fmt.Sprintf("Membership %s is not in the semester of the event", req.MembershipID))
+		}
+
+		var existing int64
+		res = tx.Model(&models.Participant{}).
+			Where("membership_id = ? AND event_id = ?", req.MembershipID, eventID).
+			Count(&existing)
+		if res.Error != nil {
+			return res.Error
+		}
+		if existing > 0 {
+			return e.InvalidRequest(fmt.Sprintf("Membership %s already has an entry in this event", req.MembershipID))
+		}
+
+		participant.MembershipID = &req.MembershipID
+		participant.GuestName = nil
+		res = tx.Model(&models.Participant{}).
+			Where("id = ?", participant.ID).
+			Updates(map[string]any{"membership_id": req.MembershipID, "guest_name": nil})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		// Points are only earned once the event ends
+		if event.State != models.EventStateEnded {
+			return nil
+		}
+
+		entries := []models.Participant{}
+		res = tx.Where("event_id = ?", eventID).Find(&entries)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		scheme, err := NewPointsSchemeService(tx).resolvePointsScheme(event.SemesterID)
+		if err != nil {
+			return err
+		}
+
+		points := entryPoints(scheme, event, entries, storedPlacements(entries))
+		err = NewRankingService(tx).BatchUpdateRankings(map[uuid.UUID]int{req.MembershipID: points[req.MembershipID]})
+		if err != nil {
+			return err
+		}
+
+		return recordRankingSnapshot(tx, event.SemesterID, event.ID, models.RankingSnapshotReasonGuestConverted)
+	})
+
+	var apiErr e.APIErrorResponse
+	if errors.As(err, &apiErr) {
+		return nil, apiErr
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &participant, nil
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrKioskMemberNotFound is returned when a check-in does not match a
+// membership for the semester of the event. It does not say whether the
+// student ID or QuestID belongs to anyone, so that kiosks cannot be used to
+// find out who is a member.
+var ErrKioskMemberNotFound = e.NotFound("No membership for this semester was found with that student ID or QuestID. Please see an executive")
+
+type kioskService struct {
+	db *gorm.DB
+}
+
+func NewKioskService(db *gorm.DB) *kioskService {
+	return &kioskService{
+		db: db,
+	}
+}
+
+// CheckIn enters a member into the event of a kiosk, identified by their
+// student ID or QuestID. The member must hold a membership for the semester
+// of the event. Members who were already entered are told where they sit, and
+// members checking in to a full event are told their place on the waitlist.
+func (svc *kioskService) CheckIn(event *models.Event, req *models.KioskCheckInRequest) (*models.KioskCheckInResponse, error) {
+	if (req.StudentID == 0) == (req.QuestID == "") {
+		return nil, e.InvalidRequest("Enter either a student ID or a QuestID")
+	}
+
+	user := models.User{}
+	query := svc.db.Where("id = ?", req.StudentID)
+	if req.QuestID != "" {
+		query = svc.db.Where("LOWER(quest_id) = LOWER(?)", req.QuestID)
+	}
+
+	res := query.First(&user)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrKioskMemberNotFound
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	membership := models.Membership{}
+	res = svc.db.Where("user_id = ? AND semester_id = ?", user.ID, event.SemesterID).First(&membership)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrKioskMemberNotFound
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	ret := models.KioskCheckInResponse{
+		FirstName: user.FirstName,
+	}
+
+	participant := models.Participant{}
+	res = svc.db.Where("membership_id = ? AND event_id = ?", membership.ID, event.ID).Limit(1).Find(&participant)
+	if err := res.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	if res.RowsAffected > 0 {
+		ret.AlreadyCheckedIn = true
+	} else {
+		entered, registration, err := NewRegistrationService(svc.db).EnterOrWaitlist(event.ID, membership.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Members checking in to a full event join its waitlist
+		if registration != nil {
+			ret.WaitlistPosition = &registration.Position
+			return &ret, nil
+		}
+		participant = *entered
+	}
+
+	ret.TableNumber = participant.TableNumber
+	ret.SeatNumber = participant.SeatNumber
+
+	return &ret, nil
+}
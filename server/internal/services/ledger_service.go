@@ -0,0 +1,154 @@
+package services
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ledgerService struct {
+	db *gorm.DB
+}
+
+func NewLedgerService(db *gorm.DB) *ledgerService {
+	return &ledgerService{
+		db: db,
+	}
+}
+
+// ListEntries returns a page of the ledger of a semester, newest first, along
+// with the balance of the whole ledger.
+func (ls *ledgerService) ListEntries(semesterID uuid.UUID, pagination *models.Pagination) (*models.LedgerResponse, error) {
+	semester, err := ls.findSemester(semesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerCents, err := ls.sumEntries(semesterID)
+	if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	var total int64
+	if err := ls.db.Model(&models.LedgerEntry{}).Where("semester_id = ?", semesterID).Count(&total).Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	entries := []models.LedgerEntry{}
+	query := ls.db.Where("semester_id = ?", semesterID).Order("created_at DESC, id DESC")
+	if err := pagination.Apply(query).Find(&entries).Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	startingBudgetCents := models.DollarsToCents(semester.StartingBudget)
+	return &models.LedgerResponse{
+		StartingBudgetCents: startingBudgetCents,
+		BalanceCents:        startingBudgetCents + ledgerCents,
+		Data:                entries,
+		Total:               total,
+	}, nil
+}
+
+// Reconcile compares the balance computed from the ledger with the legacy
+// current budget of a semester.
+func (ls *ledgerService) Reconcile(semesterID uuid.UUID) (*models.LedgerReconciliation, error) {
+	semester, err := ls.findSemester(semesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var totals []struct {
+		Type        string
+		AmountCents int64
+	}
+	res := ls.db.Model(&models.LedgerEntry{}).
+		Select("type, SUM(amount_cents) AS amount_cents").
+		Where("semester_id = ?", semesterID).
+		Group("type").
+		Scan(&totals)
+	if err := res.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	// Round the legacy column in the database, as it is stored with more
+	// precision than a float32 holds
+	var legacyBalanceCents int64
+	res = ls.db.Model(&models.Semester{}).
+		Select("ROUND(current_budget * 100)::bigint").
+		Where("id = ?", semesterID).
+		Scan(&legacyBalanceCents)
+	if err := res.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	reconciliation := models.LedgerReconciliation{
+		SemesterID:          semesterID,
+		StartingBudgetCents: models.DollarsToCents(semester.StartingBudget),
+		LegacyBalanceCents:  legacyBalanceCents,
+		Totals:              make(map[string]int64),
+	}
+
+	reconciliation.BalanceCents = reconciliation.StartingBudgetCents
+	for _, total := range totals {
+		reconciliation.Totals[total.Type] = total.AmountCents
+		reconciliation.BalanceCents += total.AmountCents
+	}
+
+	reconciliation.DriftCents = reconciliation.LegacyBalanceCents - reconciliation.BalanceCents
+	reconciliation.Drifted = reconciliation.DriftCents != 0
+
+	return &reconciliation, nil
+}
+
+func (ls *ledgerService) findSemester(semesterID uuid.UUID) (*models.Semester, error) {
+	semester := models.Semester{}
+	res := ls.db.Where("id = ?", semesterID).First(&semester)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, e.NotFound("Semester not found")
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &semester, nil
+}
+
+func (ls *ledgerService) sumEntries(semesterID uuid.UUID) (int64, error) {
+	var sum int64
+	res := ls.db.Model(&models.LedgerEntry{}).
+		Select("COALESCE(SUM(amount_cents), 0)").
+		Where("semester_id = ?", semesterID).
+		Scan(&sum)
+
+	return sum, res.Error
+}
+
+// recordLedgerEntry appends an entry to the ledger of its semester. Entries
+// with no amount are skipped. The legacy current budget of the semester is
+// adjusted by the same amount, so that clients reading it keep seeing the
+// balance.
+func recordLedgerEntry(tx *gorm.DB, entry *models.LedgerEntry) error {
+	if entry.AmountCents == 0 {
+		return nil
+	}
+
+	// Divide in the database, so that whole cents are added exactly
+	res := tx.Model(&models.Semester{}).
+		Where("id = ?", entry.SemesterID).
+		Update("current_budget", gorm.Expr("current_budget + ?::numeric / 100", entry.AmountCents))
+	if err := res.Error; err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	if res.RowsAffected == 0 {
+		return e.NotFound("semester not found")
+	}
+
+	if err := tx.Create(entry).Error; err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	return nil
+}
@@ -159,6 +159,7 @@ func (svc *loginService) DeleteLogin(username string) error {
 }
 
 // UpdateLogin updates a login's password and/or role. At least one field must be provided.
+// Every session of the login is ended, as sessions keep the role they were created with.
 // Returns ErrUpdateLoginNoFields when both inputs are nil and ErrLoginNotFound when the
 // username does not exist.
 func (svc *loginService) UpdateLogin(username string, password *string, role *string) error {
@@ -180,15 +181,40 @@ func (svc *loginService) UpdateLogin(username string, password *string, role *st
 		updates["role"] = *role
 	}
 
-	res := svc.db.Model(&models.Login{}).Where("username = ?", username).Updates(updates)
+	return svc.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.Login{}).Where("username = ?", username).Updates(updates)
+		if err := res.Error; err != nil {
+			return fmt.Errorf("update login: %w", err)
+		}
+
+		if res.RowsAffected == 0 {
+			return ErrLoginNotFound
+		}
+
+		if err := tx.Where("username = ?", username).Delete(&models.Session{}).Error; err != nil {
+			return fmt.Errorf("delete sessions: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// LogoutLogin ends every session of a login. Returns ErrLoginNotFound when the username
+// does not exist.
+func (svc *loginService) LogoutLogin(username string) error {
+	res := svc.db.Where("username = ?", username).Limit(1).Find(&models.Login{})
 	if err := res.Error; err != nil {
-		return fmt.Errorf("update login: %w", err)
+		return fmt.Errorf("find login: %w", err)
 	}
 
 	if res.RowsAffected == 0 {
 		return ErrLoginNotFound
 	}
 
+	if err := svc.db.Where("username = ?", username).Delete(&models.Session{}).Error; err != nil {
+		return fmt.Errorf("delete sessions: %w", err)
+	}
+
 	return nil
 }
 
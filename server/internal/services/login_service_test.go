@@ -6,6 +6,7 @@ import (
 	"api/internal/models"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/crypto/bcrypt"
@@ -78,6 +79,14 @@ func TestLoginService(t *testing.T) {
 			name: "CreateLoginFromRequest",
 			test: CreateLoginFromRequestTest,
 		},
+		{
+			name: "UpdateLogin_EndsSessions",
+			test: UpdateLoginEndsSessionsTest,
+		},
+		{
+			name: "LogoutLogin",
+			test: LogoutLoginTest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -490,3 +499,60 @@ func CreateLoginFromRequestTest(t *testing.T) {
 	err = bcrypt.CompareHashAndPassword([]byte(rawLogin.Password), []byte("password123"))
 	assert.NoError(t, err)
 }
+
+func UpdateLoginEndsSessionsTest(t *testing.T) {
+	db, err := database.OpenTestConnection()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer database.WipeDB(db)
+
+	svc := NewLoginService(db)
+
+	err = svc.CreateLogin("alice", "originalpassword", "executive")
+	assert.NoError(t, err)
+	err = db.Create(&models.Session{Username: "alice", Role: "executive", ExpiresAt: time.Now().Add(time.Hour)}).Error
+	assert.NoError(t, err)
+
+	err = svc.UpdateLogin("alice", nil, ptr("president"))
+	assert.NoError(t, err)
+
+	var count int64
+	err = db.Model(&models.Session{}).Where("username = ?", "alice").Count(&count).Error
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func LogoutLoginTest(t *testing.T) {
+	db, err := database.OpenTestConnection()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer database.WipeDB(db)
+
+	svc := NewLoginService(db)
+
+	err = svc.CreateLogin("alice", "originalpassword", "executive")
+	assert.NoError(t, err)
+	err = svc.CreateLogin("bob", "originalpassword", "executive")
+	assert.NoError(t, err)
+	for _, username := range []string{"alice", "alice", "bob"} {
+		err = db.Create(&models.Session{Username: username, Role: "executive", ExpiresAt: time.Now().Add(time.Hour)}).Error
+		assert.NoError(t, err)
+	}
+
+	err = svc.LogoutLogin("alice")
+	assert.NoError(t, err)
+
+	var count int64
+	err = db.Model(&models.Session{}).Where("username = ?", "alice").Count(&count).Error
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	err = db.Model(&models.Session{}).Where("username = ?", "bob").Count(&count).Error
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	err = svc.LogoutLogin("nobody")
+	assert.ErrorIs(t, err, ErrLoginNotFound)
+}
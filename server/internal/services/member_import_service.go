@@ -0,0 +1,465 @@
+package services
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// memberImportMaxRows is the number of members a single import can have.
+const memberImportMaxRows = 5000
+
+// memberCSVColumns maps the accepted CSV header names, after lowercasing and
+// removing spaces, underscores and dashes, to the member field they hold.
+var memberCSVColumns = map[string]string{
+	"id":         "id",
+	"studentid":  "id",
+	"studentno":  "id",
+	"firstname":  "firstName",
+	"lastname":   "lastName",
+	"email":      "email",
+	"faculty":    "faculty",
+	"questid":    "questId",
+	"paid":       "paid",
+	"discounted": "discounted",
+}
+
+var memberFaculties = []string{
+	models.FacultyAHS,
+	models.FacultyArts,
+	models.FacultyEngineering,
+	models.FacultyEnvironment,
+	models.FacultyMath,
+	models.FacultyScience,
+}
+
+// ParseMemberCSV reads members from CSV. The first row must be a header naming
+// the columns; unknown columns are ignored. Rows are validated with the same
+// rules as a CreateUserRequest, and every invalid row is reported as row
+// errors.
+func ParseMemberCSV(r io.Reader) ([]models.MemberImportRow, []models.MemberImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, e.InvalidRequest("The CSV file is empty")
+	} else if err != nil {
+		return nil, nil, e.InvalidRequest(fmt.Sprintf("Invalid CSV: %s", err.Error()))
+	}
+
+	// Spreadsheet applications may start the file with a byte order mark
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff")
+		name = strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "").Replace(name))
+		if field, ok := memberCSVColumns[name]; ok {
+			if _, duplicate := columns[field]; duplicate {
+				return nil, nil, e.InvalidRequest(fmt.Sprintf("The CSV header has more than one '%s' column", field))
+			}
+			columns[field] = i
+		}
+	}
+	for _, field := range []string{"id", "firstName", "lastName", "email", "faculty"} {
+		if _, ok := columns[field]; !ok {
+			return nil, nil, e.InvalidRequest(fmt.Sprintf("The CSV header is missing the '%s' column", field))
+		}
+	}
+
+	members := []models.MemberImportRow{}
+	rowErrors := []models.MemberImportRowError{}
+	rowsByID := make(map[uint64]int)
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, nil, e.InvalidRequest(fmt.Sprintf("Invalid CSV: %s", err.Error()))
+		}
+
+		cell := func(field string) string {
+			i, ok := columns[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		// Skip blank lines left at the end of spreadsheets
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		if len(members) == memberImportMaxRows {
+			return nil, nil, e.InvalidRequest(fmt.Sprintf("An import can have at most %d members", memberImportMaxRows))
+		}
+
+		member := models.MemberImportRow{
+			Row: row,
+			Member: models.CreateUserRequest{
+				FirstName: cell("firstName"),
+				LastName:  cell("lastName"),
+				Email:     cell("email"),
+				Faculty:   normalizeFaculty(cell("faculty")),
+				QuestID:   strings.ToLower(cell("questId")),
+			},
+		}
+
+		valid := true
+		if id := cell("id"); id != "" {
+			n, err := strconv.ParseUint(id, 10, 64)
+			if err != nil || n == 0 {
+				rowErrors = append(rowErrors, models.MemberImportRowError{
+					Row:     row,
+					Field:   "id",
+					Message: "id must be a positive whole number",
+				})
+				valid = false
+			} else if other, duplicate := rowsByID[n]; duplicate {
+				rowErrors = append(rowErrors, models.MemberImportRowError{
+					Row:     row,
+					Field:   "id",
+					Message: fmt.Sprintf("id %d is also on row %d", n, other),
+				})
+				valid = false
+			} else {
+				member.Member.ID = n
+				rowsByID[n] = row
+			}
+		}
+
+		for _, flag := range []struct {
+			field string
+			dest  **bool
+		}{
+			{field: "paid", dest: &member.Paid},
+			{field: "discounted", dest: &member.Discounted},
+		} {
+			value := cell(flag.field)
+			if value == "" {
+				continue
+			}
+
+			b, ok := parseImportBool(value)
+			if !ok {
+				rowErrors = append(rowErrors, models.MemberImportRowError{
+					Row:     row,
+					Field:   flag.field,
+					Message: fmt.Sprintf("%s must be yes or no", flag.field),
+				})
+				valid = false
+				continue
+			}
+			*flag.dest = &b
+		}
+
+		members = append(members, member)
+		if valid {
+			rowErrors = append(rowErrors, validateMemberImportRow(row, member.Member)...)
+		}
+	}
+
+	if len(members) == 0 {
+		return nil, nil, e.InvalidRequest("The CSV file has no members")
+	}
+
+	return members, rowErrors, nil
+}
+
+// normalizeFaculty matches a faculty regardless of case, so that "math" is
+// imported as "Math". Unknown faculties are returned unchanged to be reported
+// by validation.
+func normalizeFaculty(faculty string) string {
+	for _, known := range memberFaculties {
+		if strings.EqualFold(faculty, known) {
+			return known
+		}
+	}
+
+	return faculty
+}
+
+// parseImportBool parses the ways spreadsheets commonly write booleans.
+func parseImportBool(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "true", "yes", "y", "1", "x":
+		return true, true
+	case "false", "no", "n", "0":
+		return false, true
+	}
+
+	return false, false
+}
+
+// validateMemberImportRow validates an imported member with the same rules as
+// a CreateUserRequest.
+func validateMemberImportRow(row int, member models.CreateUserRequest) []models.MemberImportRowError {
+	err := binding.Validator.ValidateStruct(member)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return []models.MemberImportRowError{{Row: row, Message: err.Error()}}
+	}
+
+	fields := map[string]string{
+		"ID":        "id",
+		"FirstName": "firstName",
+		"LastName":  "lastName",
+		"Email":     "email",
+		"Faculty":   "faculty",
+		"QuestID":   "questId",
+	}
+
+	rowErrors := make([]models.MemberImportRowError, len(validationErrors))
+	for i, fieldErr := range validationErrors {
+		field := fields[fieldErr.Field()]
+
+		var message string
+		switch fieldErr.Tag() {
+		case "required":
+			message = fmt.Sprintf("%s is required", field)
+		case "email":
+			message = fmt.Sprintf("%s must be a valid email address", field)
+		case "oneof":
+			message = fmt.Sprintf("%s must be one of %s", field, strings.ReplaceAll(fieldErr.Param(), " ", ", "))
+		case "alphanum":
+			message = fmt.Sprintf("%s must only contain letters and numbers", field)
+		case "max":
+			message = fmt.Sprintf("%s must be at most %s characters", field, fieldErr.Param())
+		default:
+			message = fmt.Sprintf("%s is invalid", field)
+		}
+
+		rowErrors[i] = models.MemberImportRowError{
+			Row:     row,
+			Field:   field,
+			Message: message,
+		}
+	}
+
+	return rowErrors
+}
+
+// membershipImportFlags returns whether the membership of a row is paid and
+// discounted, using the flags of the import unless the row has its own.
+func membershipImportFlags(row models.MemberImportRow, opts models.MemberImportOptions) (bool, bool) {
+	paid, discounted := opts.Paid, opts.Discounted
+	if row.Paid != nil {
+		paid = *row.Paid
+	}
+	if row.Discounted != nil {
+		discounted = *row.Discounted
+	}
+
+	return paid, discounted
+}
+
+// ValidateMembershipImport reports the rows whose memberships would be
+// discounted without being paid. Rows are not checked when the import has no
+// semester, since no memberships are created.
+func ValidateMembershipImport(rows []models.MemberImportRow, opts models.MemberImportOptions) []models.MemberImportRowError {
+	rowErrors := []models.MemberImportRowError{}
+	if opts.SemesterID == nil {
+		return rowErrors
+	}
+
+	for _, row := range rows {
+		if paid, discounted := membershipImportFlags(row, opts); discounted && !paid {
+			rowErrors = append(rowErrors, models.MemberImportRowError{
+				Row:     row.Row,
+				Field:   "discounted",
+				Message: "a membership cannot be discounted without being paid",
+			})
+		}
+	}
+
+	return rowErrors
+}
+
+type memberImportService struct {
+	db *gorm.DB
+}
+
+func NewMemberImportService(db *gorm.DB) *memberImportService {
+	return &memberImportService{db: db}
+}
+
+// Import creates members that do not exist yet and updates the others by
+// their student ID. When the options have a semester, members without a
+// membership in it are given one, recording the fee of paid memberships in the
+// semester's ledger. Every row is written in a single transaction, which is
+// rolled back for dry runs.
+func (svc *memberImportService) Import(rows []models.MemberImportRow, opts models.MemberImportOptions) (*models.MemberImportResponse, error) {
+	result := models.MemberImportResponse{
+		DryRun:     opts.DryRun,
+		SemesterID: opts.SemesterID,
+		Rows:       make([]models.MemberImportRowResult, 0, len(rows)),
+	}
+
+	tx := svc.db.Begin()
+	if err := tx.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+	defer tx.Rollback()
+
+	var semester *models.Semester
+	if opts.SemesterID != nil {
+		semester = &models.Semester{}
+		res := tx.Where("id = ?", *opts.SemesterID).First(semester)
+		if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.NotFound("Semester not found")
+		} else if err != nil {
+			return nil, e.InternalServerError(err.Error())
+		}
+	}
+
+	for _, row := range rows {
+		rowResult := models.MemberImportRowResult{
+			Row: row.Row,
+			ID:  row.Member.ID,
+		}
+
+		status, err := upsertImportedMember(tx, row.Member)
+		if err != nil {
+			return nil, err
+		}
+		rowResult.Member = status
+
+		switch status {
+		case models.MemberImportStatusCreated:
+			result.Created++
+		case models.MemberImportStatusUpdated:
+			result.Updated++
+		default:
+			result.Unchanged++
+		}
+
+		if semester != nil {
+			paid, discounted := membershipImportFlags(row, opts)
+			if discounted && !paid {
+				return nil, e.InvalidRequest(fmt.Sprintf("Row %d: a membership cannot be discounted without being paid", row.Row))
+			}
+
+			status, err := createImportedMembership(tx, semester, row.Member.ID, paid, discounted)
+			if err != nil {
+				return nil, err
+			}
+			rowResult.Membership = status
+
+			if status == models.MembershipImportStatusCreated {
+				result.MembershipsCreated++
+			}
+		}
+
+		result.Rows = append(result.Rows, rowResult)
+	}
+
+	if opts.DryRun {
+		return &result, nil
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &result, nil
+}
+
+// upsertImportedMember creates a member, or updates the member with the same
+// student ID. An empty quest ID does not clear the quest ID of a member.
+func upsertImportedMember(tx *gorm.DB, req models.CreateUserRequest) (string, error) {
+	member := models.User{}
+	res := tx.Where("id = ?", req.ID).Limit(1).Find(&member)
+	if err := res.Error; err != nil {
+		return "", e.InternalServerError(err.Error())
+	}
+
+	if res.RowsAffected == 0 {
+		member = models.User{
+			ID:        req.ID,
+			FirstName: req.FirstName,
+			LastName:  req.LastName,
+			Email:     req.Email,
+			Faculty:   req.Faculty,
+			QuestID:   req.QuestID,
+		}
+		if err := tx.Create(&member).Error; err != nil {
+			return "", e.InternalServerError(err.Error())
+		}
+
+		return models.MemberImportStatusCreated, nil
+	}
+
+	updated := member
+	updated.FirstName = req.FirstName
+	updated.LastName = req.LastName
+	updated.Email = req.Email
+	updated.Faculty = req.Faculty
+	if req.QuestID != "" {
+		updated.QuestID = req.QuestID
+	}
+
+	if updated == member {
+		return models.MemberImportStatusUnchanged, nil
+	}
+
+	res = tx.Model(&updated).Select("first_name", "last_name", "email", "faculty", "quest_id").Updates(&updated)
+	if err := res.Error; err != nil {
+		return "", e.InternalServerError(err.Error())
+	}
+
+	return models.MemberImportStatusUpdated, nil
+}
+
+// createImportedMembership gives a member a membership in a semester, unless
+// they already have one. Existing memberships are left as they are.
+func createImportedMembership(tx *gorm.DB, semester *models.Semester, userID uint64, paid bool, discounted bool) (string, error) {
+	var count int64
+	res := tx.Model(&models.Membership{}).Where("user_id = ? AND semester_id = ?", userID, semester.ID).Count(&count)
+	if err := res.Error; err != nil {
+		return "", e.InternalServerError(err.Error())
+	}
+
+	if count > 0 {
+		return models.MembershipImportStatusExists, nil
+	}
+
+	membership := models.Membership{
+		UserID:     userID,
+		SemesterID: semester.ID,
+		Paid:       paid,
+		Discounted: discounted,
+	}
+	if err := tx.Create(&membership).Error; err != nil {
+		return "", e.InternalServerError(err.Error())
+	}
+
+	if paid {
+		if discounted {
+			err := recordMembershipFee(tx, &membership, int64(semester.MembershipDiscountFee)*100, "Discounted membership fee")
+			if err != nil {
+				return "", err
+			}
+		} else {
+			err := recordMembershipFee(tx, &membership, int64(semester.MembershipFee)*100, "Membership fee")
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return models.MembershipImportStatusCreated, nil
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"api/internal/database"
+	"api/internal/models"
+	"api/internal/testhelpers"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMemberCSV(t *testing.T) {
+	yes := true
+	no := false
+
+	testCases := []struct {
+		name              string
+		csv               string
+		expectedRows      []models.MemberImportRow
+		expectedRowErrors []models.MemberImportRowError
+		expectedError     string
+	}{
+		{
+			name: "sign up sheet",
+			csv: "\ufeffStudent ID,First Name,Last Name,Email,Faculty,Quest ID,Paid,Timestamp\n" +
+				"20801234, Jane ,Doe,jdoe@uwaterloo.ca,math,JDOE,yes,2026-09-10\n" +
+				"20805678,John,Smith,jsmith@uwaterloo.ca,Engineering,,,2026-09-10\n" +
+				",,,,,,,\n",
+			expectedRows: []models.MemberImportRow{
+				{
+					Row: 1,
+					Member: models.CreateUserRequest{
+						ID: 20801234, FirstName: "Jane", LastName: "Doe", Email: "jdoe@uwaterloo.ca",
+						Faculty: models.FacultyMath, QuestID: "jdoe",
+					},
+					Paid: &yes,
+				},
+				{
+					Row: 2,
+					Member: models.CreateUserRequest{
+						ID: 20805678, FirstName: "John", LastName: "Smith", Email: "jsmith@uwaterloo.ca",
+						Faculty: models.FacultyEngineering,
+					},
+				},
+			},
+			expectedRowErrors: []models.MemberImportRowError{},
+		},
+		{
+			name: "reports every invalid row",
+			csv: "id,first_name,last_name,email,faculty,quest_id,discounted\n" +
+				"1,Jane,Doe,jdoe@uwaterloo.ca,Math,jdoe,no\n" +
+				"abc,John,Smith,jsmith@uwaterloo.ca,Math,,\n" +
+				"1,Jane,Doe,jdoe@uwaterloo.ca,Math,jdoe,\n" +
+				"2,,Smith,not-an-email,Law,j.smith,\n" +
+				"3,Bob,Lee,blee@uwaterloo.ca,Arts,,maybe\n",
+			expectedRows: []models.MemberImportRow{
+				{
+					Row:        1,
+					Member:     models.CreateUserRequest{ID: 1, FirstName: "Jane", LastName: "Doe", Email: "jdoe@uwaterloo.ca", Faculty: "Math", QuestID: "jdoe"},
+					Discounted: &no,
+				},
+				{Row: 2, Member: models.CreateUserRequest{FirstName: "John", LastName: "Smith", Email: "jsmith@uwaterloo.ca", Faculty: "Math"}},
+				{Row: 3, Member: models.CreateUserRequest{FirstName: "Jane", LastName: "Doe", Email: "jdoe@uwaterloo.ca", Faculty: "Math", QuestID: "jdoe"}},
+				{Row: 4, Member: models.CreateUserRequest{ID: 2, LastName: "Smith", Email: "not-an-email", Faculty: "Law", QuestID: "j.smith"}},
+				{Row: 5, Member: models.CreateUserRequest{ID: 3, FirstName: "Bob", LastName: "Lee", Email: "blee@uwaterloo.ca", Faculty: "Arts"}},
+			},
+			expectedRowErrors: []models.MemberImportRowError{
+				{Row: 2, Field: "id", Message: "id must be a positive whole number"},
+				{Row: 3, Field: "id", Message: "id 1 is also on row 1"},
+				{Row: 4, Field: "firstName", Message: "firstName is required"},
+				{Row: 4, Field: "email", Message: "email must be a valid email address"},
+				{Row: 4, Field: "faculty", Message: "faculty must be one of AHS, Arts, Engineering, Environment, Math, Science"},
+				{Row: 4, Field: "questId", Message: "questId must only contain letters and numbers"},
+				{Row: 5, Field: "discounted", Message: "discounted must be yes or no"},
+			},
+		},
+		{
+			name:          "missing required column",
+			csv:           "id,first name,last name,faculty\n1,Jane,Doe,Math\n",
+			expectedError: "The CSV header is missing the 'email' column",
+		},
+		{
+			name:          "duplicate column",
+			csv:           "id,student id,first name,last name,email,faculty\n",
+			expectedError: "The CSV header has more than one 'id' column",
+		},
+		{
+			name:          "no members",
+			csv:           "id,first name,last name,email,faculty\n",
+			expectedError: "The CSV file has no members",
+		},
+		{
+			name:          "empty file",
+			csv:           "",
+			expectedError: "The CSV file is empty",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			rows, rowErrors, err := ParseMemberCSV(strings.NewReader(tC.csv))
+			if tC.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tC.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.expectedRows, rows)
+			assert.Equal(t, tC.expectedRowErrors, rowErrors)
+		})
+	}
+}
+
+func TestValidateMembershipImport(t *testing.T) {
+	yes := true
+	no := false
+	semesterID := uuid.New()
+
+	rows := []models.MemberImportRow{
+		{Row: 1},
+		{Row: 2, Discounted: &yes},
+		{Row: 3, Paid: &no},
+	}
+
+	// Rows are not checked without a semester
+	assert.Empty(t, ValidateMembershipImport(rows, models.MemberImportOptions{}))
+
+	assert.Equal(t, []models.MemberImportRowError{
+		{Row: 2, Field: "discounted", Message: "a membership cannot be discounted without being paid"},
+	}, ValidateMembershipImport(rows, models.MemberImportOptions{SemesterID: &semesterID}))
+
+	assert.Equal(t, []models.MemberImportRowError{
+		{Row: 3, Field: "discounted", Message: "a membership cannot be discounted without being paid"},
+	}, ValidateMembershipImport(rows, models.MemberImportOptions{SemesterID: &semesterID, Paid: true, Discounted: true}))
+}
+
+func TestMemberImportService_Import(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "TEST")
+
+	db, err := database.OpenTestConnection()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer database.WipeDB(db)
+
+	semesterID := uuid.New()
+	semester, err := testhelpers.CreateSemester(
+		db,
+		semesterID,
+		"test",
+		"",
+		time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2022, 2, 1, 12, 0, 0, 0, time.UTC),
+		100.0,
+		100.0,
+		10,
+		7,
+		2,
+	)
+	require.NoError(t, err)
+
+	_, err = testhelpers.CreateUser(db, 20801234, "Jane", "Doe", "jane@gmail.com", models.FacultyMath, "jdoe")
+	require.NoError(t, err)
+	_, err = testhelpers.CreateUser(db, 20809999, "Sam", "Lee", "slee@uwaterloo.ca", models.FacultyArts, "slee")
+	require.NoError(t, err)
+	_, err = testhelpers.CreateMembership(db, 20809999, semester.ID, false, false)
+	require.NoError(t, err)
+
+	yes := true
+	rows := []models.MemberImportRow{
+		{Row: 1, Member: models.CreateUserRequest{ID: 20801234, FirstName: "Jane", LastName: "Doe", Email: "jdoe@uwaterloo.ca", Faculty: models.FacultyMath}},
+		{Row: 2, Member: models.CreateUserRequest{ID: 20805678, FirstName: "John", LastName: "Smith", Email: "jsmith@uwaterloo.ca", Faculty: models.FacultyScience}, Discounted: &yes},
+		{Row: 3, Member: models.CreateUserRequest{ID: 20809999, FirstName: "Sam", LastName: "Lee", Email: "slee@uwaterloo.ca", Faculty: models.FacultyArts}},
+	}
+
+	memberImportService := NewMemberImportService(db)
+
+	expectedRows := []models.MemberImportRowResult{
+		{Row: 1, ID: 20801234, Member: models.MemberImportStatusUpdated, Membership: models.MembershipImportStatusCreated},
+		{Row: 2, ID: 20805678, Member: models.MemberImportStatusCreated, Membership: models.MembershipImportStatusCreated},
+		{Row: 3, ID: 20809999, Member: models.MemberImportStatusUnchanged, Membership: models.MembershipImportStatusExists},
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		res, err := memberImportService.Import(rows, models.MemberImportOptions{SemesterID: &semesterID, Paid: true, DryRun: true})
+		require.NoError(t, err)
+		assert.True(t, res.DryRun)
+		assert.Equal(t, 1, res.Created)
+		assert.Equal(t, 1, res.Updated)
+		assert.Equal(t, 1, res.Unchanged)
+		assert.Equal(t, 2, res.MembershipsCreated)
+		assert.Equal(t, expectedRows, res.Rows)
+
+		// Nothing is saved
+		var count int64
+		require.NoError(t, db.Model(&models.User{}).Where("id = ?", 20805678).Count(&count).Error)
+		assert.Zero(t, count)
+		require.NoError(t, db.Model(&models.Membership{}).Where("semester_id = ?", semesterID).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		res, err := memberImportService.Import(rows, models.MemberImportOptions{SemesterID: &semesterID, Paid: true})
+		require.NoError(t, err)
+		assert.False(t, res.DryRun)
+		assert.Equal(t, expectedRows, res.Rows)
+
+		// The quest ID is kept when the row has none
+		user := models.User{}
+		require.NoError(t, db.First(&user, 20801234).Error)
+		assert.Equal(t, "jdoe@uwaterloo.ca", user.Email)
+		assert.Equal(t, "jdoe", user.QuestID)
+
+		membership := models.Membership{}
+		require.NoError(t, db.Where("user_id = ? AND semester_id = ?", 20805678, semesterID).First(&membership).Error)
+		assert.True(t, membership.Paid)
+		assert.True(t, membership.Discounted)
+
+		// The fees of both paid memberships are added to the budget
+		updated := models.Semester{}
+		require.NoError(t, db.First(&updated, "id = ?", semesterID).Error)
+		assert.InDelta(t, 117.0, updated.CurrentBudget, 0.001)
+
+		var entries []models.LedgerEntry
+		require.NoError(t, db.Where("semester_id = ? AND type = ?", semesterID, models.LedgerEntryTypeMembershipFee).Find(&entries).Error)
+		assert.Len(t, entries, 2)
+	})
+
+	t.Run("missing semester", func(t *testing.T) {
+		missing := uuid.New()
+		_, err := memberImportService.Import(rows, models.MemberImportOptions{SemesterID: &missing})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Semester not found")
+	})
+}
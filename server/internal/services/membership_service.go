@@ -3,7 +3,11 @@ package services
 import (
 	e "api/internal/errors"
 	"api/internal/models"
+	"encoding/csv"
 	"errors"
+	"fmt"
+	"io"
+	"strconv"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -56,13 +60,13 @@ func (ms *membershipService) CreateMembership(req *models.CreateMembershipReques
 	if req.Paid {
 		// If the membership has been discounted, use the discounted rate instead
 		if req.Discounted {
-			err = ss.UpdateBudget(semesterId, float32(semester.MembershipDiscountFee))
+			err = recordMembershipFee(tx, &membership, int64(semester.MembershipDiscountFee)*100, "Discounted membership fee")
 			if err != nil {
 				tx.Rollback()
 				return nil, err
 			}
 		} else {
-			err = ss.UpdateBudget(semesterId, float32(semester.MembershipFee))
+			err = recordMembershipFee(tx, &membership, int64(semester.MembershipFee)*100, "Membership fee")
 			if err != nil {
 				tx.Rollback()
 				return nil, err
@@ -222,13 +226,13 @@ func (ms *membershipService) UpdateMembership(req *models.UpdateMembershipReques
 		if existingMembership.Paid {
 			// Update semester's budget
 			if existingMembership.Discounted {
-				err = ss.UpdateBudget(existingMembership.SemesterID, -float32(semester.MembershipDiscountFee))
+				err = recordMembershipFee(tx, &existingMembership, -int64(semester.MembershipDiscountFee)*100, "Membership fee refunded")
 				if err != nil {
 					tx.Rollback()
 					return nil, err
 				}
 			} else {
-				err = ss.UpdateBudget(existingMembership.SemesterID, -float32(semester.MembershipFee))
+				err = recordMembershipFee(tx, &existingMembership, -int64(semester.MembershipFee)*100, "Membership fee refunded")
 				if err != nil {
 					tx.Rollback()
 					return nil, err
@@ -242,14 +246,14 @@ func (ms *membershipService) UpdateMembership(req *models.UpdateMembershipReques
 			// Next compare the discounted flag
 			if !req.Discounted && existingMembership.Discounted {
 				// Member is marked as discounted and updating them to not discounted
-				err = ss.UpdateBudget(semester.ID, float32(semester.MembershipFee-semester.MembershipDiscountFee))
+				err = recordMembershipFee(tx, &existingMembership, (int64(semester.MembershipFee)-int64(semester.MembershipDiscountFee))*100, "Membership discount removed")
 				if err != nil {
 					tx.Rollback()
 					return nil, err
 				}
 			} else if req.Discounted && !existingMembership.Discounted {
 				// Member is not marked as discounted and updating them to discounted
-				err = ss.UpdateBudget(semester.ID, -float32(semester.MembershipFee-semester.MembershipDiscountFee))
+				err = recordMembershipFee(tx, &existingMembership, -(int64(semester.MembershipFee)-int64(semester.MembershipDiscountFee))*100, "Membership discount applied")
 				if err != nil {
 					tx.Rollback()
 					return nil, err
@@ -258,13 +262,13 @@ func (ms *membershipService) UpdateMembership(req *models.UpdateMembershipReques
 		} else {
 			// Existing member has not paid, and we are updating them to paid
 			if req.Discounted {
-				err = ss.UpdateBudget(semester.ID, float32(semester.MembershipDiscountFee))
+				err = recordMembershipFee(tx, &existingMembership, int64(semester.MembershipDiscountFee)*100, "Discounted membership fee")
 				if err != nil {
 					tx.Rollback()
 					return nil, err
 				}
 			} else {
-				err = ss.UpdateBudget(semester.ID, float32(semester.MembershipFee))
+				err = recordMembershipFee(tx, &existingMembership, int64(semester.MembershipFee)*100, "Membership fee")
 				if err != nil {
 					tx.Rollback()
 					return nil, err
@@ -329,13 +333,13 @@ func (ms *membershipService) CreateMembershipV2(semesterID uuid.UUID, req *model
 	if req.Paid {
 		// If the membership has been discounted, use the discounted rate instead
 		if req.Discounted {
-			err = ss.UpdateBudget(semesterID, float32(semester.MembershipDiscountFee))
+			err = recordMembershipFee(tx, &membership, int64(semester.MembershipDiscountFee)*100, "Discounted membership fee")
 			if err != nil {
 				tx.Rollback()
 				return nil, err
 			}
 		} else {
-			err = ss.UpdateBudget(semesterID, float32(semester.MembershipFee))
+			err = recordMembershipFee(tx, &membership, int64(semester.MembershipFee)*100, "Membership fee")
 			if err != nil {
 				tx.Rollback()
 				return nil, err
@@ -419,35 +423,43 @@ func (ms *membershipService) UpdateMembershipV2(id uuid.UUID, semesterID uuid.UU
 		return nil, err
 	}
 
-	// Determine budget adjustment based on original vs final state
-	var budgetAdjustment float32
+	// Determine budget adjustment in cents based on original vs final state
+	var budgetAdjustment int64
+	var description string
+	fee := int64(semester.MembershipFee) * 100
+	discountFee := int64(semester.MembershipDiscountFee) * 100
 
 	// Membership changed from paid to not paid
 	if originalPaid && !finalPaid {
+		description = "Membership fee refunded"
 		if originalDiscounted {
-			budgetAdjustment -= float32(semester.MembershipDiscountFee)
+			budgetAdjustment -= discountFee
 		} else {
-			budgetAdjustment -= float32(semester.MembershipFee)
+			budgetAdjustment -= fee
 		}
 	} else if !originalPaid && finalPaid {
 		// Membership changed from not paid to paid
 		if finalDiscounted {
-			budgetAdjustment += float32(semester.MembershipDiscountFee)
+			description = "Discounted membership fee"
+			budgetAdjustment += discountFee
 		} else {
-			budgetAdjustment += float32(semester.MembershipFee)
+			description = "Membership fee"
+			budgetAdjustment += fee
 		}
 	} else if originalPaid && finalPaid {
 		// Membership remained paid, check for discount changes
 		if originalDiscounted && !finalDiscounted {
-			budgetAdjustment += float32(semester.MembershipFee - semester.MembershipDiscountFee)
+			description = "Membership discount removed"
+			budgetAdjustment += fee - discountFee
 		} else if !originalDiscounted && finalDiscounted {
-			budgetAdjustment -= float32(semester.MembershipFee - semester.MembershipDiscountFee)
+			description = "Membership discount applied"
+			budgetAdjustment -= fee - discountFee
 		}
 	}
 
 	// Apply budget adjustment if needed
 	if budgetAdjustment != 0 {
-		err = ss.UpdateBudget(existingMembership.SemesterID, budgetAdjustment)
+		err = recordMembershipFee(tx, &existingMembership, budgetAdjustment, description)
 		if err != nil {
 			tx.Rollback()
 			return nil, err
@@ -527,3 +539,131 @@ func (ms *membershipService) ListMembershipsV2(filter *models.ListMembershipsFil
 
 	return results, total, nil
 }
+
+// membershipExportRow is a membership as it is read for a CSV export
+type membershipExportRow struct {
+	ID         uuid.UUID
+	UserID     uint64
+	FirstName  string
+	LastName   string
+	Email      string
+	Faculty    string
+	QuestID    string
+	Paid       bool
+	Discounted bool
+	FeeCents   int64
+	Attendance int
+}
+
+// ExportMemberships writes every membership of the filter's semester that
+// matches the filter to w as CSV, with the fee paid for each membership so it
+// can be reconciled against payments. The fee is the sum of the membership's
+// fee entries in the ledger, so it is what was charged at the time and
+// includes any refunds. Pagination is ignored. Memberships are
+// read from the database one row at a time, and nothing is written to w if
+// the semester does not exist or the memberships cannot be queried.
+func (ms *membershipService) ExportMemberships(w io.Writer, filter *models.ListMembershipsFilter) error {
+	var semester models.Semester
+	if err := ms.db.Where("id = ?", filter.SemesterID).First(&semester).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return e.NotFound("Semester not found")
+		}
+		return e.InternalServerError(err.Error())
+	}
+
+	attendanceSubquery := ms.db.
+		Select("participants.membership_id, COUNT(*) as total").
+		Table("participants").
+		Joins("INNER JOIN events ON participants.event_id = events.id").
+		Where("events.semester_id = ?", filter.SemesterID).
+		Group("participants.membership_id")
+
+	feeSubquery := ms.db.
+		Select("membership_id, SUM(amount_cents) as total").
+		Table("ledger_entries").
+		Where("semester_id = ? AND type = ?", filter.SemesterID, models.LedgerEntryTypeMembershipFee).
+		Group("membership_id")
+
+	// The users table is aliased the same as Joins("User") so that the list
+	// filter clauses apply unchanged
+	query := ms.db.
+		Table("memberships").
+		Select("memberships.id, memberships.user_id, \"User\".first_name, \"User\".last_name, \"User\".email, \"User\".faculty, \"User\".quest_id, memberships.paid, memberships.discounted, COALESCE(fee.total, 0) as fee_cents, COALESCE(att.total, 0) as attendance").
+		Joins("INNER JOIN users \"User\" ON \"User\".id = memberships.user_id").
+		Joins("LEFT JOIN (?) as fee ON fee.membership_id = memberships.id", feeSubquery).
+		Joins("LEFT JOIN (?) as att ON att.membership_id = memberships.id", attendanceSubquery).
+		Where("memberships.semester_id = ?", filter.SemesterID).
+		Order("\"User\".first_name ASC").
+		Order("\"User\".last_name ASC")
+
+	rows, err := addFilterClauses(query, filter).Rows()
+	if err != nil {
+		return e.InternalServerError(err.Error())
+	}
+	defer rows.Close()
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{
+		"membership_id", "user_id", "first_name", "last_name", "email", "faculty", "quest_id",
+		"paid", "discounted", "fee", "attendance",
+	})
+
+	for rows.Next() {
+		var membership membershipExportRow
+		if err := ms.db.ScanRows(rows, &membership); err != nil {
+			return e.InternalServerError(err.Error())
+		}
+
+		record := []string{
+			membership.ID.String(),
+			strconv.FormatUint(membership.UserID, 10),
+			membership.FirstName,
+			membership.LastName,
+			membership.Email,
+			membership.Faculty,
+			membership.QuestID,
+			strconv.FormatBool(membership.Paid),
+			strconv.FormatBool(membership.Discounted),
+			formatCents(membership.FeeCents),
+			strconv.Itoa(membership.Attendance),
+		}
+
+		if err := writer.Write(record); err != nil {
+			return e.InternalServerError(err.Error())
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+// formatCents formats an amount of cents as dollars, e.g. 1050 as "10.50".
+func formatCents(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// recordMembershipFee records a change to the fee paid for a membership in the
+// ledger of its semester.
+func recordMembershipFee(tx *gorm.DB, membership *models.Membership, amountCents int64, description string) error {
+	return recordLedgerEntry(tx, &models.LedgerEntry{
+		SemesterID:   membership.SemesterID,
+		Type:         models.LedgerEntryTypeMembershipFee,
+		AmountCents:  amountCents,
+		Description:  description,
+		MembershipID: &membership.ID,
+	})
+}
@@ -4,6 +4,8 @@ import (
 	e "api/internal/errors"
 	"api/internal/models"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -20,9 +22,37 @@ func NewParticipantsService(db *gorm.DB) *participantsService {
 }
 
 func (svc *participantsService) CreateParticipant(req *models.CreateParticipantRequest) (*models.Participant, error) {
+	participant := models.Participant{
+		MembershipID: &req.MembershipID,
+		EventID:      req.EventID,
+		Placement:    0,
+		SignedOutAt:  nil,
+	}
+
+	return svc.enterParticipant(&participant)
+}
+
+// CreateGuestParticipant enters a walk-in player without a membership
+func (svc *participantsService) CreateGuestParticipant(eventID int32, req *models.CreateGuestEntryRequest) (*models.Participant, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, e.InvalidRequest("Guest name cannot be blank")
+	}
+
+	participant := models.Participant{
+		GuestName: &name,
+		EventID:   eventID,
+	}
+
+	return svc.enterParticipant(&participant)
+}
+
+// enterParticipant adds an entry to an event which has not ended and has room
+// for the player, and draws a seat for them
+func (svc *participantsService) enterParticipant(participant *models.Participant) (*models.Participant, error) {
 	eventService := NewEventService(svc.db)
 
-	event, err := eventService.GetEvent(req.EventID)
+	event, err := eventService.GetEvent(participant.EventID)
 	if err != nil {
 		return nil, err
 	}
@@ -31,19 +61,32 @@ func (svc *participantsService) CreateParticipant(req *models.CreateParticipantR
 		return nil, e.Forbidden("Modification of a completed event is forbidden")
 	}
 
-	participant := models.Participant{
-		MembershipID: &req.MembershipID,
-		EventID:      req.EventID,
-		Placement:    0,
-		SignedOutAt:  nil,
-	}
+	// Draw a seat for the new player
+	err = svc.db.Transaction(func(tx *gorm.DB) error {
+		event, err := lockEventForSeating(tx, participant.EventID)
+		if err != nil {
+			return err
+		}
 
-	res := svc.db.Create(&participant)
-	if err := res.Error; err != nil {
+		room, err := hasRoom(tx, event, participant.MembershipID)
+		if err != nil {
+			return err
+		}
+		if !room {
+			return e.Forbidden("The event is full")
+		}
+
+		return admitParticipant(tx, event, participant)
+	})
+
+	var apiErr e.APIErrorResponse
+	if errors.As(err, &apiErr) {
+		return nil, apiErr
+	} else if err != nil {
 		return nil, e.InternalServerError(err.Error())
 	}
 
-	return &participant, nil
+	return participant, nil
 }
 
 func (svc *participantsService) ListParticipants(eventId int32) ([]models.ListParticipantsResult, error) {
@@ -52,13 +95,13 @@ func (svc *participantsService) ListParticipants(eventId int32) ([]models.ListPa
 	// TODO: Update this query eventually to return a specific array of objects
 	subQuery := svc.db.
 		Table("participants").
-		Select("memberships.id, memberships.user_id, participants.signed_out_at, participants.placement").
+		Select("memberships.id, memberships.user_id, participants.signed_out_at, participants.placement, participants.placement_override, participants.rebuys, participants.add_ons, participants.knocked_out_by_id").
 		Joins("INNER JOIN memberships on memberships.id = participants.membership_id").
 		Where("participants.event_id = ?", eventId)
 
 	res := svc.db.
 		Table("(?) as entries", subQuery).
-		Select("users.first_name, users.last_name, users.id, entries.signed_out_at, entries.placement, entries.id as membership_id").
+		Select("users.first_name, users.last_name, users.id, entries.signed_out_at, entries.placement, entries.placement_override, entries.rebuys, entries.add_ons, entries.knocked_out_by_id, entries.id as membership_id").
 		Joins("INNER JOIN users ON users.id = entries.user_id").
 		Order("entries.signed_out_at DESC").
 		Find(&ret)
@@ -76,11 +119,12 @@ func (svc *participantsService) ListParticipantsV2(eventId int32, pagination *mo
 		if search != "" {
 			sanitized := sanitizeLikeInput(search)
 			pattern := "%" + sanitized + "%"
-			q = q.Joins("JOIN memberships ON memberships.id = participants.membership_id").
-				Joins("JOIN users ON users.id = memberships.user_id").
+			// Guests have no membership, so they are matched by name
+			q = q.Joins("LEFT JOIN memberships ON memberships.id = participants.membership_id").
+				Joins("LEFT JOIN users ON users.id = memberships.user_id").
 				Where(
-					"users.first_name ILIKE ? OR users.last_name ILIKE ? OR (users.first_name || ' ' || users.last_name) ILIKE ? OR CAST(users.id AS TEXT) ILIKE ?",
-					pattern, pattern, pattern, pattern,
+					"users.first_name ILIKE ? OR users.last_name ILIKE ? OR (users.first_name || ' ' || users.last_name) ILIKE ? OR CAST(users.id AS TEXT) ILIKE ? OR participants.guest_name ILIKE ?",
+					pattern, pattern, pattern, pattern, pattern,
 				)
 		}
 		return q
@@ -116,14 +160,18 @@ func (svc *participantsService) UpdateParticipant(req *models.UpdateParticipantR
 		return nil, e.Forbidden("Modification of a completed event is forbidden")
 	}
 
-	participant := models.Participant{
-		MembershipID: &req.MembershipID,
-		EventID:      req.EventID,
+	participant := models.Participant{}
+	res := svc.db.Where("membership_id = ? AND event_id = ?", req.MembershipID, req.EventID)
+	if req.GuestID != 0 {
+		res = svc.db.Where("id = ? AND event_id = ? AND membership_id IS NULL AND guest_name IS NOT NULL", req.GuestID, req.EventID)
 	}
 
-	res := svc.db.Where("membership_id = ? AND event_id = ?", req.MembershipID, req.EventID).First(&participant)
+	res = res.First(&participant)
 	// Check if the error is a not found error
 	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		if req.GuestID != 0 {
+			return nil, e.NotFound("Guest entry not found")
+		}
 		return nil, e.NotFound(err.Error())
 	}
 
@@ -138,13 +186,60 @@ func (svc *participantsService) UpdateParticipant(req *models.UpdateParticipantR
 		return nil, e.InternalServerError(err.Error())
 	}
 
+	// Seats are drawn and freed one at a time
+	lockedEvent, err := lockEventForSeating(tx, req.EventID)
+	if err != nil {
+		tx.Rollback()
+		return nil, e.InternalServerError(err.Error())
+	}
+
 	if req.SignIn {
 		participant.SignedOutAt = nil
+		participant.KnockedOutByID = nil
 	}
 
 	if req.SignOut {
 		now := time.Now().UTC()
 		participant.SignedOutAt = &now
+		participant.KnockedOutByID = nil
+	}
+
+	// Record the knockout for bounty events
+	if req.SignOut && (req.KnockedOutBy != nil || req.KnockedOutByEntryID != nil) {
+		if req.KnockedOutBy != nil && req.KnockedOutByEntryID != nil {
+			tx.Rollback()
+			return nil, e.InvalidRequest("Only one of knockedOutBy and knockedOutByEntry can be set")
+		}
+
+		eliminator := models.Participant{}
+		notFound := ""
+		if req.KnockedOutByEntryID != nil {
+			res = tx.Where("id = ? AND event_id = ?", *req.KnockedOutByEntryID, req.EventID).First(&eliminator)
+			notFound = fmt.Sprintf("Entry %d is not in this event", *req.KnockedOutByEntryID)
+		} else {
+			res = tx.Where("membership_id = ? AND event_id = ?", *req.KnockedOutBy, req.EventID).First(&eliminator)
+			notFound = fmt.Sprintf("Membership %s has no entry in this event", *req.KnockedOutBy)
+		}
+		if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			tx.Rollback()
+			return nil, e.NotFound(notFound)
+		} else if err != nil {
+			tx.Rollback()
+			return nil, e.InternalServerError(err.Error())
+		}
+
+		if eliminator.ID == participant.ID {
+			tx.Rollback()
+			return nil, e.InvalidRequest("A player cannot knock themselves out")
+		}
+
+		// Only a player still in the event can eliminate another
+		if eliminator.SignedOutAt != nil {
+			tx.Rollback()
+			return nil, e.InvalidRequest("A player cannot be knocked out by a player who has already been signed out")
+		}
+
+		participant.KnockedOutByID = &eliminator.ID
 	}
 
 	res = tx.Save(&participant)
@@ -153,6 +248,18 @@ func (svc *participantsService) UpdateParticipant(req *models.UpdateParticipantR
 		return nil, e.InternalServerError(err.Error())
 	}
 
+	// Players that sign back in get a new seat, and players that sign out
+	// give up theirs, which may leave the tables unbalanced
+	if req.SignIn && participant.TableNumber == nil {
+		err = seatParticipant(tx, lockedEvent, &participant)
+	} else if req.SignOut && participant.TableNumber != nil {
+		_, err = unseatParticipant(tx, lockedEvent, &participant)
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, e.InternalServerError(err.Error())
+	}
+
 	res = tx.Commit()
 	if err := res.Error; err != nil {
 		tx.Rollback()
@@ -163,15 +270,49 @@ func (svc *participantsService) UpdateParticipant(req *models.UpdateParticipantR
 }
 
 func (svc *participantsService) DeleteParticipant(req *models.DeleteParticipantRequest) error {
-	// Do not need to update semester budget
-	res := svc.db.Where("membership_id = ? AND event_id = ?", req.MembershipID, req.EventID).Delete(models.Participant{})
-	if err := res.Error; err != nil {
-		return e.InternalServerError(err.Error())
-	}
+	err := svc.db.Transaction(func(tx *gorm.DB) error {
+		event, err := lockEventForSeating(tx, req.EventID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return e.NotFound("Entry not found")
+		} else if err != nil {
+			return err
+		}
+
+		// Do not need to update semester budget
+		res := tx.Where("membership_id = ? AND event_id = ?", req.MembershipID, req.EventID)
+		if req.GuestID != 0 {
+			res = tx.Where("id = ? AND event_id = ? AND membership_id IS NULL AND guest_name IS NOT NULL", req.GuestID, req.EventID)
+		}
+
+		res = res.Delete(models.Participant{})
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		// Check if any rows were actually deleted
+		if res.RowsAffected == 0 {
+			return e.NotFound("Entry not found")
+		}
+
+		if event.State == models.EventStateEnded {
+			return nil
+		}
 
-	// Check if any rows were actually deleted
-	if res.RowsAffected == 0 {
-		return e.NotFound("Entry not found")
+		// The spot of a withdrawn player goes to the waitlist
+		if err := promoteWaitlist(tx, event); err != nil {
+			return err
+		}
+
+		// Removing a seated player may leave the tables unbalanced
+		_, err = rebalanceTables(tx, event)
+		return err
+	})
+
+	var apiErr e.APIErrorResponse
+	if errors.As(err, &apiErr) {
+		return apiErr
+	} else if err != nil {
+		return e.InternalServerError(err.Error())
 	}
 
 	return nil
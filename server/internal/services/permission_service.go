@@ -0,0 +1,137 @@
+package services
+
+import (
+	"api/internal/authorization"
+	e "api/internal/errors"
+	"api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type permissionService struct {
+	db     *gorm.DB
+	policy *authorization.PolicyStore
+}
+
+func NewPermissionService(db *gorm.DB) *permissionService {
+	return &permissionService{
+		db:     db,
+		policy: authorization.ActivePolicy,
+	}
+}
+
+// LoadPolicy seeds the stored policy with the default roles of any action
+// that has no row yet, then loads it into the active policy.
+func (svc *permissionService) LoadPolicy() error {
+	rows := []models.RolePermission{}
+	if err := svc.db.Find(&rows).Error; err != nil {
+		return err
+	}
+
+	policy := make(authorization.Policy)
+	for _, row := range rows {
+		policy[row.Action] = row.Roles
+	}
+
+	missing := []models.RolePermission{}
+	for _, action := range svc.policy.Actions() {
+		if _, exists := policy[action]; exists {
+			continue
+		}
+
+		roles, _ := svc.policy.DefaultRoles(action)
+		missing = append(missing, models.RolePermission{Action: action, Roles: roles})
+		policy[action] = roles
+	}
+
+	if len(missing) > 0 {
+		res := svc.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&missing)
+		if err := res.Error; err != nil {
+			return err
+		}
+	}
+
+	return svc.policy.Load(policy)
+}
+
+// ListPermissions returns the roles allowed to perform every action, sorted
+// by action.
+func (svc *permissionService) ListPermissions() ([]models.RolePermission, error) {
+	rows := []models.RolePermission{}
+	if err := svc.db.Find(&rows).Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	updatedAt := make(map[string]models.RolePermission, len(rows))
+	for _, row := range rows {
+		updatedAt[row.Action] = row
+	}
+
+	permissions := []models.RolePermission{}
+	for _, action := range svc.policy.Actions() {
+		permission := svc.getPermission(action)
+		permission.UpdatedAt = updatedAt[action].UpdatedAt
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, nil
+}
+
+// UpdatePermission changes the roles allowed to perform an action. The change
+// is stored before it is applied, so that a failed write leaves the active
+// policy untouched. It applies to the next request on this instance, and to
+// other instances of the API when they next reload the policy.
+func (svc *permissionService) UpdatePermission(action string, roles []string) (*models.RolePermission, error) {
+	if _, exists := svc.policy.Roles(action); !exists {
+		return nil, e.NotFound("Action not found")
+	}
+
+	if authorization.IsProtected(action) {
+		return nil, e.Forbidden("The roles of permission actions cannot be changed")
+	}
+
+	normalized, err := svc.policy.Validate(action, roles)
+	if err != nil {
+		return nil, e.InvalidRequest(err.Error())
+	}
+
+	stored := models.RolePermission{Action: action, Roles: normalized}
+	res := svc.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "action"}},
+		DoUpdates: clause.AssignmentColumns([]string{"roles", "updated_at"}),
+	}).Create(&stored)
+	if err := res.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	if err := svc.policy.Set(action, normalized); err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	permission := svc.getPermission(action)
+	permission.UpdatedAt = stored.UpdatedAt
+	return &permission, nil
+}
+
+// ResetPermission restores the default roles of an action.
+func (svc *permissionService) ResetPermission(action string) (*models.RolePermission, error) {
+	roles, exists := svc.policy.DefaultRoles(action)
+	if !exists {
+		return nil, e.NotFound("Action not found")
+	}
+
+	return svc.UpdatePermission(action, roles)
+}
+
+func (svc *permissionService) getPermission(action string) models.RolePermission {
+	roles, _ := svc.policy.Roles(action)
+	defaults, _ := svc.policy.DefaultRoles(action)
+
+	return models.RolePermission{
+		Action:       action,
+		Roles:        roles,
+		DefaultRoles: defaults,
+		Protected:    authorization.IsProtected(action),
+	}
+}
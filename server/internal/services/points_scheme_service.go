@@ -0,0 +1,163 @@
+package services
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type pointsSchemeService struct {
+	db *gorm.DB
+}
+
+func NewPointsSchemeService(db *gorm.DB) *pointsSchemeService {
+	return &pointsSchemeService{
+		db: db,
+	}
+}
+
+// GetPointsScheme returns the points scheme configured for a semester. If the
+// semester has not configured one, the default scheme is returned.
+func (svc *pointsSchemeService) GetPointsScheme(semesterID uuid.UUID) (*models.PointsScheme, error) {
+	if err := svc.ensureSemesterExists(semesterID); err != nil {
+		return nil, err
+	}
+
+	return svc.resolvePointsScheme(semesterID)
+}
+
+// UpsertPointsScheme creates or replaces the points scheme for a semester. The
+// scheme cannot be changed once an event of the semester has ended, since
+// undoing that event subtracts the points of the current scheme.
+func (svc *pointsSchemeService) UpsertPointsScheme(
+	semesterID uuid.UUID,
+	req *models.UpsertPointsSchemeRequest,
+) (*models.PointsScheme, error) {
+	if err := svc.ensureSemesterExists(semesterID); err != nil {
+		return nil, err
+	}
+
+	if err := svc.ensureNoEndedEvents(semesterID); err != nil {
+		return nil, err
+	}
+
+	tx := svc.db.Begin()
+	if err := tx.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	scheme := models.PointsScheme{}
+	res := tx.Where("semester_id = ?", semesterID).First(&scheme)
+	if err := res.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	scheme.SemesterID = semesterID
+	scheme.SizeFactor = req.SizeFactor
+	scheme.RoundingMode = req.RoundingMode
+	scheme.MinimumPoints = req.MinimumPoints
+	scheme.DefaultPayout = req.DefaultPayout
+
+	// Payouts are replaced below, so do not let GORM upsert the association
+	if err := tx.Omit("Payouts").Save(&scheme).Error; err != nil {
+		tx.Rollback()
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	if err := tx.Where("points_scheme_id = ?", scheme.ID).Delete(&models.PointsPayout{}).Error; err != nil {
+		tx.Rollback()
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	payouts := make([]models.PointsPayout, len(req.Payouts))
+	for i, points := range req.Payouts {
+		payouts[i] = models.PointsPayout{
+			PointsSchemeID: scheme.ID,
+			Placement:      int32(i + 1),
+			Points:         points,
+		}
+	}
+
+	if err := tx.Create(&payouts).Error; err != nil {
+		tx.Rollback()
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	scheme.Payouts = payouts
+	return &scheme, nil
+}
+
+// DeletePointsScheme removes a semester's configured points scheme, reverting
+// the semester to the default scheme. Like UpsertPointsScheme, it is refused
+// once an event of the semester has ended.
+func (svc *pointsSchemeService) DeletePointsScheme(semesterID uuid.UUID) error {
+	if err := svc.ensureSemesterExists(semesterID); err != nil {
+		return err
+	}
+
+	if err := svc.ensureNoEndedEvents(semesterID); err != nil {
+		return err
+	}
+
+	res := svc.db.Where("semester_id = ?", semesterID).Delete(&models.PointsScheme{})
+	if err := res.Error; err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+// resolvePointsScheme loads the scheme for a semester without checking that
+// the semester exists, falling back to the default scheme.
+func (svc *pointsSchemeService) resolvePointsScheme(semesterID uuid.UUID) (*models.PointsScheme, error) {
+	scheme := models.PointsScheme{}
+	res := scheme.Preload(svc.db, models.PointsSchemePreloadOptions{Payouts: true}).
+		Where("semester_id = ?", semesterID).
+		First(&scheme)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		scheme = DefaultPointsScheme(semesterID)
+		return &scheme, nil
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &scheme, nil
+}
+
+func (svc *pointsSchemeService) ensureSemesterExists(semesterID uuid.UUID) error {
+	var count int64
+	res := svc.db.Model(&models.Semester{}).Where("id = ?", semesterID).Count(&count)
+	if err := res.Error; err != nil {
+		return e.InternalServerError(err.Error())
+	}
+	if count == 0 {
+		return e.NotFound("Semester not found")
+	}
+
+	return nil
+}
+
+// ensureNoEndedEvents refuses changes to the points scheme of a semester that
+// has ended events, as their rankings were awarded with the current scheme.
+func (svc *pointsSchemeService) ensureNoEndedEvents(semesterID uuid.UUID) error {
+	var count int64
+	res := svc.db.Model(&models.Event{}).
+		Where("semester_id = ? AND state = ?", semesterID, models.EventStateEnded).
+		Count(&count)
+	if err := res.Error; err != nil {
+		return e.InternalServerError(err.Error())
+	}
+	if count > 0 {
+		return e.Forbidden("The points scheme cannot be changed once an event of the semester has ended")
+	}
+
+	return nil
+}
@@ -1,62 +1,89 @@
 package services
 
-import "math"
+import (
+	"api/internal/models"
+	"math"
+
+	"github.com/google/uuid"
+)
 
 const (
 	SizeFactor float64 = 50.0
 )
 
-func getPayout(placement int) int {
-	payouts := map[int]int{
-		1:  32,
-		2:  28,
-		3:  24,
-		4:  21,
-		5:  18,
-		6:  16,
-		7:  14,
-		8:  12,
-		9:  11,
-		10: 10,
-		11: 9,
-		12: 9,
-		13: 8,
-		14: 8,
-		15: 7,
-		16: 7,
-		17: 6,
-		18: 6,
-		19: 5,
-		20: 5,
-		21: 4,
-		22: 4,
-		23: 4,
-		24: 4,
-		25: 4,
-		26: 3,
-		27: 3,
-		28: 3,
-		29: 3,
-		30: 3,
-		31: 2,
-		32: 2,
-		33: 2,
-		34: 2,
-		35: 2,
-		36: 2,
-		37: 2,
-		38: 2,
-		39: 2,
-		40: 2,
-	}
+// defaultPayouts is the payout table used by semesters which have not
+// configured their own points scheme. Index 0 is the payout for 1st place.
+var defaultPayouts = []int32{
+	32, 28, 24, 21, 18, 16, 14, 12, 11, 10,
+	9, 9, 8, 8, 7, 7, 6, 6, 5, 5,
+	4, 4, 4, 4, 4, 3, 3, 3, 3, 3,
+	2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
+}
 
-	if placement > 40 {
-		return 1
+// DefaultPointsScheme returns the scheme used when a semester has not
+// configured its own points scheme.
+func DefaultPointsScheme(semesterID uuid.UUID) models.PointsScheme {
+	payouts := make([]models.PointsPayout, len(defaultPayouts))
+	for i, points := range defaultPayouts {
+		payouts[i] = models.PointsPayout{
+			Placement: int32(i + 1),
+			Points:    points,
+		}
 	}
 
-	return payouts[placement]
+	return models.PointsScheme{
+		SemesterID:    semesterID,
+		SizeFactor:    SizeFactor,
+		RoundingMode:  models.PointsRoundingCeil,
+		MinimumPoints: 0,
+		DefaultPayout: 1,
+		Payouts:       payouts,
+	}
 }
 
 func CalculatePoints(eventSize int, placement int, pointsMultiplier float32) int {
-	return int(math.Ceil(float64((getPayout(placement)*eventSize))/SizeFactor) * float64(pointsMultiplier))
+	scheme := DefaultPointsScheme(uuid.Nil)
+	return CalculateSchemePoints(&scheme, eventSize, placement, pointsMultiplier)
+}
+
+// CalculateSchemePoints calculates the points awarded for a placement in an
+// event of the given size using the provided points scheme.
+func CalculateSchemePoints(scheme *models.PointsScheme, eventSize int, placement int, pointsMultiplier float32) int {
+	return CalculateTiedSchemePoints(scheme, eventSize, placement, 1, pointsMultiplier)
+}
+
+// CalculateTiedPoints calculates the points awarded to each of the entries
+// tied at a placement using the default points scheme.
+func CalculateTiedPoints(eventSize int, placement int, tied int, pointsMultiplier float32) int {
+	scheme := DefaultPointsScheme(uuid.Nil)
+	return CalculateTiedSchemePoints(&scheme, eventSize, placement, tied, pointsMultiplier)
+}
+
+// CalculateTiedSchemePoints calculates the points awarded to each of the
+// entries tied at a placement. Tied entries split the payouts of the positions
+// they share, so two entries tied for 2nd each get the average of the 2nd and
+// 3rd place payouts. The average is scaled and rounded the same as a single
+// payout.
+func CalculateTiedSchemePoints(scheme *models.PointsScheme, eventSize int, placement int, tied int, pointsMultiplier float32) int {
+	tied = max(tied, 1)
+
+	payout := 0
+	for i := 0; i < tied; i++ {
+		payout += int(scheme.Payout(placement + i))
+	}
+
+	scaled := float64(payout*eventSize) / float64(tied) / scheme.SizeFactor
+
+	switch scheme.RoundingMode {
+	case models.PointsRoundingFloor:
+		scaled = math.Floor(scaled)
+	case models.PointsRoundingRound:
+		scaled = math.Round(scaled)
+	default:
+		scaled = math.Ceil(scaled)
+	}
+
+	scaled = math.Max(scaled, float64(scheme.MinimumPoints))
+
+	return int(scaled * float64(pointsMultiplier))
 }
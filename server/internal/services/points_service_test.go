@@ -1,6 +1,12 @@
 package services
 
-import "testing"
+import (
+	"api/internal/models"
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+)
 
 func TestCalculatePoints(t *testing.T) {
 	type args struct {
@@ -49,3 +55,98 @@ func TestCalculatePoints(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateSchemePoints(t *testing.T) {
+	scheme := func(roundingMode string, minimumPoints int32) *models.PointsScheme {
+		return &models.PointsScheme{
+			SizeFactor:    20,
+			RoundingMode:  roundingMode,
+			MinimumPoints: minimumPoints,
+			DefaultPayout: 1,
+			Payouts: []models.PointsPayout{
+				{Placement: 1, Points: 10},
+				{Placement: 2, Points: 5},
+			},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		scheme           *models.PointsScheme
+		eventSize        int
+		placement        int
+		pointsMultiplier float32
+		want             int
+	}{
+		{name: "ceil", scheme: scheme(models.PointsRoundingCeil, 0), eventSize: 30, placement: 2, pointsMultiplier: 1.0, want: 8},
+		{name: "floor", scheme: scheme(models.PointsRoundingFloor, 0), eventSize: 30, placement: 2, pointsMultiplier: 1.0, want: 7},
+		{name: "round", scheme: scheme(models.PointsRoundingRound, 0), eventSize: 30, placement: 2, pointsMultiplier: 1.0, want: 8},
+		{name: "beyond payout table", scheme: scheme(models.PointsRoundingFloor, 0), eventSize: 30, placement: 3, pointsMultiplier: 1.0, want: 1},
+		{name: "minimum points", scheme: scheme(models.PointsRoundingFloor, 3), eventSize: 30, placement: 3, pointsMultiplier: 2.0, want: 6},
+		{name: "multiplier", scheme: scheme(models.PointsRoundingCeil, 0), eventSize: 30, placement: 1, pointsMultiplier: 1.5, want: 22},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculateSchemePoints(tt.scheme, tt.eventSize, tt.placement, tt.pointsMultiplier); got != tt.want {
+				t.Errorf("CalculateSchemePoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultPointsSchemeMatchesLegacyPayouts(t *testing.T) {
+	scheme := DefaultPointsScheme(uuid.Nil)
+	for size := 1; size <= 120; size++ {
+		for placement := 1; placement <= size; placement++ {
+			legacyPayout := 1
+			if placement <= len(defaultPayouts) {
+				legacyPayout = int(defaultPayouts[placement-1])
+			}
+			want := int(math.Ceil(float64(legacyPayout*size)/SizeFactor) * 1.5)
+			if got := CalculateSchemePoints(&scheme, size, placement, 1.5); got != want {
+				t.Fatalf("CalculateSchemePoints(%d, %d) = %v, want %v", size, placement, got, want)
+			}
+		}
+	}
+}
+
+func TestCalculateTiedSchemePoints(t *testing.T) {
+	scheme := func(roundingMode string) *models.PointsScheme {
+		return &models.PointsScheme{
+			SizeFactor:    20,
+			RoundingMode:  roundingMode,
+			DefaultPayout: 1,
+			Payouts: []models.PointsPayout{
+				{Placement: 1, Points: 10},
+				{Placement: 2, Points: 5},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		scheme    *models.PointsScheme
+		placement int
+		tied      int
+		want      int
+	}{
+		{name: "not tied", scheme: scheme(models.PointsRoundingCeil), placement: 1, tied: 1, want: 15},
+		{name: "zero ties is not tied", scheme: scheme(models.PointsRoundingCeil), placement: 1, tied: 0, want: 15},
+		{name: "tied for first ceil", scheme: scheme(models.PointsRoundingCeil), placement: 1, tied: 2, want: 12},
+		{name: "tied for first floor", scheme: scheme(models.PointsRoundingFloor), placement: 1, tied: 2, want: 11},
+		{name: "tied past payout table", scheme: scheme(models.PointsRoundingFloor), placement: 2, tied: 2, want: 4},
+		{name: "three way tie", scheme: scheme(models.PointsRoundingCeil), placement: 1, tied: 3, want: 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculateTiedSchemePoints(tt.scheme, 30, tt.placement, tt.tied, 1.0); got != tt.want {
+				t.Errorf("CalculateTiedSchemePoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Two entries tied for 1st of 64 split 32 and 28 points before scaling
+	if got := CalculateTiedPoints(64, 1, 2, 1.0); got != 39 {
+		t.Errorf("CalculateTiedPoints() = %v, want %v", got, 39)
+	}
+}
@@ -0,0 +1,342 @@
+package services
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// rankingExportHeader is the header row of CSV and TSV rankings exports
+var rankingExportHeader = []string{"position", "id", "first_name", "last_name", "points", "attendance", "events_played"}
+
+// rankingExportWriter writes the rows of a rankings export in one format.
+// Close must be called once every row is written.
+type rankingExportWriter interface {
+	Write(row *models.RankingExportRow) error
+	Close() error
+}
+
+// newRankingExportWriter creates a writer for the given export format
+func newRankingExportWriter(w io.Writer, format string) (rankingExportWriter, error) {
+	switch format {
+	case models.RankingExportFormatCSV, "":
+		return newDelimitedRankingWriter(w, ',', false), nil
+	case models.RankingExportFormatTSV:
+		return newDelimitedRankingWriter(w, '\t', true), nil
+	case models.RankingExportFormatJSON:
+		return &jsonRankingWriter{w: bufio.NewWriter(w)}, nil
+	}
+
+	return nil, e.InvalidRequest(fmt.Sprintf("Export format '%s' is not supported, use csv, json or tsv", format))
+}
+
+type delimitedRankingWriter struct {
+	buf    *bufio.Writer
+	writer *csv.Writer
+}
+
+// newDelimitedRankingWriter creates a writer for CSV, or for TSV that
+// spreadsheet programs open directly when excel is set
+func newDelimitedRankingWriter(w io.Writer, comma rune, excel bool) *delimitedRankingWriter {
+	buf := bufio.NewWriter(w)
+	if excel {
+		// Excel only reads the file as UTF-8 when it starts with a byte order mark
+		buf.WriteString("\ufeff")
+	}
+
+	writer := csv.NewWriter(buf)
+	writer.Comma = comma
+	writer.UseCRLF = excel
+	writer.Write(rankingExportHeader)
+
+	return &delimitedRankingWriter{buf: buf, writer: writer}
+}
+
+func (rw *delimitedRankingWriter) Write(row *models.RankingExportRow) error {
+	return rw.writer.Write([]string{
+		strconv.FormatInt(int64(row.Position), 10),
+		strconv.FormatUint(row.ID, 10),
+		row.FirstName,
+		row.LastName,
+		strconv.FormatInt(int64(row.Points), 10),
+		strconv.FormatInt(int64(row.Attendance), 10),
+		strconv.FormatInt(int64(row.EventsPlayed), 10),
+	})
+}
+
+func (rw *delimitedRankingWriter) Close() error {
+	rw.writer.Flush()
+	if err := rw.writer.Error(); err != nil {
+		return err
+	}
+
+	return rw.buf.Flush()
+}
+
+// jsonRankingWriter writes a JSON array one element at a time
+type jsonRankingWriter struct {
+	w    *bufio.Writer
+	rows int
+}
+
+func (rw *jsonRankingWriter) Write(row *models.RankingExportRow) error {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n"
+	if rw.rows == 0 {
+		separator = "[\n"
+	}
+	rw.rows++
+
+	if _, err := rw.w.WriteString(separator); err != nil {
+		return err
+	}
+	_, err = rw.w.Write(data)
+	return err
+}
+
+func (rw *jsonRankingWriter) Close() error {
+	end := "\n]\n"
+	if rw.rows == 0 {
+		end = "[]\n"
+	}
+
+	if _, err := rw.w.WriteString(end); err != nil {
+		return err
+	}
+
+	return rw.w.Flush()
+}
+
+// ExportRankings writes the rankings of a semester to w in the format of the
+// options, ordered by position and then name. Without a snapshot the current
+// rankings are read from the database one row at a time. Snapshots are
+// computed from the placements of the ended events in the snapshot, the same
+// as when rankings are rebuilt. Nothing is written to w if the export fails
+// before its first row.
+func (svc *rankingService) ExportRankings(w io.Writer, semesterID uuid.UUID, opts models.RankingExportOptions) error {
+	if opts.EventID != nil || opts.AsOf != nil {
+		rows, err := svc.rankingSnapshot(semesterID, opts)
+		if err != nil {
+			return err
+		}
+
+		writer, err := newRankingExportWriter(w, opts.Format)
+		if err != nil {
+			return err
+		}
+
+		for i := range rows {
+			if err := writer.Write(&rows[i]); err != nil {
+				return e.InternalServerError(err.Error())
+			}
+		}
+
+		if err := writer.Close(); err != nil {
+			return e.InternalServerError(err.Error())
+		}
+
+		return nil
+	}
+
+	// Count the entries of every membership, split by whether the event ended
+	entriesSubquery := svc.db.
+		Table("participants").
+		Select(
+			"participants.membership_id, COUNT(*) AS attendance, COUNT(*) FILTER (WHERE events.state = ?) AS events_played",
+			models.EventStateEnded,
+		).
+		Joins("INNER JOIN events ON events.id = participants.event_id").
+		Where("events.semester_id = ?", semesterID).
+		Group("participants.membership_id")
+
+	rows, err := svc.db.
+		Table(models.SemesterRankingsView).
+		Select(
+			"position", "user_id AS id", "first_name", "last_name", "points",
+			"COALESCE(entries.attendance, 0) AS attendance", "COALESCE(entries.events_played, 0) AS events_played",
+		).
+		Joins("LEFT JOIN (?) AS entries ON entries.membership_id = "+models.SemesterRankingsView+".membership_id", entriesSubquery).
+		Where(models.SemesterRankingsView+".semester_id = ?", semesterID).
+		Order("position ASC, last_name ASC, first_name ASC").
+		Rows()
+	if err != nil {
+		return e.InternalServerError(fmt.Sprintf("Error when retrieving rankings: %s", err.Error()))
+	}
+	defer rows.Close()
+
+	writer, err := newRankingExportWriter(w, opts.Format)
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		var row models.RankingExportRow
+		if err := svc.db.ScanRows(rows, &row); err != nil {
+			return e.InternalServerError(err.Error())
+		}
+
+		if err := writer.Write(&row); err != nil {
+			return e.InternalServerError(err.Error())
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	if err := writer.Close(); err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+// rankingSnapshot computes the rankings of a semester from the events that
+// started no later than the event or time of the options
+func (svc *rankingService) rankingSnapshot(semesterID uuid.UUID, opts models.RankingExportOptions) ([]models.RankingExportRow, error) {
+	schemeService := NewPointsSchemeService(svc.db)
+	if err := schemeService.ensureSemesterExists(semesterID); err != nil {
+		return nil, err
+	}
+
+	scheme, err := schemeService.resolvePointsScheme(semesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []models.Event{}
+	query := svc.db.Where("semester_id = ?", semesterID).Order("start_date ASC, id ASC")
+	if opts.EventID != nil {
+		event := models.Event{}
+		res := svc.db.Where("id = ? AND semester_id = ?", *opts.EventID, semesterID).First(&event)
+		if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.NotFound(fmt.Sprintf("Event %d not found in this semester", *opts.EventID))
+		} else if err != nil {
+			return nil, e.InternalServerError(err.Error())
+		}
+
+		if event.State != models.EventStateEnded {
+			return nil, e.InvalidRequest(fmt.Sprintf("Event %d has not ended", event.ID))
+		}
+
+		// Events that started at the same time are ordered by ID
+		query = query.Where("(start_date, id) <= (?, ?)", event.StartDate, event.ID)
+	} else {
+		query = query.Where("start_date <= ?", *opts.AsOf)
+	}
+
+	if err := query.Find(&events).Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	ended := make([]models.Event, 0, len(events))
+	eventIDs := make([]int32, 0, len(events))
+	for _, event := range events {
+		eventIDs = append(eventIDs, event.ID)
+		if event.State == models.EventStateEnded {
+			ended = append(ended, event)
+		}
+	}
+
+	points, played, err := tallyEventResults(svc.db, scheme, ended)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attendance also counts the entries of events that have not ended
+	attendance := []struct {
+		MembershipID uuid.UUID
+		Total        int32
+	}{}
+	if len(eventIDs) > 0 {
+		res := svc.db.
+			Table("participants").
+			Select("membership_id, COUNT(*) AS total").
+			Where("event_id IN ? AND membership_id IS NOT NULL", eventIDs).
+			Group("membership_id").
+			Scan(&attendance)
+		if err := res.Error; err != nil {
+			return nil, e.InternalServerError(err.Error())
+		}
+	}
+
+	if len(played) == 0 {
+		return []models.RankingExportRow{}, nil
+	}
+
+	membershipIDs := make([]uuid.UUID, 0, len(played))
+	for membershipID := range played {
+		membershipIDs = append(membershipIDs, membershipID)
+	}
+
+	// Members are ranked once they have played an ended event, the same as a
+	// ranking is created when an event they are in ends
+	members := []struct {
+		MembershipID uuid.UUID
+		UserID       uint64
+		FirstName    string
+		LastName     string
+	}{}
+	res := svc.db.
+		Table("memberships").
+		Select("memberships.id AS membership_id", "users.id AS user_id", "users.first_name", "users.last_name").
+		Joins("INNER JOIN users ON users.id = memberships.user_id").
+		Where("memberships.id IN ?", membershipIDs).
+		Scan(&members)
+	if err := res.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	attendanceByMembership := make(map[uuid.UUID]int32, len(attendance))
+	for _, a := range attendance {
+		attendanceByMembership[a.MembershipID] = a.Total
+	}
+
+	rows := make([]models.RankingExportRow, 0, len(members))
+	for _, member := range members {
+		rows = append(rows, models.RankingExportRow{
+			ID:           member.UserID,
+			FirstName:    member.FirstName,
+			LastName:     member.LastName,
+			Points:       points[member.MembershipID],
+			Attendance:   attendanceByMembership[member.MembershipID],
+			EventsPlayed: played[member.MembershipID],
+		})
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Points != rows[j].Points {
+			return rows[i].Points > rows[j].Points
+		}
+		if rows[i].LastName != rows[j].LastName {
+			return rows[i].LastName < rows[j].LastName
+		}
+		return rows[i].FirstName < rows[j].FirstName
+	})
+
+	// Tied points share a position, and the positions after them are skipped,
+	// the same as RANK() in the rankings view
+	for i := range rows {
+		if i > 0 && rows[i].Points == rows[i-1].Points {
+			rows[i].Position = rows[i-1].Position
+		} else {
+			rows[i].Position = int32(i + 1)
+		}
+	}
+
+	return rows, nil
+}
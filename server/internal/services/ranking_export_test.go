@@ -0,0 +1,177 @@
+package services
+
+import (
+	"api/internal/database"
+	"api/internal/models"
+	"api/internal/testhelpers"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankingExportWriters(t *testing.T) {
+	rows := []models.RankingExportRow{
+		{Position: 1, ID: 20780648, FirstName: "Adam", LastName: "Mahood", Points: 20, Attendance: 3, EventsPlayed: 2},
+		{Position: 2, ID: 36459367, FirstName: "Deep", LastName: "Kalra, Jr.", Points: 10, Attendance: 1, EventsPlayed: 1},
+	}
+
+	write := func(format string, rows []models.RankingExportRow) string {
+		var buf bytes.Buffer
+		writer, err := newRankingExportWriter(&buf, format)
+		require.NoError(t, err)
+		for i := range rows {
+			require.NoError(t, writer.Write(&rows[i]))
+		}
+		require.NoError(t, writer.Close())
+		return buf.String()
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		assert.Equal(t,
+			"position,id,first_name,last_name,points,attendance,events_played\n"+
+				"1,20780648,Adam,Mahood,20,3,2\n"+
+				"2,36459367,Deep,\"Kalra, Jr.\",10,1,1\n",
+			write(models.RankingExportFormatCSV, rows),
+		)
+	})
+
+	t.Run("tsv", func(t *testing.T) {
+		assert.Equal(t,
+			"\ufeffposition\tid\tfirst_name\tlast_name\tpoints\tattendance\tevents_played\r\n"+
+				"1\t20780648\tAdam\tMahood\t20\t3\t2\r\n"+
+				"2\t36459367\tDeep\tKalra, Jr.\t10\t1\t1\r\n",
+			write(models.RankingExportFormatTSV, rows),
+		)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var decoded []models.RankingExportRow
+		require.NoError(t, json.Unmarshal([]byte(write(models.RankingExportFormatJSON, rows)), &decoded))
+		assert.Equal(t, rows, decoded)
+
+		assert.Equal(t, "[]\n", write(models.RankingExportFormatJSON, nil))
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := newRankingExportWriter(&bytes.Buffer{}, "xlsx")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Export format 'xlsx' is not supported")
+	})
+}
+
+func TestRankingService_ExportRankings(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "TEST")
+
+	db, err := database.OpenTestConnection()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer database.WipeDB(db)
+
+	set, err := testhelpers.SetupSemester(db, "Fall 2022")
+	require.NoError(t, err)
+
+	firstStart := time.Date(2022, time.January, 10, 19, 0, 0, 0, time.UTC)
+	first, err := testhelpers.CreateEvent(db, "First Event", set.Semester.ID, firstStart)
+	require.NoError(t, err)
+	second, err := testhelpers.CreateEvent(db, "Second Event", set.Semester.ID, firstStart.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	running, err := testhelpers.CreateEvent(db, "Running Event", set.Semester.ID, firstStart.AddDate(0, 0, 14))
+	require.NoError(t, err)
+
+	// Everyone plays the first event, Adam busts first
+	for i, membership := range set.Memberships {
+		signedOutAt := firstStart.Add(time.Duration(i) * time.Minute)
+		_, err := testhelpers.CreateParticipant(db, membership.ID, first.ID, 0, &signedOutAt)
+		require.NoError(t, err)
+	}
+	require.NoError(t, NewEventService(db).EndEvent(first.ID))
+
+	afterFirst := make(map[uint64]int32)
+	for i, membership := range set.Memberships {
+		var ranking models.Ranking
+		require.NoError(t, db.Where("membership_id = ?", membership.ID).First(&ranking).Error)
+		afterFirst[set.Users[i].ID] = ranking.Points
+	}
+
+	// Only Adam and Deep play the second event, and Jane enters the running one
+	for i, membership := range set.Memberships[:2] {
+		signedOutAt := second.StartDate.Add(time.Duration(2-i) * time.Minute)
+		_, err := testhelpers.CreateParticipant(db, membership.ID, second.ID, 0, &signedOutAt)
+		require.NoError(t, err)
+	}
+	require.NoError(t, NewEventService(db).EndEvent(second.ID))
+	_, err = testhelpers.CreateParticipant(db, set.Memberships[2].ID, running.ID, 0, nil)
+	require.NoError(t, err)
+
+	svc := NewRankingService(db)
+
+	export := func(opts models.RankingExportOptions) [][]string {
+		var buf bytes.Buffer
+		require.NoError(t, svc.ExportRankings(&buf, set.Semester.ID, opts))
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		return records
+	}
+
+	// byID maps the ID column of each exported row to the row
+	byID := func(records [][]string) map[string][]string {
+		rows := make(map[string][]string, len(records)-1)
+		for _, record := range records[1:] {
+			rows[record[1]] = record
+		}
+		return rows
+	}
+
+	t.Run("current rankings", func(t *testing.T) {
+		records := export(models.RankingExportOptions{Format: models.RankingExportFormatCSV})
+		require.Len(t, records, 4)
+		assert.Equal(t, rankingExportHeader, records[0])
+		assert.Equal(t, "1", records[1][0])
+
+		rows := byID(records)
+		assert.Equal(t, []string{"2", "2"}, rows["20780648"][5:])
+		assert.Equal(t, []string{"2", "2"}, rows["36459367"][5:])
+		// Jane's running event counts towards attendance but not events played
+		assert.Equal(t, []string{"2", "1"}, rows["13274944"][5:])
+	})
+
+	t.Run("as of an event", func(t *testing.T) {
+		records := export(models.RankingExportOptions{EventID: &first.ID})
+		require.Len(t, records, 4)
+
+		rows := byID(records)
+		for userID, points := range afterFirst {
+			row := rows[strconv.FormatUint(userID, 10)]
+			assert.Equal(t, []string{strconv.Itoa(int(points)), "1", "1"}, row[4:])
+		}
+	})
+
+	t.Run("as of a date", func(t *testing.T) {
+		asOf := firstStart.AddDate(0, 0, 1)
+		assert.Equal(t, export(models.RankingExportOptions{EventID: &first.ID}), export(models.RankingExportOptions{AsOf: &asOf}))
+
+		before := firstStart.Add(-time.Hour)
+		assert.Equal(t, [][]string{rankingExportHeader}, export(models.RankingExportOptions{AsOf: &before}))
+	})
+
+	t.Run("event that has not ended", func(t *testing.T) {
+		err := svc.ExportRankings(&bytes.Buffer{}, set.Semester.ID, models.RankingExportOptions{EventID: &running.ID})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "has not ended")
+	})
+
+	t.Run("missing semester", func(t *testing.T) {
+		asOf := time.Now()
+		err := svc.ExportRankings(&bytes.Buffer{}, uuid.New(), models.RankingExportOptions{AsOf: &asOf})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Semester not found")
+	})
+}
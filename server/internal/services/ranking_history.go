@@ -0,0 +1,135 @@
+package services
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// recordRankingSnapshot saves the rankings of a semester as a snapshot. It is
+// called in the transaction that changed the rankings so the snapshot includes
+// the changes, and is discarded with them if the transaction rolls back.
+func recordRankingSnapshot(tx *gorm.DB, semesterID uuid.UUID, eventID int32, reason string) error {
+	snapshot := models.RankingSnapshot{
+		SemesterID: semesterID,
+		EventID:    &eventID,
+		Reason:     reason,
+	}
+	if err := tx.Create(&snapshot).Error; err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	res := tx.Exec(
+		`INSERT INTO ranking_snapshot_entries (snapshot_id, membership_id, points, position)
+		SELECT ?, membership_id, COALESCE(points, 0), position FROM `+models.SemesterRankingsView+` WHERE semester_id = ?`,
+		snapshot.ID, semesterID,
+	)
+	if err := res.Error; err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+// GetRankingHistory lists the ranking snapshots of a semester, oldest first.
+// When top is positive only the rankings at or above that position are
+// included in each snapshot.
+func (svc *rankingService) GetRankingHistory(semesterID uuid.UUID, pagination *models.Pagination, top int) ([]models.RankingHistorySnapshot, int64, error) {
+	if err := NewPointsSchemeService(svc.db).ensureSemesterExists(semesterID); err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := svc.db.Model(&models.RankingSnapshot{}).Where("semester_id = ?", semesterID).Count(&total).Error; err != nil {
+		return nil, 0, e.InternalServerError(err.Error())
+	}
+
+	snapshots := []models.RankingHistorySnapshot{}
+	query := svc.db.
+		Table("ranking_snapshots").
+		Select(
+			"ranking_snapshots.id", "ranking_snapshots.event_id", "COALESCE(events.name, '') AS event_name",
+			"ranking_snapshots.reason", "ranking_snapshots.created_at",
+		).
+		Joins("LEFT JOIN events ON events.id = ranking_snapshots.event_id").
+		Where("ranking_snapshots.semester_id = ?", semesterID).
+		Order("ranking_snapshots.created_at ASC, ranking_snapshots.id ASC")
+	if err := pagination.Apply(query).Scan(&snapshots).Error; err != nil {
+		return nil, 0, e.InternalServerError(err.Error())
+	}
+
+	if len(snapshots) == 0 {
+		return snapshots, total, nil
+	}
+
+	snapshotIDs := make([]int64, len(snapshots))
+	bySnapshot := make(map[int64]int, len(snapshots))
+	for i := range snapshots {
+		snapshotIDs[i] = snapshots[i].ID
+		bySnapshot[snapshots[i].ID] = i
+		snapshots[i].Rankings = []models.RankingHistoryEntry{}
+	}
+
+	entries := []struct {
+		SnapshotID int64
+		models.RankingHistoryEntry
+	}{}
+	query = svc.db.
+		Table("ranking_snapshot_entries").
+		Select(
+			"ranking_snapshot_entries.snapshot_id", "ranking_snapshot_entries.position",
+			"ranking_snapshot_entries.membership_id", "users.id AS user_id", "users.first_name",
+			"users.last_name", "ranking_snapshot_entries.points",
+		).
+		Joins("INNER JOIN memberships ON memberships.id = ranking_snapshot_entries.membership_id").
+		Joins("INNER JOIN users ON users.id = memberships.user_id").
+		Where("ranking_snapshot_entries.snapshot_id IN ?", snapshotIDs).
+		Order("ranking_snapshot_entries.position ASC, users.last_name ASC, users.first_name ASC")
+	if top > 0 {
+		query = query.Where("ranking_snapshot_entries.position <= ?", top)
+	}
+	if err := query.Scan(&entries).Error; err != nil {
+		return nil, 0, e.InternalServerError(err.Error())
+	}
+
+	for _, entry := range entries {
+		i := bySnapshot[entry.SnapshotID]
+		snapshots[i].Rankings = append(snapshots[i].Rankings, entry.RankingHistoryEntry)
+	}
+
+	return snapshots, total, nil
+}
+
+// GetMembershipRankingHistory lists the points and position of a membership
+// in every ranking snapshot of its semester it is ranked in, oldest first.
+func (svc *rankingService) GetMembershipRankingHistory(semesterID uuid.UUID, membershipID uuid.UUID) ([]models.RankingHistoryPoint, error) {
+	membership := models.Membership{}
+	res := svc.db.Where("id = ? AND semester_id = ?", membershipID, semesterID).First(&membership)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, e.NotFound("Membership not found")
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	points := []models.RankingHistoryPoint{}
+	res = svc.db.
+		Table("ranking_snapshot_entries").
+		Select(
+			"ranking_snapshots.id AS snapshot_id", "ranking_snapshots.event_id", "COALESCE(events.name, '') AS event_name",
+			"ranking_snapshots.reason", "ranking_snapshots.created_at",
+			"ranking_snapshot_entries.points", "ranking_snapshot_entries.position",
+		).
+		Joins("INNER JOIN ranking_snapshots ON ranking_snapshots.id = ranking_snapshot_entries.snapshot_id").
+		Joins("LEFT JOIN events ON events.id = ranking_snapshots.event_id").
+		Where("ranking_snapshot_entries.membership_id = ? AND ranking_snapshots.semester_id = ?", membershipID, semesterID).
+		Order("ranking_snapshots.created_at ASC, ranking_snapshots.id ASC").
+		Scan(&points)
+	if err := res.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return points, nil
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"api/internal/database"
+	"api/internal/models"
+	"api/internal/testhelpers"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankingService_RankingHistory(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "TEST")
+
+	db, err := database.OpenTestConnection()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer database.WipeDB(db)
+
+	set, err := testhelpers.SetupSemester(db, "Fall 2022")
+	require.NoError(t, err)
+
+	event, err := testhelpers.CreateEvent(db, "History Event", set.Semester.ID, time.Now().UTC())
+	require.NoError(t, err)
+
+	// The last membership to sign out wins
+	baseTime := time.Now().UTC()
+	for i, membership := range set.Memberships {
+		signedOutAt := baseTime.Add(time.Duration(i) * time.Minute)
+		_, err := testhelpers.CreateParticipant(db, membership.ID, event.ID, 0, &signedOutAt)
+		require.NoError(t, err)
+	}
+
+	eventService := NewEventService(db)
+	require.NoError(t, eventService.EndEvent(event.ID))
+	require.NoError(t, eventService.UndoEndEvent(event.ID))
+
+	svc := NewRankingService(db)
+
+	t.Run("semester history", func(t *testing.T) {
+		pagination := models.Pagination{}
+		snapshots, total, err := svc.GetRankingHistory(set.Semester.ID, &pagination, 0)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, total)
+		require.Len(t, snapshots, 2)
+
+		ended := snapshots[0]
+		assert.Equal(t, models.RankingSnapshotReasonEventEnded, ended.Reason)
+		assert.Equal(t, event.ID, *ended.EventID)
+		assert.Equal(t, "History Event", ended.EventName)
+		require.Len(t, ended.Rankings, len(set.Memberships))
+		assert.EqualValues(t, 1, ended.Rankings[0].Position)
+		assert.Equal(t, set.Memberships[2].ID, ended.Rankings[0].MembershipID)
+		assert.Equal(t, set.Users[2].ID, ended.Rankings[0].UserID)
+		assert.Greater(t, ended.Rankings[0].Points, int32(0))
+
+		// Undoing the end takes every point back, so everyone ties for first
+		undone := snapshots[1]
+		assert.Equal(t, models.RankingSnapshotReasonEventEndUndone, undone.Reason)
+		require.Len(t, undone.Rankings, len(set.Memberships))
+		for _, ranking := range undone.Rankings {
+			assert.EqualValues(t, 0, ranking.Points)
+			assert.EqualValues(t, 1, ranking.Position)
+		}
+	})
+
+	t.Run("top positions only", func(t *testing.T) {
+		limit := 1
+		pagination := models.Pagination{Limit: &limit}
+		snapshots, total, err := svc.GetRankingHistory(set.Semester.ID, &pagination, 1)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, total)
+		require.Len(t, snapshots, 1)
+		require.Len(t, snapshots[0].Rankings, 1)
+		assert.Equal(t, set.Memberships[2].ID, snapshots[0].Rankings[0].MembershipID)
+	})
+
+	t.Run("membership history", func(t *testing.T) {
+		history, err := svc.GetMembershipRankingHistory(set.Semester.ID, set.Memberships[0].ID)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, models.RankingSnapshotReasonEventEnded, history[0].Reason)
+		assert.Greater(t, history[0].Position, int32(1))
+		assert.Equal(t, models.RankingSnapshotReasonEventEndUndone, history[1].Reason)
+		assert.EqualValues(t, 0, history[1].Points)
+	})
+
+	t.Run("missing membership", func(t *testing.T) {
+		_, err := svc.GetMembershipRankingHistory(set.Semester.ID, uuid.New())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Membership not found")
+	})
+
+	t.Run("missing semester", func(t *testing.T) {
+		_, _, err := svc.GetRankingHistory(uuid.New(), &models.Pagination{}, 0)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Semester not found")
+	})
+}
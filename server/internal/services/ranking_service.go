@@ -101,3 +101,136 @@ func (svc *rankingService) GetRanking(semesterID uuid.UUID, membershipID uuid.UU
 
 	return &ret, nil
 }
+
+// RebuildRankings recomputes the points and attendance of every membership in a
+// semester from the stored placements of the semester's ended events. When
+// dryRun is set the changes are computed and reported but not saved.
+func (svc *rankingService) RebuildRankings(semesterID uuid.UUID, dryRun bool) (*models.RebuildRankingsResponse, error) {
+	tx := svc.db.Begin()
+	if err := tx.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+	defer tx.Rollback()
+
+	schemeService := NewPointsSchemeService(tx)
+	if err := schemeService.ensureSemesterExists(semesterID); err != nil {
+		return nil, err
+	}
+
+	scheme, err := schemeService.resolvePointsScheme(semesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retrieve the current rankings of every membership in the semester
+	current := []struct {
+		MembershipID uuid.UUID
+		FirstName    string
+		LastName     string
+		Points       int32
+		Attendance   int32
+	}{}
+	res := tx.
+		Table("memberships").
+		Select(
+			"memberships.id AS membership_id", "users.first_name", "users.last_name",
+			"COALESCE(rankings.points, 0) AS points", "COALESCE(rankings.attendance, 0) AS attendance",
+		).
+		Joins("INNER JOIN users ON users.id = memberships.user_id").
+		Joins("LEFT JOIN rankings ON rankings.membership_id = memberships.id").
+		Where("memberships.semester_id = ?", semesterID).
+		Order("users.last_name, users.first_name").
+		Scan(&current)
+	if err := res.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	// Retrieve the entries of every ended event in the semester
+	events := []models.Event{}
+	res = tx.Where("semester_id = ? AND state = ?", semesterID, models.EventStateEnded).Find(&events)
+	if err := res.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	points, attendance, err := tallyEventResults(tx, scheme, events)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compare the recomputed values against the stored rankings
+	diffs := make([]models.RankingDiff, 0)
+	for _, c := range current {
+		diff := models.RankingDiff{
+			MembershipID:       c.MembershipID,
+			FirstName:          c.FirstName,
+			LastName:           c.LastName,
+			PreviousPoints:     c.Points,
+			Points:             points[c.MembershipID],
+			PreviousAttendance: c.Attendance,
+			Attendance:         attendance[c.MembershipID],
+		}
+
+		if diff.Points != diff.PreviousPoints || diff.Attendance != diff.PreviousAttendance {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	ret := models.RebuildRankingsResponse{
+		SemesterID:      semesterID,
+		DryRun:          dryRun,
+		EventsProcessed: len(events),
+		Diffs:           diffs,
+	}
+
+	if dryRun || len(diffs) == 0 {
+		return &ret, nil
+	}
+
+	// Overwrite the changed rankings in a single UPSERT
+	valueStrings := make([]string, 0, len(diffs))
+	args := make([]interface{}, 0, len(diffs)*3)
+	for i, diff := range diffs {
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d::uuid, $%d, $%d)", i*3+1, i*3+2, i*3+3))
+		args = append(args, diff.MembershipID, diff.Points, diff.Attendance)
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO rankings (membership_id, points, attendance) VALUES %s ON CONFLICT (membership_id) DO UPDATE SET points = EXCLUDED.points, attendance = EXCLUDED.attendance`,
+		strings.Join(valueStrings, ", "),
+	)
+	if err := tx.Exec(query, args...).Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &ret, nil
+}
+
+// tallyEventResults adds up the points and the number of entries of every
+// membership in a set of ended events from their stored placements
+func tallyEventResults(db *gorm.DB, scheme *models.PointsScheme, events []models.Event) (map[uuid.UUID]int32, map[uuid.UUID]int32, error) {
+	points := make(map[uuid.UUID]int32)
+	entryCounts := make(map[uuid.UUID]int32)
+	for _, event := range events {
+		entries := []models.Participant{}
+		res := db.Where("event_id = ?", event.ID).Find(&entries)
+		if err := res.Error; err != nil {
+			return nil, nil, e.InternalServerError(err.Error())
+		}
+
+		for _, entry := range entries {
+			if entry.MembershipID != nil {
+				entryCounts[*entry.MembershipID]++
+			}
+		}
+
+		for membershipID, p := range entryPoints(scheme, &event, entries, storedPlacements(entries)) {
+			points[membershipID] += int32(p)
+		}
+	}
+
+	return points, entryCounts, nil
+}
@@ -329,3 +329,88 @@ func TestRankingService_GetRanking_TiedRankings(t *testing.T) {
 	assert.Equal(t, int32(50), ranking3.Points)
 	assert.Equal(t, int32(3), ranking3.Position, "50-point member should be rank 3 (skipping rank 2)")
 }
+
+func TestRankingService_RebuildRankings(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "TEST")
+
+	db, err := database.OpenTestConnection()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer database.WipeDB(db)
+
+	set, err := testhelpers.SetupSemester(db, "Fall 2022")
+	if err != nil {
+		t.Fatalf("Failed to setup test environment: %v", err)
+	}
+
+	event, err := testhelpers.CreateEvent(db, "Rebuild Event", set.Semester.ID, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	baseTime := time.Now().UTC()
+	for i, membership := range set.Memberships {
+		signOutTime := baseTime.Add(time.Duration(i) * time.Minute)
+		if _, err := testhelpers.CreateParticipant(db, membership.ID, event.ID, 0, &signOutTime); err != nil {
+			t.Fatalf("Failed to create participant: %v", err)
+		}
+	}
+
+	if err := NewEventService(db).EndEvent(event.ID); err != nil {
+		t.Fatalf("EndEvent() error = %v", err)
+	}
+
+	// Drift the first membership's ranking away from the event results
+	expected := make(map[string]int32, len(set.Memberships))
+	for _, membership := range set.Memberships {
+		var ranking models.Ranking
+		assert.NoError(t, db.Where("membership_id = ?", membership.ID).First(&ranking).Error)
+		expected[membership.ID.String()] = ranking.Points
+	}
+	assert.NoError(t, db.Model(&models.Ranking{}).
+		Where("membership_id = ?", set.Memberships[0].ID).
+		Update("points", 999).Error)
+
+	svc := NewRankingService(db)
+
+	// A dry run reports every change without saving it
+	result, err := svc.RebuildRankings(set.Semester.ID, true)
+	if !assert.NoError(t, err, "RebuildRankings(dryRun)") {
+		t.FailNow()
+	}
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 1, result.EventsProcessed)
+	assert.Len(t, result.Diffs, len(set.Memberships))
+	for _, diff := range result.Diffs {
+		assert.Equal(t, expected[diff.MembershipID.String()], diff.Points)
+		assert.EqualValues(t, 1, diff.Attendance)
+		assert.EqualValues(t, 0, diff.PreviousAttendance)
+	}
+
+	var drifted models.Ranking
+	assert.NoError(t, db.Where("membership_id = ?", set.Memberships[0].ID).First(&drifted).Error)
+	assert.EqualValues(t, 999, drifted.Points)
+
+	// A real run saves the recomputed rankings
+	result, err = svc.RebuildRankings(set.Semester.ID, false)
+	if !assert.NoError(t, err, "RebuildRankings()") {
+		t.FailNow()
+	}
+	assert.False(t, result.DryRun)
+	assert.Len(t, result.Diffs, len(set.Memberships))
+
+	for _, membership := range set.Memberships {
+		var ranking models.Ranking
+		assert.NoError(t, db.Where("membership_id = ?", membership.ID).First(&ranking).Error)
+		assert.Equal(t, expected[membership.ID.String()], ranking.Points)
+		assert.EqualValues(t, 1, ranking.Attendance)
+	}
+
+	// Rebuilding again is a no-op
+	result, err = svc.RebuildRankings(set.Semester.ID, false)
+	if !assert.NoError(t, err, "RebuildRankings() second run") {
+		t.FailNow()
+	}
+	assert.Empty(t, result.Diffs)
+}
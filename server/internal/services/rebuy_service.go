@@ -0,0 +1,210 @@
+package services
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type rebuyService struct {
+	db *gorm.DB
+}
+
+func NewRebuyService(db *gorm.DB) *rebuyService {
+	return &rebuyService{
+		db: db,
+	}
+}
+
+// ListRebuys returns the rebuys and add-ons of a player in an event, oldest
+// first.
+func (svc *rebuyService) ListRebuys(semesterID uuid.UUID, eventID int32, membershipID uuid.UUID) ([]models.Rebuy, error) {
+	if _, err := findSeatingEvent(svc.db, semesterID, eventID, false); err != nil {
+		return nil, err
+	}
+
+	participant, err := findRebuyParticipant(svc.db, eventID, membershipID)
+	if err != nil {
+		return nil, err
+	}
+
+	rebuys := []models.Rebuy{}
+	res := svc.db.Where("participant_id = ?", participant.ID).Order("created_at ASC, id ASC").Find(&rebuys)
+	if err := res.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return rebuys, nil
+}
+
+// CreateRebuy records a rebuy or add-on for a player and adds its fee to the
+// semester budget. Rebuys count towards the event's max rebuys, add-ons do
+// not.
+func (svc *rebuyService) CreateRebuy(semesterID uuid.UUID, eventID int32, membershipID uuid.UUID, rebuyType string) (*models.Rebuy, error) {
+	if rebuyType == "" {
+		rebuyType = models.RebuyTypeRebuy
+	}
+
+	rebuy := models.Rebuy{Type: rebuyType}
+	err := svc.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the event so that concurrent rebuys cannot exceed the limit
+		event, err := findSeatingEvent(tx, semesterID, eventID, true)
+		if err != nil {
+			return err
+		}
+
+		if event.State == models.EventStateEnded {
+			return e.Forbidden("Modification of a completed event is forbidden")
+		}
+
+		participant, err := findRebuyParticipant(tx, eventID, membershipID)
+		if err != nil {
+			return err
+		}
+
+		if rebuyType == models.RebuyTypeRebuy && event.MaxRebuys != nil && participant.Rebuys >= *event.MaxRebuys {
+			return e.Forbidden(fmt.Sprintf("This event allows at most %d rebuys per player", *event.MaxRebuys))
+		}
+
+		semester := models.Semester{}
+		if err := tx.Where("id = ?", event.SemesterID).First(&semester).Error; err != nil {
+			return err
+		}
+
+		rebuy.ParticipantID = participant.ID
+		rebuy.Amount = float32(semester.RebuyFee)
+		if rebuyType == models.RebuyTypeAddOn {
+			rebuy.Amount = float32(semester.AddOnFee)
+		}
+
+		if err := tx.Create(&rebuy).Error; err != nil {
+			return err
+		}
+
+		if err := countRebuy(tx, event.ID, participant.ID, rebuyType, 1); err != nil {
+			return err
+		}
+
+		return recordRebuyFee(tx, event, &rebuy, 1)
+	})
+	if err != nil {
+		var apiErr e.APIErrorResponse
+		if errors.As(err, &apiErr) {
+			return nil, apiErr
+		}
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &rebuy, nil
+}
+
+// UndoRebuy removes a rebuy or add-on recorded by mistake and takes its fee
+// back out of the semester budget.
+func (svc *rebuyService) UndoRebuy(semesterID uuid.UUID, eventID int32, membershipID uuid.UUID, rebuyID int32) error {
+	err := svc.db.Transaction(func(tx *gorm.DB) error {
+		event, err := findSeatingEvent(tx, semesterID, eventID, true)
+		if err != nil {
+			return err
+		}
+
+		if event.State == models.EventStateEnded {
+			return e.Forbidden("Modification of a completed event is forbidden")
+		}
+
+		participant, err := findRebuyParticipant(tx, eventID, membershipID)
+		if err != nil {
+			return err
+		}
+
+		rebuy := models.Rebuy{}
+		res := tx.Where("id = ? AND participant_id = ?", rebuyID, participant.ID).First(&rebuy)
+		if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return e.NotFound("Rebuy not found")
+		} else if err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&rebuy).Error; err != nil {
+			return err
+		}
+
+		if err := countRebuy(tx, event.ID, participant.ID, rebuy.Type, -1); err != nil {
+			return err
+		}
+
+		return recordRebuyFee(tx, event, &rebuy, -1)
+	})
+
+	var apiErr e.APIErrorResponse
+	if errors.As(err, &apiErr) {
+		return apiErr
+	} else if err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+// findRebuyParticipant finds the entry of a member in an event.
+func findRebuyParticipant(tx *gorm.DB, eventID int32, membershipID uuid.UUID) (*models.Participant, error) {
+	participant := models.Participant{}
+	res := tx.Where("event_id = ? AND membership_id = ?", eventID, membershipID).First(&participant)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, e.NotFound("Entry not found")
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &participant, nil
+}
+
+// recordRebuyFee records the fee of a rebuy or add-on in the ledger of the
+// semester, or takes it back out when sign is -1.
+func recordRebuyFee(tx *gorm.DB, event *models.Event, rebuy *models.Rebuy, sign int64) error {
+	entry := models.LedgerEntry{
+		SemesterID:  event.SemesterID,
+		Type:        models.LedgerEntryTypeRebuy,
+		AmountCents: sign * models.DollarsToCents(rebuy.Amount),
+		Description: "Rebuy",
+		EventID:     &event.ID,
+	}
+	if rebuy.Type == models.RebuyTypeAddOn {
+		entry.Type = models.LedgerEntryTypeAddOn
+		entry.Description = "Add-on"
+	}
+	if sign < 0 {
+		entry.Description = "Undo " + strings.ToLower(entry.Description)
+	}
+
+	return recordLedgerEntry(tx, &entry)
+}
+
+// countRebuy adds delta to the rebuy or add-on count of a player. Rebuys are
+// also counted in the event-wide total, alongside rebuys recorded without a
+// player.
+func countRebuy(tx *gorm.DB, eventID int32, participantID int32, rebuyType string, delta int) error {
+	column := "rebuys"
+	if rebuyType == models.RebuyTypeAddOn {
+		column = "add_ons"
+	}
+
+	res := tx.Model(&models.Participant{}).
+		Where("id = ?", participantID).
+		Update(column, gorm.Expr(column+" + ?", delta))
+	if err := res.Error; err != nil {
+		return err
+	}
+
+	if rebuyType != models.RebuyTypeRebuy {
+		return nil
+	}
+
+	return tx.Model(&models.Event{}).
+		Where("id = ?", eventID).
+		Update("rebuys", gorm.Expr("rebuys + ?", delta)).Error
+}
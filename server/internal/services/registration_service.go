@@ -0,0 +1,414 @@
+package services
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type registrationService struct {
+	db *gorm.DB
+}
+
+func NewRegistrationService(db *gorm.DB) *registrationService {
+	return &registrationService{
+		db: db,
+	}
+}
+
+// takenSpots counts the entries of an event and the registrations holding a
+// spot in it. The registration of excludeMembershipID is not counted, so that
+// members holding a spot can always take it.
+func takenSpots(tx *gorm.DB, eventID int32, excludeMembershipID *uuid.UUID) (int64, error) {
+	var entries int64
+	res := tx.Model(&models.Participant{}).Where("event_id = ?", eventID).Count(&entries)
+	if res.Error != nil {
+		return 0, res.Error
+	}
+
+	var registered int64
+	query := tx.Model(&models.Registration{}).
+		Where("event_id = ? AND status = ?", eventID, models.RegistrationStatusRegistered)
+	if excludeMembershipID != nil {
+		query = query.Where("membership_id <> ?", *excludeMembershipID)
+	}
+	if res := query.Count(&registered); res.Error != nil {
+		return 0, res.Error
+	}
+
+	return entries + registered, nil
+}
+
+// hasRoom reports whether an event has a spot for another player, or for the
+// member of membershipID when they are registered.
+func hasRoom(tx *gorm.DB, event *models.Event, membershipID *uuid.UUID) (bool, error) {
+	if event.MaxEntries == nil {
+		return true, nil
+	}
+
+	taken, err := takenSpots(tx, event.ID, membershipID)
+	if err != nil {
+		return false, err
+	}
+
+	return taken < int64(*event.MaxEntries), nil
+}
+
+// admitParticipant enters a player into an event which has room for them and
+// draws their seat. A registration of the member is used up by the entry.
+func admitParticipant(tx *gorm.DB, event *models.Event, participant *models.Participant) error {
+	if err := tx.Create(participant).Error; err != nil {
+		return err
+	}
+
+	if participant.MembershipID != nil {
+		res := tx.Where("event_id = ? AND membership_id = ?", event.ID, *participant.MembershipID).
+			Delete(&models.Registration{})
+		if res.Error != nil {
+			return res.Error
+		}
+	}
+
+	return seatParticipant(tx, event, participant)
+}
+
+// promoteWaitlist gives the spots left in an event to its waitlist, oldest
+// registration first.
+func promoteWaitlist(tx *gorm.DB, event *models.Event) error {
+	query := tx.Model(&models.Registration{}).
+		Where("event_id = ? AND status = ?", event.ID, models.RegistrationStatusWaitlisted).
+		Order("created_at ASC, id ASC")
+
+	if event.MaxEntries != nil {
+		taken, err := takenSpots(tx, event.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		free := int64(*event.MaxEntries) - taken
+		if free <= 0 {
+			return nil
+		}
+		query = query.Limit(int(free))
+	}
+
+	var promoted []int32
+	if res := query.Pluck("id", &promoted); res.Error != nil {
+		return res.Error
+	}
+	if len(promoted) == 0 {
+		return nil
+	}
+
+	return tx.Model(&models.Registration{}).
+		Where("id IN ?", promoted).
+		Update("status", models.RegistrationStatusRegistered).
+		Error
+}
+
+// waitlistPosition returns the place of a waitlisted registration in the
+// waitlist of its event, starting at 1.
+func waitlistPosition(tx *gorm.DB, registration *models.Registration) (int, error) {
+	var ahead int64
+	res := tx.Model(&models.Registration{}).
+		Where("event_id = ? AND status = ?", registration.EventID, models.RegistrationStatusWaitlisted).
+		Where("created_at < ? OR (created_at = ? AND id < ?)", registration.CreatedAt, registration.CreatedAt, registration.ID).
+		Count(&ahead)
+	if res.Error != nil {
+		return 0, res.Error
+	}
+
+	return int(ahead) + 1, nil
+}
+
+// lockSemesterEvent locks an event of a semester for seating, so that spots
+// are given out one at a time.
+func lockSemesterEvent(tx *gorm.DB, semesterID uuid.UUID, eventID int32) (*models.Event, error) {
+	event, err := lockEventForSeating(tx, eventID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && event.SemesterID != semesterID) {
+		return nil, e.NotFound("Event not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// registerMembership registers a membership of the semester of an event,
+// holding a spot when the event has room and waitlisting it otherwise.
+func registerMembership(tx *gorm.DB, event *models.Event, membershipID uuid.UUID) (*models.Registration, error) {
+	membership := models.Membership{}
+	res := tx.Where("id = ?", membershipID).First(&membership)
+	if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return nil, e.NotFound(fmt.Sprintf("Membership %s not found", membershipID))
+	} else if res.Error != nil {
+		return nil, res.Error
+	}
+	if membership.SemesterID != event.SemesterID {
+		return nil, e.InvalidRequest(fmt.Sprintf("Membership %s is not in the semester of the event", membershipID))
+	}
+
+	room, err := hasRoom(tx, event, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	registration := models.Registration{
+		EventID:      event.ID,
+		MembershipID: membershipID,
+		Status:       models.RegistrationStatusRegistered,
+	}
+	if !room {
+		registration.Status = models.RegistrationStatusWaitlisted
+	}
+
+	if err := tx.Create(&registration).Error; err != nil {
+		return nil, err
+	}
+
+	if registration.Status == models.RegistrationStatusWaitlisted {
+		registration.Position, err = waitlistPosition(tx, &registration)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &registration, nil
+}
+
+// ListRegistrations returns the registrations of an event. Registrations
+// holding a spot come first, followed by the waitlist in order.
+func (svc *registrationService) ListRegistrations(semesterID uuid.UUID, eventID int32) ([]models.Registration, error) {
+	event := models.Event{}
+	res := svc.db.Where("id = ? AND semester_id = ?", eventID, semesterID).First(&event)
+	if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return nil, e.NotFound("Event not found")
+	} else if res.Error != nil {
+		return nil, e.InternalServerError(res.Error.Error())
+	}
+
+	registrations := []models.Registration{}
+	res = svc.db.
+		Preload("Membership").
+		Preload("Membership.User").
+		Where("event_id = ?", eventID).
+		Order("status ASC, created_at ASC, id ASC").
+		Find(&registrations)
+	if res.Error != nil {
+		return nil, e.InternalServerError(res.Error.Error())
+	}
+
+	position := 0
+	for i := range registrations {
+		if registrations[i].Status == models.RegistrationStatusWaitlisted {
+			position++
+			registrations[i].Position = position
+		}
+	}
+
+	return registrations, nil
+}
+
+// Register registers a membership for an event before it starts. Once the
+// event is full, the membership is added to the end of the waitlist.
+func (svc *registrationService) Register(semesterID uuid.UUID, eventID int32, req *models.CreateRegistrationRequest) (*models.Registration, error) {
+	var registration *models.Registration
+	err := svc.db.Transaction(func(tx *gorm.DB) error {
+		event, err := lockSemesterEvent(tx, semesterID, eventID)
+		if err != nil {
+			return err
+		}
+
+		if event.State == models.EventStateEnded {
+			return e.Forbidden("Registration is closed because the event has ended")
+		}
+		if !time.Now().Before(event.StartDate) {
+			return e.Forbidden("Registration is closed because the event has started")
+		}
+
+		var existing int64
+		res := tx.Model(&models.Participant{}).
+			Where("membership_id = ? AND event_id = ?", req.MembershipID, eventID).
+			Count(&existing)
+		if res.Error != nil {
+			return res.Error
+		}
+		if existing > 0 {
+			return e.InvalidRequest(fmt.Sprintf("Membership %s already has an entry in this event", req.MembershipID))
+		}
+
+		res = tx.Model(&models.Registration{}).
+			Where("membership_id = ? AND event_id = ?", req.MembershipID, eventID).
+			Count(&existing)
+		if res.Error != nil {
+			return res.Error
+		}
+		if existing > 0 {
+			return e.InvalidRequest(fmt.Sprintf("Membership %s is already registered for this event", req.MembershipID))
+		}
+
+		registration, err = registerMembership(tx, event, req.MembershipID)
+		return err
+	})
+
+	var apiErr e.APIErrorResponse
+	if errors.As(err, &apiErr) {
+		return nil, apiErr
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return registration, nil
+}
+
+// Withdraw removes a registration from an event. A spot it held is given to
+// the waitlist.
+func (svc *registrationService) Withdraw(semesterID uuid.UUID, eventID int32, registrationID int32) error {
+	err := svc.db.Transaction(func(tx *gorm.DB) error {
+		event, err := lockSemesterEvent(tx, semesterID, eventID)
+		if err != nil {
+			return err
+		}
+
+		res := tx.Where("id = ? AND event_id = ?", registrationID, eventID).Delete(&models.Registration{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return e.NotFound("Registration not found")
+		}
+
+		return promoteWaitlist(tx, event)
+	})
+
+	var apiErr e.APIErrorResponse
+	if errors.As(err, &apiErr) {
+		return apiErr
+	} else if err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+// ConvertRegistrations enters every registration holding a spot into its
+// event and draws their seats. Waitlisted registrations are kept.
+func (svc *registrationService) ConvertRegistrations(semesterID uuid.UUID, eventID int32) ([]models.Participant, error) {
+	participants := []models.Participant{}
+	err := svc.db.Transaction(func(tx *gorm.DB) error {
+		event, err := lockSemesterEvent(tx, semesterID, eventID)
+		if err != nil {
+			return err
+		}
+
+		if event.State == models.EventStateEnded {
+			return e.Forbidden("Modification of a completed event is forbidden")
+		}
+
+		registrations := []models.Registration{}
+		res := tx.
+			Where("event_id = ? AND status = ?", eventID, models.RegistrationStatusRegistered).
+			Order("created_at ASC, id ASC").
+			Find(&registrations)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		for _, registration := range registrations {
+			participant := models.Participant{
+				MembershipID: &registration.MembershipID,
+				EventID:      eventID,
+			}
+			if err := admitParticipant(tx, event, &participant); err != nil {
+				return err
+			}
+			participants = append(participants, participant)
+		}
+
+		return nil
+	})
+
+	var apiErr e.APIErrorResponse
+	if errors.As(err, &apiErr) {
+		return nil, apiErr
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return participants, nil
+}
+
+// EnterOrWaitlist enters a member into an event, or adds them to the end of
+// its waitlist when the event is full. Exactly one of the entry and the
+// registration is returned. Members who are already waitlisted keep their
+// place.
+func (svc *registrationService) EnterOrWaitlist(eventID int32, membershipID uuid.UUID) (*models.Participant, *models.Registration, error) {
+	var participant *models.Participant
+	var registration *models.Registration
+	err := svc.db.Transaction(func(tx *gorm.DB) error {
+		event, err := lockEventForSeating(tx, eventID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return e.NotFound("Event not found")
+		} else if err != nil {
+			return err
+		}
+
+		if event.State == models.EventStateEnded {
+			return e.Forbidden("Modification of a completed event is forbidden")
+		}
+
+		var entered int64
+		res := tx.Model(&models.Participant{}).
+			Where("membership_id = ? AND event_id = ?", membershipID, eventID).
+			Count(&entered)
+		if res.Error != nil {
+			return res.Error
+		}
+		if entered > 0 {
+			return e.InvalidRequest(fmt.Sprintf("Membership %s already has an entry in this event", membershipID))
+		}
+
+		room, err := hasRoom(tx, event, &membershipID)
+		if err != nil {
+			return err
+		}
+
+		if room {
+			participant = &models.Participant{
+				MembershipID: &membershipID,
+				EventID:      eventID,
+			}
+			return admitParticipant(tx, event, participant)
+		}
+
+		existing := models.Registration{}
+		res = tx.Where("event_id = ? AND membership_id = ?", eventID, membershipID).Limit(1).Find(&existing)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			registration = &existing
+			if registration.Status == models.RegistrationStatusWaitlisted {
+				registration.Position, err = waitlistPosition(tx, registration)
+			}
+			return err
+		}
+
+		registration, err = registerMembership(tx, event, membershipID)
+		return err
+	})
+
+	var apiErr e.APIErrorResponse
+	if errors.As(err, &apiErr) {
+		return nil, nil, apiErr
+	} else if err != nil {
+		return nil, nil, e.InternalServerError(err.Error())
+	}
+
+	return participant, registration, nil
+}
@@ -0,0 +1,430 @@
+package services
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type seatingService struct {
+	db *gorm.DB
+}
+
+func NewSeatingService(db *gorm.DB) *seatingService {
+	return &seatingService{
+		db: db,
+	}
+}
+
+// GetSeating returns the tables of an event with the players seated at them.
+func (svc *seatingService) GetSeating(semesterID uuid.UUID, eventID int32) (*models.SeatingResponse, error) {
+	event, err := findSeatingEvent(svc.db, semesterID, eventID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var seated []models.Participant
+	res := models.Participant{}.Preload(svc.db).
+		Where("event_id = ? AND signed_out_at IS NULL AND table_number IS NOT NULL", eventID).
+		Order("table_number ASC, seat_number ASC").
+		Find(&seated)
+	if err := res.Error; err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	response := models.SeatingResponse{
+		TableSize: event.TableSize,
+		Tables:    []models.SeatingTable{},
+	}
+	for _, participant := range seated {
+		last := len(response.Tables) - 1
+		if last < 0 || response.Tables[last].Number != *participant.TableNumber {
+			response.Tables = append(response.Tables, models.SeatingTable{Number: *participant.TableNumber})
+			last++
+		}
+		response.Tables[last].Seats = append(response.Tables[last].Seats, models.SeatingSeat{
+			Seat:        *participant.SeatNumber,
+			Participant: participant,
+		})
+	}
+
+	return &response, nil
+}
+
+// GetTableBreakPlan returns how the players of a table would be moved if it was
+// broken. When table is nil, the table with the fewest players is used.
+func (svc *seatingService) GetTableBreakPlan(semesterID uuid.UUID, eventID int32, table *int32) (*models.TableBreakPlan, error) {
+	event, err := findSeatingEvent(svc.db, semesterID, eventID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	seated, err := seatedParticipants(svc.db, event.ID)
+	if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return planTableBreak(seated, tableSize(event), table)
+}
+
+// BreakTable moves the players of a table to open seats at the other tables,
+// following the plan returned by GetTableBreakPlan.
+func (svc *seatingService) BreakTable(semesterID uuid.UUID, eventID int32, table *int32) (*models.TableBreakPlan, error) {
+	var plan *models.TableBreakPlan
+
+	err := svc.db.Transaction(func(tx *gorm.DB) error {
+		event, err := findSeatingEvent(tx, semesterID, eventID, true)
+		if err != nil {
+			return err
+		}
+
+		if event.State == models.EventStateEnded {
+			return e.Forbidden("Modification of a completed event is forbidden")
+		}
+
+		seated, err := seatedParticipants(tx, event.ID)
+		if err != nil {
+			return e.InternalServerError(err.Error())
+		}
+
+		plan, err = planTableBreak(seated, tableSize(event), table)
+		if err != nil {
+			return err
+		}
+
+		return applySeatMoves(tx, plan.Moves)
+	})
+	if err != nil {
+		var apiErr e.APIErrorResponse
+		if errors.As(err, &apiErr) {
+			return nil, apiErr
+		}
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return plan, nil
+}
+
+// findSeatingEvent finds an event of a semester, optionally locking it so that
+// seats are drawn, and rebuys counted, one at a time.
+func findSeatingEvent(tx *gorm.DB, semesterID uuid.UUID, eventID int32, lock bool) (*models.Event, error) {
+	query := tx
+	if lock {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	event := models.Event{}
+	res := query.Where("id = ? AND semester_id = ?", eventID, semesterID).First(&event)
+	if err := res.Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, e.NotFound("Event not found")
+	} else if err != nil {
+		return nil, e.InternalServerError(err.Error())
+	}
+
+	return &event, nil
+}
+
+// lockEventForSeating locks an event, so that seats are drawn one at a time.
+func lockEventForSeating(tx *gorm.DB, eventID int32) (*models.Event, error) {
+	event := models.Event{}
+	res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", eventID).First(&event)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// seatedParticipants returns the players of an event that are still in and
+// have a seat.
+func seatedParticipants(tx *gorm.DB, eventID int32) ([]models.Participant, error) {
+	var seated []models.Participant
+	res := tx.
+		Where("event_id = ? AND signed_out_at IS NULL AND table_number IS NOT NULL", eventID).
+		Order("table_number ASC, seat_number ASC").
+		Find(&seated)
+
+	return seated, res.Error
+}
+
+// seatParticipant draws a seat for a player that is not seated.
+func seatParticipant(tx *gorm.DB, event *models.Event, participant *models.Participant) error {
+	seated, err := seatedParticipants(tx, event.ID)
+	if err != nil {
+		return err
+	}
+
+	table, seat := drawSeat(seated, tableSize(event))
+	participant.TableNumber = &table
+	participant.SeatNumber = &seat
+
+	return tx.Model(participant).Updates(map[string]any{
+		"table_number": table,
+		"seat_number":  seat,
+	}).Error
+}
+
+// unseatParticipant frees the seat of a player and rebalances the tables.
+func unseatParticipant(tx *gorm.DB, event *models.Event, participant *models.Participant) ([]models.SeatMove, error) {
+	participant.TableNumber = nil
+	participant.SeatNumber = nil
+
+	err := tx.Model(participant).Updates(map[string]any{
+		"table_number": nil,
+		"seat_number":  nil,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rebalanceTables(tx, event)
+}
+
+// rebalanceTables moves players from the fullest tables to the shortest until
+// no table has more than one player more than another.
+func rebalanceTables(tx *gorm.DB, event *models.Event) ([]models.SeatMove, error) {
+	seated, err := seatedParticipants(tx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	moves := planRebalance(seated, tableSize(event))
+	if err := applySeatMoves(tx, moves); err != nil {
+		return nil, err
+	}
+
+	return moves, nil
+}
+
+func applySeatMoves(tx *gorm.DB, moves []models.SeatMove) error {
+	for _, move := range moves {
+		err := tx.Model(&models.Participant{}).
+			Where("id = ?", move.ParticipantID).
+			Updates(map[string]any{
+				"table_number": move.ToTable,
+				"seat_number":  move.ToSeat,
+			}).
+			Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func tableSize(event *models.Event) int32 {
+	if event.TableSize == 0 {
+		return models.DefaultTableSize
+	}
+	return int32(event.TableSize)
+}
+
+// seatingTables maps each table number to the players at the table, by seat.
+type seatingTables map[int32]map[int32]models.Participant
+
+func newSeatingTables(seated []models.Participant) seatingTables {
+	tables := make(seatingTables)
+	for _, participant := range seated {
+		if participant.TableNumber == nil || participant.SeatNumber == nil {
+			continue
+		}
+		if tables[*participant.TableNumber] == nil {
+			tables[*participant.TableNumber] = make(map[int32]models.Participant)
+		}
+		tables[*participant.TableNumber][*participant.SeatNumber] = participant
+	}
+	return tables
+}
+
+// numbers returns the table numbers in ascending order.
+func (t seatingTables) numbers() []int32 {
+	numbers := make([]int32, 0, len(t))
+	for number := range t {
+		numbers = append(numbers, number)
+	}
+	slices.Sort(numbers)
+	return numbers
+}
+
+// openSeats returns the empty seats of a table in ascending order.
+func (t seatingTables) openSeats(table int32, size int32) []int32 {
+	open := []int32{}
+	for seat := int32(1); seat <= size; seat++ {
+		if _, taken := t[table][seat]; !taken {
+			open = append(open, seat)
+		}
+	}
+	return open
+}
+
+func (t seatingTables) move(participant models.Participant, toTable int32, toSeat int32) models.SeatMove {
+	move := models.SeatMove{
+		ParticipantID: participant.ID,
+		MembershipID:  participant.MembershipID,
+		FromTable:     *participant.TableNumber,
+		FromSeat:      *participant.SeatNumber,
+		ToTable:       toTable,
+		ToSeat:        toSeat,
+	}
+
+	delete(t[move.FromTable], move.FromSeat)
+	if len(t[move.FromTable]) == 0 {
+		delete(t, move.FromTable)
+	}
+
+	participant.TableNumber = &move.ToTable
+	participant.SeatNumber = &move.ToSeat
+	if t[toTable] == nil {
+		t[toTable] = make(map[int32]models.Participant)
+	}
+	t[toTable][toSeat] = participant
+
+	return move
+}
+
+// drawSeat picks a random open seat at one of the tables with the fewest
+// players. A new table is opened when every table is full.
+func drawSeat(seated []models.Participant, size int32) (int32, int32) {
+	tables := newSeatingTables(seated)
+
+	type seat struct{ table, seat int32 }
+	var open []seat
+	fewest := size
+	for _, number := range tables.numbers() {
+		players := int32(len(tables[number]))
+		if players >= size || players > fewest {
+			continue
+		}
+		if players < fewest {
+			fewest = players
+			open = nil
+		}
+		for _, s := range tables.openSeats(number, size) {
+			open = append(open, seat{table: number, seat: s})
+		}
+	}
+
+	if len(open) == 0 {
+		// Open the lowest numbered table that is not in use
+		table := int32(1)
+		for tables[table] != nil {
+			table++
+		}
+		return table, int32(rand.IntN(int(size))) + 1
+	}
+
+	picked := open[rand.IntN(len(open))]
+	return picked.table, picked.seat
+}
+
+// planRebalance moves random players from the fullest table to random open
+// seats at the shortest table, until the tables differ by at most one player.
+func planRebalance(seated []models.Participant, size int32) []models.SeatMove {
+	tables := newSeatingTables(seated)
+	moves := []models.SeatMove{}
+
+	for len(tables) > 1 {
+		numbers := tables.numbers()
+		fullest, shortest := numbers[0], numbers[0]
+		for _, number := range numbers[1:] {
+			if len(tables[number]) > len(tables[fullest]) {
+				fullest = number
+			}
+			if len(tables[number]) < len(tables[shortest]) {
+				shortest = number
+			}
+		}
+
+		if len(tables[fullest])-len(tables[shortest]) <= 1 {
+			break
+		}
+
+		open := tables.openSeats(shortest, size)
+		if len(open) == 0 {
+			break
+		}
+
+		seats := make([]int32, 0, len(tables[fullest]))
+		for s := range tables[fullest] {
+			seats = append(seats, s)
+		}
+		slices.Sort(seats)
+
+		participant := tables[fullest][seats[rand.IntN(len(seats))]]
+		moves = append(moves, tables.move(participant, shortest, open[rand.IntN(len(open))]))
+	}
+
+	return moves
+}
+
+// planTableBreak moves the players of a table, in seat order, to the lowest
+// open seat at whichever other table has the fewest players. When table is
+// nil, the table with the fewest players is broken, preferring the highest
+// numbered table.
+func planTableBreak(seated []models.Participant, size int32, table *int32) (*models.TableBreakPlan, error) {
+	tables := newSeatingTables(seated)
+	if len(tables) < 2 {
+		return nil, e.InvalidRequest("There are fewer than two tables, so no table can be broken")
+	}
+
+	numbers := tables.numbers()
+	var broken int32
+	if table != nil {
+		if tables[*table] == nil {
+			return nil, e.NotFound(fmt.Sprintf("Table %d has no players", *table))
+		}
+		broken = *table
+	} else {
+		broken = numbers[0]
+		for _, number := range numbers[1:] {
+			if len(tables[number]) <= len(tables[broken]) {
+				broken = number
+			}
+		}
+	}
+
+	openSeats := 0
+	for _, number := range numbers {
+		if number != broken {
+			openSeats += len(tables.openSeats(number, size))
+		}
+	}
+	if openSeats < len(tables[broken]) {
+		return nil, e.InvalidRequest(fmt.Sprintf("The other tables do not have enough open seats for the players at table %d", broken))
+	}
+
+	plan := models.TableBreakPlan{
+		Table: broken,
+		Moves: []models.SeatMove{},
+	}
+
+	seats := make([]int32, 0, len(tables[broken]))
+	for s := range tables[broken] {
+		seats = append(seats, s)
+	}
+	slices.Sort(seats)
+
+	for _, s := range seats {
+		var target int32
+		for _, number := range numbers {
+			if number == broken || len(tables.openSeats(number, size)) == 0 {
+				continue
+			}
+			if target == 0 || len(tables[number]) < len(tables[target]) {
+				target = number
+			}
+		}
+
+		plan.Moves = append(plan.Moves, tables.move(tables[broken][s], target, tables.openSeats(target, size)[0]))
+	}
+
+	return &plan, nil
+}
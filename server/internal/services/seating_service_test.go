@@ -0,0 +1,173 @@
+package services
+
+import (
+	"api/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seatedAt builds seated participants from a list of {table, seat} pairs. The
+// participant IDs are assigned in order, starting at 1.
+func seatedAt(seats ...[2]int32) []models.Participant {
+	participants := make([]models.Participant, len(seats))
+	for i, s := range seats {
+		table, seat := s[0], s[1]
+		participants[i] = models.Participant{ID: int32(i + 1), TableNumber: &table, SeatNumber: &seat}
+	}
+	return participants
+}
+
+// tableCounts returns the number of players at each table.
+func tableCounts(participants []models.Participant) map[int32]int {
+	counts := make(map[int32]int)
+	for _, p := range participants {
+		counts[*p.TableNumber]++
+	}
+	return counts
+}
+
+func TestDrawSeat(t *testing.T) {
+	t.Run("fills tables evenly with unique seats", func(t *testing.T) {
+		var seated []models.Participant
+		for i := range 20 {
+			table, seat := drawSeat(seated, 9)
+			seated = append(seated, models.Participant{ID: int32(i + 1), TableNumber: &table, SeatNumber: &seat})
+		}
+
+		taken := make(map[[2]int32]bool)
+		for _, p := range seated {
+			key := [2]int32{*p.TableNumber, *p.SeatNumber}
+			require.False(t, taken[key], "seat %v was drawn twice", key)
+			taken[key] = true
+			assert.GreaterOrEqual(t, *p.SeatNumber, int32(1))
+			assert.LessOrEqual(t, *p.SeatNumber, int32(9))
+		}
+
+		// 20 players need three tables of nine
+		assert.Equal(t, map[int32]int{1: 9, 2: 9, 3: 2}, tableCounts(seated))
+	})
+
+	t.Run("seats at the shortest table", func(t *testing.T) {
+		seated := seatedAt([2]int32{1, 1}, [2]int32{1, 2}, [2]int32{1, 3}, [2]int32{2, 4})
+		for range 10 {
+			table, seat := drawSeat(seated, 6)
+			assert.Equal(t, int32(2), table)
+			assert.NotEqual(t, int32(4), seat)
+		}
+	})
+
+	t.Run("reopens the lowest free table number", func(t *testing.T) {
+		seated := seatedAt([2]int32{2, 1}, [2]int32{2, 2})
+		table, _ := drawSeat(seated, 2)
+		assert.Equal(t, int32(1), table)
+	})
+}
+
+func TestPlanRebalance(t *testing.T) {
+	testCases := []struct {
+		name           string
+		seated         []models.Participant
+		expectedMoves  int
+		expectedCounts map[int32]int
+	}{
+		{
+			name:           "balanced tables",
+			seated:         seatedAt([2]int32{1, 1}, [2]int32{1, 2}, [2]int32{2, 1}),
+			expectedMoves:  0,
+			expectedCounts: map[int32]int{1: 2, 2: 1},
+		},
+		{
+			name: "moves one player",
+			seated: seatedAt(
+				[2]int32{1, 1}, [2]int32{1, 2}, [2]int32{1, 3}, [2]int32{1, 4},
+				[2]int32{2, 1}, [2]int32{2, 2},
+			),
+			expectedMoves:  1,
+			expectedCounts: map[int32]int{1: 3, 2: 3},
+		},
+		{
+			name: "moves several players",
+			seated: seatedAt(
+				[2]int32{1, 1}, [2]int32{1, 2}, [2]int32{1, 3}, [2]int32{1, 4}, [2]int32{1, 5}, [2]int32{1, 6},
+				[2]int32{2, 1}, [2]int32{2, 2}, [2]int32{2, 3}, [2]int32{2, 4}, [2]int32{2, 5}, [2]int32{2, 6},
+				[2]int32{3, 1},
+			),
+			expectedMoves:  3,
+			expectedCounts: map[int32]int{1: 4, 2: 5, 3: 4},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			moves := planRebalance(tC.seated, 6)
+			require.Len(t, moves, tC.expectedMoves)
+
+			seated := tC.seated
+			for _, move := range moves {
+				for i := range seated {
+					if seated[i].ID == move.ParticipantID {
+						assert.Equal(t, *seated[i].TableNumber, move.FromTable)
+						assert.Equal(t, *seated[i].SeatNumber, move.FromSeat)
+						seated[i].TableNumber = &move.ToTable
+						seated[i].SeatNumber = &move.ToSeat
+					}
+				}
+			}
+
+			assert.Equal(t, tC.expectedCounts, tableCounts(seated))
+		})
+	}
+}
+
+func TestPlanTableBreak(t *testing.T) {
+	threeTables := seatedAt(
+		[2]int32{1, 1}, [2]int32{1, 2}, [2]int32{1, 3},
+		[2]int32{2, 2}, [2]int32{2, 5},
+		[2]int32{3, 1}, [2]int32{3, 4},
+	)
+
+	t.Run("breaks the shortest table with the highest number", func(t *testing.T) {
+		plan, err := planTableBreak(threeTables, 6, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(3), plan.Table)
+		assert.Equal(t, []models.SeatMove{
+			{ParticipantID: 6, FromTable: 3, FromSeat: 1, ToTable: 2, ToSeat: 1},
+			{ParticipantID: 7, FromTable: 3, FromSeat: 4, ToTable: 1, ToSeat: 4},
+		}, plan.Moves)
+	})
+
+	t.Run("breaks the given table", func(t *testing.T) {
+		table := int32(1)
+		plan, err := planTableBreak(threeTables, 6, &table)
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(1), plan.Table)
+		assert.Equal(t, []models.SeatMove{
+			{ParticipantID: 1, FromTable: 1, FromSeat: 1, ToTable: 2, ToSeat: 1},
+			{ParticipantID: 2, FromTable: 1, FromSeat: 2, ToTable: 3, ToSeat: 2},
+			{ParticipantID: 3, FromTable: 1, FromSeat: 3, ToTable: 2, ToSeat: 3},
+		}, plan.Moves)
+	})
+
+	t.Run("not enough open seats", func(t *testing.T) {
+		seated := seatedAt([2]int32{1, 1}, [2]int32{1, 2}, [2]int32{2, 1})
+		_, err := planTableBreak(seated, 2, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "The other tables do not have enough open seats for the players at table 2")
+	})
+
+	t.Run("single table", func(t *testing.T) {
+		_, err := planTableBreak(seatedAt([2]int32{1, 1}), 9, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "There are fewer than two tables")
+	})
+
+	t.Run("table without players", func(t *testing.T) {
+		table := int32(5)
+		_, err := planTableBreak(threeTables, 6, &table)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Table 5 has no players")
+	})
+}
@@ -3,11 +3,7 @@ package services
 import (
 	e "api/internal/errors"
 	"api/internal/models"
-	"encoding/csv"
 	"errors"
-	"fmt"
-	"os"
-	"strconv"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -34,6 +30,7 @@ func (ss *semesterService) CreateSemester(req *models.CreateSemesterRequest) (*m
 		MembershipFee:         req.MembershipFee,
 		MembershipDiscountFee: req.MembershipDiscountFee,
 		RebuyFee:              req.RebuyFee,
+		AddOnFee:              req.AddOnFee,
 	}
 
 	res := ss.db.Create(&semester)
@@ -90,12 +87,25 @@ func (ss *semesterService) ListSemestersV2(pagination *models.Pagination) ([]mod
 	return semesters, total, nil
 }
 
+// knockoutCounts counts the players each membership eliminated in the ended
+// events of a semester
+func knockoutCounts(db *gorm.DB, semesterID uuid.UUID) *gorm.DB {
+	return db.
+		Table("participants AS eliminated").
+		Select("eliminators.membership_id, COUNT(*) AS knockouts").
+		Joins("INNER JOIN participants AS eliminators ON eliminators.id = eliminated.knocked_out_by_id").
+		Joins("INNER JOIN events ON events.id = eliminated.event_id").
+		Where("events.semester_id = ? AND events.state = ?", semesterID, models.EventStateEnded).
+		Group("eliminators.membership_id")
+}
+
 func (ss *semesterService) GetRankings(id uuid.UUID) ([]models.RankingResponse, error) {
 	var rankings []models.RankingResponse
 
 	res := ss.db.
 		Table(models.SemesterRankingsView).
-		Select("user_id as id, first_name, last_name, points, position").
+		Select("user_id as id, first_name, last_name, points, position", "COALESCE(knockouts.knockouts, 0) AS knockouts").
+		Joins("LEFT JOIN (?) AS knockouts ON knockouts.membership_id = "+models.SemesterRankingsView+".membership_id", knockoutCounts(ss.db, id)).
 		Where("semester_id = ?", id).
 		Order("position ASC, last_name ASC, first_name ASC").
 		Find(&rankings)
@@ -128,7 +138,8 @@ func (ss *semesterService) GetRankingsV2(id uuid.UUID, pagination *models.Pagina
 
 	var rankings []models.RankingResponse
 	query := buildBase().
-		Select("user_id as id, first_name, last_name, points, position").
+		Select("user_id as id, first_name, last_name, points, position", "COALESCE(knockouts.knockouts, 0) AS knockouts").
+		Joins("LEFT JOIN (?) AS knockouts ON knockouts.membership_id = "+models.SemesterRankingsView+".membership_id", knockoutCounts(ss.db, id)).
 		Order("position ASC, last_name ASC, first_name ASC")
 	query = pagination.Apply(query)
 
@@ -139,6 +150,9 @@ func (ss *semesterService) GetRankingsV2(id uuid.UUID, pagination *models.Pagina
 	return rankings, total, nil
 }
 
+// UpdateBudget adjusts the legacy current budget of a semester without
+// recording it in the ledger. Services record budget changes with
+// recordLedgerEntry, which keeps the legacy column in step.
 func (ss *semesterService) UpdateBudget(id uuid.UUID, amount float32) error {
 	// Use atomic update to prevent race conditions
 	res := ss.db.Model(&models.Semester{}).
@@ -156,53 +170,3 @@ func (ss *semesterService) UpdateBudget(id uuid.UUID, amount float32) error {
 
 	return nil
 }
-
-func (ss *semesterService) ExportRankings(id uuid.UUID) (string, error) {
-	var rankings []models.RankingResponse
-
-	// Get the top 100 rankings for export (limited to prevent excessive file sizes)
-	res := ss.db.
-		Table(models.SemesterRankingsView).
-		Select("user_id as id, first_name, last_name, points, position").
-		Where("semester_id = ?", id).
-		Order("position ASC, last_name ASC, first_name ASC").
-		Limit(100).
-		Find(&rankings)
-
-	if err := res.Error; err != nil {
-		return "", e.InternalServerError(fmt.Sprintf("Error when retrieving rankings: %s", err.Error()))
-	}
-
-	// Open a new CSV file in the OS temp directory
-	file, err := os.CreateTemp("", "rankings-*.csv")
-	if err != nil {
-		return "", e.InternalServerError(fmt.Sprintf("Error when creating rankings file: %s", err.Error()))
-	}
-	// Ensure the file is closed at the end of the function
-	defer file.Close()
-
-	// Initialize a new CSV writer
-	writer := csv.NewWriter(file)
-	// Ensure all data is written to the file
-	defer writer.Flush()
-
-	// Write headers to the file
-	writer.Write([]string{"position", "id", "first_name", "last_name", "points"})
-
-	// Write each row from the database to the CSV
-	for _, ranking := range rankings {
-		record := []string{
-			strconv.FormatInt(int64(ranking.Position), 10),
-			strconv.FormatUint(ranking.ID, 10),
-			ranking.FirstName,
-			ranking.LastName,
-			strconv.FormatInt(int64(ranking.Points), 10),
-		}
-
-		if err := writer.Write(record); err != nil {
-			return "", e.InternalServerError(fmt.Sprintf("Error writing to the CSV file: %s", err.Error()))
-		}
-	}
-
-	return file.Name(), nil
-}
@@ -3,11 +3,7 @@ package services
 import (
 	"api/internal/database"
 	"api/internal/models"
-	"api/internal/testhelpers"
-	"encoding/csv"
 	"math"
-	"os"
-	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -52,10 +48,6 @@ func TestSemesterService(t *testing.T) {
 			name: "UpdateBudget_Negative",
 			test: UpdateBudget_Negative(),
 		},
-		{
-			name: "ExportRankings",
-			test: ExportRankingsTest,
-		},
 	}
 
 	for _, tt := range tests {
@@ -589,74 +581,3 @@ func UpdateBudget_Negative() func(*testing.T) {
 		}
 	}
 }
-
-func ExportRankingsTest(t *testing.T) {
-	db, err := database.OpenTestConnection()
-	if !assert.NoError(t, err, "Failed to initialize the database") {
-		t.Fatal(err.Error())
-	}
-	defer database.WipeDB(db)
-
-	semester, err := testhelpers.SetupSemester(db, "Fall 2022")
-	if !assert.NoError(t, err) {
-		t.Fatalf("Failed to seed a semester: %v", err)
-	}
-
-	// Seed rankings
-	r := []models.Ranking{
-		{
-			MembershipID: semester.Memberships[0].ID,
-			Points:       10,
-		},
-		{
-			MembershipID: semester.Memberships[1].ID,
-			Points:       5,
-		},
-		{
-			MembershipID: semester.Memberships[2].ID,
-			Points:       2,
-		},
-	}
-	res := db.Create(&r)
-	if !assert.NoError(t, res.Error) {
-		t.Fatalf("Failed to seed rankings: %v", res.Error)
-	}
-
-	svc := NewSemesterService(db)
-
-	// Ensure filepath was returned in the OS temp directory with the expected pattern
-	fp, err := svc.ExportRankings(semester.Semester.ID)
-	require.NoError(t, err, "ExportRankings should not return an error")
-
-	assert.Equal(t, os.TempDir(), filepath.Dir(fp), "File should be directly in the OS temp directory")
-	matched, err := filepath.Match("rankings-*.csv", filepath.Base(fp))
-	assert.NoError(t, err, "Should not error matching the filename pattern")
-	assert.True(t, matched, "Filename should match the rankings-*.csv pattern")
-
-	// Check that the file exists
-	assert.FileExists(t, fp, "The rankings CSV file should exist")
-
-	// Read the file
-	file, err := os.Open(fp)
-	assert.NoError(t, err, "Should not error when opening a file")
-	defer file.Close()
-
-	// Initialize reader
-	reader := csv.NewReader(file)
-
-	records, err := reader.ReadAll()
-	assert.NoError(t, err, "Should not error when reading the CSV file")
-
-	// Check records were inserted correctly (now includes position column)
-	expectedRecords := [][]string{
-		{"position", "id", "first_name", "last_name", "points"},
-		{"1", "20780648", "Adam", "Mahood", "10"},
-		{"2", "36459367", "Deep", "Kalra", "5"},
-		{"3", "13274944", "Jane", "Doe", "2"},
-	}
-
-	assert.ElementsMatch(t, expectedRecords, records)
-
-	// Remove file
-	_ = os.Remove(fp)
-}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	e "api/internal/errors"
+	"api/internal/models"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// structureCSVColumns maps the accepted CSV header names, after trimming and
+// lowercasing, to the level field they hold.
+var structureCSVColumns = map[string]string{
+	"type":        "type",
+	"label":       "label",
+	"name":        "label",
+	"small":       "small",
+	"small blind": "small",
+	"sb":          "small",
+	"big":         "big",
+	"big blind":   "big",
+	"bb":          "big",
+	"ante":        "ante",
+	"time":        "time",
+	"minutes":     "time",
+	"duration":    "time",
+}
+
+// ParseStructureCSV reads the levels of a structure from CSV. The first row
+// must be a header naming the columns; unknown columns are ignored. Levels that
+// cannot be parsed or are invalid are reported as row errors.
+func ParseStructureCSV(r io.Reader) ([]models.BlindJSON, []models.StructureImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, e.InvalidRequest("The CSV file is empty")
+	} else if err != nil {
+		return nil, nil, e.InvalidRequest(fmt.Sprintf("Invalid CSV: %s", err.Error()))
+	}
+
+	// Spreadsheet applications may start the file with a byte order mark
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if field, ok := structureCSVColumns[name]; ok {
+			if _, duplicate := columns[field]; duplicate {
+				return nil, nil, e.InvalidRequest(fmt.Sprintf("The CSV header has more than one '%s' column", field))
+			}
+			columns[field] = i
+		}
+	}
+	for _, field := range []string{"small", "big", "time"} {
+		if _, ok := columns[field]; !ok {
+			return nil, nil, e.InvalidRequest(fmt.Sprintf("The CSV header is missing the '%s' column", field))
+		}
+	}
+
+	levels := []models.BlindJSON{}
+	rowErrors := []models.StructureImportRowError{}
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, nil, e.InvalidRequest(fmt.Sprintf("Invalid CSV: %s", err.Error()))
+		}
+
+		cell := func(field string) string {
+			i, ok := columns[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		level := models.BlindJSON{
+			Type:  strings.ToLower(cell("type")),
+			Label: cell("label"),
+		}
+
+		valid := true
+		for _, number := range []struct {
+			field string
+			dest  *int32
+		}{
+			{field: "small", dest: &level.Small},
+			{field: "big", dest: &level.Big},
+			{field: "ante", dest: &level.Ante},
+			{field: "time", dest: &level.Time},
+		} {
+			value := cell(number.field)
+			if value == "" {
+				continue
+			}
+
+			n, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				rowErrors = append(rowErrors, models.StructureImportRowError{
+					Row:     row,
+					Field:   number.field,
+					Message: fmt.Sprintf("%s must be a whole number", number.field),
+				})
+				valid = false
+				continue
+			}
+			*number.dest = int32(n)
+		}
+
+		levels = append(levels, level)
+		if valid {
+			rowErrors = append(rowErrors, validateStructureLevel(row, level)...)
+		}
+	}
+
+	if len(levels) == 0 {
+		return nil, nil, e.InvalidRequest("The structure has no levels")
+	}
+	if len(levels) > math.MaxInt8 {
+		return nil, nil, e.InvalidRequest(fmt.Sprintf("A structure can have at most %d levels", math.MaxInt8))
+	}
+
+	return levels, rowErrors, nil
+}
+
+// ParseStructureJSON reads a structure in the JSON interchange format. Invalid
+// levels are reported as row errors.
+func ParseStructureJSON(r io.Reader) (*models.StructureInterchange, []models.StructureImportRowError, error) {
+	structure := models.StructureInterchange{}
+	if err := json.NewDecoder(r).Decode(&structure); err != nil {
+		return nil, nil, e.InvalidRequest(fmt.Sprintf("Invalid JSON: %s", err.Error()))
+	}
+
+	if structure.Version == 0 {
+		structure.Version = models.StructureInterchangeVersion
+	}
+	if structure.Version != models.StructureInterchangeVersion {
+		return nil, nil, e.InvalidRequest(fmt.Sprintf("Unsupported structure format version %d", structure.Version))
+	}
+	if len(structure.Levels) == 0 {
+		return nil, nil, e.InvalidRequest("The structure has no levels")
+	}
+	if len(structure.Levels) > math.MaxInt8 {
+		return nil, nil, e.InvalidRequest(fmt.Sprintf("A structure can have at most %d levels", math.MaxInt8))
+	}
+
+	rowErrors := []models.StructureImportRowError{}
+	for i, level := range structure.Levels {
+		rowErrors = append(rowErrors, validateStructureLevel(i+1, level)...)
+	}
+
+	return &structure, rowErrors, nil
+}
+
+// validateStructureLevel validates an imported level with the same rules as
+// the levels of a CreateStructureRequest.
+func validateStructureLevel(row int, level models.BlindJSON) []models.StructureImportRowError {
+	err := binding.Validator.ValidateStruct(level)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return []models.StructureImportRowError{{Row: row, Message: err.Error()}}
+	}
+
+	rowErrors := make([]models.StructureImportRowError, len(validationErrors))
+	for i, fieldErr := range validationErrors {
+		field := strings.ToLower(fieldErr.Field())
+
+		var message string
+		switch fieldErr.Tag() {
+		case "required", "required_if", "required_without":
+			message = fmt.Sprintf("%s is required", field)
+		case "excluded_if":
+			message = fmt.Sprintf("%s must be empty for %s levels", field, level.Type)
+		case "oneof":
+			message = fmt.Sprintf("%s must be one of %s", field, strings.ReplaceAll(fieldErr.Param(), " ", ", "))
+		case "gt":
+			message = fmt.Sprintf("%s must be greater than %s", field, fieldErr.Param())
+		case "gte":
+			message = fmt.Sprintf("%s must be at least %s", field, fieldErr.Param())
+		case "lte":
+			message = fmt.Sprintf("%s must be at most %s", field, fieldErr.Param())
+		case "max":
+			message = fmt.Sprintf("%s must be at most %s characters", field, fieldErr.Param())
+		default:
+			message = fmt.Sprintf("%s is invalid", field)
+		}
+
+		rowErrors[i] = models.StructureImportRowError{
+			Row:     row,
+			Field:   field,
+			Message: message,
+		}
+	}
+
+	return rowErrors
+}
+
+// NewStructureInterchange converts a structure to the JSON interchange format.
+func NewStructureInterchange(structure models.Structure) models.StructureInterchange {
+	levels := make([]models.BlindJSON, len(structure.Blinds))
+	for i, blind := range structure.Blinds {
+		levels[i] = models.BlindJSON{
+			Type:  blind.Type,
+			Label: blind.Label,
+			Small: blind.Small,
+			Big:   blind.Big,
+			Ante:  blind.Ante,
+			Time:  blind.Time,
+		}
+		if levels[i].Type == "" {
+			levels[i].Type = models.BlindLevelTypeBlind
+		}
+	}
+
+	return models.StructureInterchange{
+		Version: models.StructureInterchangeVersion,
+		Name:    structure.Name,
+		Levels:  levels,
+	}
+}
+
+// WriteStructureCSV writes the levels of a structure as CSV, starting with
+// StructureCSVHeader.
+func WriteStructureCSV(w io.Writer, structure models.Structure) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(models.StructureCSVHeader); err != nil {
+		return err
+	}
+
+	for _, level := range NewStructureInterchange(structure).Levels {
+		record := []string{
+			level.Type,
+			level.Label,
+			strconv.FormatInt(int64(level.Small), 10),
+			strconv.FormatInt(int64(level.Big), 10),
+			strconv.FormatInt(int64(level.Ante), 10),
+			strconv.FormatInt(int64(level.Time), 10),
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"api/internal/models"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStructureCSV(t *testing.T) {
+	testCases := []struct {
+		name              string
+		csv               string
+		expectedLevels    []models.BlindJSON
+		expectedRowErrors []models.StructureImportRowError
+		expectedError     string
+	}{
+		{
+			name: "exported format",
+			csv: "type,label,small,big,ante,time\n" +
+				"blind,,25,50,0,20\n" +
+				"break,Dinner break,0,0,0,45\n" +
+				"chip_race,Colour up 25s,,,,5\n",
+			expectedLevels: []models.BlindJSON{
+				{Type: "blind", Small: 25, Big: 50, Time: 20},
+				{Type: "break", Label: "Dinner break", Time: 45},
+				{Type: "chip_race", Label: "Colour up 25s", Time: 5},
+			},
+			expectedRowErrors: []models.StructureImportRowError{},
+		},
+		{
+			name: "columns in another order with aliases and unknown columns",
+			csv: "\ufeffLevel, Minutes, SB, BB, Ante\n" +
+				"1, 90, 100, 200, 200\n" +
+				"2, 90, 200, 400, 400\n",
+			expectedLevels: []models.BlindJSON{
+				{Small: 100, Big: 200, Ante: 200, Time: 90},
+				{Small: 200, Big: 400, Ante: 400, Time: 90},
+			},
+			expectedRowErrors: []models.StructureImportRowError{},
+		},
+		{
+			name: "reports every invalid row",
+			csv: "type,small,big,time\n" +
+				"blind,25,50,20\n" +
+				"blind,,50,20\n" +
+				"break,25,50,10\n" +
+				"blind,abc,50,0\n" +
+				"lunch,,,30\n",
+			expectedLevels: []models.BlindJSON{
+				{Type: "blind", Small: 25, Big: 50, Time: 20},
+				{Type: "blind", Big: 50, Time: 20},
+				{Type: "break", Small: 25, Big: 50, Time: 10},
+				{Type: "blind", Big: 50},
+				{Type: "lunch", Time: 30},
+			},
+			expectedRowErrors: []models.StructureImportRowError{
+				{Row: 2, Field: "small", Message: "small is required"},
+				{Row: 3, Field: "small", Message: "small must be empty for break levels"},
+				{Row: 3, Field: "big", Message: "big must be empty for break levels"},
+				{Row: 4, Field: "small", Message: "small must be a whole number"},
+				{Row: 5, Field: "type", Message: "type must be one of blind, break, chip_race"},
+			},
+		},
+		{
+			name:          "missing required column",
+			csv:           "small,big\n25,50\n",
+			expectedError: "The CSV header is missing the 'time' column",
+		},
+		{
+			name:          "duplicate column",
+			csv:           "small,sb,big,time\n25,25,50,20\n",
+			expectedError: "The CSV header has more than one 'small' column",
+		},
+		{
+			name:          "no levels",
+			csv:           "small,big,time\n",
+			expectedError: "The structure has no levels",
+		},
+		{
+			name:          "empty file",
+			csv:           "",
+			expectedError: "The CSV file is empty",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			levels, rowErrors, err := ParseStructureCSV(strings.NewReader(tC.csv))
+			if tC.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tC.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.expectedLevels, levels)
+			assert.Equal(t, tC.expectedRowErrors, rowErrors)
+		})
+	}
+}
+
+func TestParseStructureJSON(t *testing.T) {
+	testCases := []struct {
+		name              string
+		json              string
+		expectedName      string
+		expectedLevels    int
+		expectedRowErrors []models.StructureImportRowError
+		expectedError     string
+	}{
+		{
+			name: "valid structure",
+			json: `{"version":1,"name":"Deep Stack","levels":[
+				{"small":25,"big":50,"time":90},
+				{"type":"break","label":"Dinner break","time":45}
+			]}`,
+			expectedName:      "Deep Stack",
+			expectedLevels:    2,
+			expectedRowErrors: []models.StructureImportRowError{},
+		},
+		{
+			name:              "version defaults to 1",
+			json:              `{"name":"Turbo","levels":[{"small":25,"big":50,"time":10}]}`,
+			expectedName:      "Turbo",
+			expectedLevels:    1,
+			expectedRowErrors: []models.StructureImportRowError{},
+		},
+		{
+			name: "reports invalid levels",
+			json: `{"name":"Turbo","levels":[
+				{"small":25,"big":50,"time":10},
+				{"small":25,"big":50,"time":2000},
+				{"type":"chip_race","ante":25,"time":5,"label":"Colour up"}
+			]}`,
+			expectedName:   "Turbo",
+			expectedLevels: 3,
+			expectedRowErrors: []models.StructureImportRowError{
+				{Row: 2, Field: "time", Message: "time must be at most 1440"},
+				{Row: 3, Field: "ante", Message: "ante must be empty for chip_race levels"},
+			},
+		},
+		{
+			name:          "unsupported version",
+			json:          `{"version":2,"name":"Turbo","levels":[{"small":25,"big":50,"time":10}]}`,
+			expectedError: "Unsupported structure format version 2",
+		},
+		{
+			name:          "no levels",
+			json:          `{"name":"Turbo","levels":[]}`,
+			expectedError: "The structure has no levels",
+		},
+		{
+			name:          "malformed JSON",
+			json:          `{"name":`,
+			expectedError: "Invalid JSON",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			structure, rowErrors, err := ParseStructureJSON(strings.NewReader(tC.json))
+			if tC.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tC.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, models.StructureInterchangeVersion, structure.Version)
+			assert.Equal(t, tC.expectedName, structure.Name)
+			assert.Len(t, structure.Levels, tC.expectedLevels)
+			assert.Equal(t, tC.expectedRowErrors, rowErrors)
+		})
+	}
+}
+
+func TestWriteStructureCSV_RoundTrip(t *testing.T) {
+	structure := models.Structure{
+		Name: "Deep Stack",
+		Blinds: []models.Blind{
+			{Small: 25, Big: 50, Time: 90},
+			{Type: models.BlindLevelTypeChipRace, Label: "Colour up, 25s", Time: 5},
+			{Type: models.BlindLevelTypeBreak, Label: "Dinner break", Time: 45},
+			{Type: models.BlindLevelTypeBlind, Small: 100, Big: 200, Ante: 200, Time: 90},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteStructureCSV(&buf, structure))
+	assert.True(t, strings.HasPrefix(buf.String(), "type,label,small,big,ante,time\nblind,,25,50,0,90\n"))
+
+	levels, rowErrors, err := ParseStructureCSV(&buf)
+	require.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	assert.Equal(t, NewStructureInterchange(structure).Levels, levels)
+}
@@ -19,24 +19,18 @@ func NewStructureService(db *gorm.DB) *structureService {
 }
 
 func (ss *structureService) CreateStructure(req *models.CreateStructureRequest) (*models.Structure, error) {
-	blinds := make([]models.Blind, len(req.Blinds))
-	for i, blind := range req.Blinds {
-		blinds[i] = models.Blind{
-			Small: blind.Small,
-			Big:   blind.Big,
-			Ante:  blind.Ante,
-			Time:  blind.Time,
-			Index: int8(i),
-		}
-	}
-
 	structure := models.Structure{
 		Name:   req.Name,
-		Blinds: blinds,
+		Blinds: models.NewBlindsFromJSON(0, req.Blinds),
 	}
 
-	res := ss.db.Create(&structure)
-	if err := res.Error; err != nil {
+	err := ss.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&structure).Error; err != nil {
+			return err
+		}
+		return recordStructureVersion(tx, &structure)
+	})
+	if err != nil {
 		return nil, e.InternalServerError(err.Error())
 	}
 
@@ -124,17 +118,7 @@ func (ss *structureService) UpdateStructure(req *models.UpdateStructureRequest)
 	}
 
 	// Insert new levels
-	blinds := make([]models.Blind, len(req.Blinds))
-	for i, blind := range req.Blinds {
-		blinds[i] = models.Blind{
-			Small:       blind.Small,
-			Big:         blind.Big,
-			Ante:        blind.Ante,
-			Time:        blind.Time,
-			StructureId: structure.ID,
-			Index:       int8(i),
-		}
-	}
+	blinds := models.NewBlindsFromJSON(structure.ID, req.Blinds)
 
 	// Create new blinds
 	if len(blinds) > 0 {
@@ -145,6 +129,12 @@ func (ss *structureService) UpdateStructure(req *models.UpdateStructureRequest)
 		}
 	}
 
+	structure.Blinds = blinds
+	if err := recordStructureVersion(tx, &structure); err != nil {
+		tx.Rollback()
+		return nil, e.InternalServerError(err.Error())
+	}
+
 	// Commit the transaction
 	err = tx.Commit().Error
 	if err != nil {
@@ -207,18 +197,7 @@ func (ss *structureService) UpdateStructureV2(id int32, updateMap map[string]any
 		}
 
 		// Insert new blinds
-		blindsJSON := blindsData.([]models.BlindJSON)
-		blinds := make([]models.Blind, len(blindsJSON))
-		for i, b := range blindsJSON {
-			blinds[i] = models.Blind{
-				Small:       b.Small,
-				Big:         b.Big,
-				Ante:        b.Ante,
-				Time:        b.Time,
-				StructureId: structure.ID,
-				Index:       int8(i),
-			}
-		}
+		blinds := models.NewBlindsFromJSON(structure.ID, blindsData.([]models.BlindJSON))
 
 		if len(blinds) > 0 {
 			err = tx.Create(&blinds).Error
@@ -227,6 +206,14 @@ func (ss *structureService) UpdateStructureV2(id int32, updateMap map[string]any
 				return nil, e.InternalServerError(err.Error())
 			}
 		}
+		structure.Blinds = blinds
+	}
+
+	// Record the edit as a new version, so that events pinned to an earlier
+	// version keep their levels
+	if err := recordStructureVersion(tx, &structure); err != nil {
+		tx.Rollback()
+		return nil, e.InternalServerError(err.Error())
 	}
 
 	// Commit the transaction
@@ -245,6 +232,45 @@ func (ss *structureService) UpdateStructureV2(id int32, updateMap map[string]any
 	return &result, nil
 }
 
+// recordStructureVersion snapshots a structure, with its current name and
+// blinds, as the next version of the structure.
+func recordStructureVersion(tx *gorm.DB, structure *models.Structure) error {
+	var latest int32
+	err := tx.Model(&models.StructureVersion{}).
+		Where("structure_id = ?", structure.ID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).
+		Error
+	if err != nil {
+		return err
+	}
+
+	structure.Version = latest + 1
+	version := models.NewStructureVersion(*structure)
+	if err := tx.Create(&version).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&models.Structure{}).Where("id = ?", structure.ID).Update("version", structure.Version).Error
+}
+
+// latestStructureVersionID returns the ID of the current version of a
+// structure, or nil if no version of the structure was recorded.
+func latestStructureVersionID(db *gorm.DB, structureID int32) (*int32, error) {
+	var ids []int32
+	err := db.Model(&models.StructureVersion{}).
+		Where("structure_id = ?", structureID).
+		Order("version DESC").
+		Limit(1).
+		Pluck("id", &ids).
+		Error
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	return &ids[0], nil
+}
+
 // DeleteStructure deletes a structure by ID
 // Manually deletes associated blinds first since FK constraint is ON DELETE NO ACTION
 func (ss *structureService) DeleteStructure(id int32) error {
@@ -259,6 +285,14 @@ func (ss *structureService) DeleteStructure(id int32) error {
 		return e.InternalServerError(err.Error())
 	}
 
+	var events int64
+	if err := ss.db.Model(&models.Event{}).Where("structure_id = ?", id).Count(&events).Error; err != nil {
+		return e.InternalServerError(err.Error())
+	}
+	if events > 0 {
+		return e.Forbidden("Structures used by an event cannot be deleted")
+	}
+
 	// Use transaction to ensure atomicity
 	tx := ss.db.Begin()
 	defer func() {
@@ -38,9 +38,14 @@ func (ts *transactionService) CreateTransaction(semesterId uuid.UUID, req *model
 		return nil, e.InternalServerError(err.Error())
 	}
 
-	// Update semester's budget with transaction amount
-	ss := NewSemesterService(tx)
-	err := ss.UpdateBudget(semesterId, transaction.Amount)
+	// Record the transaction amount in the semester's ledger
+	err := recordLedgerEntry(tx, &models.LedgerEntry{
+		SemesterID:    semesterId,
+		Type:          models.LedgerEntryTypeTransaction,
+		AmountCents:   models.DollarsToCents(transaction.Amount),
+		Description:   transaction.Description,
+		TransactionID: &transaction.ID,
+	})
 	if err != nil {
 		tx.Rollback()
 		return nil, err
@@ -128,9 +133,18 @@ func (ts *transactionService) UpdateTransaction(semesterId uuid.UUID, req *model
 	// If the amount of the transaction changes, we need to update the budget to
 	// reflect this change. This calculation can be formed as follows:
 	// NEW_TOTAL = OLD_TOTAL - (OLD_AMOUNT - NEW_AMOUNT)
-	// Therefore we update the budget with -(OLD_AMOUNT - NEW_AMOUNT)
-	ss := NewSemesterService(tx)
-	err := ss.UpdateBudget(semesterId, -(oldAmount - req.Amount))
+	// Therefore we record -(OLD_AMOUNT - NEW_AMOUNT) in the ledger
+	var adjustment int64
+	if req.Amount != 0.0 {
+		adjustment = models.DollarsToCents(req.Amount) - models.DollarsToCents(oldAmount)
+	}
+	err := recordLedgerEntry(tx, &models.LedgerEntry{
+		SemesterID:    semesterId,
+		Type:          models.LedgerEntryTypeTransaction,
+		AmountCents:   adjustment,
+		Description:   "Transaction amount changed: " + transaction.Description,
+		TransactionID: &transaction.ID,
+	})
 	if err != nil {
 		tx.Rollback()
 		return nil, err
@@ -174,9 +188,14 @@ func (ts *transactionService) DeleteTransaction(semesterId uuid.UUID, transactio
 		return e.InternalServerError(err.Error())
 	}
 
-	// Update the semesters budget by adding the negation of the transaction amount
-	ss := NewSemesterService(tx)
-	err := ss.UpdateBudget(semesterId, -transaction.Amount)
+	// Reverse the transaction amount in the semester's ledger
+	err := recordLedgerEntry(tx, &models.LedgerEntry{
+		SemesterID:    semesterId,
+		Type:          models.LedgerEntryTypeTransaction,
+		AmountCents:   -models.DollarsToCents(transaction.Amount),
+		Description:   "Transaction deleted: " + transaction.Description,
+		TransactionID: &transaction.ID,
+	})
 	if err != nil {
 		tx.Rollback()
 		return e.InternalServerError(err.Error())
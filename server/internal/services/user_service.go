@@ -3,7 +3,11 @@ package services
 import (
 	e "api/internal/errors"
 	"api/internal/models"
+	"encoding/csv"
 	"errors"
+	"io"
+	"strconv"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -41,21 +45,7 @@ func (u *userService) ListUsers(filter *models.ListUsersFilter) ([]models.User,
 	res := u.db.Order("created_at DESC")
 
 	// Add filter to SQL query if they are present
-	if filter.ID != nil {
-		res = res.Where("id = ?", *filter.ID)
-	}
-
-	if filter.Name != nil {
-		res = res.Where("first_name || ' ' || last_name ILIKE ?", "%"+*filter.Name+"%")
-	}
-
-	if filter.Email != nil {
-		res = res.Where("email ILIKE ?", "%"+*filter.Email+"%")
-	}
-
-	if filter.Faculty != nil {
-		res = res.Where("faculty = ?", *filter.Faculty)
-	}
+	res = filterUsers(res, filter)
 
 	// Find all results matching the query
 	res = res.Find(&users)
@@ -66,12 +56,8 @@ func (u *userService) ListUsers(filter *models.ListUsersFilter) ([]models.User,
 	return users, nil
 }
 
-func (u *userService) ListUsersV2(filter *models.ListUsersFilter, pagination *models.Pagination) ([]models.User, int64, error) {
-	var users []models.User
-
-	// Build base query with filters
-	query := u.db.Model(&models.User{})
-
+// filterUsers adds the clauses of a list users filter to a query
+func filterUsers(query *gorm.DB, filter *models.ListUsersFilter) *gorm.DB {
 	if filter.ID != nil {
 		query = query.Where("id = ?", *filter.ID)
 	}
@@ -88,6 +74,15 @@ func (u *userService) ListUsersV2(filter *models.ListUsersFilter, pagination *mo
 		query = query.Where("faculty = ?", *filter.Faculty)
 	}
 
+	return query
+}
+
+func (u *userService) ListUsersV2(filter *models.ListUsersFilter, pagination *models.Pagination) ([]models.User, int64, error) {
+	var users []models.User
+
+	// Build base query with filters
+	query := filterUsers(u.db.Model(&models.User{}), filter)
+
 	// Get total count before pagination
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
@@ -185,3 +180,51 @@ func (u *userService) DeleteUser(id uint64) error {
 
 	return nil
 }
+
+// ExportUsers writes every user matching the filter to w as CSV. Users are
+// read from the database one row at a time, so the export is never held in
+// memory. Nothing is written to w if the users cannot be queried.
+func (u *userService) ExportUsers(w io.Writer, filter *models.ListUsersFilter) error {
+	rows, err := filterUsers(u.db.Model(&models.User{}), filter).
+		Order("created_at DESC").
+		Rows()
+	if err != nil {
+		return e.InternalServerError(err.Error())
+	}
+	defer rows.Close()
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"id", "first_name", "last_name", "email", "faculty", "quest_id", "created_at"})
+
+	for rows.Next() {
+		var user models.User
+		if err := u.db.ScanRows(rows, &user); err != nil {
+			return e.InternalServerError(err.Error())
+		}
+
+		record := []string{
+			strconv.FormatUint(user.ID, 10),
+			user.FirstName,
+			user.LastName,
+			user.Email,
+			user.Faculty,
+			user.QuestID,
+			user.CreatedAt.Format(time.RFC3339),
+		}
+
+		if err := writer.Write(record); err != nil {
+			return e.InternalServerError(err.Error())
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return e.InternalServerError(err.Error())
+	}
+
+	return nil
+}
@@ -3,3 +3,5 @@ package store
 import "errors"
 
 var ErrNotFound = errors.New("record not found")
+
+var ErrInUse = errors.New("record is in use")
@@ -0,0 +1,74 @@
+package inmemory
+
+import (
+	"api/internal/models"
+	"api/internal/store"
+	"sync"
+	"time"
+)
+
+type inMemoryLoginAttemptRepository struct {
+	mu       sync.RWMutex
+	attempts map[string]models.LoginAttempt
+}
+
+var _ store.LoginAttemptRepository = (*inMemoryLoginAttemptRepository)(nil)
+
+func newLoginAttemptRepository() *inMemoryLoginAttemptRepository {
+	return &inMemoryLoginAttemptRepository{
+		attempts: make(map[string]models.LoginAttempt),
+	}
+}
+
+// NewLoginAttemptRepository creates a repository which keeps failed login
+// attempts in process. Attempts are lost when the process restarts.
+func NewLoginAttemptRepository() store.LoginAttemptRepository {
+	return newLoginAttemptRepository()
+}
+
+func (r *inMemoryLoginAttemptRepository) Find(key string) (models.LoginAttempt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	attempt, exists := r.attempts[key]
+	if !exists {
+		return models.LoginAttempt{}, store.ErrNotFound
+	}
+
+	return attempt, nil
+}
+
+func (r *inMemoryLoginAttemptRepository) Save(attempt *models.LoginAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.attempts[attempt.Key] = *attempt
+
+	return nil
+}
+
+func (r *inMemoryLoginAttemptRepository) Delete(key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.attempts[key]; !exists {
+		return store.ErrNotFound
+	}
+
+	delete(r.attempts, key)
+
+	return nil
+}
+
+func (r *inMemoryLoginAttemptRepository) DeleteStale(before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, attempt := range r.attempts {
+		if attempt.LastFailureAt.Before(before) && attempt.LockedUntil.Before(before) {
+			delete(r.attempts, key)
+		}
+	}
+
+	return nil
+}
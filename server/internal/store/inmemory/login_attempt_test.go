@@ -0,0 +1,76 @@
+package inmemory
+
+import (
+	"testing"
+	"time"
+
+	"api/internal/models"
+	"api/internal/store"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginAttemptRepository_SaveAndFind(t *testing.T) {
+	t.Parallel()
+
+	repo := newLoginAttemptRepository()
+
+	now := time.Now().UTC()
+	require.NoError(t, repo.Save(&models.LoginAttempt{Key: "username:alice", Failures: 1, LastFailureAt: now}))
+
+	found, err := repo.Find("username:alice")
+	require.NoError(t, err)
+	require.Equal(t, 1, found.Failures)
+	require.Equal(t, now, found.LastFailureAt)
+
+	// Saving again replaces the attempt
+	require.NoError(t, repo.Save(&models.LoginAttempt{Key: "username:alice", Failures: 2, LastFailureAt: now}))
+
+	found, err = repo.Find("username:alice")
+	require.NoError(t, err)
+	require.Equal(t, 2, found.Failures)
+
+	_, err = repo.Find("username:bob")
+	require.ErrorIs(t, err, store.ErrNotFound)
+}
+
+func TestLoginAttemptRepository_Delete(t *testing.T) {
+	t.Parallel()
+
+	repo := newLoginAttemptRepository()
+
+	require.NoError(t, repo.Save(&models.LoginAttempt{Key: "ip:192.0.2.1", Failures: 1}))
+	require.NoError(t, repo.Delete("ip:192.0.2.1"))
+
+	_, err := repo.Find("ip:192.0.2.1")
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	require.ErrorIs(t, repo.Delete("ip:192.0.2.1"), store.ErrNotFound)
+}
+
+func TestLoginAttemptRepository_DeleteStale(t *testing.T) {
+	t.Parallel()
+
+	repo := newLoginAttemptRepository()
+
+	now := time.Now().UTC()
+	require.NoError(t, repo.Save(&models.LoginAttempt{Key: "username:stale", Failures: 1, LastFailureAt: now.Add(-2 * time.Hour)}))
+	require.NoError(t, repo.Save(&models.LoginAttempt{Key: "username:recent", Failures: 1, LastFailureAt: now}))
+	require.NoError(t, repo.Save(&models.LoginAttempt{
+		Key:           "username:locked",
+		Failures:      10,
+		LastFailureAt: now.Add(-2 * time.Hour),
+		LockedUntil:   now.Add(time.Hour),
+	}))
+
+	require.NoError(t, repo.DeleteStale(now.Add(-time.Hour)))
+
+	_, err := repo.Find("username:stale")
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	_, err = repo.Find("username:recent")
+	require.NoError(t, err)
+
+	_, err = repo.Find("username:locked")
+	require.NoError(t, err)
+}
@@ -6,12 +6,16 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 )
 
 type inMemoryStructureRepository struct {
   mu         sync.RWMutex
   structures map[int32]*models.Structure
   nextID     int32
+
+  versions      map[int32][]models.StructureVersion
+  nextVersionID int32
 }
 
 var _ store.StructureRepository = (*inMemoryStructureRepository)(nil)
@@ -19,6 +23,7 @@ var _ store.StructureRepository = (*inMemoryStructureRepository)(nil)
 func newStructureRepository() *inMemoryStructureRepository {
   return &inMemoryStructureRepository{
     structures: make(map[int32]*models.Structure),
+    versions:   make(map[int32][]models.StructureVersion),
   }
 }
 
@@ -33,6 +38,9 @@ func (r *inMemoryStructureRepository) clone() *inMemoryStructureRepository {
   c := &inMemoryStructureRepository{
     structures: make(map[int32]*models.Structure, len(r.structures)),
     nextID:     r.nextID,
+
+    versions:      make(map[int32][]models.StructureVersion, len(r.versions)),
+    nextVersionID: r.nextVersionID,
   }
   for id, s := range r.structures {
     sc := *s
@@ -42,6 +50,9 @@ func (r *inMemoryStructureRepository) clone() *inMemoryStructureRepository {
     }
     c.structures[id] = &sc
   }
+  for id, versions := range r.versions {
+    c.versions[id] = append([]models.StructureVersion(nil), versions...)
+  }
   return c
 }
 
@@ -56,7 +67,13 @@ func (r *inMemoryStructureRepository) Create(structure *models.Structure) error
     return fmt.Errorf("structure with ID %d already exists", structure.ID)
   }
 
+  if structure.Version == 0 {
+    structure.Version = 1
+  }
+  structure.Blinds = normaliseBlinds(structure.ID, structure.Blinds)
+
   copy := *structure
+  copy.Blinds = normaliseBlinds(structure.ID, structure.Blinds)
   r.structures[structure.ID] = &copy
 
   return nil
@@ -130,10 +147,115 @@ func (r *inMemoryStructureRepository) ReplaceBlindsByStructureID(structureID int
     return store.ErrNotFound
   }
 
-  structure.Blinds = blinds
+  structure.Blinds = normaliseBlinds(structureID, blinds)
+  return nil
+}
+
+// normaliseBlinds returns a copy of blinds belonging to the given structure,
+// applying the same defaults as the blinds table.
+func normaliseBlinds(structureID int32, blinds []models.Blind) []models.Blind {
+  if blinds == nil {
+    return nil
+  }
+
+  ret := make([]models.Blind, len(blinds))
+  for i, blind := range blinds {
+    if blind.Type == "" {
+      blind.Type = models.BlindLevelTypeBlind
+    }
+    blind.StructureId = structureID
+    ret[i] = blind
+  }
+  return ret
+}
+
+func (r *inMemoryStructureRepository) CreateVersion(version *models.StructureVersion) error {
+  r.mu.Lock()
+  defer r.mu.Unlock()
+
+  structure, exists := r.structures[version.StructureID]
+  if !exists {
+    return store.ErrNotFound
+  }
+
+  for _, existing := range r.versions[version.StructureID] {
+    if existing.Version == version.Version {
+      return fmt.Errorf("version %d of structure %d already exists", version.Version, version.StructureID)
+    }
+  }
+
+  r.nextVersionID++
+  version.ID = r.nextVersionID
+  if version.CreatedAt.IsZero() {
+    version.CreatedAt = time.Now()
+  }
+
+  r.versions[version.StructureID] = append(r.versions[version.StructureID], *version)
+  structure.Version = version.Version
+
   return nil
 }
 
+func (r *inMemoryStructureRepository) FindVersion(structureID int32, version int32) (models.StructureVersion, error) {
+  r.mu.RLock()
+  defer r.mu.RUnlock()
+
+  for _, v := range r.versions[structureID] {
+    if v.Version == version {
+      return v, nil
+    }
+  }
+
+  return models.StructureVersion{}, store.ErrNotFound
+}
+
+func (r *inMemoryStructureRepository) FindLatestVersion(structureID int32) (models.StructureVersion, error) {
+  r.mu.RLock()
+  defer r.mu.RUnlock()
+
+  var latest *models.StructureVersion
+  for i, v := range r.versions[structureID] {
+    if latest == nil || v.Version > latest.Version {
+      latest = &r.versions[structureID][i]
+    }
+  }
+
+  if latest == nil {
+    return models.StructureVersion{}, store.ErrNotFound
+  }
+
+  return *latest, nil
+}
+
+func (r *inMemoryStructureRepository) ListVersions(structureID int32, pagination *models.Pagination) ([]models.StructureVersion, int64, error) {
+  r.mu.RLock()
+  defer r.mu.RUnlock()
+
+  versions := append([]models.StructureVersion{}, r.versions[structureID]...)
+  sort.Slice(versions, func(i, j int) bool {
+    return versions[i].Version > versions[j].Version
+  })
+
+  total := int64(len(versions))
+
+  offset := 0
+  if pagination.Offset != nil && *pagination.Offset > 0 {
+    offset = *pagination.Offset
+  }
+
+  if offset >= len(versions) {
+    return []models.StructureVersion{}, total, nil
+  }
+
+  versions = versions[offset:]
+
+  if pagination.Limit != nil && *pagination.Limit > 0 && *pagination.Limit < len(versions) {
+    versions = versions[:*pagination.Limit]
+  }
+
+  return versions, total, nil
+}
+
 func (r *inMemoryStructureRepository) Delete(id int32) error {
   r.mu.Lock()
   defer r.mu.Unlock()
@@ -143,6 +265,7 @@ func (r *inMemoryStructureRepository) Delete(id int32) error {
   }
 
   delete(r.structures, id)
+  delete(r.versions, id)
   
   return nil
 }
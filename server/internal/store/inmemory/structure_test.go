@@ -0,0 +1,116 @@
+package inmemory
+
+import (
+	"testing"
+
+	"api/internal/models"
+	"api/internal/store"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructureRepository_Create_DefaultsLevelType(t *testing.T) {
+	t.Parallel()
+
+	repo := newStructureRepository()
+
+	structure := &models.Structure{
+		Name: "Deep Stack",
+		Blinds: []models.Blind{
+			{Small: 25, Big: 50, Time: 90, Index: 0},
+			{Type: models.BlindLevelTypeBreak, Label: "Dinner break", Time: 45, Index: 1},
+		},
+	}
+	require.NoError(t, repo.Create(structure))
+
+	found, err := repo.FindByID(structure.ID)
+	require.NoError(t, err)
+	require.Len(t, found.Blinds, 2)
+	require.Equal(t, models.BlindLevelTypeBlind, found.Blinds[0].Type)
+	require.Equal(t, int32(90), found.Blinds[0].Time)
+	require.Equal(t, models.BlindLevelTypeBreak, found.Blinds[1].Type)
+	require.Equal(t, "Dinner break", found.Blinds[1].Label)
+	require.Equal(t, structure.ID, found.Blinds[1].StructureId)
+}
+
+func TestStructureRepository_ReplaceBlindsByStructureID(t *testing.T) {
+	t.Parallel()
+
+	repo := newStructureRepository()
+
+	structure := &models.Structure{
+		Name:   "Turbo",
+		Blinds: []models.Blind{{Small: 25, Big: 50, Time: 10}},
+	}
+	require.NoError(t, repo.Create(structure))
+
+	blinds := models.NewBlindsFromJSON(structure.ID, []models.BlindJSON{
+		{Small: 50, Big: 100, Time: 10},
+		{Type: models.BlindLevelTypeChipRace, Label: "Colour up 25s", Time: 5},
+	})
+	require.NoError(t, repo.ReplaceBlindsByStructureID(structure.ID, blinds))
+
+	// Changes to the caller's slice must not leak into the store
+	blinds[0].Small = 1000
+
+	found, err := repo.FindByID(structure.ID)
+	require.NoError(t, err)
+	require.Len(t, found.Blinds, 2)
+	require.Equal(t, int32(50), found.Blinds[0].Small)
+	require.Equal(t, models.BlindLevelTypeChipRace, found.Blinds[1].Type)
+	require.Equal(t, "Colour up 25s", found.Blinds[1].Label)
+
+	err = repo.ReplaceBlindsByStructureID(structure.ID+1, blinds)
+	require.ErrorIs(t, err, store.ErrNotFound)
+}
+
+func TestStructureRepository_Versions(t *testing.T) {
+	t.Parallel()
+
+	repo := newStructureRepository()
+
+	structure := &models.Structure{
+		Name:   "Turbo",
+		Blinds: []models.Blind{{Small: 25, Big: 50, Time: 10}},
+	}
+	require.NoError(t, repo.Create(structure))
+	require.Equal(t, int32(1), structure.Version)
+
+	first := models.NewStructureVersion(*structure)
+	require.NoError(t, repo.CreateVersion(&first))
+	require.NotZero(t, first.ID)
+
+	structure.Version = 2
+	structure.Blinds = []models.Blind{{Small: 50, Big: 100, Time: 20}}
+	second := models.NewStructureVersion(*structure)
+	require.NoError(t, repo.CreateVersion(&second))
+
+	// A version number cannot be recorded twice
+	duplicate := models.NewStructureVersion(*structure)
+	require.Error(t, repo.CreateVersion(&duplicate))
+
+	found, err := repo.FindByID(structure.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), found.Version)
+
+	latest, err := repo.FindLatestVersion(structure.ID)
+	require.NoError(t, err)
+	require.Equal(t, second.ID, latest.ID)
+
+	version, err := repo.FindVersion(structure.ID, 1)
+	require.NoError(t, err)
+	require.Equal(t, int32(10), version.Blinds[0].Time)
+
+	_, err = repo.FindVersion(structure.ID, 3)
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	versions, total, err := repo.ListVersions(structure.ID, &models.Pagination{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Equal(t, int32(2), versions[0].Version)
+	require.Equal(t, int32(1), versions[1].Version)
+
+	require.NoError(t, repo.Delete(structure.ID))
+	_, err = repo.FindLatestVersion(structure.ID)
+	require.ErrorIs(t, err, store.ErrNotFound)
+}
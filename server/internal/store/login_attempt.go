@@ -0,0 +1,25 @@
+package store
+
+import (
+	"api/internal/models"
+	"time"
+)
+
+// LoginAttemptRepository is the interface for accessing the failed login attempts tracked by
+// the login limiter. It provides methods for reading, saving, and deleting attempts.
+type LoginAttemptRepository interface {
+	// Find retrieves the attempts tracked for a key.
+	// Returns store.ErrNotFound if no attempts are tracked for the given key.
+	Find(key string) (models.LoginAttempt, error)
+
+	// Save creates or replaces the attempts tracked for a key.
+	Save(attempt *models.LoginAttempt) error
+
+	// Delete stops tracking the attempts for a key.
+	// Returns store.ErrNotFound if no attempts are tracked for the given key.
+	Delete(key string) error
+
+	// DeleteStale stops tracking every key whose last failure and lockout both
+	// ended before the given time.
+	DeleteStale(before time.Time) error
+}
@@ -83,6 +83,15 @@ func (r *postgresStructureRepository) ReplaceBlindsByStructureID(structureID int
 }
 
 func (r *postgresStructureRepository) Delete(id int32) error {
+	var events int64
+	if err := r.db.Model(&models.Event{}).Where("structure_id = ?", id).Count(&events).Error; err != nil {
+		return err
+	}
+
+	if events > 0 {
+		return store.ErrInUse
+	}
+
 	result := r.db.Delete(&models.Structure{}, id)
 	if err := result.Error; err != nil {
 		return err
@@ -94,3 +103,62 @@ func (r *postgresStructureRepository) Delete(id int32) error {
 
 	return nil
 }
+
+func (r *postgresStructureRepository) CreateVersion(version *models.StructureVersion) error {
+	if err := r.db.Create(version).Error; err != nil {
+		return err
+	}
+
+	return r.db.Model(&models.Structure{}).
+		Where("id = ?", version.StructureID).
+		Update("version", version.Version).
+		Error
+}
+
+func (r *postgresStructureRepository) FindVersion(structureID int32, version int32) (models.StructureVersion, error) {
+	var structureVersion models.StructureVersion
+
+	res := r.db.Where("structure_id = ? AND version = ?", structureID, version).First(&structureVersion)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.StructureVersion{}, store.ErrNotFound
+		}
+		return models.StructureVersion{}, err
+	}
+
+	return structureVersion, nil
+}
+
+func (r *postgresStructureRepository) FindLatestVersion(structureID int32) (models.StructureVersion, error) {
+	var structureVersion models.StructureVersion
+
+	res := r.db.Where("structure_id = ?", structureID).Order("version DESC").First(&structureVersion)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.StructureVersion{}, store.ErrNotFound
+		}
+		return models.StructureVersion{}, err
+	}
+
+	return structureVersion, nil
+}
+
+func (r *postgresStructureRepository) ListVersions(structureID int32, pagination *models.Pagination) ([]models.StructureVersion, int64, error) {
+	versions := []models.StructureVersion{}
+	var total int64
+
+	base := r.db.Model(&models.StructureVersion{}).Where("structure_id = ?", structureID)
+
+	if err := base.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := base.Order("version DESC")
+	query = pagination.Apply(query)
+
+	if err := query.Find(&versions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return versions, total, nil
+}
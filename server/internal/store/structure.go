@@ -19,6 +19,22 @@ type StructureRepository interface {
 	// ReplaceBlindsByStructureID replaces all blinds for a structure with the given blinds.
 	ReplaceBlindsByStructureID(structureID int32, blinds []models.Blind) error
 
-	// Delete deletes a structure from the data store by its ID.
+	// CreateVersion records a snapshot of a structure and makes it the
+	// structure's current version.
+	CreateVersion(version *models.StructureVersion) error
+
+	// FindVersion retrieves a version of a structure by its number.
+	FindVersion(structureID int32, version int32) (models.StructureVersion, error)
+
+	// FindLatestVersion retrieves the current version of a structure. It
+	// returns store.ErrNotFound if no version of the structure was recorded.
+	FindLatestVersion(structureID int32) (models.StructureVersion, error)
+
+	// ListVersions retrieves the versions of a structure, newest first, along
+	// with the total number of versions before pagination is applied.
+	ListVersions(structureID int32, pagination *models.Pagination) ([]models.StructureVersion, int64, error)
+
+	// Delete deletes a structure from the data store by its ID. Returns
+	// ErrInUse if an event is played with the structure.
 	Delete(id int32) error
 }
@@ -9,8 +9,9 @@ import (
 
 var TEST_STRUCTURES = []models.Structure{
 	{
-		ID:   1,
-		Name: "Standard Structure",
+		ID:      1,
+		Name:    "Standard Structure",
+		Version: 1,
 		Blinds: []models.Blind{
 			{Index: 0, Small: 10, Big: 20, Ante: 0, Time: 15},
 			{Index: 1, Small: 20, Big: 40, Ante: 0, Time: 15},
@@ -26,6 +27,11 @@ func SeedStructures(db *gorm.DB) error {
 		if err := db.Create(&structure).Error; err != nil {
 			return err
 		}
+
+		version := models.NewStructureVersion(structure)
+		if err := db.Create(&version).Error; err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -58,5 +64,10 @@ func CreateTestStructure(db *gorm.DB, name string) (*models.Structure, error) {
 		return nil, err
 	}
 
+	version := models.NewStructureVersion(structure)
+	if err := db.Create(&version).Error; err != nil {
+		return nil, err
+	}
+
 	return &structure, nil
 }
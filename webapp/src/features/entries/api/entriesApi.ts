@@ -1,11 +1,12 @@
 import { apiClient } from "@/lib/apiClient";
-import { Entry } from "@/types";
+import { Entry, Registration } from "@/types";
 
 /**
  * Raw participant shape returned by the entries endpoint. Components typically
  * transform this into the flatter `Entry` shape via `participantToEntry`.
  */
 export interface ParticipantResponse {
+  id?: number;
   membershipId: string;
   membership?: {
     id: string;
@@ -17,12 +18,22 @@ export interface ParticipantResponse {
   };
   signedOutAt: Date;
   placement?: number;
+  placementOverride?: number | null;
+  tableNumber?: number | null;
+  seatNumber?: number | null;
+  rebuys?: number;
+  addOns?: number;
+  knockedOutById?: number | null;
+  /** Name of a walk-in player without a membership */
+  guestName?: string | null;
   eventId: string;
 }
 
 export interface CreateEntryResult {
   membershipId: string;
-  status: "created" | "error";
+  status: "created" | "waitlisted" | "error";
+  /** Place of the member on the waitlist of a full event */
+  registration?: Registration;
   error?: string;
 }
 
@@ -64,9 +75,112 @@ export async function signInEntry(semesterId: string, eventId: number, membershi
   });
 }
 
-export async function signOutEntry(semesterId: string, eventId: number, membershipId: string): Promise<void> {
+/**
+ * Sign out an entry, optionally recording the entry that knocked the player
+ * out, which may be a guest.
+ */
+export async function signOutEntry(
+  semesterId: string,
+  eventId: number,
+  membershipId: string,
+  knockedOutByEntry?: number,
+): Promise<void> {
   return apiClient<void>(`v2/semesters/${semesterId}/events/${eventId}/entries/${membershipId}/sign-out`, {
     method: "POST",
+    body: knockedOutByEntry !== undefined ? { knockedOutByEntry } : undefined,
+  });
+}
+
+/**
+ * Enter a walk-in player without a membership. Guests count towards the size
+ * of the event but do not earn ranking points.
+ */
+export async function createGuestEntry(semesterId: string, eventId: number, name: string): Promise<ParticipantResponse> {
+  return apiClient<ParticipantResponse>(`v2/semesters/${semesterId}/events/${eventId}/guests`, {
+    method: "POST",
+    body: { name },
+  });
+}
+
+export async function signInGuestEntry(semesterId: string, eventId: number, guestId: number): Promise<void> {
+  return apiClient<void>(`v2/semesters/${semesterId}/events/${eventId}/guests/${guestId}/sign-in`, {
+    method: "POST",
+  });
+}
+
+export async function signOutGuestEntry(
+  semesterId: string,
+  eventId: number,
+  guestId: number,
+  knockedOutByEntry?: number,
+): Promise<void> {
+  return apiClient<void>(`v2/semesters/${semesterId}/events/${eventId}/guests/${guestId}/sign-out`, {
+    method: "POST",
+    body: knockedOutByEntry !== undefined ? { knockedOutByEntry } : undefined,
+  });
+}
+
+export async function deleteGuestEntry(semesterId: string, eventId: number, guestId: number): Promise<void> {
+  return apiClient<void>(`v2/semesters/${semesterId}/events/${eventId}/guests/${guestId}`, {
+    method: "DELETE",
+  });
+}
+
+/**
+ * Give a guest entry to the membership the player took out afterwards. If the
+ * event has ended, the membership earns the points of the entry.
+ */
+export async function convertGuestEntry(
+  semesterId: string,
+  eventId: number,
+  guestId: number,
+  membershipId: string,
+): Promise<ParticipantResponse> {
+  return apiClient<ParticipantResponse>(`v2/semesters/${semesterId}/events/${eventId}/guests/${guestId}/convert`, {
+    method: "POST",
+    body: { membershipId },
+  });
+}
+
+/**
+ * List the members registered for an event, followed by its waitlist in order.
+ */
+export async function fetchRegistrations(semesterId: string, eventId: number): Promise<Registration[]> {
+  return apiClient<Registration[]>(`v2/semesters/${semesterId}/events/${eventId}/registrations`);
+}
+
+/**
+ * Register a member for an event before it starts. Once the event is full, the
+ * member is added to the end of its waitlist.
+ */
+export async function createRegistration(
+  semesterId: string,
+  eventId: number,
+  membershipId: string,
+): Promise<Registration> {
+  return apiClient<Registration>(`v2/semesters/${semesterId}/events/${eventId}/registrations`, {
+    method: "POST",
+    body: { membershipId },
+  });
+}
+
+/**
+ * Withdraw a registration. The spot it held goes to the first member on the
+ * waitlist.
+ */
+export async function deleteRegistration(semesterId: string, eventId: number, registrationId: number): Promise<void> {
+  return apiClient<void>(`v2/semesters/${semesterId}/events/${eventId}/registrations/${registrationId}`, {
+    method: "DELETE",
+  });
+}
+
+/**
+ * Enter every registered member into the event and draw their seats. Waitlisted
+ * members stay on the waitlist.
+ */
+export async function convertRegistrations(semesterId: string, eventId: number): Promise<ParticipantResponse[]> {
+  return apiClient<ParticipantResponse[]>(`v2/semesters/${semesterId}/events/${eventId}/registrations/convert`, {
+    method: "POST",
   });
 }
 
@@ -79,9 +193,16 @@ export function participantToEntry(participant: ParticipantResponse): Entry {
     id: participant.membership?.user?.id ?? "",
     membershipId: participant.membershipId,
     eventId: participant.eventId,
-    firstName: participant.membership?.user?.firstName ?? "",
+    firstName: participant.membership?.user?.firstName ?? participant.guestName ?? "",
     lastName: participant.membership?.user?.lastName ?? "",
     signedOutAt: participant.signedOutAt,
     placement: participant.placement,
+    placementOverride: participant.placementOverride,
+    tableNumber: participant.tableNumber,
+    seatNumber: participant.seatNumber,
+    rebuys: participant.rebuys,
+    addOns: participant.addOns,
+    knockedOutById: participant.knockedOutById,
+    guestName: participant.guestName,
   };
 }
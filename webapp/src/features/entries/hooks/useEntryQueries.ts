@@ -1,5 +1,13 @@
 import { useQuery, useMutation, useQueryClient, keepPreviousData } from "@tanstack/react-query";
-import { fetchEntries, unregisterEntry, signInEntry, signOutEntry } from "../api/entriesApi";
+import {
+  fetchEntries,
+  unregisterEntry,
+  signInEntry,
+  signOutEntry,
+  createGuestEntry,
+  convertGuestEntry,
+} from "../api/entriesApi";
+import { rankingKeys } from "@/features/rankings/hooks/useRankingQueries";
 
 export const entryKeys = {
   all: ["entries"] as const,
@@ -67,13 +75,49 @@ export function useSignOutEntry() {
       semesterId,
       eventId,
       membershipId,
+      knockedOutByEntry,
     }: {
       semesterId: string;
       eventId: number;
       membershipId: string;
-    }) => signOutEntry(semesterId, eventId, membershipId),
+      knockedOutByEntry?: number;
+    }) => signOutEntry(semesterId, eventId, membershipId, knockedOutByEntry),
     onSuccess: (_data, { semesterId, eventId }) => {
       queryClient.invalidateQueries({ queryKey: entryKeys.byEvent(semesterId, eventId) });
     },
   });
 }
+
+export function useCreateGuestEntry() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: ({ semesterId, eventId, name }: { semesterId: string; eventId: number; name: string }) =>
+      createGuestEntry(semesterId, eventId, name),
+    onSuccess: (_data, { semesterId, eventId }) => {
+      queryClient.invalidateQueries({ queryKey: entryKeys.byEvent(semesterId, eventId) });
+    },
+  });
+}
+
+export function useConvertGuestEntry() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: ({
+      semesterId,
+      eventId,
+      guestId,
+      membershipId,
+    }: {
+      semesterId: string;
+      eventId: number;
+      guestId: number;
+      membershipId: string;
+    }) => convertGuestEntry(semesterId, eventId, guestId, membershipId),
+    onSuccess: (_data, { semesterId, eventId }) => {
+      queryClient.invalidateQueries({ queryKey: entryKeys.byEvent(semesterId, eventId) });
+      queryClient.invalidateQueries({ queryKey: rankingKeys.bySemester(semesterId) });
+    },
+  });
+}
@@ -11,6 +11,7 @@ export interface CreateEventRequest {
   startDate: Date;
   structureId: number;
   pointsMultiplier: number;
+  knockoutPoints?: number;
 }
 
 export async function createEvent(semesterId: string, eventData: CreateEventRequest): Promise<Event> {
@@ -29,6 +30,7 @@ export interface UpdateEventRequest {
   notes?: string;
   startDate: string;
   pointsMultiplier: number;
+  knockoutPoints?: number;
 }
 
 export async function fetchEvent(semesterId: string, eventId: number): Promise<Event> {
@@ -65,3 +67,40 @@ export async function restartEvent(semesterId: string, eventId: number): Promise
 export async function rebuyEvent(semesterId: string, eventId: number): Promise<void> {
   return apiClient<void>(`v2/semesters/${semesterId}/events/${eventId}/rebuy`, { method: "POST" });
 }
+
+/**
+ * Placement override for the entry of a membership. Entries overridden to the same placement are tied.
+ */
+export interface PlacementOverride {
+  membershipId: string;
+  placement: number;
+}
+
+/**
+ * Placement and points of an entry, or the ones it will have once the event ends
+ */
+export interface EventPlacement {
+  entryId: number;
+  membershipId: string | null;
+  firstName: string;
+  lastName: string;
+  placement: number;
+  tied: boolean;
+  overridden: boolean;
+  points: number;
+}
+
+/**
+ * Replace the placement overrides of an event. Entries that are not listed are placed by the order they signed out
+ * in. Placements of an ended event are corrected and the rankings are updated.
+ */
+export async function setPlacements(
+  semesterId: string,
+  eventId: number,
+  placements: PlacementOverride[],
+): Promise<EventPlacement[]> {
+  return apiClient<EventPlacement[]>(`v2/semesters/${semesterId}/events/${eventId}/placements`, {
+    method: "PUT",
+    body: { placements },
+  });
+}
@@ -7,10 +7,13 @@ import {
   endEvent,
   restartEvent,
   rebuyEvent,
+  setPlacements,
   CreateEventRequest,
+  PlacementOverride,
   UpdateEventRequest,
 } from "../api/eventApi";
 import { entryKeys } from "@/features/entries/hooks/useEntryQueries";
+import { rankingKeys } from "@/features/rankings/hooks/useRankingQueries";
 
 export const eventKeys = {
   all: ["events"] as const,
@@ -91,6 +94,27 @@ export function useRestartEvent() {
   });
 }
 
+export function useSetPlacements() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: ({
+      semesterId,
+      eventId,
+      placements,
+    }: {
+      semesterId: string;
+      eventId: number;
+      placements: PlacementOverride[];
+    }) => setPlacements(semesterId, eventId, placements),
+    onSuccess: (_data, { semesterId, eventId }) => {
+      queryClient.invalidateQueries({ queryKey: entryKeys.byEvent(semesterId, eventId) });
+      // Correcting the placements of an ended event updates the rankings
+      queryClient.invalidateQueries({ queryKey: rankingKeys.bySemester(semesterId) });
+    },
+  });
+}
+
 export function useRebuyEvent() {
   const queryClient = useQueryClient();
 
@@ -0,0 +1,63 @@
+import { ApiError, apiClient } from "@/lib/apiClient";
+import { CreateKioskTokenResponse, KioskCheckInRequest, KioskCheckInResponse, KioskEvent, KioskToken } from "@/types";
+
+export async function fetchKioskTokens(semesterId: string, eventId: number): Promise<KioskToken[]> {
+  return apiClient<KioskToken[]>(`v2/semesters/${semesterId}/events/${eventId}/kiosk-tokens`);
+}
+
+export async function createKioskToken(
+  semesterId: string,
+  eventId: number,
+  request: { name: string; expiresAt?: string },
+): Promise<CreateKioskTokenResponse> {
+  return apiClient<CreateKioskTokenResponse>(`v2/semesters/${semesterId}/events/${eventId}/kiosk-tokens`, {
+    method: "POST",
+    body: request,
+  });
+}
+
+export async function revokeKioskToken(semesterId: string, eventId: number, tokenId: string): Promise<void> {
+  return apiClient<void>(`v2/semesters/${semesterId}/events/${eventId}/kiosk-tokens/${tokenId}`, {
+    method: "DELETE",
+  });
+}
+
+/**
+ * Kiosks authenticate with their token instead of a session, so they do not use apiClient, which sends the session
+ * cookie and redirects to the login page when it expires.
+ */
+async function kioskRequest<T>(path: string, token: string, body?: unknown): Promise<T> {
+  const res = await fetch(`${import.meta.env.VITE_API_URL}/v2/kiosk/${path}`, {
+    method: body ? "POST" : "GET",
+    headers: {
+      Authorization: `Bearer ${token}`,
+      ...(body ? { "Content-Type": "application/json" } : {}),
+    },
+    body: body ? JSON.stringify(body) : undefined,
+  });
+
+  if (!res.ok) {
+    let message = "An unexpected error occurred";
+    let type = "unknown";
+
+    try {
+      const errorData = await res.json();
+      message = errorData.message || message;
+      type = errorData.type || type;
+    } catch {
+      /* response may not be JSON */
+    }
+
+    throw new ApiError(res.status, type, message);
+  }
+
+  return res.json();
+}
+
+export async function fetchKioskEvent(token: string): Promise<KioskEvent> {
+  return kioskRequest<KioskEvent>("event", token);
+}
+
+export async function kioskCheckIn(token: string, request: KioskCheckInRequest): Promise<KioskCheckInResponse> {
+  return kioskRequest<KioskCheckInResponse>("check-in", token, request);
+}
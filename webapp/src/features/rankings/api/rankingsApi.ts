@@ -1,5 +1,5 @@
 import { apiClient, ApiError } from "@/lib/apiClient";
-import { Ranking } from "@/types";
+import { Ranking, RankingHistoryPoint, RankingHistorySnapshot } from "@/types";
 
 export interface FetchRankingsParams {
   limit: number;
@@ -23,9 +23,56 @@ export async function fetchRankings(
   return { data: response.data ?? [], total: response.total ?? 0 };
 }
 
-// Direct fetch (not apiClient) because the response is a CSV blob, not JSON.
-export async function exportRankings(semesterId: string): Promise<{ blob: Blob; filename: string }> {
-  const res = await fetch(`${import.meta.env.VITE_API_URL}/v2/semesters/${semesterId}/rankings/export`, {
+export interface FetchRankingHistoryParams {
+  limit?: number;
+  offset?: number;
+  // Only include rankings at or above this position in each snapshot
+  top?: number;
+}
+
+export async function fetchRankingHistory(
+  semesterId: string,
+  params: FetchRankingHistoryParams = {},
+): Promise<{ data: RankingHistorySnapshot[]; total: number }> {
+  const query = new URLSearchParams();
+  if (params.limit !== undefined) query.set("limit", String(params.limit));
+  if (params.offset !== undefined) query.set("offset", String(params.offset));
+  if (params.top !== undefined) query.set("top", String(params.top));
+
+  const response = await apiClient<{ data: RankingHistorySnapshot[]; total: number }>(
+    `v2/semesters/${semesterId}/rankings/history?${query.toString()}`,
+  );
+  return { data: response.data ?? [], total: response.total ?? 0 };
+}
+
+export async function fetchMembershipRankingHistory(
+  semesterId: string,
+  membershipId: string,
+): Promise<RankingHistoryPoint[]> {
+  const response = await apiClient<RankingHistoryPoint[]>(`v2/semesters/${semesterId}/rankings/${membershipId}/history`);
+  return response ?? [];
+}
+
+export type RankingsExportFormat = "csv" | "json" | "tsv";
+
+export interface ExportRankingsParams {
+  format?: RankingsExportFormat;
+  // Export the rankings as they stood once this event ended
+  eventId?: number;
+  // Export the rankings as of this date (YYYY-MM-DD)
+  asOf?: string;
+}
+
+// Direct fetch (not apiClient) because the response is a file blob, not JSON.
+export async function exportRankings(
+  semesterId: string,
+  params: ExportRankingsParams = {},
+): Promise<{ blob: Blob; filename: string }> {
+  const query = new URLSearchParams({ format: params.format ?? "csv" });
+  if (params.eventId !== undefined) query.set("eventId", String(params.eventId));
+  if (params.asOf) query.set("asOf", params.asOf);
+
+  const res = await fetch(`${import.meta.env.VITE_API_URL}/v2/semesters/${semesterId}/rankings/export?${query.toString()}`, {
     credentials: "include",
   });
 
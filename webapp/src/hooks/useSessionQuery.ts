@@ -1,6 +1,7 @@
 import { useQuery, useMutation, useQueryClient } from "@tanstack/react-query";
 import { apiClient, ApiError } from "@/lib/apiClient";
 import { UserSession } from "@/interfaces/responses";
+import { TwoFactorChallengeResponse } from "@/types";
 
 export const sessionKeys = {
   all: ["session"] as const,
@@ -37,9 +38,10 @@ async function fetchSession(): Promise<UserSession> {
 /**
  * Login uses a direct fetch instead of apiClient because the backend returns
  * 401 for invalid credentials, and apiClient's 401 interceptor would redirect
- * to the login page instead of showing the error message.
+ * to the login page instead of showing the error message. Logins with
+ * two-factor authentication get a challenge instead of a session.
  */
-async function loginRequest(credentials: { username: string; password: string }): Promise<void> {
+async function loginRequest(credentials: { username: string; password: string }): Promise<TwoFactorChallengeResponse | void> {
   const res = await fetch(`${import.meta.env.VITE_API_URL}/v2/session`, {
     method: "POST",
     credentials: "include",
@@ -59,6 +61,10 @@ async function loginRequest(credentials: { username: string; password: string })
     }
     throw new ApiError(res.status, type, message);
   }
+
+  if (res.status === 202) {
+    return res.json();
+  }
 }
 
 async function logoutRequest(): Promise<void> {
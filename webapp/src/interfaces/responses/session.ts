@@ -20,6 +20,9 @@ interface Permissions {
   signin: boolean;
   signout: boolean;
   export: boolean;
+  rebuild: boolean;
+  import: boolean;
+  convert: boolean;
 }
 
 /**
@@ -36,24 +39,44 @@ export interface PermissionList {
           [key: string]: boolean;
         };
   };
-  user: Pick<Permissions, "create" | "get" | "list" | "edit" | "delete">;
+  user: Pick<Permissions, "create" | "get" | "list" | "edit" | "delete" | "import" | "export">;
   event: Pick<Permissions, "create" | "get" | "list" | "edit" | "end" | "restart" | "rebuy"> & {
-    participant: Pick<Permissions, "create" | "get" | "list" | "signin" | "signout" | "delete">;
+    participant: Pick<Permissions, "create" | "get" | "list" | "signin" | "signout" | "delete" | "rebuy">;
+    clock: Pick<Permissions, "get" | "edit">;
+    seating: Pick<Permissions, "get" | "edit">;
+    kiosk: Pick<Permissions, "create" | "list" | "delete">;
+    registration: Pick<Permissions, "create" | "list" | "delete" | "convert">;
   };
-  login: Pick<Permissions, "create" | "list" | "get" | "edit" | "delete">;
-  membership: Pick<Permissions, "create" | "get" | "list" | "edit" | "delete">;
+  login: Pick<Permissions, "create" | "list" | "get" | "edit" | "delete"> & {
+    apiKey: Pick<Permissions, "create" | "list" | "delete">;
+  };
+  membership: Pick<Permissions, "create" | "get" | "list" | "edit" | "delete" | "export">;
   semester: Pick<Permissions, "create" | "get" | "list" | "edit"> & {
-    rankings: Pick<Permissions, "get" | "list" | "export">;
+    rankings: Pick<Permissions, "get" | "list" | "export" | "rebuild">;
     transaction: Pick<Permissions, "create" | "get" | "list" | "edit" | "delete">;
+    pointsScheme: Pick<Permissions, "get" | "edit" | "delete">;
+    ledger: Pick<Permissions, "get" | "list">;
   };
   structure: Pick<Permissions, "create" | "get" | "list" | "edit">;
+  audit: Pick<Permissions, "list">;
+  permission: Pick<Permissions, "list" | "edit">;
 }
 
 export type Resources = keyof PermissionList;
 
 export type Actions = keyof Permissions;
 
-export type SubResources = "participant" | "rankings" | "transaction";
+export type SubResources =
+  | "participant"
+  | "rankings"
+  | "transaction"
+  | "pointsScheme"
+  | "clock"
+  | "seating"
+  | "kiosk"
+  | "registration"
+  | "ledger"
+  | "apiKey";
 
 /**
  * @interface UserSession
@@ -63,4 +86,19 @@ export interface UserSession {
   username: string;
   role: Role;
   permissions: PermissionList;
+  twoFactorSetupRequired: boolean;
+}
+
+/**
+ * @interface SessionInfo
+ * @description This interface defines an active session in the response of the GET /api/v2/session/sessions endpoint.
+ */
+export interface SessionInfo {
+  id: string;
+  startedAt: string;
+  expiresAt: string;
+  lastSeenAt: string;
+  userAgent: string;
+  ipAddress: string;
+  current: boolean;
 }
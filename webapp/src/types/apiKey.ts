@@ -0,0 +1,18 @@
+/**
+ * APIKey is a long-lived credential for a bot login. The key itself is only returned when it is created.
+ */
+export type APIKey = {
+  id: string;
+  username: string;
+  name: string;
+  prefix: string;
+  scopes: string[];
+  expiresAt: string | null;
+  lastUsedAt: string | null;
+  revokedAt: string | null;
+  createdAt: string;
+};
+
+export type CreateAPIKeyResponse = APIKey & {
+  key: string;
+};
@@ -6,4 +6,52 @@ export type Entry = {
   lastName: string;
   signedOutAt: Date;
   placement?: number;
+  /** Placement set by the director, which takes precedence over the sign out order */
+  placementOverride?: number | null;
+  /** Table and seat of a player still in the event */
+  tableNumber?: number | null;
+  seatNumber?: number | null;
+  /** Rebuys and add-ons bought by the player */
+  rebuys?: number;
+  addOns?: number;
+  /** Entry that knocked the player out, if it was recorded */
+  knockedOutById?: number | null;
+  /** Name of a walk-in player without a membership */
+  guestName?: string | null;
+};
+
+export type RegistrationStatus = "registered" | "waitlisted";
+
+/**
+ * Registration is a member registered for an event before it starts, or waiting for a spot once it is full.
+ * `position` is the place of a waitlisted registration on the waitlist, starting at 1.
+ */
+export type Registration = {
+  id: number;
+  eventId: number;
+  membershipId: string;
+  membership?: {
+    id: string;
+    user?: {
+      id?: string;
+      firstName?: string;
+      lastName?: string;
+    };
+  };
+  status: RegistrationStatus;
+  position?: number;
+  createdAt: string;
+};
+
+export type RebuyType = "rebuy" | "add_on";
+
+/**
+ * Rebuy is a rebuy or add-on recorded against an entry. `amount` is the fee that was added to the semester budget.
+ */
+export type Rebuy = {
+  id: number;
+  participantId: number;
+  type: RebuyType;
+  amount: number;
+  createdAt: string;
 };
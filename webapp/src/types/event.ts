@@ -19,7 +19,17 @@ export type Event = {
   state: EventState;
   rebuys: number;
   pointsMultiplier: number;
+  /** Bonus points for each player knocked out, not scaled by the multiplier */
+  knockoutPoints: number;
+  /** Number of seats at each table */
+  tableSize: number;
+  /** Number of times each player may rebuy, null for unlimited */
+  maxRebuys: number | null;
+  /** Number of players the event has room for, null for unlimited. Members entered once it is full are waitlisted */
+  maxEntries: number | null;
   structureId: number;
+  /** The version of the structure the event is played with, null for events created before structures were versioned */
+  structureVersionId: number | null;
   structure?: Structure;
   entries?: { membershipId: string }[];
 };
@@ -7,3 +7,9 @@ export * from "./rankings";
 export * from "./event";
 export * from "./structures";
 export * from "./entry";
+export * from "./ledger";
+export * from "./permission";
+export * from "./apiKey";
+export * from "./password";
+export * from "./twoFactor";
+export * from "./kiosk";
@@ -0,0 +1,39 @@
+/**
+ * KioskToken lets a self check-in kiosk enter members into a single event. The token itself is only returned when it
+ * is created, and stops working when the event ends.
+ */
+export type KioskToken = {
+  id: string;
+  eventId: number;
+  name: string;
+  prefix: string;
+  createdBy: string;
+  expiresAt: string | null;
+  lastUsedAt: string | null;
+  revokedAt: string | null;
+  createdAt: string;
+};
+
+export type CreateKioskTokenResponse = KioskToken & {
+  token: string;
+};
+
+export type KioskEvent = {
+  id: number;
+  name: string;
+  startDate: string;
+  state: number;
+};
+
+/** Exactly one of studentId and questId must be set */
+export type KioskCheckInRequest = { studentId: number; questId?: never } | { questId: string; studentId?: never };
+
+export type KioskCheckInResponse = {
+  firstName: string;
+  tableNumber: number | null;
+  seatNumber: number | null;
+  /** True when the member had already been entered */
+  alreadyCheckedIn: boolean;
+  /** Place of the member on the waitlist when the event is full */
+  waitlistPosition?: number;
+};
@@ -0,0 +1,36 @@
+export type LedgerEntryType = "membership_fee" | "rebuy" | "add_on" | "transaction";
+
+/**
+ * LedgerEntry is a change to the budget of a semester, in cents. Entries are never edited; refunds and corrections append another entry.
+ */
+export type LedgerEntry = {
+  id: number;
+  semesterId: string;
+  type: LedgerEntryType;
+  amountCents: number;
+  description: string;
+  membershipId: string | null;
+  eventId: number | null;
+  transactionId: number | null;
+  createdAt: string;
+};
+
+export type LedgerResponse = {
+  startingBudgetCents: number;
+  balanceCents: number;
+  data: LedgerEntry[];
+  total: number;
+};
+
+/**
+ * LedgerReconciliation compares the ledger balance with the legacy current budget of a semester. `driftCents` is the legacy balance minus the ledger balance.
+ */
+export type LedgerReconciliation = {
+  semesterId: string;
+  startingBudgetCents: number;
+  balanceCents: number;
+  legacyBalanceCents: number;
+  driftCents: number;
+  drifted: boolean;
+  totals: Partial<Record<LedgerEntryType, number>>;
+};
@@ -0,0 +1,18 @@
+export type ChangePasswordRequest = {
+  currentPassword: string;
+  newPassword: string;
+};
+
+export type ResetPasswordRequest = {
+  token: string;
+  newPassword: string;
+};
+
+/**
+ * CreatePasswordResetTokenResponse contains a single-use reset token. The token itself is only returned when it is created.
+ */
+export type CreatePasswordResetTokenResponse = {
+  username: string;
+  token: string;
+  expiresAt: string;
+};
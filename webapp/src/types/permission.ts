@@ -0,0 +1,13 @@
+import { Role } from "./roles";
+
+/**
+ * RolePermission is the set of roles allowed to perform an action, such as `event.end`. Protected actions manage the
+ * permission policy itself and cannot be changed.
+ */
+export type RolePermission = {
+  action: string;
+  roles: Role[];
+  defaultRoles: Role[];
+  protected: boolean;
+  updatedAt: string;
+};
@@ -4,4 +4,46 @@ export type Ranking = {
   lastName: string;
   points: number;
   position: number;
+  /** Players knocked out by the member in ended events */
+  knockouts: number;
+};
+
+export type RankingSnapshotReason =
+  | "event_ended"
+  | "event_end_undone"
+  | "placements_corrected"
+  | "guest_converted";
+
+export type RankingHistoryEntry = {
+  position: number;
+  membershipId: string;
+  userId: number;
+  firstName: string;
+  lastName: string;
+  points: number;
+};
+
+/**
+ * RankingHistorySnapshot is the rankings of a semester right after an event was ended or its end was undone.
+ */
+export type RankingHistorySnapshot = {
+  id: number;
+  eventId: number | null;
+  eventName: string;
+  reason: RankingSnapshotReason;
+  createdAt: string;
+  rankings: RankingHistoryEntry[];
+};
+
+/**
+ * RankingHistoryPoint is the points and position of one membership in a snapshot, for charting its ranking over the semester.
+ */
+export type RankingHistoryPoint = {
+  snapshotId: number;
+  eventId: number | null;
+  eventName: string;
+  reason: RankingSnapshotReason;
+  createdAt: string;
+  points: number;
+  position: number;
 };
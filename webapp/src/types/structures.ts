@@ -1,13 +1,30 @@
+export type BlindLevelType = "blind" | "break" | "chip_race";
+
 export interface Blind {
+  /** Levels without a type are blind levels. Breaks and chip races have no blinds or antes. */
+  type?: BlindLevelType;
+  label?: string;
   small: number;
   big: number;
   ante: number;
+  /** Length of the level in minutes */
   time: number;
 }
 
 export interface Structure {
   id: number;
   name: string;
+  /** Number of the current version, increased every time the structure is edited */
+  version: number;
 }
 
 export type StructureWithBlinds = Structure & { blinds: Blind[] };
+
+export interface StructureVersion {
+  id: number;
+  structureId: number;
+  version: number;
+  name: string;
+  blinds: Blind[];
+  createdAt: string;
+}
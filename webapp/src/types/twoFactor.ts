@@ -0,0 +1,32 @@
+/**
+ * TwoFactorChallengeResponse is returned instead of a session when a login has two-factor authentication enabled.
+ * The challenge token and a code are submitted to POST /api/v2/session/2fa/verify to create the session.
+ */
+export type TwoFactorChallengeResponse = {
+  twoFactorRequired: boolean;
+  challengeToken: string;
+  expiresAt: string;
+};
+
+export type VerifyTwoFactorRequest = {
+  challengeToken: string;
+  code: string;
+};
+
+export type TwoFactorStatus = {
+  enabled: boolean;
+  required: boolean;
+  recoveryCodesRemaining: number;
+};
+
+export type TwoFactorSetupResponse = {
+  secret: string;
+  otpauthUrl: string;
+};
+
+/**
+ * TwoFactorRecoveryCodesResponse contains single-use recovery codes. The codes are only returned when they are generated.
+ */
+export type TwoFactorRecoveryCodesResponse = {
+  recoveryCodes: string[];
+};